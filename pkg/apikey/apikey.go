@@ -0,0 +1,350 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package apikey - API keys for machine clients
+// ═══════════════════════════════════════════════════════════════════════════
+
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/fintech-bank-platform/pkg/errors"
+	"github.com/google/uuid"
+)
+
+// Keys look like fk_<prefix>_<secret>. The prefix is stored in clear to find
+// the key; only the SHA-256 of the whole token is stored, and the token is
+// shown once when the key is created or rotated.
+const (
+	TokenPrefix  = "fk_"
+	prefixLength = 8
+	secretBytes  = 32
+)
+
+// Rate-limit tiers of a key
+const (
+	TierStandard = "standard"
+	TierPremium  = "premium"
+)
+
+// ScopeAdmin allows managing API keys
+const ScopeAdmin = "api-keys:admin"
+
+// DefaultOverlap is how long the previous key keeps working after a rotation
+const DefaultOverlap = 24 * time.Hour
+
+const prefixAlphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// ═══════════════════════════════════════════════════════════════════════════
+// KEY
+// ═══════════════════════════════════════════════════════════════════════════
+
+// Status is the state of a key at a given time
+type Status string
+
+const (
+	StatusActive  Status = "active"
+	StatusExpired Status = "expired"
+	StatusRevoked Status = "revoked"
+)
+
+// Key is a stored API key. Hash never leaves the store.
+type Key struct {
+	ID         string     `json:"id"`
+	Prefix     string     `json:"prefix"`
+	Hash       string     `json:"-"`
+	Name       string     `json:"name"`
+	Owner      string     `json:"owner"`
+	Scopes     []string   `json:"scopes"`
+	Tier       string     `json:"tier"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	ReplacedBy string     `json:"replaced_by,omitempty"`
+}
+
+// Status returns whether the key is usable at now
+func (k *Key) Status(now time.Time) Status {
+	switch {
+	case k.RevokedAt != nil:
+		return StatusRevoked
+	case k.ExpiresAt != nil && !now.Before(*k.ExpiresAt):
+		return StatusExpired
+	}
+	return StatusActive
+}
+
+// HasScope reports whether the key was granted scope
+func (k *Key) HasScope(scope string) bool {
+	for _, granted := range k.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateRequest describes a new key. Tier defaults to TierStandard and a nil
+// ExpiresAt never expires.
+type CreateRequest struct {
+	Name      string     `json:"name" validate:"required"`
+	Owner     string     `json:"owner" validate:"required"`
+	Scopes    []string   `json:"scopes" validate:"required,min=1,dive,required"`
+	Tier      string     `json:"tier" validate:"omitempty,oneof=standard premium"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// Issued is a key with its token, returned only by Create and Rotate
+type Issued struct {
+	Key   *Key   `json:"key"`
+	Token string `json:"token"`
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// MANAGER
+// ═══════════════════════════════════════════════════════════════════════════
+
+// Manager issues, rotates, revokes and authenticates API keys
+type Manager struct {
+	store   Store
+	overlap time.Duration
+	now     func() time.Time
+}
+
+// Option configures a Manager
+type Option func(*Manager)
+
+// WithOverlap sets how long rotated keys keep working by default
+func WithOverlap(overlap time.Duration) Option {
+	return func(m *Manager) {
+		m.overlap = overlap
+	}
+}
+
+// WithClock sets the clock used for creation, expiry and revocation
+func WithClock(now func() time.Time) Option {
+	return func(m *Manager) {
+		m.now = now
+	}
+}
+
+// NewManager creates a manager with DefaultOverlap
+func NewManager(store Store, opts ...Option) *Manager {
+	m := &Manager{store: store, overlap: DefaultOverlap, now: time.Now}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Create issues a new key
+func (m *Manager) Create(ctx context.Context, req CreateRequest) (*Issued, error) {
+	key, err := m.newKey(req)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		token, prefix := generateToken()
+		key.Prefix, key.Hash = prefix, hash(token)
+
+		// Prefixes identify keys, so a collision draws a new token
+		if _, err := m.store.FindByPrefix(ctx, key.Prefix); errors.ErrAPIKeyNotFound.Is(err) {
+			if err := m.store.Save(ctx, key); err != nil {
+				return nil, err
+			}
+			return &Issued{Key: key, Token: token}, nil
+		} else if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Import stores a key with a token generated elsewhere, such as the
+// bootstrap admin key from configuration
+func (m *Manager) Import(ctx context.Context, token string, req CreateRequest) (*Key, error) {
+	prefix, ok := parse(token)
+	if !ok {
+		return nil, errors.ErrInvalidToken
+	}
+	if _, err := m.store.FindByPrefix(ctx, prefix); err == nil {
+		return nil, errors.Conflict(errors.ErrConflict.Code, errors.ErrConflict.Message).WithDetail("prefix", prefix)
+	} else if !errors.ErrAPIKeyNotFound.Is(err) {
+		return nil, err
+	}
+
+	key, err := m.newKey(req)
+	if err != nil {
+		return nil, err
+	}
+	key.Prefix, key.Hash = prefix, hash(token)
+	if err := m.store.Save(ctx, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func (m *Manager) newKey(req CreateRequest) (*Key, error) {
+	if len(req.Scopes) == 0 {
+		return nil, errors.BadRequest(errors.ErrMissingField.Code, errors.ErrMissingField.Message).WithDetail("field", "scopes")
+	}
+	if req.Tier == "" {
+		req.Tier = TierStandard
+	}
+	if req.Tier != TierStandard && req.Tier != TierPremium {
+		return nil, errors.BadRequest(errors.ErrInvalidField.Code, errors.ErrInvalidField.Message).WithDetail("tier", req.Tier)
+	}
+
+	return &Key{
+		ID:        uuid.New().String(),
+		Name:      req.Name,
+		Owner:     req.Owner,
+		Scopes:    append([]string(nil), req.Scopes...),
+		Tier:      req.Tier,
+		CreatedAt: m.now(),
+		ExpiresAt: req.ExpiresAt,
+	}, nil
+}
+
+// Get returns a key by ID
+func (m *Manager) Get(ctx context.Context, id string) (*Key, error) {
+	return m.store.Get(ctx, id)
+}
+
+// List returns the keys of owner, or every key when owner is empty
+func (m *Manager) List(ctx context.Context, owner string) ([]*Key, error) {
+	return m.store.List(ctx, owner)
+}
+
+// Revoke disables a key at once. Revoking a revoked key is a no-op.
+func (m *Manager) Revoke(ctx context.Context, id string) (*Key, error) {
+	key, err := m.store.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if key.RevokedAt != nil {
+		return key, nil
+	}
+
+	now := m.now()
+	key.RevokedAt = &now
+	if err := m.store.Save(ctx, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Rotate issues a replacement with the same owner, scopes, tier and expiry,
+// and makes the old key expire after overlap (the manager default when
+// overlap is not positive) so clients can switch without downtime.
+func (m *Manager) Rotate(ctx context.Context, id string, overlap time.Duration) (*Issued, error) {
+	old, err := m.store.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := statusError(old.Status(m.now())); err != nil {
+		return nil, err
+	}
+
+	issued, err := m.Create(ctx, CreateRequest{
+		Name:      old.Name,
+		Owner:     old.Owner,
+		Scopes:    old.Scopes,
+		Tier:      old.Tier,
+		ExpiresAt: old.ExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if overlap <= 0 {
+		overlap = m.overlap
+	}
+	if until := m.now().Add(overlap); old.ExpiresAt == nil || until.Before(*old.ExpiresAt) {
+		old.ExpiresAt = &until
+	}
+	old.ReplacedBy = issued.Key.ID
+	if err := m.store.Save(ctx, old); err != nil {
+		return nil, err
+	}
+	return issued, nil
+}
+
+// Authenticate returns the active key of token. Malformed and unknown tokens
+// return errors.ErrInvalidToken.
+func (m *Manager) Authenticate(ctx context.Context, token string) (*Key, error) {
+	prefix, ok := parse(token)
+	if !ok {
+		return nil, errors.ErrInvalidToken
+	}
+
+	key, err := m.store.FindByPrefix(ctx, prefix)
+	if errors.ErrAPIKeyNotFound.Is(err) {
+		return nil, errors.ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(key.Hash), []byte(hash(token))) != 1 {
+		return nil, errors.ErrInvalidToken
+	}
+	if err := statusError(key.Status(m.now())); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func statusError(status Status) error {
+	switch status {
+	case StatusRevoked:
+		return errors.ErrAPIKeyRevoked
+	case StatusExpired:
+		return errors.ErrAPIKeyExpired
+	}
+	return nil
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// TOKENS
+// ═══════════════════════════════════════════════════════════════════════════
+
+// generateToken returns a new token and its prefix. crypto/rand.Read never
+// fails since Go 1.24.
+func generateToken() (token, prefix string) {
+	random := make([]byte, prefixLength+secretBytes)
+	rand.Read(random)
+
+	letters := make([]byte, prefixLength)
+	for i, b := range random[:prefixLength] {
+		letters[i] = prefixAlphabet[int(b)%len(prefixAlphabet)]
+	}
+	prefix = string(letters)
+	return TokenPrefix + prefix + "_" + base64.RawURLEncoding.EncodeToString(random[prefixLength:]), prefix
+}
+
+// parse returns the prefix of a well-formed token
+func parse(token string) (string, bool) {
+	rest, ok := strings.CutPrefix(token, TokenPrefix)
+	if !ok {
+		return "", false
+	}
+	prefix, secret, ok := strings.Cut(rest, "_")
+	if !ok || len(prefix) != prefixLength || strings.Trim(prefix, prefixAlphabet) != "" {
+		return "", false
+	}
+	if decoded, err := base64.RawURLEncoding.DecodeString(secret); err != nil || len(decoded) != secretBytes {
+		return "", false
+	}
+	return prefix, true
+}
+
+func hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,296 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package apikey - Manager tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package apikey
+
+import (
+	"context"
+	stderrors "errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fintech-bank-platform/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var epoch = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+type clock struct{ now time.Time }
+
+func (c *clock) Now() time.Time { return c.now }
+
+func newManager(opts ...Option) (*Manager, *MemoryStore, *clock) {
+	store := NewMemoryStore()
+	c := &clock{now: epoch}
+	return NewManager(store, append([]Option{WithClock(c.Now)}, opts...)...), store, c
+}
+
+func partnerKey() CreateRequest {
+	return CreateRequest{Name: "settlement", Owner: "partner-bank-341", Scopes: []string{"transfers:write", "accounts:read"}}
+}
+
+// stubStore wraps a MemoryStore, failing or faking lookups on demand
+type stubStore struct {
+	*MemoryStore
+	saveErr   error
+	findErr   error
+	collision int
+}
+
+func (s *stubStore) Save(ctx context.Context, key *Key) error {
+	if s.saveErr != nil {
+		return s.saveErr
+	}
+	return s.MemoryStore.Save(ctx, key)
+}
+
+func (s *stubStore) FindByPrefix(ctx context.Context, prefix string) (*Key, error) {
+	if s.findErr != nil {
+		return nil, s.findErr
+	}
+	if s.collision > 0 {
+		s.collision--
+		return &Key{Prefix: prefix}, nil
+	}
+	return s.MemoryStore.FindByPrefix(ctx, prefix)
+}
+
+func TestCreateAndAuthenticate(t *testing.T) {
+	m, store, _ := newManager()
+	ctx := context.Background()
+
+	issued, err := m.Create(ctx, partnerKey())
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(issued.Token, TokenPrefix+issued.Key.Prefix+"_"))
+	assert.Equal(t, TierStandard, issued.Key.Tier)
+	assert.Equal(t, epoch, issued.Key.CreatedAt)
+
+	stored, err := store.Get(ctx, issued.Key.ID)
+	require.NoError(t, err)
+	assert.Equal(t, hash(issued.Token), stored.Hash, "only the hash is stored")
+
+	key, err := m.Authenticate(ctx, issued.Token)
+	require.NoError(t, err)
+	assert.Equal(t, issued.Key.ID, key.ID)
+	assert.True(t, key.HasScope("transfers:write"))
+	assert.False(t, key.HasScope(ScopeAdmin))
+
+	keys, err := m.List(ctx, "partner-bank-341")
+	require.NoError(t, err)
+	assert.Equal(t, []string{issued.Key.ID}, ids(keys))
+}
+
+func TestAuthenticateRejectsBadTokens(t *testing.T) {
+	m, _, _ := newManager()
+	ctx := context.Background()
+	issued, err := m.Create(ctx, partnerKey())
+	require.NoError(t, err)
+	other, _ := generateToken()
+
+	for name, token := range map[string]string{
+		"empty":          "",
+		"scheme":         "sk_" + strings.TrimPrefix(issued.Token, TokenPrefix),
+		"no secret":      TokenPrefix + issued.Key.Prefix,
+		"short prefix":   TokenPrefix + "abc_" + strings.SplitN(issued.Token, "_", 3)[2],
+		"upper prefix":   TokenPrefix + strings.ToUpper(issued.Key.Prefix) + "_" + strings.SplitN(issued.Token, "_", 3)[2],
+		"short secret":   TokenPrefix + issued.Key.Prefix + "_c2VjcmV0",
+		"unknown prefix": other,
+		"wrong secret":   TokenPrefix + issued.Key.Prefix + "_" + strings.SplitN(other, "_", 3)[2],
+	} {
+		_, err := m.Authenticate(ctx, token)
+		assert.ErrorIs(t, err, errors.ErrInvalidToken, name)
+	}
+}
+
+func TestCreateValidates(t *testing.T) {
+	m, _, _ := newManager()
+	ctx := context.Background()
+
+	_, err := m.Create(ctx, CreateRequest{Name: "no scopes", Owner: "partner"})
+	assert.ErrorIs(t, err, errors.ErrMissingField)
+
+	req := partnerKey()
+	req.Tier = "gold"
+	_, err = m.Create(ctx, req)
+	assert.ErrorIs(t, err, errors.ErrInvalidField)
+
+	req.Tier = TierPremium
+	issued, err := m.Create(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, TierPremium, issued.Key.Tier)
+	assert.Empty(t, errors.ErrMissingField.Details, "the sentinels are not modified")
+	assert.Empty(t, errors.ErrInvalidField.Details)
+}
+
+func TestCreateRetriesPrefixCollisions(t *testing.T) {
+	store := &stubStore{MemoryStore: NewMemoryStore(), collision: 2}
+	m := NewManager(store)
+
+	issued, err := m.Create(context.Background(), partnerKey())
+
+	require.NoError(t, err)
+	assert.Zero(t, store.collision)
+	_, err = m.Authenticate(context.Background(), issued.Token)
+	assert.NoError(t, err)
+}
+
+func TestExpiryAndRevocation(t *testing.T) {
+	m, _, c := newManager()
+	ctx := context.Background()
+	req := partnerKey()
+	expires := epoch.Add(time.Hour)
+	req.ExpiresAt = &expires
+	issued, err := m.Create(ctx, req)
+	require.NoError(t, err)
+
+	c.now = expires
+	_, err = m.Authenticate(ctx, issued.Token)
+	assert.ErrorIs(t, err, errors.ErrAPIKeyExpired)
+
+	c.now = epoch
+	revoked, err := m.Revoke(ctx, issued.Key.ID)
+	require.NoError(t, err)
+	assert.Equal(t, epoch, *revoked.RevokedAt)
+	assert.Equal(t, StatusRevoked, revoked.Status(epoch))
+
+	c.now = epoch.Add(time.Minute)
+	again, err := m.Revoke(ctx, issued.Key.ID)
+	require.NoError(t, err)
+	assert.Equal(t, epoch, *again.RevokedAt, "revoking twice keeps the first revocation")
+
+	_, err = m.Authenticate(ctx, issued.Token)
+	assert.ErrorIs(t, err, errors.ErrAPIKeyRevoked)
+
+	_, err = m.Revoke(ctx, "missing")
+	assert.ErrorIs(t, err, errors.ErrAPIKeyNotFound)
+}
+
+func TestRotateKeepsOldKeyDuringOverlap(t *testing.T) {
+	m, _, c := newManager(WithOverlap(time.Hour))
+	ctx := context.Background()
+	old, err := m.Create(ctx, partnerKey())
+	require.NoError(t, err)
+
+	rotated, err := m.Rotate(ctx, old.Key.ID, 0)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, old.Token, rotated.Token)
+	assert.Equal(t, old.Key.Scopes, rotated.Key.Scopes)
+	assert.Equal(t, old.Key.Owner, rotated.Key.Owner)
+	previous, _ := m.Get(ctx, old.Key.ID)
+	assert.Equal(t, rotated.Key.ID, previous.ReplacedBy)
+	assert.Equal(t, epoch.Add(time.Hour), *previous.ExpiresAt)
+
+	c.now = epoch.Add(59 * time.Minute)
+	_, err = m.Authenticate(ctx, old.Token)
+	assert.NoError(t, err, "the old key works during the overlap")
+
+	c.now = epoch.Add(time.Hour)
+	_, err = m.Authenticate(ctx, old.Token)
+	assert.ErrorIs(t, err, errors.ErrAPIKeyExpired)
+	_, err = m.Authenticate(ctx, rotated.Token)
+	assert.NoError(t, err)
+
+	_, err = m.Rotate(ctx, old.Key.ID, time.Minute)
+	assert.ErrorIs(t, err, errors.ErrAPIKeyExpired, "expired keys cannot be rotated")
+	_, err = m.Rotate(ctx, "missing", time.Minute)
+	assert.ErrorIs(t, err, errors.ErrAPIKeyNotFound)
+}
+
+func TestRotateKeepsEarlierExpiry(t *testing.T) {
+	m, _, _ := newManager()
+	ctx := context.Background()
+	req := partnerKey()
+	expires := epoch.Add(time.Hour)
+	req.ExpiresAt = &expires
+	old, err := m.Create(ctx, req)
+	require.NoError(t, err)
+
+	rotated, err := m.Rotate(ctx, old.Key.ID, 48*time.Hour)
+	require.NoError(t, err)
+
+	previous, _ := m.Get(ctx, old.Key.ID)
+	assert.Equal(t, expires, *previous.ExpiresAt)
+	assert.Equal(t, expires, *rotated.Key.ExpiresAt)
+}
+
+func TestImport(t *testing.T) {
+	m, _, _ := newManager()
+	ctx := context.Background()
+	token, _ := generateToken()
+	req := CreateRequest{Name: "bootstrap", Owner: "platform", Scopes: []string{ScopeAdmin}}
+
+	key, err := m.Import(ctx, token, req)
+	require.NoError(t, err)
+	authenticated, err := m.Authenticate(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, key.ID, authenticated.ID)
+
+	_, err = m.Import(ctx, token, req)
+	assert.ErrorIs(t, err, errors.ErrConflict)
+	_, err = m.Import(ctx, "not-a-token", req)
+	assert.ErrorIs(t, err, errors.ErrInvalidToken)
+	other, _ := generateToken()
+	_, err = m.Import(ctx, other, CreateRequest{Name: "no scopes"})
+	assert.ErrorIs(t, err, errors.ErrMissingField)
+	assert.Empty(t, errors.ErrConflict.Details, "the sentinel is not modified")
+}
+
+func TestStoreFailures(t *testing.T) {
+	boom := stderrors.New("store unavailable")
+	ctx := context.Background()
+	store := &stubStore{MemoryStore: NewMemoryStore()}
+	m := NewManager(store)
+	existing, err := m.Create(ctx, partnerKey())
+	require.NoError(t, err)
+	token, _ := generateToken()
+
+	store.saveErr = boom
+	_, err = m.Create(ctx, partnerKey())
+	assert.ErrorIs(t, err, boom)
+	_, err = m.Import(ctx, token, partnerKey())
+	assert.ErrorIs(t, err, boom)
+	_, err = m.Revoke(ctx, existing.Key.ID)
+	assert.ErrorIs(t, err, boom)
+	_, err = m.Rotate(ctx, existing.Key.ID, 0)
+	assert.ErrorIs(t, err, boom)
+
+	store.saveErr = nil
+	store.findErr = boom
+	_, err = m.Create(ctx, partnerKey())
+	assert.ErrorIs(t, err, boom)
+	_, err = m.Import(ctx, token, partnerKey())
+	assert.ErrorIs(t, err, boom)
+	_, err = m.Authenticate(ctx, existing.Token)
+	assert.ErrorIs(t, err, boom)
+}
+
+func TestRotateFailsWhenOldKeyCannotBeSaved(t *testing.T) {
+	ctx := context.Background()
+	memory := NewMemoryStore()
+	existing, err := NewManager(memory).Create(ctx, partnerKey())
+	require.NoError(t, err)
+
+	m := NewManager(&failingSaveStore{MemoryStore: memory, failAfter: 1})
+	_, err = m.Rotate(ctx, existing.Key.ID, 0)
+
+	assert.EqualError(t, err, "store unavailable")
+}
+
+// failingSaveStore fails every Save after the next failAfter ones
+type failingSaveStore struct {
+	*MemoryStore
+	failAfter int
+}
+
+func (s *failingSaveStore) Save(ctx context.Context, key *Key) error {
+	if s.failAfter == 0 {
+		return stderrors.New("store unavailable")
+	}
+	s.failAfter--
+	return s.MemoryStore.Save(ctx, key)
+}
@@ -0,0 +1,101 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package apikey - Key store
+// ═══════════════════════════════════════════════════════════════════════════
+
+package apikey
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/fintech-bank-platform/pkg/errors"
+)
+
+// Store keeps API keys. Get and FindByPrefix return errors.ErrAPIKeyNotFound
+// for missing keys.
+type Store interface {
+	// Save inserts or replaces the key with the same ID
+	Save(ctx context.Context, key *Key) error
+	Get(ctx context.Context, id string) (*Key, error)
+	FindByPrefix(ctx context.Context, prefix string) (*Key, error)
+	// List returns the keys of owner (every key when empty), oldest first
+	List(ctx context.Context, owner string) ([]*Key, error)
+}
+
+// MemoryStore is an in-memory Store used in tests and local development
+type MemoryStore struct {
+	mu       sync.Mutex
+	keys     map[string]Key
+	prefixes map[string]string
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		keys:     make(map[string]Key),
+		prefixes: make(map[string]string),
+	}
+}
+
+// Save implements Store
+func (s *MemoryStore) Save(_ context.Context, key *Key) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.keys[key.ID] = copyKey(key)
+	s.prefixes[key.Prefix] = key.ID
+	return nil
+}
+
+// Get implements Store
+func (s *MemoryStore) Get(_ context.Context, id string) (*Key, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := s.keys[id]
+	if !ok {
+		return nil, errors.ErrAPIKeyNotFound
+	}
+	copied := copyKey(&key)
+	return &copied, nil
+}
+
+// FindByPrefix implements Store
+func (s *MemoryStore) FindByPrefix(ctx context.Context, prefix string) (*Key, error) {
+	s.mu.Lock()
+	id, ok := s.prefixes[prefix]
+	s.mu.Unlock()
+
+	if !ok {
+		return nil, errors.ErrAPIKeyNotFound
+	}
+	return s.Get(ctx, id)
+}
+
+// List implements Store
+func (s *MemoryStore) List(_ context.Context, owner string) ([]*Key, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys := make([]*Key, 0, len(s.keys))
+	for _, key := range s.keys {
+		if owner == "" || key.Owner == owner {
+			copied := copyKey(&key)
+			keys = append(keys, &copied)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].CreatedAt.Equal(keys[j].CreatedAt) {
+			return keys[i].ID < keys[j].ID
+		}
+		return keys[i].CreatedAt.Before(keys[j].CreatedAt)
+	})
+	return keys, nil
+}
+
+func copyKey(key *Key) Key {
+	copied := *key
+	copied.Scopes = append([]string(nil), key.Scopes...)
+	return copied
+}
@@ -0,0 +1,54 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package apikey - Key store tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package apikey
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fintech-bank-platform/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	keys := []*Key{
+		{ID: "b", Prefix: "bbbbbbbb", Owner: "partner-a", Scopes: []string{"x"}, CreatedAt: epoch},
+		{ID: "a", Prefix: "aaaaaaaa", Owner: "partner-a", CreatedAt: epoch},
+		{ID: "c", Prefix: "cccccccc", Owner: "partner-b", CreatedAt: epoch.Add(-time.Hour)},
+	}
+	for _, key := range keys {
+		require.NoError(t, store.Save(ctx, key))
+	}
+
+	keys[0].Scopes[0] = "mutated"
+	found, err := store.FindByPrefix(ctx, "bbbbbbbb")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"x"}, found.Scopes, "the store keeps its own copy")
+
+	all, err := store.List(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c", "a", "b"}, ids(all))
+
+	owned, err := store.List(ctx, "partner-a")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, ids(owned))
+
+	_, err = store.Get(ctx, "missing")
+	assert.ErrorIs(t, err, errors.ErrAPIKeyNotFound)
+	_, err = store.FindByPrefix(ctx, "zzzzzzzz")
+	assert.ErrorIs(t, err, errors.ErrAPIKeyNotFound)
+}
+
+func ids(keys []*Key) []string {
+	result := make([]string, len(keys))
+	for i, key := range keys {
+		result[i] = key.ID
+	}
+	return result
+}
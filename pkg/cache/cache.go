@@ -0,0 +1,331 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package cache - Typed cache-aside over LRU and Redis backends
+// ═══════════════════════════════════════════════════════════════════════════
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"math"
+	"math/rand"
+	"sync/atomic"
+	"time"
+
+	"github.com/fintech-bank-platform/pkg/errors"
+	"golang.org/x/sync/singleflight"
+)
+
+// Defaults used when no option overrides them
+const (
+	DefaultTTL         = time.Minute
+	DefaultNegativeTTL = 10 * time.Second
+	// DefaultBeta weights the probabilistic early expiry; above 1 favors
+	// earlier refreshes
+	DefaultBeta = 1.0
+)
+
+// Backend stores encoded entries. Implementations must be safe for
+// concurrent use.
+type Backend interface {
+	// Get returns the value stored under key and whether it was found
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores value under key, expiring after ttl (never when ttl <= 0)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// Loader reads the value of a key from the source of truth
+type Loader[T any] func(ctx context.Context) (T, error)
+
+// ═══════════════════════════════════════════════════════════════════════════
+// OPTIONS
+// ═══════════════════════════════════════════════════════════════════════════
+
+type config struct {
+	ttl         time.Duration
+	negativeTTL time.Duration
+	beta        float64
+	negative    []error
+	now         func() time.Time
+}
+
+// Option configures a Cache
+type Option func(*config)
+
+// WithTTL sets how long loaded values are kept
+func WithTTL(ttl time.Duration) Option {
+	return func(c *config) {
+		c.ttl = ttl
+	}
+}
+
+// WithNegativeTTL sets how long negative results are kept
+func WithNegativeTTL(ttl time.Duration) Option {
+	return func(c *config) {
+		c.negativeTTL = ttl
+	}
+}
+
+// WithNegative replaces the loader errors that are cached (negative
+// caching), matched with errors.Is. Defaults to errors.ErrAccountNotFound.
+func WithNegative(errs ...error) Option {
+	return func(c *config) {
+		c.negative = errs
+	}
+}
+
+// WithEarlyExpiry sets the beta of the probabilistic early expiry; 0
+// disables it
+func WithEarlyExpiry(beta float64) Option {
+	return func(c *config) {
+		c.beta = beta
+	}
+}
+
+// WithClock replaces time.Now
+func WithClock(now func() time.Time) Option {
+	return func(c *config) {
+		c.now = now
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// CACHE
+// ═══════════════════════════════════════════════════════════════════════════
+
+// Cache keeps values of T under "<name>:<key>" in a backend. Concurrent
+// misses of a key share one load (singleflight), and entries are refreshed
+// shortly before expiring with a probability that grows as expiry nears and
+// with how long the load took (XFetch), so hot keys do not stampede the
+// source when they expire.
+type Cache[T any] struct {
+	backend Backend
+	name    string
+	config
+	group  singleflight.Group
+	stats  counters
+	random func() float64
+}
+
+// New creates a cache named name over backend
+func New[T any](backend Backend, name string, opts ...Option) *Cache[T] {
+	c := &Cache[T]{
+		backend: backend,
+		name:    name,
+		config: config{
+			ttl:         DefaultTTL,
+			negativeTTL: DefaultNegativeTTL,
+			beta:        DefaultBeta,
+			negative:    []error{errors.ErrAccountNotFound},
+			now:         time.Now,
+		},
+		random: rand.Float64,
+	}
+	for _, opt := range opts {
+		opt(&c.config)
+	}
+	return c
+}
+
+// entry is the stored form of a value or negative result
+type entry struct {
+	Value json.RawMessage `json:"v,omitempty"`
+	// Negative is the 1-based index of the cached error in config.negative
+	Negative int   `json:"n,omitempty"`
+	Expiry   int64 `json:"e"`
+	Delta    int64 `json:"d"`
+}
+
+type cached[T any] struct {
+	value  T
+	err    error
+	expiry time.Time
+	delta  time.Duration
+}
+
+// Get returns the cached value of key, calling load on a miss. Cached
+// negative results return their error without calling load.
+func (c *Cache[T]) Get(ctx context.Context, key string, load Loader[T]) (T, error) {
+	hit, found := c.lookup(ctx, key)
+	if found && !c.refreshEarly(hit) {
+		if hit.err != nil {
+			c.stats.negativeHits.Add(1)
+		} else {
+			c.stats.hits.Add(1)
+		}
+		return hit.value, hit.err
+	}
+	if found {
+		c.stats.earlyRefreshes.Add(1)
+	} else {
+		c.stats.misses.Add(1)
+	}
+
+	value, err, _ := c.group.Do(key, func() (interface{}, error) {
+		return c.load(ctx, key, load)
+	})
+	if err != nil && found && !c.isNegative(err) {
+		// The entry has not expired yet: serve it rather than the failure
+		return hit.value, hit.err
+	}
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return value.(T), nil
+}
+
+// Set stores value under key, e.g. right after writing it to the source
+func (c *Cache[T]) Set(ctx context.Context, key string, value T) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return c.store(ctx, key, entry{Value: data}, c.ttl, 0)
+}
+
+// Invalidate removes keys, so the next Get loads them again
+func (c *Cache[T]) Invalidate(ctx context.Context, keys ...string) error {
+	stored := make([]string, len(keys))
+	for i, key := range keys {
+		stored[i] = c.key(key)
+	}
+	return c.backend.Delete(ctx, stored...)
+}
+
+// Stats returns the counters of the cache
+func (c *Cache[T]) Stats() Stats {
+	return c.stats.snapshot()
+}
+
+func (c *Cache[T]) key(key string) string {
+	return c.name + ":" + key
+}
+
+// lookup reads key, treating backend failures and undecodable or expired
+// entries as misses
+func (c *Cache[T]) lookup(ctx context.Context, key string) (cached[T], bool) {
+	var hit cached[T]
+	data, found, err := c.backend.Get(ctx, c.key(key))
+	if err != nil {
+		c.stats.errors.Add(1)
+		return hit, false
+	}
+	if !found {
+		return hit, false
+	}
+
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil || e.Negative < 0 || e.Negative > len(c.negative) {
+		c.stats.errors.Add(1)
+		return hit, false
+	}
+	if e.Negative > 0 {
+		hit.err = c.negative[e.Negative-1]
+	} else if err := json.Unmarshal(e.Value, &hit.value); err != nil {
+		c.stats.errors.Add(1)
+		return hit, false
+	}
+	hit.expiry = time.Unix(0, e.Expiry)
+	hit.delta = time.Duration(e.Delta)
+	return hit, c.now().Before(hit.expiry)
+}
+
+// refreshEarly implements XFetch: now - delta * beta * ln(rand) >= expiry
+func (c *Cache[T]) refreshEarly(hit cached[T]) bool {
+	if c.beta <= 0 || hit.delta <= 0 {
+		return false
+	}
+	gap := -float64(hit.delta) * c.beta * math.Log(1-c.random())
+	return gap >= float64(hit.expiry.Sub(c.now()))
+}
+
+func (c *Cache[T]) load(ctx context.Context, key string, load Loader[T]) (interface{}, error) {
+	start := c.now()
+	value, err := load(ctx)
+	delta := c.now().Sub(start)
+	c.stats.loads.Add(1)
+
+	if err != nil {
+		if i := c.negativeIndex(err); i > 0 {
+			c.storeQuietly(ctx, key, entry{Negative: i}, c.negativeTTL, delta)
+		}
+		return nil, err
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		c.stats.errors.Add(1)
+		return value, nil
+	}
+	c.storeQuietly(ctx, key, entry{Value: data}, c.ttl, delta)
+	return value, nil
+}
+
+func (c *Cache[T]) negativeIndex(err error) int {
+	for i, negative := range c.negative {
+		if stderrors.Is(err, negative) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func (c *Cache[T]) isNegative(err error) bool {
+	return c.negativeIndex(err) > 0
+}
+
+func (c *Cache[T]) store(ctx context.Context, key string, e entry, ttl time.Duration, delta time.Duration) error {
+	e.Expiry = c.now().Add(ttl).UnixNano()
+	e.Delta = int64(delta)
+	data, _ := json.Marshal(e)
+	return c.backend.Set(ctx, c.key(key), data, ttl)
+}
+
+// storeQuietly stores a loaded entry; the value was loaded either way, so a
+// backend failure is only counted
+func (c *Cache[T]) storeQuietly(ctx context.Context, key string, e entry, ttl time.Duration, delta time.Duration) {
+	if err := c.store(ctx, key, e, ttl, delta); err != nil {
+		c.stats.errors.Add(1)
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// METRICS
+// ═══════════════════════════════════════════════════════════════════════════
+
+// Stats counts the lookups of a cache since it was created
+type Stats struct {
+	Hits           int64 `json:"hits"`
+	NegativeHits   int64 `json:"negative_hits"`
+	Misses         int64 `json:"misses"`
+	EarlyRefreshes int64 `json:"early_refreshes"`
+	Loads          int64 `json:"loads"`
+	Errors         int64 `json:"errors"`
+}
+
+// HitRatio is the share of lookups answered from the cache
+func (s Stats) HitRatio() float64 {
+	lookups := s.Hits + s.NegativeHits + s.Misses + s.EarlyRefreshes
+	if lookups == 0 {
+		return 0
+	}
+	return float64(s.Hits+s.NegativeHits) / float64(lookups)
+}
+
+type counters struct {
+	hits, negativeHits, misses, earlyRefreshes, loads, errors atomic.Int64
+}
+
+func (c *counters) snapshot() Stats {
+	return Stats{
+		Hits:           c.hits.Load(),
+		NegativeHits:   c.negativeHits.Load(),
+		Misses:         c.misses.Load(),
+		EarlyRefreshes: c.earlyRefreshes.Load(),
+		Loads:          c.loads.Load(),
+		Errors:         c.errors.Load(),
+	}
+}
@@ -0,0 +1,295 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package cache - Cache-aside tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package cache
+
+import (
+	"context"
+	stderrors "errors"
+	"math"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fintech-bank-platform/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newClock() *clock {
+	return &clock{now: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)}
+}
+
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+type balance struct {
+	AccountID string  `json:"account_id"`
+	Amount    float64 `json:"amount"`
+}
+
+// loader counts its calls and returns value, err
+type loader[T any] struct {
+	calls atomic.Int64
+	value T
+	err   error
+	took  time.Duration
+	clock *clock
+}
+
+func (l *loader[T]) load(context.Context) (T, error) {
+	l.calls.Add(1)
+	if l.clock != nil {
+		l.clock.Advance(l.took)
+	}
+	return l.value, l.err
+}
+
+func newBalances(backend Backend, clk *clock, opts ...Option) *Cache[balance] {
+	return New[balance](backend, "balances", append([]Option{WithClock(clk.Now)}, opts...)...)
+}
+
+func TestCacheAside(t *testing.T) {
+	clk := newClock()
+	backend := NewLRU(10)
+	backend.now = clk.Now
+	c := newBalances(backend, clk, WithTTL(time.Minute))
+	source := &loader[balance]{value: balance{AccountID: "acc-1", Amount: 10}}
+	ctx := context.Background()
+
+	first, err := c.Get(ctx, "acc-1", source.load)
+	require.NoError(t, err)
+	second, err := c.Get(ctx, "acc-1", source.load)
+	require.NoError(t, err)
+
+	assert.Equal(t, source.value, first)
+	assert.Equal(t, first, second)
+	assert.EqualValues(t, 1, source.calls.Load())
+	_, stored, _ := backend.Get(ctx, "balances:acc-1")
+	assert.True(t, stored)
+	assert.Equal(t, Stats{Hits: 1, Misses: 1, Loads: 1}, c.Stats())
+
+	clk.Advance(time.Minute)
+	_, err = c.Get(ctx, "acc-1", source.load)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, source.calls.Load(), "expired")
+}
+
+func TestCacheSetAndInvalidate(t *testing.T) {
+	c := newBalances(NewLRU(10), newClock())
+	source := &loader[balance]{value: balance{Amount: 1}}
+	ctx := context.Background()
+
+	require.NoError(t, c.Set(ctx, "acc-1", balance{Amount: 99}))
+	cached, _ := c.Get(ctx, "acc-1", source.load)
+	assert.Equal(t, 99.0, cached.Amount)
+
+	require.NoError(t, c.Invalidate(ctx, "acc-1"))
+	loaded, _ := c.Get(ctx, "acc-1", source.load)
+	assert.Equal(t, 1.0, loaded.Amount)
+}
+
+func TestCacheNegative(t *testing.T) {
+	clk := newClock()
+	c := newBalances(NewLRU(10), clk, WithNegativeTTL(5*time.Second))
+	source := &loader[balance]{err: errors.ErrAccountNotFound}
+	ctx := context.Background()
+
+	_, err := c.Get(ctx, "acc-404", source.load)
+	assert.ErrorIs(t, err, errors.ErrAccountNotFound)
+	_, err = c.Get(ctx, "acc-404", source.load)
+	assert.ErrorIs(t, err, errors.ErrAccountNotFound)
+	assert.EqualValues(t, 1, source.calls.Load())
+	assert.EqualValues(t, 1, c.Stats().NegativeHits)
+
+	clk.Advance(5 * time.Second)
+	_, _ = c.Get(ctx, "acc-404", source.load)
+	assert.EqualValues(t, 2, source.calls.Load(), "negative TTL elapsed")
+}
+
+func TestCacheNegativeErrorsAreConfigurable(t *testing.T) {
+	c := newBalances(NewLRU(10), newClock(), WithNegative(errors.ErrTransactionNotFound))
+	source := &loader[balance]{err: errors.ErrAccountNotFound}
+
+	_, _ = c.Get(context.Background(), "acc-1", source.load)
+	_, _ = c.Get(context.Background(), "acc-1", source.load)
+
+	assert.EqualValues(t, 2, source.calls.Load())
+}
+
+func TestCacheDoesNotCacheFailures(t *testing.T) {
+	c := newBalances(NewLRU(10), newClock())
+	source := &loader[balance]{err: stderrors.New("cassandra unavailable")}
+
+	_, err := c.Get(context.Background(), "acc-1", source.load)
+	assert.EqualError(t, err, "cassandra unavailable")
+	_, _ = c.Get(context.Background(), "acc-1", source.load)
+
+	assert.EqualValues(t, 2, source.calls.Load())
+}
+
+func TestCacheSingleflight(t *testing.T) {
+	c := newBalances(NewLRU(10), newClock())
+	release := make(chan struct{})
+	var calls atomic.Int64
+	load := func(context.Context) (balance, error) {
+		calls.Add(1)
+		<-release
+		return balance{Amount: 7}, nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]balance, 10)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = c.Get(context.Background(), "acc-1", load)
+		}()
+	}
+	require.Eventually(t, func() bool { return c.Stats().Misses == 10 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.EqualValues(t, 1, calls.Load())
+	for _, result := range results {
+		assert.Equal(t, 7.0, result.Amount)
+	}
+}
+
+// earlyRoll makes -ln(1-random()) equal k
+func earlyRoll(k float64) func() float64 {
+	return func() float64 { return 1 - math.Exp(-k) }
+}
+
+func TestCacheEarlyExpiry(t *testing.T) {
+	clk := newClock()
+	c := newBalances(NewLRU(10), clk, WithTTL(time.Minute))
+	source := &loader[balance]{value: balance{Amount: 1}, took: time.Second, clock: clk}
+	ctx := context.Background()
+	_, _ = c.Get(ctx, "acc-1", source.load)
+	clk.Advance(49 * time.Second) // 10s before expiry
+
+	c.random = earlyRoll(5) // 5s early: not yet
+	_, _ = c.Get(ctx, "acc-1", source.load)
+	assert.EqualValues(t, 1, source.calls.Load())
+
+	c.random = earlyRoll(20) // 20s early: refresh
+	source.value = balance{Amount: 2}
+	refreshed, err := c.Get(ctx, "acc-1", source.load)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2.0, refreshed.Amount)
+	assert.EqualValues(t, 2, source.calls.Load())
+	assert.Equal(t, Stats{Hits: 1, Misses: 1, EarlyRefreshes: 1, Loads: 2}, c.Stats())
+}
+
+func TestCacheEarlyExpiryDisabled(t *testing.T) {
+	clk := newClock()
+	c := newBalances(NewLRU(10), clk, WithEarlyExpiry(0))
+	c.random = earlyRoll(1000)
+	source := &loader[balance]{took: time.Second, clock: clk}
+	_, _ = c.Get(context.Background(), "acc-1", source.load)
+
+	_, _ = c.Get(context.Background(), "acc-1", source.load)
+
+	assert.EqualValues(t, 1, source.calls.Load())
+}
+
+func TestCacheEarlyRefreshFailure(t *testing.T) {
+	clk := newClock()
+	c := newBalances(NewLRU(10), clk)
+	c.random = earlyRoll(1000)
+	source := &loader[balance]{value: balance{Amount: 1}, took: time.Second, clock: clk}
+	ctx := context.Background()
+	_, _ = c.Get(ctx, "acc-1", source.load)
+
+	source.err = stderrors.New("timeout")
+	stale, err := c.Get(ctx, "acc-1", source.load)
+	require.NoError(t, err, "the entry has not expired, so it is served")
+	assert.Equal(t, 1.0, stale.Amount)
+
+	source.err = errors.ErrAccountNotFound
+	_, err = c.Get(ctx, "acc-1", source.load)
+	assert.ErrorIs(t, err, errors.ErrAccountNotFound, "the account is gone")
+}
+
+type failingBackend struct{}
+
+func (failingBackend) Get(context.Context, string) ([]byte, bool, error) {
+	return nil, false, stderrors.New("redis down")
+}
+
+func (failingBackend) Set(context.Context, string, []byte, time.Duration) error {
+	return stderrors.New("redis down")
+}
+
+func (failingBackend) Delete(context.Context, ...string) error {
+	return stderrors.New("redis down")
+}
+
+func TestCacheBackendFailuresFallBackToLoader(t *testing.T) {
+	c := newBalances(failingBackend{}, newClock())
+	source := &loader[balance]{value: balance{Amount: 3}}
+
+	loaded, err := c.Get(context.Background(), "acc-1", source.load)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3.0, loaded.Amount)
+	assert.EqualValues(t, 2, c.Stats().Errors, "read and write failures")
+	assert.Error(t, c.Set(context.Background(), "acc-1", balance{}))
+	assert.Error(t, c.Invalidate(context.Background(), "acc-1"))
+}
+
+func TestCacheIgnoresUnreadableEntries(t *testing.T) {
+	for name, stored := range map[string]string{
+		"not json":       "{",
+		"unknown error":  `{"n":3,"e":4102444800000000000}`,
+		"negative index": `{"n":-1,"e":4102444800000000000}`,
+		"wrong type":     `{"v":"ten","e":4102444800000000000}`,
+	} {
+		backend := NewLRU(10)
+		c := New[int](backend, "n")
+		require.NoError(t, backend.Set(context.Background(), "n:key", []byte(stored), 0))
+
+		value, err := c.Get(context.Background(), "key", func(context.Context) (int, error) { return 10, nil })
+
+		require.NoError(t, err, name)
+		assert.Equal(t, 10, value, name)
+		assert.EqualValues(t, 1, c.Stats().Errors, name)
+	}
+}
+
+func TestCacheUnencodableValues(t *testing.T) {
+	c := New[chan int](NewLRU(10), "chans")
+	ch := make(chan int)
+
+	loaded, err := c.Get(context.Background(), "key", func(context.Context) (chan int, error) { return ch, nil })
+
+	require.NoError(t, err)
+	assert.Equal(t, ch, loaded)
+	assert.EqualValues(t, 1, c.Stats().Errors)
+	assert.Error(t, c.Set(context.Background(), "key", ch))
+}
+
+func TestStatsHitRatio(t *testing.T) {
+	assert.Zero(t, Stats{}.HitRatio())
+	assert.Equal(t, 0.75, Stats{Hits: 2, NegativeHits: 1, Misses: 1}.HitRatio())
+}
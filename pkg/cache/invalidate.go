@@ -0,0 +1,114 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package cache - Event-driven invalidation
+// ═══════════════════════════════════════════════════════════════════════════
+
+package cache
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/fintech-bank-platform/pkg/events"
+)
+
+// AccountEventTypes change what is cached about an account. AccountCreated
+// drops lookups cached before the account existed.
+var AccountEventTypes = []string{
+	events.EventTypes.AccountCreated,
+	events.EventTypes.AccountUpdated,
+	events.EventTypes.AccountDeleted,
+	events.EventTypes.AccountVerified,
+	events.EventTypes.KYCCompleted,
+}
+
+// BalanceEventTypes change account balances
+var BalanceEventTypes = []string{
+	events.EventTypes.TransactionCompleted,
+	events.EventTypes.TransactionReversed,
+	events.EventTypes.TransferCompleted,
+}
+
+// Invalidatable is implemented by Cache
+type Invalidatable interface {
+	Invalidate(ctx context.Context, keys ...string) error
+}
+
+type invalidation struct {
+	cache Invalidatable
+	key   func(accountID string) string
+}
+
+// Invalidator removes cached entries of the accounts an event refers to
+type Invalidator struct {
+	mu    sync.RWMutex
+	rules map[string][]invalidation
+}
+
+// NewInvalidator creates an invalidator without rules
+func NewInvalidator() *Invalidator {
+	return &Invalidator{rules: make(map[string][]invalidation)}
+}
+
+// On invalidates key(accountID) in cache for every account referred to by
+// events of the given types
+func (i *Invalidator) On(cache Invalidatable, key func(accountID string) string, eventTypes ...string) *Invalidator {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, eventType := range eventTypes {
+		i.rules[eventType] = append(i.rules[eventType], invalidation{cache: cache, key: key})
+	}
+	return i
+}
+
+// Handle is an events.Handler; events without rules are ignored
+func (i *Invalidator) Handle(ctx context.Context, event *events.Event) error {
+	i.mu.RLock()
+	rules := i.rules[event.Type]
+	i.mu.RUnlock()
+	if len(rules) == 0 {
+		return nil
+	}
+
+	accountIDs, err := AccountIDs(event)
+	if err != nil || len(accountIDs) == 0 {
+		return err
+	}
+	for _, rule := range rules {
+		keys := make([]string, len(accountIDs))
+		for n, accountID := range accountIDs {
+			keys[n] = rule.key(accountID)
+		}
+		if err := rule.cache.Invalidate(ctx, keys...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Subscribe registers Handle on account.events and transaction.events
+func (i *Invalidator) Subscribe(sub events.Subscriber) {
+	sub.Subscribe(events.Topics.AccountEvents, i.Handle)
+	sub.Subscribe(events.Topics.TransactionEvents, i.Handle)
+}
+
+// AccountIDs returns the distinct account_id, from_account_id and
+// to_account_id of an event payload
+func AccountIDs(event *events.Event) ([]string, error) {
+	var refs struct {
+		AccountID     string `json:"account_id"`
+		FromAccountID string `json:"from_account_id"`
+		ToAccountID   string `json:"to_account_id"`
+	}
+	if err := event.DecodePayload(&refs); err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, id := range []string{refs.AccountID, refs.FromAccountID, refs.ToAccountID} {
+		if id != "" && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
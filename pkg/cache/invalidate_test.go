@@ -0,0 +1,76 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package cache - Invalidation tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fintech-bank-platform/pkg/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func cachedKeys(t *testing.T, backend *LRU, keys ...string) []string {
+	t.Helper()
+	var found []string
+	for _, key := range keys {
+		if _, ok, _ := backend.Get(context.Background(), key); ok {
+			found = append(found, key)
+		}
+	}
+	return found
+}
+
+func TestInvalidatorOnEvents(t *testing.T) {
+	backend := NewLRU(10)
+	accounts := New[string](backend, "accounts")
+	balances := New[float64](backend, "balances")
+	ctx := context.Background()
+	for _, id := range []string{"acc-1", "acc-2", "acc-3"} {
+		require.NoError(t, accounts.Set(ctx, id, "account "+id))
+		require.NoError(t, balances.Set(ctx, id, 10))
+	}
+
+	bus := events.NewMemoryBus()
+	NewInvalidator().
+		On(accounts, func(id string) string { return id }, AccountEventTypes...).
+		On(balances, func(id string) string { return id }, BalanceEventTypes...).
+		Subscribe(bus)
+
+	require.NoError(t, bus.Publish(ctx, events.Topics.TransactionEvents, events.NewTransactionEvent(events.EventTypes.TransferCompleted,
+		events.TransferCompletedPayload{TransferID: "t-1", FromAccountID: "acc-1", ToAccountID: "acc-2", Amount: 5})))
+	assert.Equal(t, []string{"balances:acc-3"}, cachedKeys(t, backend, "balances:acc-1", "balances:acc-2", "balances:acc-3"))
+	assert.Len(t, cachedKeys(t, backend, "accounts:acc-1", "accounts:acc-2"), 2, "accounts unchanged")
+
+	require.NoError(t, bus.Publish(ctx, events.Topics.AccountEvents, events.NewAccountEvent(events.EventTypes.AccountUpdated,
+		events.UpdateAccountPayload{AccountID: "acc-3"})))
+	assert.Equal(t, []string{"accounts:acc-1", "accounts:acc-2"}, cachedKeys(t, backend, "accounts:acc-1", "accounts:acc-2", "accounts:acc-3"))
+
+	require.NoError(t, bus.Publish(ctx, events.Topics.AccountEvents, events.NewAccountEvent(events.EventTypes.AccountCreated,
+		events.AccountCreatedPayload{AccountID: "acc-1"})))
+	assert.Equal(t, []string{"accounts:acc-2"}, cachedKeys(t, backend, "accounts:acc-1", "accounts:acc-2"), "account.created evicts the account")
+}
+
+func TestInvalidatorErrors(t *testing.T) {
+	invalidator := NewInvalidator().On(New[int](failingBackend{}, "n"), func(id string) string { return id }, BalanceEventTypes...)
+	ctx := context.Background()
+
+	assert.Error(t, invalidator.Handle(ctx, events.NewTransactionEvent(events.EventTypes.TransactionCompleted, "not an object")))
+	assert.EqualError(t, invalidator.Handle(ctx, events.NewTransactionEvent(events.EventTypes.TransactionCompleted,
+		events.TransactionCompletedPayload{AccountID: "acc-1"})), "redis down")
+	assert.NoError(t, invalidator.Handle(ctx, events.NewTransactionEvent(events.EventTypes.TransactionCompleted,
+		events.TransactionCompletedPayload{})), "no account, nothing to invalidate")
+	assert.NoError(t, invalidator.Handle(ctx, events.NewAccountEvent(events.EventTypes.AccountCreated, "not an object")), "no rule, payload not read")
+}
+
+func TestAccountIDs(t *testing.T) {
+	ids, err := AccountIDs(events.NewTransactionEvent(events.EventTypes.TransferCompleted, map[string]string{
+		"account_id": "acc-1", "from_account_id": "acc-1", "to_account_id": "acc-2",
+	}))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acc-1", "acc-2"}, ids)
+}
@@ -0,0 +1,110 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package cache - In-memory LRU backend
+// ═══════════════════════════════════════════════════════════════════════════
+
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultLRUCapacity is used when NewLRU gets a capacity below 1
+const DefaultLRUCapacity = 10000
+
+// LRU is an in-memory Backend holding up to capacity entries, evicting the
+// least recently used one when full. Expired entries are dropped when read.
+type LRU struct {
+	mu       sync.Mutex
+	capacity int
+	items    map[string]*list.Element
+	order    *list.List
+	now      func() time.Time
+}
+
+type lruItem struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewLRU creates an empty LRU backend
+func NewLRU(capacity int) *LRU {
+	if capacity < 1 {
+		capacity = DefaultLRUCapacity
+	}
+	return &LRU{
+		capacity: capacity,
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+		now:      time.Now,
+	}
+}
+
+// Get implements Backend
+func (l *LRU) Get(_ context.Context, key string) ([]byte, bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	element, ok := l.items[key]
+	if !ok {
+		return nil, false, nil
+	}
+	item := element.Value.(*lruItem)
+	if !item.expiresAt.IsZero() && !l.now().Before(item.expiresAt) {
+		l.remove(element)
+		return nil, false, nil
+	}
+	l.order.MoveToFront(element)
+	return item.value, true, nil
+}
+
+// Set implements Backend
+func (l *LRU) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	item := &lruItem{key: key, value: value}
+	if ttl > 0 {
+		item.expiresAt = l.now().Add(ttl)
+	}
+	if element, ok := l.items[key]; ok {
+		element.Value = item
+		l.order.MoveToFront(element)
+		return nil
+	}
+
+	l.items[key] = l.order.PushFront(item)
+	if l.order.Len() > l.capacity {
+		l.remove(l.order.Back())
+	}
+	return nil
+}
+
+// Delete implements Backend
+func (l *LRU) Delete(_ context.Context, keys ...string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, key := range keys {
+		if element, ok := l.items[key]; ok {
+			l.remove(element)
+		}
+	}
+	return nil
+}
+
+// Len returns the number of stored entries, including expired ones not
+// read since they expired
+func (l *LRU) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.order.Len()
+}
+
+func (l *LRU) remove(element *list.Element) {
+	l.order.Remove(element)
+	delete(l.items, element.Value.(*lruItem).key)
+}
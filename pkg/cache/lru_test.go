@@ -0,0 +1,76 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package cache - LRU backend tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	lru := NewLRU(2)
+	ctx := context.Background()
+	require.NoError(t, lru.Set(ctx, "a", []byte("1"), 0))
+	require.NoError(t, lru.Set(ctx, "b", []byte("2"), 0))
+	_, _, _ = lru.Get(ctx, "a")
+
+	require.NoError(t, lru.Set(ctx, "c", []byte("3"), 0))
+
+	_, found, _ := lru.Get(ctx, "b")
+	assert.False(t, found, "b was the least recently used")
+	value, found, _ := lru.Get(ctx, "a")
+	assert.True(t, found)
+	assert.Equal(t, []byte("1"), value)
+	assert.Equal(t, 2, lru.Len())
+}
+
+func TestLRUUpdate(t *testing.T) {
+	lru := NewLRU(2)
+	ctx := context.Background()
+	require.NoError(t, lru.Set(ctx, "a", []byte("1"), 0))
+	require.NoError(t, lru.Set(ctx, "b", []byte("2"), 0))
+
+	require.NoError(t, lru.Set(ctx, "a", []byte("10"), 0))
+	require.NoError(t, lru.Set(ctx, "c", []byte("3"), 0))
+
+	value, found, _ := lru.Get(ctx, "a")
+	assert.True(t, found, "updating a refreshed it")
+	assert.Equal(t, []byte("10"), value)
+	assert.Equal(t, 2, lru.Len())
+}
+
+func TestLRUExpiry(t *testing.T) {
+	clk := newClock()
+	lru := NewLRU(0)
+	lru.now = clk.Now
+	ctx := context.Background()
+	require.NoError(t, lru.Set(ctx, "short", []byte("1"), time.Second))
+	require.NoError(t, lru.Set(ctx, "forever", []byte("2"), 0))
+
+	clk.Advance(time.Second)
+
+	_, found, _ := lru.Get(ctx, "short")
+	assert.False(t, found)
+	_, found, _ = lru.Get(ctx, "forever")
+	assert.True(t, found)
+	assert.Equal(t, 1, lru.Len(), "expired entries are dropped when read")
+	assert.Equal(t, DefaultLRUCapacity, lru.capacity)
+}
+
+func TestLRUDelete(t *testing.T) {
+	lru := NewLRU(10)
+	ctx := context.Background()
+	require.NoError(t, lru.Set(ctx, "a", []byte("1"), 0))
+
+	require.NoError(t, lru.Delete(ctx, "a", "missing"))
+
+	_, found, _ := lru.Get(ctx, "a")
+	assert.False(t, found)
+	assert.Zero(t, lru.Len())
+}
@@ -0,0 +1,68 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package cache - Redis backend
+// ═══════════════════════════════════════════════════════════════════════════
+
+package cache
+
+import (
+	"context"
+	stderrors "errors"
+	"net"
+	"os"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis is a Backend speaking the Redis protocol. Expiry is left to Redis.
+type Redis struct {
+	client redis.Cmdable
+}
+
+// NewRedis creates a backend over a go-redis client
+func NewRedis(client redis.Cmdable) *Redis {
+	return &Redis{client: client}
+}
+
+// RedisOptionsFromEnv reads REDIS_HOST, REDIS_PORT and REDIS_PASSWORD
+func RedisOptionsFromEnv() *redis.Options {
+	return &redis.Options{
+		Addr:     net.JoinHostPort(getEnv("REDIS_HOST", "localhost"), getEnv("REDIS_PORT", "6379")),
+		Password: os.Getenv("REDIS_PASSWORD"),
+	}
+}
+
+// Get implements Backend
+func (r *Redis) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := r.client.Get(ctx, key).Bytes()
+	if stderrors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+// Set implements Backend
+func (r *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if ttl < 0 {
+		ttl = 0
+	}
+	return r.client.Set(ctx, key, value, ttl).Err()
+}
+
+// Delete implements Backend
+func (r *Redis) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return r.client.Del(ctx, keys...).Err()
+}
+
+func getEnv(key, defaultValue string) string {
+	if value, exists := os.LookupEnv(key); exists {
+		return value
+	}
+	return defaultValue
+}
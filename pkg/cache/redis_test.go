@@ -0,0 +1,92 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package cache - Redis backend tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/fintech-bank-platform/pkg/errors"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRedis(t *testing.T) (*Redis, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return NewRedis(client), server
+}
+
+func TestRedisBackend(t *testing.T) {
+	backend, server := newRedis(t)
+	ctx := context.Background()
+
+	_, found, err := backend.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	require.NoError(t, backend.Set(ctx, "a", []byte("1"), time.Minute))
+	require.NoError(t, backend.Set(ctx, "b", []byte("2"), -1))
+	value, found, err := backend.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []byte("1"), value)
+	assert.Equal(t, time.Minute, server.TTL("a"))
+	assert.Zero(t, server.TTL("b"), "no expiry")
+
+	server.FastForward(time.Minute)
+	_, found, _ = backend.Get(ctx, "a")
+	assert.False(t, found, "expired by Redis")
+
+	require.NoError(t, backend.Delete(ctx))
+	require.NoError(t, backend.Delete(ctx, "b"))
+	assert.False(t, server.Exists("b"))
+}
+
+func TestRedisBackendUnavailable(t *testing.T) {
+	backend, server := newRedis(t)
+	server.Close()
+
+	_, _, err := backend.Get(context.Background(), "a")
+
+	assert.Error(t, err)
+}
+
+func TestCacheOverRedis(t *testing.T) {
+	backend, server := newRedis(t)
+	c := New[balance](backend, "balances", WithTTL(30*time.Second))
+	source := &loader[balance]{err: errors.ErrAccountNotFound}
+	ctx := context.Background()
+
+	_, err := c.Get(ctx, "acc-404", source.load)
+	assert.ErrorIs(t, err, errors.ErrAccountNotFound)
+	assert.Equal(t, DefaultNegativeTTL, server.TTL("balances:acc-404"))
+
+	source.err = nil
+	source.value = balance{AccountID: "acc-1", Amount: 5}
+	_, _ = c.Get(ctx, "acc-1", source.load)
+	cached, err := c.Get(ctx, "acc-1", source.load)
+	require.NoError(t, err)
+	assert.Equal(t, source.value, cached)
+	assert.Equal(t, 30*time.Second, server.TTL("balances:acc-1"))
+	assert.EqualValues(t, 2, source.calls.Load())
+}
+
+func TestRedisOptionsFromEnv(t *testing.T) {
+	assert.Equal(t, "localhost:6379", RedisOptionsFromEnv().Addr)
+
+	t.Setenv("REDIS_HOST", "fintech-redis")
+	t.Setenv("REDIS_PORT", "6380")
+	t.Setenv("REDIS_PASSWORD", "secret")
+	options := RedisOptionsFromEnv()
+
+	assert.Equal(t, "fintech-redis:6380", options.Addr)
+	assert.Equal(t, "secret", options.Password)
+}
@@ -0,0 +1,392 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package calendar - Brazilian banking business-day calendar
+// ═══════════════════════════════════════════════════════════════════════════
+
+package calendar
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// dateLayout is the layout used for holiday keys and holiday files
+const dateLayout = "2006-01-02"
+
+// recurringLayout is the layout used for holidays repeated every year
+const recurringLayout = "01-02"
+
+// Holiday represents a non-business day
+type Holiday struct {
+	Date time.Time `json:"date"`
+	Name string    `json:"name"`
+}
+
+// Calendar holds national holidays plus any extra municipal/state holidays
+type Calendar struct {
+	mu        sync.RWMutex
+	location  *time.Location
+	extra     map[string]string
+	recurring map[string]string
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// CONSTRUCTORS
+// ═══════════════════════════════════════════════════════════════════════════
+
+// New creates a calendar with the Brazilian national banking holidays
+func New() *Calendar {
+	return NewWithLocation(defaultLocation())
+}
+
+// NewWithLocation creates a calendar that evaluates dates in the given location
+func NewWithLocation(loc *time.Location) *Calendar {
+	if loc == nil {
+		loc = defaultLocation()
+	}
+	return &Calendar{
+		location:  loc,
+		extra:     make(map[string]string),
+		recurring: make(map[string]string),
+	}
+}
+
+// defaultLocation returns the location used by Brazilian banks
+func defaultLocation() *time.Location {
+	return loadLocation("America/Sao_Paulo")
+}
+
+// loadLocation loads a location, falling back to a fixed UTC-3 zone when the
+// tz database is not available in the container
+func loadLocation(name string) *time.Location {
+	if loc, err := time.LoadLocation(name); err == nil {
+		return loc
+	}
+	return time.FixedZone("BRT", -3*60*60)
+}
+
+// Location returns the location used to evaluate dates
+func (c *Calendar) Location() *time.Location {
+	return c.location
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// EXTRA HOLIDAYS
+// ═══════════════════════════════════════════════════════════════════════════
+
+// AddHoliday registers a one-off holiday (e.g. a municipal holiday for a given year)
+func (c *Calendar) AddHoliday(date time.Time, name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.extra[c.key(date)] = name
+}
+
+// AddRecurringHoliday registers a holiday that repeats every year on the same day
+func (c *Calendar) AddRecurringHoliday(month time.Month, day int, name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.recurring[fmt.Sprintf("%02d-%02d", int(month), day)] = name
+}
+
+// LoadHolidays reads extra holidays from CSV data.
+// Each line is "YYYY-MM-DD,Name" for one-off holidays or "MM-DD,Name" for
+// recurring ones. Empty lines and lines starting with '#' are ignored.
+func (c *Calendar) LoadHolidays(r io.Reader) error {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return fmt.Errorf("calendar: invalid holiday file: %w", err)
+	}
+
+	for i, record := range records {
+		value := strings.TrimSpace(record[0])
+		name := strings.TrimSpace(record[1])
+
+		if date, err := time.ParseInLocation(dateLayout, value, c.location); err == nil {
+			c.AddHoliday(date, name)
+			continue
+		}
+
+		if date, err := time.Parse(recurringLayout, value); err == nil {
+			c.AddRecurringHoliday(date.Month(), date.Day(), name)
+			continue
+		}
+
+		return fmt.Errorf("calendar: invalid holiday date %q on line %d", value, i+1)
+	}
+
+	return nil
+}
+
+// LoadHolidaysFile reads extra holidays from a CSV file (see LoadHolidays)
+func (c *Calendar) LoadHolidaysFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("calendar: %w", err)
+	}
+	defer file.Close()
+
+	return c.LoadHolidays(file)
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// QUERIES
+// ═══════════════════════════════════════════════════════════════════════════
+
+// HolidayName returns the holiday name for the given date, if any
+func (c *Calendar) HolidayName(t time.Time) (string, bool) {
+	local := t.In(c.location)
+
+	if name, ok := nationalHolidays(local.Year())[c.key(local)]; ok {
+		return name, true
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if name, ok := c.extra[c.key(local)]; ok {
+		return name, true
+	}
+
+	name, ok := c.recurring[local.Format(recurringLayout)]
+	return name, ok
+}
+
+// IsHoliday checks if the given date is a holiday
+func (c *Calendar) IsHoliday(t time.Time) bool {
+	_, ok := c.HolidayName(t)
+	return ok
+}
+
+// IsBusinessDay checks if banks are open on the given date
+func (c *Calendar) IsBusinessDay(t time.Time) bool {
+	local := t.In(c.location)
+	if local.Weekday() == time.Saturday || local.Weekday() == time.Sunday {
+		return false
+	}
+	return !c.IsHoliday(local)
+}
+
+// NextBusinessDay returns the first business day strictly after t
+func (c *Calendar) NextBusinessDay(t time.Time) time.Time {
+	return c.AddBusinessDays(t, 1)
+}
+
+// PreviousBusinessDay returns the last business day strictly before t
+func (c *Calendar) PreviousBusinessDay(t time.Time) time.Time {
+	return c.AddBusinessDays(t, -1)
+}
+
+// AdjustToBusinessDay returns t when it is a business day, otherwise the next
+// business day (the rule used for boleto due dates falling on holidays)
+func (c *Calendar) AdjustToBusinessDay(t time.Time) time.Time {
+	if c.IsBusinessDay(t) {
+		return t
+	}
+	return c.NextBusinessDay(t)
+}
+
+// AddBusinessDays moves t by n business days, backwards when n is negative.
+// The time of day is preserved.
+func (c *Calendar) AddBusinessDays(t time.Time, n int) time.Time {
+	step := 1
+	if n < 0 {
+		step = -1
+		n = -n
+	}
+
+	current := t.In(c.location)
+	for n > 0 {
+		current = current.AddDate(0, 0, step)
+		if c.IsBusinessDay(current) {
+			n--
+		}
+	}
+
+	return current.In(t.Location())
+}
+
+// BusinessDaysBetween counts the business days in the interval (from, to].
+// The result is negative when to is before from.
+func (c *Calendar) BusinessDaysBetween(from, to time.Time) int {
+	sign := 1
+	if to.Before(from) {
+		from, to = to, from
+		sign = -1
+	}
+
+	start := c.startOfDay(from)
+	end := c.startOfDay(to)
+
+	count := 0
+	for day := start.AddDate(0, 0, 1); !day.After(end); day = day.AddDate(0, 0, 1) {
+		if c.IsBusinessDay(day) {
+			count++
+		}
+	}
+
+	return sign * count
+}
+
+// Holidays returns every holiday in the given year, sorted by date
+func (c *Calendar) Holidays(year int) []Holiday {
+	byDate := make(map[string]string)
+	for key, name := range nationalHolidays(year) {
+		byDate[key] = name
+	}
+
+	c.mu.RLock()
+	for key, name := range c.recurring {
+		byDate[fmt.Sprintf("%04d-%s", year, key)] = name
+	}
+	for key, name := range c.extra {
+		if strings.HasPrefix(key, fmt.Sprintf("%04d-", year)) {
+			byDate[key] = name
+		}
+	}
+	c.mu.RUnlock()
+
+	holidays := make([]Holiday, 0, len(byDate))
+	for key, name := range byDate {
+		date, err := time.ParseInLocation(dateLayout, key, c.location)
+		if err != nil {
+			// Recurring entries such as 02-29 do not exist in every year
+			continue
+		}
+		holidays = append(holidays, Holiday{Date: date, Name: name})
+	}
+
+	sort.Slice(holidays, func(i, j int) bool {
+		return holidays[i].Date.Before(holidays[j].Date)
+	})
+
+	return holidays
+}
+
+// key returns the holiday map key for a date in the calendar location
+func (c *Calendar) key(t time.Time) string {
+	return t.In(c.location).Format(dateLayout)
+}
+
+// startOfDay truncates t to midnight in the calendar location
+func (c *Calendar) startOfDay(t time.Time) time.Time {
+	local := t.In(c.location)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, c.location)
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// NATIONAL HOLIDAYS
+// ═══════════════════════════════════════════════════════════════════════════
+
+// Easter returns Easter Sunday for the given year (anonymous Gregorian algorithm)
+func Easter(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// nationalHolidays returns the national banking holidays for a year keyed by date.
+// Carnival Monday/Tuesday and Corpus Christi are not federal holidays, but banks
+// do not open on those days (FEBRABAN calendar).
+func nationalHolidays(year int) map[string]string {
+	easter := Easter(year)
+	format := func(t time.Time) string { return t.Format(dateLayout) }
+	fixed := func(month time.Month, day int) string {
+		return format(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+	}
+
+	holidays := map[string]string{
+		fixed(time.January, 1):            "Confraternização Universal",
+		format(easter.AddDate(0, 0, -48)): "Carnaval (segunda-feira)",
+		format(easter.AddDate(0, 0, -47)): "Carnaval (terça-feira)",
+		format(easter.AddDate(0, 0, -2)):  "Sexta-feira Santa",
+		fixed(time.April, 21):             "Tiradentes",
+		fixed(time.May, 1):                "Dia do Trabalho",
+		format(easter.AddDate(0, 0, 60)):  "Corpus Christi",
+		fixed(time.September, 7):          "Independência do Brasil",
+		fixed(time.October, 12):           "Nossa Senhora Aparecida",
+		fixed(time.November, 2):           "Finados",
+		fixed(time.November, 15):          "Proclamação da República",
+		fixed(time.December, 25):          "Natal",
+	}
+
+	// Lei 14.759/2023 made Black Awareness Day a national holiday from 2024
+	if year >= 2024 {
+		holidays[fixed(time.November, 20)] = "Dia Nacional de Zumbi e da Consciência Negra"
+	}
+
+	return holidays
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// DEFAULT CALENDAR
+// ═══════════════════════════════════════════════════════════════════════════
+
+var defaultCalendar = New()
+
+// Default returns the shared calendar used by the package-level helpers
+func Default() *Calendar {
+	return defaultCalendar
+}
+
+// IsBusinessDay checks if banks are open on the given date (default calendar)
+func IsBusinessDay(t time.Time) bool {
+	return defaultCalendar.IsBusinessDay(t)
+}
+
+// IsHoliday checks if the given date is a holiday (default calendar)
+func IsHoliday(t time.Time) bool {
+	return defaultCalendar.IsHoliday(t)
+}
+
+// NextBusinessDay returns the first business day strictly after t (default calendar)
+func NextBusinessDay(t time.Time) time.Time {
+	return defaultCalendar.NextBusinessDay(t)
+}
+
+// PreviousBusinessDay returns the last business day strictly before t (default calendar)
+func PreviousBusinessDay(t time.Time) time.Time {
+	return defaultCalendar.PreviousBusinessDay(t)
+}
+
+// AdjustToBusinessDay returns t or the next business day (default calendar)
+func AdjustToBusinessDay(t time.Time) time.Time {
+	return defaultCalendar.AdjustToBusinessDay(t)
+}
+
+// AddBusinessDays moves t by n business days (default calendar)
+func AddBusinessDays(t time.Time, n int) time.Time {
+	return defaultCalendar.AddBusinessDays(t, n)
+}
+
+// BusinessDaysBetween counts the business days in (from, to] (default calendar)
+func BusinessDaysBetween(from, to time.Time) int {
+	return defaultCalendar.BusinessDaysBetween(from, to)
+}
+
+// LoadHolidaysFile loads extra holidays into the default calendar
+func LoadHolidaysFile(path string) error {
+	return defaultCalendar.LoadHolidaysFile(path)
+}
@@ -0,0 +1,329 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package calendar - Tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package calendar
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 12, 0, 0, 0, defaultLocation())
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// EASTER TESTS
+// ═══════════════════════════════════════════════════════════════════════════
+
+func TestEaster(t *testing.T) {
+	tests := []struct {
+		year     int
+		expected string
+	}{
+		{2019, "2019-04-21"},
+		{2024, "2024-03-31"},
+		{2025, "2025-04-20"},
+		{2026, "2026-04-05"},
+		{2038, "2038-04-25"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Easter(tt.year).Format(dateLayout))
+		})
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// NATIONAL HOLIDAY TESTS
+// ═══════════════════════════════════════════════════════════════════════════
+
+func TestNationalHolidays(t *testing.T) {
+	cal := New()
+
+	tests := []struct {
+		name string
+		date time.Time
+	}{
+		{"Confraternização Universal", date(2025, time.January, 1)},
+		{"Carnaval (segunda-feira)", date(2025, time.March, 3)},
+		{"Carnaval (terça-feira)", date(2025, time.March, 4)},
+		{"Sexta-feira Santa", date(2025, time.April, 18)},
+		{"Tiradentes", date(2025, time.April, 21)},
+		{"Dia do Trabalho", date(2025, time.May, 1)},
+		{"Corpus Christi", date(2025, time.June, 19)},
+		{"Independência do Brasil", date(2025, time.September, 7)},
+		{"Nossa Senhora Aparecida", date(2025, time.October, 12)},
+		{"Finados", date(2025, time.November, 2)},
+		{"Proclamação da República", date(2025, time.November, 15)},
+		{"Dia Nacional de Zumbi e da Consciência Negra", date(2025, time.November, 20)},
+		{"Natal", date(2025, time.December, 25)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, ok := cal.HolidayName(tt.date)
+			assert.True(t, ok)
+			assert.Equal(t, tt.name, name)
+			assert.True(t, cal.IsHoliday(tt.date))
+		})
+	}
+}
+
+func TestBlackAwarenessDayOnlyFrom2024(t *testing.T) {
+	cal := New()
+
+	assert.False(t, cal.IsHoliday(date(2023, time.November, 20)))
+	assert.True(t, cal.IsHoliday(date(2024, time.November, 20)))
+}
+
+func TestAshWednesdayIsBusinessDay(t *testing.T) {
+	assert.True(t, New().IsBusinessDay(date(2025, time.March, 5)))
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// BUSINESS DAY TESTS
+// ═══════════════════════════════════════════════════════════════════════════
+
+func TestIsBusinessDay(t *testing.T) {
+	cal := New()
+
+	tests := []struct {
+		name     string
+		date     time.Time
+		expected bool
+	}{
+		{"regular weekday", date(2025, time.June, 10), true},
+		{"saturday", date(2025, time.June, 14), false},
+		{"sunday", date(2025, time.June, 15), false},
+		{"holiday on weekday", date(2025, time.April, 21), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, cal.IsBusinessDay(tt.date))
+		})
+	}
+}
+
+func TestIsBusinessDayUsesCalendarLocation(t *testing.T) {
+	cal := New()
+
+	// 2025-04-22 01:00 UTC is still Tiradentes (2025-04-21) in São Paulo
+	utc := time.Date(2025, time.April, 22, 1, 0, 0, 0, time.UTC)
+	assert.False(t, cal.IsBusinessDay(utc))
+}
+
+func TestNextBusinessDay(t *testing.T) {
+	cal := New()
+
+	// Friday before Carnival → Ash Wednesday
+	next := cal.NextBusinessDay(date(2025, time.February, 28))
+	assert.Equal(t, "2025-03-05", next.Format(dateLayout))
+	assert.Equal(t, 12, next.Hour())
+}
+
+func TestPreviousBusinessDay(t *testing.T) {
+	cal := New()
+
+	// Monday after Easter → Thursday before Good Friday
+	previous := cal.PreviousBusinessDay(date(2025, time.April, 21))
+	assert.Equal(t, "2025-04-17", previous.Format(dateLayout))
+}
+
+func TestAdjustToBusinessDay(t *testing.T) {
+	cal := New()
+
+	businessDay := date(2025, time.June, 10)
+	assert.Equal(t, businessDay, cal.AdjustToBusinessDay(businessDay))
+
+	adjusted := cal.AdjustToBusinessDay(date(2025, time.December, 25))
+	assert.Equal(t, "2025-12-26", adjusted.Format(dateLayout))
+}
+
+func TestAddBusinessDays(t *testing.T) {
+	cal := New()
+
+	tests := []struct {
+		name     string
+		start    time.Time
+		days     int
+		expected string
+	}{
+		{"zero days", date(2025, time.June, 10), 0, "2025-06-10"},
+		{"skips weekend", date(2025, time.June, 13), 1, "2025-06-16"},
+		{"skips Corpus Christi", date(2025, time.June, 18), 2, "2025-06-23"},
+		{"backwards", date(2025, time.June, 16), -1, "2025-06-13"},
+		{"year boundary", date(2025, time.December, 31), 1, "2026-01-02"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, cal.AddBusinessDays(tt.start, tt.days).Format(dateLayout))
+		})
+	}
+}
+
+func TestAddBusinessDaysPreservesLocation(t *testing.T) {
+	start := time.Date(2025, time.June, 13, 15, 0, 0, 0, time.UTC)
+	result := New().AddBusinessDays(start, 1)
+
+	assert.Equal(t, time.UTC, result.Location())
+	assert.Equal(t, "2025-06-16", result.Format(dateLayout))
+}
+
+func TestBusinessDaysBetween(t *testing.T) {
+	cal := New()
+
+	tests := []struct {
+		name     string
+		from     time.Time
+		to       time.Time
+		expected int
+	}{
+		{"same day", date(2025, time.June, 10), date(2025, time.June, 10), 0},
+		{"one week", date(2025, time.June, 9), date(2025, time.June, 16), 5},
+		{"with holiday", date(2025, time.June, 16), date(2025, time.June, 23), 4},
+		{"reversed", date(2025, time.June, 16), date(2025, time.June, 9), -5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, cal.BusinessDaysBetween(tt.from, tt.to))
+		})
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// EXTRA HOLIDAY TESTS
+// ═══════════════════════════════════════════════════════════════════════════
+
+func TestAddHoliday(t *testing.T) {
+	cal := New()
+	cal.AddHoliday(date(2025, time.July, 9), "Revolução Constitucionalista")
+
+	name, ok := cal.HolidayName(date(2025, time.July, 9))
+	assert.True(t, ok)
+	assert.Equal(t, "Revolução Constitucionalista", name)
+	assert.False(t, cal.IsHoliday(date(2026, time.July, 9)))
+}
+
+func TestAddRecurringHoliday(t *testing.T) {
+	cal := New()
+	cal.AddRecurringHoliday(time.January, 25, "Aniversário de São Paulo")
+
+	assert.True(t, cal.IsHoliday(date(2027, time.January, 25)))
+	assert.True(t, cal.IsHoliday(date(2030, time.January, 25)))
+}
+
+func TestLoadHolidays(t *testing.T) {
+	cal := New()
+	data := `# municipal and state holidays
+2025-07-09,Revolução Constitucionalista
+01-25, Aniversário de São Paulo
+`
+
+	err := cal.LoadHolidays(strings.NewReader(data))
+
+	require.NoError(t, err)
+	assert.True(t, cal.IsHoliday(date(2025, time.July, 9)))
+	assert.True(t, cal.IsHoliday(date(2026, time.January, 25)))
+
+	name, _ := cal.HolidayName(date(2026, time.January, 25))
+	assert.Equal(t, "Aniversário de São Paulo", name)
+}
+
+func TestLoadHolidaysInvalidDate(t *testing.T) {
+	err := New().LoadHolidays(strings.NewReader("2025-13-40,Invalid\n"))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "line 1")
+}
+
+func TestLoadHolidaysInvalidCSV(t *testing.T) {
+	err := New().LoadHolidays(strings.NewReader("2025-07-09\n"))
+
+	assert.Error(t, err)
+}
+
+func TestLoadHolidaysFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "holidays.csv")
+	require.NoError(t, os.WriteFile(path, []byte("11-20,Consciência Negra\n"), 0o600))
+
+	cal := New()
+	require.NoError(t, cal.LoadHolidaysFile(path))
+	assert.True(t, cal.IsHoliday(date(2023, time.November, 20)))
+}
+
+func TestLoadHolidaysFileMissing(t *testing.T) {
+	err := New().LoadHolidaysFile(filepath.Join(t.TempDir(), "missing.csv"))
+
+	assert.Error(t, err)
+}
+
+func TestHolidays(t *testing.T) {
+	cal := New()
+	cal.AddRecurringHoliday(time.January, 25, "Aniversário de São Paulo")
+	cal.AddRecurringHoliday(time.February, 29, "Leap Day")
+	cal.AddHoliday(date(2025, time.July, 9), "Revolução Constitucionalista")
+	cal.AddHoliday(date(2026, time.July, 9), "Revolução Constitucionalista")
+
+	holidays := cal.Holidays(2025)
+
+	assert.Len(t, holidays, 15)
+	assert.Equal(t, "Confraternização Universal", holidays[0].Name)
+	assert.Equal(t, "Aniversário de São Paulo", holidays[1].Name)
+	assert.Equal(t, "Natal", holidays[len(holidays)-1].Name)
+
+	for i := 1; i < len(holidays); i++ {
+		assert.True(t, holidays[i-1].Date.Before(holidays[i].Date))
+	}
+}
+
+func TestNewWithLocation(t *testing.T) {
+	cal := NewWithLocation(time.UTC)
+	assert.Equal(t, time.UTC, cal.Location())
+
+	cal = NewWithLocation(nil)
+	assert.NotNil(t, cal.Location())
+}
+
+func TestLoadLocationFallback(t *testing.T) {
+	loc := loadLocation("Invalid/Zone")
+
+	_, offset := time.Date(2025, time.June, 10, 0, 0, 0, 0, loc).Zone()
+	assert.Equal(t, -3*60*60, offset)
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// DEFAULT CALENDAR TESTS
+// ═══════════════════════════════════════════════════════════════════════════
+
+func TestDefaultCalendarHelpers(t *testing.T) {
+	assert.Same(t, defaultCalendar, Default())
+
+	friday := date(2025, time.June, 13)
+	assert.True(t, IsBusinessDay(friday))
+	assert.False(t, IsHoliday(friday))
+	assert.Equal(t, "2025-06-16", NextBusinessDay(friday).Format(dateLayout))
+	assert.Equal(t, "2025-06-12", PreviousBusinessDay(friday).Format(dateLayout))
+	assert.Equal(t, "2025-06-16", AdjustToBusinessDay(date(2025, time.June, 14)).Format(dateLayout))
+	assert.Equal(t, "2025-06-17", AddBusinessDays(friday, 2).Format(dateLayout))
+	assert.Equal(t, 1, BusinessDaysBetween(friday, date(2025, time.June, 16)))
+}
+
+func TestDefaultLoadHolidaysFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "holidays.csv")
+	require.NoError(t, os.WriteFile(path, []byte("2099-03-10,Test Holiday\n"), 0o600))
+
+	require.NoError(t, LoadHolidaysFile(path))
+	assert.True(t, IsHoliday(date(2099, time.March, 10)))
+}
@@ -0,0 +1,214 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package cassandra - Sessions, migrations and repositories
+// ═══════════════════════════════════════════════════════════════════════════
+
+package cassandra
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+// Defaults used when the environment does not override them
+const (
+	DefaultHosts       = "localhost:9042"
+	DefaultKeyspace    = "fintech"
+	DefaultConsistency = gocql.LocalQuorum
+	DefaultTimeout     = 5 * time.Second
+)
+
+// ErrInvalidIdentifier is returned for keyspace or table names that are not
+// plain CQL identifiers
+var ErrInvalidIdentifier = stderrors.New("cassandra: invalid identifier")
+
+var identifier = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]{0,47}$`)
+
+// ═══════════════════════════════════════════════════════════════════════════
+// CONFIG
+// ═══════════════════════════════════════════════════════════════════════════
+
+// Config holds the connection settings of a session
+type Config struct {
+	Hosts       []string
+	Keyspace    string
+	Consistency gocql.Consistency
+	Timeout     time.Duration
+	Username    string
+	Password    string
+}
+
+// ConfigFromEnv reads CASSANDRA_HOSTS (comma separated), CASSANDRA_KEYSPACE,
+// CASSANDRA_CONSISTENCY, CASSANDRA_TIMEOUT, CASSANDRA_USERNAME and
+// CASSANDRA_PASSWORD
+func ConfigFromEnv() (Config, error) {
+	cfg := Config{
+		Hosts:       splitHosts(getEnv("CASSANDRA_HOSTS", DefaultHosts)),
+		Keyspace:    getEnv("CASSANDRA_KEYSPACE", DefaultKeyspace),
+		Consistency: DefaultConsistency,
+		Timeout:     DefaultTimeout,
+		Username:    os.Getenv("CASSANDRA_USERNAME"),
+		Password:    os.Getenv("CASSANDRA_PASSWORD"),
+	}
+
+	if value, exists := os.LookupEnv("CASSANDRA_CONSISTENCY"); exists {
+		consistency, err := gocql.ParseConsistencyWrapper(value)
+		if err != nil {
+			return Config{}, fmt.Errorf("CASSANDRA_CONSISTENCY: %w", err)
+		}
+		cfg.Consistency = consistency
+	}
+	if value, exists := os.LookupEnv("CASSANDRA_TIMEOUT"); exists {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return Config{}, fmt.Errorf("CASSANDRA_TIMEOUT: %w", err)
+		}
+		cfg.Timeout = timeout
+	}
+	if cfg.Keyspace != "" && !identifier.MatchString(cfg.Keyspace) {
+		return Config{}, fmt.Errorf("CASSANDRA_KEYSPACE: %w: %q", ErrInvalidIdentifier, cfg.Keyspace)
+	}
+	return cfg, nil
+}
+
+// Cluster builds the gocql cluster configuration
+func (c Config) Cluster() *gocql.ClusterConfig {
+	cluster := gocql.NewCluster(c.Hosts...)
+	cluster.Keyspace = c.Keyspace
+	cluster.Consistency = c.Consistency
+	cluster.Timeout = c.Timeout
+	cluster.ConnectTimeout = c.Timeout
+	if c.Username != "" {
+		cluster.Authenticator = gocql.PasswordAuthenticator{Username: c.Username, Password: c.Password}
+	}
+	return cluster
+}
+
+func getEnv(key, defaultValue string) string {
+	if value, exists := os.LookupEnv(key); exists {
+		return value
+	}
+	return defaultValue
+}
+
+func splitHosts(value string) []string {
+	var hosts []string
+	for _, host := range strings.Split(value, ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// SESSION
+// ═══════════════════════════════════════════════════════════════════════════
+
+// Session is the part of *gocql.Session used by the platform. NewSession
+// returns the gocql implementation and FakeSession replaces it in tests.
+type Session interface {
+	Query(stmt string, values ...interface{}) Query
+	Close()
+}
+
+// Query mirrors *gocql.Query. Scan returns gocql.ErrNotFound without rows.
+type Query interface {
+	WithContext(ctx context.Context) Query
+	Consistency(c gocql.Consistency) Query
+	SerialConsistency(c gocql.SerialConsistency) Query
+	Exec() error
+	Scan(dest ...interface{}) error
+	MapScanCAS(dest map[string]interface{}) (applied bool, err error)
+	Iter() Iter
+}
+
+// Iter mirrors *gocql.Iter
+type Iter interface {
+	Scan(dest ...interface{}) bool
+	Close() error
+}
+
+var createSession = func(cluster *gocql.ClusterConfig) (*gocql.Session, error) {
+	return cluster.CreateSession()
+}
+
+// NewSession connects to the cluster described by cfg
+func NewSession(cfg Config) (Session, error) {
+	session, err := createSession(cfg.Cluster())
+	if err != nil {
+		return nil, err
+	}
+	return Wrap(session), nil
+}
+
+// Wrap adapts a gocql session to Session
+func Wrap(session *gocql.Session) Session {
+	return gocqlSession{session}
+}
+
+type gocqlSession struct {
+	session *gocql.Session
+}
+
+func (s gocqlSession) Query(stmt string, values ...interface{}) Query {
+	return gocqlQuery{s.session.Query(stmt, values...)}
+}
+
+func (s gocqlSession) Close() {
+	s.session.Close()
+}
+
+type gocqlQuery struct {
+	query *gocql.Query
+}
+
+func (q gocqlQuery) WithContext(ctx context.Context) Query {
+	return gocqlQuery{q.query.WithContext(ctx)}
+}
+
+func (q gocqlQuery) Consistency(c gocql.Consistency) Query {
+	return gocqlQuery{q.query.Consistency(c)}
+}
+
+func (q gocqlQuery) SerialConsistency(c gocql.SerialConsistency) Query {
+	return gocqlQuery{q.query.SerialConsistency(c)}
+}
+
+func (q gocqlQuery) Exec() error {
+	return q.query.Exec()
+}
+
+func (q gocqlQuery) Scan(dest ...interface{}) error {
+	return q.query.Scan(dest...)
+}
+
+func (q gocqlQuery) MapScanCAS(dest map[string]interface{}) (bool, error) {
+	return q.query.MapScanCAS(dest)
+}
+
+func (q gocqlQuery) Iter() Iter {
+	return q.query.Iter()
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// KEYSPACE
+// ═══════════════════════════════════════════════════════════════════════════
+
+// EnsureKeyspace creates keyspace with SimpleStrategy replication if it does
+// not exist. session must not be bound to the keyspace being created.
+func EnsureKeyspace(ctx context.Context, session Session, keyspace string, replicationFactor int) error {
+	if !identifier.MatchString(keyspace) {
+		return fmt.Errorf("%w: %q", ErrInvalidIdentifier, keyspace)
+	}
+
+	stmt := fmt.Sprintf("CREATE KEYSPACE IF NOT EXISTS %s WITH replication = {'class': 'SimpleStrategy', 'replication_factor': %d}",
+		keyspace, replicationFactor)
+	return session.Query(stmt).WithContext(ctx).Consistency(gocql.All).Exec()
+}
@@ -0,0 +1,119 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package cassandra - Session and config tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package cassandra
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigFromEnvDefaults(t *testing.T) {
+	cfg, err := ConfigFromEnv()
+
+	require.NoError(t, err)
+	assert.Equal(t, Config{
+		Hosts:       []string{"localhost:9042"},
+		Keyspace:    "fintech",
+		Consistency: gocql.LocalQuorum,
+		Timeout:     5 * time.Second,
+	}, cfg)
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("CASSANDRA_HOSTS", "cass-1:9042, cass-2:9042,")
+	t.Setenv("CASSANDRA_KEYSPACE", "ledger")
+	t.Setenv("CASSANDRA_CONSISTENCY", "quorum")
+	t.Setenv("CASSANDRA_TIMEOUT", "2s")
+	t.Setenv("CASSANDRA_USERNAME", "app")
+	t.Setenv("CASSANDRA_PASSWORD", "secret")
+
+	cfg, err := ConfigFromEnv()
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cass-1:9042", "cass-2:9042"}, cfg.Hosts)
+	assert.Equal(t, "ledger", cfg.Keyspace)
+	assert.Equal(t, gocql.Quorum, cfg.Consistency)
+	assert.Equal(t, 2*time.Second, cfg.Timeout)
+
+	cluster := cfg.Cluster()
+	assert.Equal(t, []string{"cass-1:9042", "cass-2:9042"}, cluster.Hosts)
+	assert.Equal(t, "ledger", cluster.Keyspace)
+	assert.Equal(t, gocql.Quorum, cluster.Consistency)
+	assert.Equal(t, 2*time.Second, cluster.ConnectTimeout)
+	assert.Equal(t, gocql.PasswordAuthenticator{Username: "app", Password: "secret"}, cluster.Authenticator)
+}
+
+func TestConfigFromEnvInvalid(t *testing.T) {
+	for env, value := range map[string]string{
+		"CASSANDRA_CONSISTENCY": "most",
+		"CASSANDRA_TIMEOUT":     "soon",
+		"CASSANDRA_KEYSPACE":    "fintech; DROP KEYSPACE x",
+	} {
+		t.Run(env, func(t *testing.T) {
+			t.Setenv(env, value)
+
+			_, err := ConfigFromEnv()
+
+			assert.ErrorContains(t, err, env)
+		})
+	}
+}
+
+func TestNewSession(t *testing.T) {
+	_, err := NewSession(Config{Hosts: []string{"127.0.0.1:1"}, Consistency: gocql.One, Timeout: 100 * time.Millisecond})
+	assert.Error(t, err)
+
+	original := createSession
+	t.Cleanup(func() { createSession = original })
+	var keyspace string
+	createSession = func(cluster *gocql.ClusterConfig) (*gocql.Session, error) {
+		keyspace = cluster.Keyspace
+		return &gocql.Session{}, nil
+	}
+
+	session, err := NewSession(Config{Keyspace: "fintech"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "fintech", keyspace)
+	session.Close()
+}
+
+// USE statements are rejected by gocql before reaching the cluster, which
+// exercises the adapter without a node
+func TestGocqlAdapter(t *testing.T) {
+	session := Wrap(&gocql.Session{})
+	query := func() Query {
+		return session.Query("USE fintech").WithContext(context.Background()).
+			Consistency(gocql.One).SerialConsistency(gocql.LocalSerial)
+	}
+
+	assert.ErrorIs(t, query().Exec(), gocql.ErrUseStmt)
+	assert.ErrorIs(t, query().Scan(), gocql.ErrUseStmt)
+	_, err := query().MapScanCAS(map[string]interface{}{})
+	assert.ErrorIs(t, err, gocql.ErrUseStmt)
+	iter := query().Iter()
+	assert.False(t, iter.Scan())
+	assert.ErrorIs(t, iter.Close(), gocql.ErrUseStmt)
+	session.Close()
+}
+
+func TestEnsureKeyspace(t *testing.T) {
+	session := NewFakeSession()
+
+	require.NoError(t, EnsureKeyspace(context.Background(), session, "fintech", 3))
+
+	executed := session.Executed()
+	require.Len(t, executed, 1)
+	assert.Equal(t, "CREATE KEYSPACE IF NOT EXISTS fintech WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 3}", executed[0].Stmt)
+	assert.Equal(t, gocql.All, executed[0].Consistency)
+
+	assert.ErrorIs(t, EnsureKeyspace(context.Background(), session, "fin-tech", 1), ErrInvalidIdentifier)
+	assert.Len(t, session.Executed(), 1)
+}
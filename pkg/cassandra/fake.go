@@ -0,0 +1,200 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package cassandra - Fake session
+// ═══════════════════════════════════════════════════════════════════════════
+
+package cassandra
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/gocql/gocql"
+)
+
+// Statement is a query executed against a FakeSession
+type Statement struct {
+	Stmt              string
+	Values            []interface{}
+	Consistency       gocql.Consistency
+	SerialConsistency gocql.SerialConsistency
+}
+
+// Result answers a FakeSession query. Rows feed Scan and Iter in column
+// order; Applied and Previous feed MapScanCAS.
+type Result struct {
+	Rows     [][]interface{}
+	Applied  bool
+	Previous map[string]interface{}
+	Err      error
+}
+
+type fakeHandler struct {
+	prefix string
+	answer func(values []interface{}) Result
+}
+
+// FakeSession is an in-memory Session used in tests. It records every
+// executed statement and answers it with the most recently registered
+// handler whose prefix matches; other statements succeed without rows.
+type FakeSession struct {
+	mu       sync.Mutex
+	handlers []fakeHandler
+	executed []Statement
+	closed   bool
+}
+
+// NewFakeSession creates a fake session without handlers
+func NewFakeSession() *FakeSession {
+	return &FakeSession{}
+}
+
+// On answers the statements starting with prefix
+func (s *FakeSession) On(prefix string, answer func(values []interface{}) Result) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers = append(s.handlers, fakeHandler{prefix: prefix, answer: answer})
+}
+
+// Executed returns the executed statements, oldest first
+func (s *FakeSession) Executed() []Statement {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Statement(nil), s.executed...)
+}
+
+// Closed reports whether Close was called
+func (s *FakeSession) Closed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
+
+// Query implements Session
+func (s *FakeSession) Query(stmt string, values ...interface{}) Query {
+	return &fakeQuery{session: s, ctx: context.Background(), statement: Statement{Stmt: stmt, Values: values}}
+}
+
+// Close implements Session
+func (s *FakeSession) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+}
+
+func (s *FakeSession) run(ctx context.Context, statement Statement) Result {
+	if err := ctx.Err(); err != nil {
+		return Result{Err: err}
+	}
+
+	s.mu.Lock()
+	s.executed = append(s.executed, statement)
+	var answer func([]interface{}) Result
+	for i := len(s.handlers) - 1; i >= 0; i-- {
+		if strings.HasPrefix(statement.Stmt, s.handlers[i].prefix) {
+			answer = s.handlers[i].answer
+			break
+		}
+	}
+	s.mu.Unlock()
+
+	if answer == nil {
+		return Result{}
+	}
+	return answer(statement.Values)
+}
+
+type fakeQuery struct {
+	session   *FakeSession
+	ctx       context.Context
+	statement Statement
+}
+
+func (q *fakeQuery) WithContext(ctx context.Context) Query {
+	q.ctx = ctx
+	return q
+}
+
+func (q *fakeQuery) Consistency(c gocql.Consistency) Query {
+	q.statement.Consistency = c
+	return q
+}
+
+func (q *fakeQuery) SerialConsistency(c gocql.SerialConsistency) Query {
+	q.statement.SerialConsistency = c
+	return q
+}
+
+func (q *fakeQuery) Exec() error {
+	return q.session.run(q.ctx, q.statement).Err
+}
+
+func (q *fakeQuery) Scan(dest ...interface{}) error {
+	result := q.session.run(q.ctx, q.statement)
+	if result.Err != nil {
+		return result.Err
+	}
+	if len(result.Rows) == 0 {
+		return gocql.ErrNotFound
+	}
+	return scanRow(result.Rows[0], dest)
+}
+
+func (q *fakeQuery) MapScanCAS(dest map[string]interface{}) (bool, error) {
+	result := q.session.run(q.ctx, q.statement)
+	for column, value := range result.Previous {
+		dest[column] = value
+	}
+	return result.Applied, result.Err
+}
+
+func (q *fakeQuery) Iter() Iter {
+	result := q.session.run(q.ctx, q.statement)
+	return &fakeIter{rows: result.Rows, err: result.Err}
+}
+
+type fakeIter struct {
+	rows [][]interface{}
+	err  error
+}
+
+func (i *fakeIter) Scan(dest ...interface{}) bool {
+	if i.err != nil || len(i.rows) == 0 {
+		return false
+	}
+	i.err = scanRow(i.rows[0], dest)
+	i.rows = i.rows[1:]
+	return i.err == nil
+}
+
+func (i *fakeIter) Close() error {
+	return i.err
+}
+
+// scanRow copies row into dest, which must be pointers to types the column
+// values are assignable to. Nil values zero the destination.
+func scanRow(row []interface{}, dest []interface{}) error {
+	if len(row) != len(dest) {
+		return fmt.Errorf("cassandra: fake row has %d columns, scanning %d", len(row), len(dest))
+	}
+	for i, d := range dest {
+		target := reflect.ValueOf(d)
+		if target.Kind() != reflect.Ptr || target.IsNil() {
+			return fmt.Errorf("cassandra: cannot scan into %T", d)
+		}
+		target = target.Elem()
+
+		if row[i] == nil {
+			target.Set(reflect.Zero(target.Type()))
+			continue
+		}
+		value := reflect.ValueOf(row[i])
+		if !value.Type().AssignableTo(target.Type()) {
+			return fmt.Errorf("cassandra: cannot scan %T into %s", row[i], target.Type())
+		}
+		target.Set(value)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package cassandra - Fake session tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package cassandra
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeSessionRecordsStatements(t *testing.T) {
+	session := NewFakeSession()
+
+	err := session.Query("INSERT INTO t (id) VALUES (?)", "1").Consistency(gocql.Quorum).SerialConsistency(gocql.Serial).Exec()
+
+	require.NoError(t, err)
+	assert.Equal(t, []Statement{{
+		Stmt:              "INSERT INTO t (id) VALUES (?)",
+		Values:            []interface{}{"1"},
+		Consistency:       gocql.Quorum,
+		SerialConsistency: gocql.Serial,
+	}}, session.Executed())
+
+	assert.False(t, session.Closed())
+	session.Close()
+	assert.True(t, session.Closed())
+}
+
+func TestFakeSessionHandlers(t *testing.T) {
+	session := NewFakeSession()
+	session.On("SELECT", func([]interface{}) Result { return Result{Err: stderrors.New("shadowed")} })
+	session.On("SELECT name", func(values []interface{}) Result {
+		return Result{Rows: [][]interface{}{{values[0]}, {nil}}}
+	})
+
+	var name string
+	require.NoError(t, session.Query("SELECT name FROM t WHERE id = ?", "ana").Scan(&name))
+	assert.Equal(t, "ana", name)
+
+	iter := session.Query("SELECT name FROM t", "bia").Iter()
+	assert.True(t, iter.Scan(&name))
+	assert.Equal(t, "bia", name)
+	assert.True(t, iter.Scan(&name))
+	assert.Empty(t, name, "nil values zero the destination")
+	assert.False(t, iter.Scan(&name))
+	assert.NoError(t, iter.Close())
+
+	assert.EqualError(t, session.Query("SELECT id FROM t").Exec(), "shadowed")
+	assert.EqualError(t, session.Query("SELECT id FROM t").Scan(&name), "shadowed")
+}
+
+func TestFakeSessionScanWithoutRows(t *testing.T) {
+	var name string
+
+	assert.ErrorIs(t, NewFakeSession().Query("SELECT name FROM t").Scan(&name), gocql.ErrNotFound)
+}
+
+func TestFakeSessionMapScanCAS(t *testing.T) {
+	session := NewFakeSession()
+	session.On("INSERT", func([]interface{}) Result {
+		return Result{Previous: map[string]interface{}{"owner": "other"}}
+	})
+
+	previous := map[string]interface{}{}
+	applied, err := session.Query("INSERT INTO t (id) VALUES (?) IF NOT EXISTS", "1").MapScanCAS(previous)
+
+	require.NoError(t, err)
+	assert.False(t, applied)
+	assert.Equal(t, "other", previous["owner"])
+}
+
+func TestFakeSessionCanceledContext(t *testing.T) {
+	session := NewFakeSession()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.ErrorIs(t, session.Query("SELECT 1").WithContext(ctx).Exec(), context.Canceled)
+	assert.Empty(t, session.Executed())
+}
+
+func TestFakeSessionScanErrors(t *testing.T) {
+	session := NewFakeSession()
+	session.On("SELECT", func([]interface{}) Result { return Result{Rows: [][]interface{}{{1}}} })
+	var name string
+	var count int
+
+	assert.ErrorContains(t, session.Query("SELECT").Scan(&name, &count), "has 1 columns, scanning 2")
+	assert.ErrorContains(t, session.Query("SELECT").Scan(name), "cannot scan into string")
+	assert.ErrorContains(t, session.Query("SELECT").Scan((*int)(nil)), "cannot scan into *int")
+	assert.ErrorContains(t, session.Query("SELECT").Scan(&name), "cannot scan int into string")
+
+	iter := session.Query("SELECT").Iter()
+	assert.False(t, iter.Scan(&name))
+	assert.Error(t, iter.Close())
+}
@@ -0,0 +1,398 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package cassandra - Schema migrations
+// ═══════════════════════════════════════════════════════════════════════════
+
+package cassandra
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	stderrors "errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/google/uuid"
+)
+
+// Migration defaults
+const (
+	DefaultSchemaTable = "schema_migrations"
+	DefaultLockTTL     = time.Minute
+)
+
+// lockID is the single row of the lock table
+const lockID = "migrations"
+
+var (
+	ErrInvalidMigration = stderrors.New("cassandra: invalid migration")
+	ErrChecksumMismatch = stderrors.New("cassandra: migration checksum mismatch")
+	ErrUnknownMigration = stderrors.New("cassandra: applied migration not found")
+	ErrIrreversible     = stderrors.New("cassandra: migration has no down script")
+	ErrLocked           = stderrors.New("cassandra: migrations locked")
+	ErrInvalidLockTTL   = stderrors.New("cassandra: invalid lock TTL")
+)
+
+// ═══════════════════════════════════════════════════════════════════════════
+// MIGRATIONS
+// ═══════════════════════════════════════════════════════════════════════════
+
+// Migration is a versioned schema change. Up and Down hold CQL statements
+// separated by semicolons, which may also appear inside string literals,
+// quoted identifiers and comments; Down is empty for irreversible migrations.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Checksum identifies the Up script, which must not change once applied
+func (m Migration) Checksum() string {
+	sum := sha256.Sum256([]byte(m.Up))
+	return hex.EncodeToString(sum[:])
+}
+
+// AppliedMigration is a row of the schema table
+type AppliedMigration struct {
+	Version   int
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+var migrationFile = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.cql$`)
+
+// LoadMigrations reads the files named <version>_<name>.up.cql and
+// <version>_<name>.down.cql at the root of fsys. Other files are ignored.
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		match := migrationFile.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, err := strconv.Atoi(match[1])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidMigration, entry.Name(), err)
+		}
+		script, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, exists := byVersion[version]
+		if !exists {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("%w: version %d used by %s and %s", ErrInvalidMigration, version, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.Up = string(script)
+		} else {
+			migration.Down = string(script)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if len(statements(migration.Up)) == 0 {
+			return nil, fmt.Errorf("%w: %d_%s has no up script", ErrInvalidMigration, migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// statements splits a script on the semicolons outside string literals
+// ('...' and $$...$$), quoted identifiers and comments. Comments (--, //
+// and /* */) are dropped.
+func statements(script string) []string {
+	var stmts []string
+	var current strings.Builder
+	flush := func() {
+		if stmt := strings.TrimSpace(current.String()); stmt != "" {
+			stmts = append(stmts, stmt)
+		}
+		current.Reset()
+	}
+
+	for i := 0; i < len(script); {
+		rest := script[i:]
+		switch {
+		case strings.HasPrefix(rest, "--"), strings.HasPrefix(rest, "//"):
+			i += until(rest, "\n", 0)
+		case strings.HasPrefix(rest, "/*"):
+			i += until(rest, "*/", 2) + 2
+		case strings.HasPrefix(rest, "$$"):
+			n := min(until(rest, "$$", 2)+2, len(rest))
+			current.WriteString(rest[:n])
+			i += n
+		case rest[0] == '\'' || rest[0] == '"':
+			n := quoted(rest)
+			current.WriteString(rest[:n])
+			i += n
+		case rest[0] == ';':
+			flush()
+			i++
+		default:
+			current.WriteByte(rest[0])
+			i++
+		}
+	}
+	flush()
+	return stmts
+}
+
+// until returns the offset of the first end in s after skip, or len(s)
+func until(s, end string, skip int) int {
+	if n := strings.Index(s[skip:], end); n >= 0 {
+		return skip + n
+	}
+	return len(s)
+}
+
+// quoted returns the length of the literal opening s, whose quote is
+// escaped by doubling it; an unterminated literal runs to the end of s
+func quoted(s string) int {
+	for i := 1; i < len(s); i++ {
+		if s[i] != s[0] {
+			continue
+		}
+		if i+1 < len(s) && s[i+1] == s[0] {
+			i++
+			continue
+		}
+		return i + 1
+	}
+	return len(s)
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// MIGRATOR
+// ═══════════════════════════════════════════════════════════════════════════
+
+// Migrator applies migrations and records them in the schema table. Runs
+// are serialized by a lightweight transaction on <schema table>_lock whose
+// row expires after the lock TTL, so the TTL must exceed the longest run.
+type Migrator struct {
+	session    Session
+	migrations []Migration
+	table      string
+	lockTTL    time.Duration
+	owner      string
+}
+
+// MigratorOption configures a Migrator
+type MigratorOption func(*Migrator)
+
+// WithSchemaTable changes the table recording applied migrations
+func WithSchemaTable(table string) MigratorOption {
+	return func(m *Migrator) {
+		m.table = table
+	}
+}
+
+// WithLockTTL changes how long an abandoned lock blocks other runs. The
+// lock row TTL is in whole seconds, so NewMigrator rejects TTLs under a
+// second.
+func WithLockTTL(ttl time.Duration) MigratorOption {
+	return func(m *Migrator) {
+		m.lockTTL = ttl
+	}
+}
+
+// WithLockOwner names the runner in the lock row (a random ID by default)
+func WithLockOwner(owner string) MigratorOption {
+	return func(m *Migrator) {
+		m.owner = owner
+	}
+}
+
+// NewMigrator creates a migrator for migrations, in any order
+func NewMigrator(session Session, migrations []Migration, opts ...MigratorOption) (*Migrator, error) {
+	m := &Migrator{
+		session:    session,
+		migrations: append([]Migration(nil), migrations...),
+		table:      DefaultSchemaTable,
+		lockTTL:    DefaultLockTTL,
+		owner:      uuid.NewString(),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	if !identifier.MatchString(m.table) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidIdentifier, m.table)
+	}
+	if m.lockTTL < time.Second {
+		return nil, fmt.Errorf("%w: %s is under a second", ErrInvalidLockTTL, m.lockTTL)
+	}
+	sort.Slice(m.migrations, func(i, j int) bool { return m.migrations[i].Version < m.migrations[j].Version })
+	for i, migration := range m.migrations {
+		if i > 0 && m.migrations[i-1].Version == migration.Version {
+			return nil, fmt.Errorf("%w: duplicate version %d", ErrInvalidMigration, migration.Version)
+		}
+	}
+	return m, nil
+}
+
+// Up applies the pending migrations in version order and returns them
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var done []Migration
+	err := m.locked(ctx, func(applied []AppliedMigration) error {
+		recorded := make(map[int]bool, len(applied))
+		for _, a := range applied {
+			recorded[a.Version] = true
+		}
+
+		for i := range m.migrations {
+			migration := &m.migrations[i]
+			if recorded[migration.Version] {
+				continue
+			}
+			if err := m.exec(ctx, migration, migration.Up); err != nil {
+				return err
+			}
+			err := m.session.Query(fmt.Sprintf("INSERT INTO %s (version, name, checksum, applied_at) VALUES (?, ?, ?, ?)", m.table),
+				migration.Version, migration.Name, migration.Checksum(), time.Now().UTC()).WithContext(ctx).Exec()
+			if err != nil {
+				return err
+			}
+			done = append(done, *migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down reverts the last steps applied migrations, newest first, and returns
+// them
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var done []Migration
+	err := m.locked(ctx, func(applied []AppliedMigration) error {
+		for i := len(applied) - 1; i >= 0 && len(done) < steps; i-- {
+			migration := m.find(applied[i].Version)
+			if len(statements(migration.Down)) == 0 {
+				return fmt.Errorf("%w: %d_%s", ErrIrreversible, migration.Version, migration.Name)
+			}
+			if err := m.exec(ctx, migration, migration.Down); err != nil {
+				return err
+			}
+			err := m.session.Query(fmt.Sprintf("DELETE FROM %s WHERE version = ?", m.table), migration.Version).WithContext(ctx).Exec()
+			if err != nil {
+				return err
+			}
+			done = append(done, *migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Applied returns the recorded migrations in version order
+func (m *Migrator) Applied(ctx context.Context) ([]AppliedMigration, error) {
+	if err := m.ensureTables(ctx); err != nil {
+		return nil, err
+	}
+	return m.applied(ctx)
+}
+
+func (m *Migrator) ensureTables(ctx context.Context) error {
+	for _, stmt := range []string{
+		fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (version int PRIMARY KEY, name text, checksum text, applied_at timestamp)", m.table),
+		fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s_lock (id text PRIMARY KEY, owner text, acquired_at timestamp)", m.table),
+	} {
+		if err := m.session.Query(stmt).WithContext(ctx).Exec(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) applied(ctx context.Context) ([]AppliedMigration, error) {
+	iter := m.session.Query(fmt.Sprintf("SELECT version, name, checksum, applied_at FROM %s", m.table)).WithContext(ctx).Iter()
+
+	var applied []AppliedMigration
+	var row AppliedMigration
+	for iter.Scan(&row.Version, &row.Name, &row.Checksum, &row.AppliedAt) {
+		applied = append(applied, row)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	sort.Slice(applied, func(i, j int) bool { return applied[i].Version < applied[j].Version })
+	return applied, nil
+}
+
+// locked runs fn holding the lock, after checking that every applied
+// migration is known and unchanged
+func (m *Migrator) locked(ctx context.Context, fn func([]AppliedMigration) error) (err error) {
+	if err := m.ensureTables(ctx); err != nil {
+		return err
+	}
+
+	previous := make(map[string]interface{})
+	acquired, err := m.session.Query(fmt.Sprintf("INSERT INTO %s_lock (id, owner, acquired_at) VALUES (?, ?, ?) IF NOT EXISTS USING TTL ?", m.table),
+		lockID, m.owner, time.Now().UTC(), int(m.lockTTL.Seconds())).WithContext(ctx).SerialConsistency(gocql.Serial).MapScanCAS(previous)
+	if err != nil {
+		return err
+	}
+	if !acquired {
+		return fmt.Errorf("%w by %v", ErrLocked, previous["owner"])
+	}
+	defer func() {
+		// The lock row may already have expired; a lost release is harmless
+		_, unlockErr := m.session.Query(fmt.Sprintf("DELETE FROM %s_lock WHERE id = ? IF owner = ?", m.table), lockID, m.owner).
+			WithContext(context.WithoutCancel(ctx)).SerialConsistency(gocql.Serial).MapScanCAS(make(map[string]interface{}))
+		if err == nil {
+			err = unlockErr
+		}
+	}()
+
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+	for _, a := range applied {
+		migration := m.find(a.Version)
+		if migration == nil {
+			return fmt.Errorf("%w: %d_%s", ErrUnknownMigration, a.Version, a.Name)
+		}
+		if migration.Checksum() != a.Checksum {
+			return fmt.Errorf("%w: %d_%s", ErrChecksumMismatch, a.Version, a.Name)
+		}
+	}
+	return fn(applied)
+}
+
+func (m *Migrator) find(version int) *Migration {
+	i := sort.Search(len(m.migrations), func(i int) bool { return m.migrations[i].Version >= version })
+	if i < len(m.migrations) && m.migrations[i].Version == version {
+		return &m.migrations[i]
+	}
+	return nil
+}
+
+func (m *Migrator) exec(ctx context.Context, migration *Migration, script string) error {
+	for _, stmt := range statements(script) {
+		if err := m.session.Query(stmt).WithContext(ctx).Exec(); err != nil {
+			return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+	}
+	return nil
+}
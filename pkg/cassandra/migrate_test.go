@@ -0,0 +1,373 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package cassandra - Migration tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package cassandra
+
+import (
+	"context"
+	stderrors "errors"
+	"io/fs"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var migrationFiles = fstest.MapFS{
+	"0001_create_accounts.up.cql": {Data: []byte(`
+-- accounts by id
+CREATE TABLE accounts (id text PRIMARY KEY, owner text);
+CREATE TABLE accounts_by_owner (owner text, id text, PRIMARY KEY (owner, id));
+`)},
+	"0001_create_accounts.down.cql": {Data: []byte("DROP TABLE accounts_by_owner;\nDROP TABLE accounts;\n")},
+	"0002_add_status.up.cql":        {Data: []byte("ALTER TABLE accounts ADD status text")},
+	"0002_add_status.down.cql":      {Data: []byte("ALTER TABLE accounts DROP status")},
+	"0003_backfill.up.cql":          {Data: []byte("UPDATE accounts SET status = 'active' WHERE id = 'seed'")},
+	"README.md":                     {Data: []byte("migrations")},
+	"0004_folder.up.cql/notes.txt":  {Data: []byte("directories are ignored")},
+}
+
+// cluster keeps the schema and lock tables of a FakeSession in memory
+type cluster struct {
+	*FakeSession
+	mu      sync.Mutex
+	applied []AppliedMigration
+	owner   string
+}
+
+func newCluster(applied ...AppliedMigration) *cluster {
+	c := &cluster{FakeSession: NewFakeSession(), applied: applied}
+	c.On("SELECT version", func([]interface{}) Result {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		var rows [][]interface{}
+		for i := len(c.applied) - 1; i >= 0; i-- {
+			a := c.applied[i]
+			rows = append(rows, []interface{}{a.Version, a.Name, a.Checksum, a.AppliedAt})
+		}
+		return Result{Rows: rows}
+	})
+	c.On("INSERT INTO schema_migrations (", func(values []interface{}) Result {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.applied = append(c.applied, AppliedMigration{
+			Version: values[0].(int), Name: values[1].(string), Checksum: values[2].(string), AppliedAt: values[3].(time.Time),
+		})
+		return Result{}
+	})
+	c.On("DELETE FROM schema_migrations WHERE", func(values []interface{}) Result {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		for i, a := range c.applied {
+			if a.Version == values[0].(int) {
+				c.applied = append(c.applied[:i], c.applied[i+1:]...)
+				break
+			}
+		}
+		return Result{}
+	})
+	c.On("INSERT INTO schema_migrations_lock", func(values []interface{}) Result {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.owner != "" {
+			return Result{Previous: map[string]interface{}{"owner": c.owner}}
+		}
+		c.owner = values[1].(string)
+		return Result{Applied: true}
+	})
+	c.On("DELETE FROM schema_migrations_lock", func(values []interface{}) Result {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.owner != values[1] {
+			return Result{Previous: map[string]interface{}{"owner": c.owner}}
+		}
+		c.owner = ""
+		return Result{Applied: true}
+	})
+	return c
+}
+
+// versions lists the applied versions, oldest first
+func (c *cluster) versions() []int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var versions []int
+	for _, a := range c.applied {
+		versions = append(versions, a.Version)
+	}
+	return versions
+}
+
+// migrationsRun lists the executed statements that are not bookkeeping
+func (c *cluster) migrationsRun() []string {
+	var stmts []string
+	for _, executed := range c.Executed() {
+		if !strings.Contains(executed.Stmt, "schema_migrations") {
+			stmts = append(stmts, executed.Stmt)
+		}
+	}
+	return stmts
+}
+
+func loadMigrations(t *testing.T) []Migration {
+	t.Helper()
+	migrations, err := LoadMigrations(migrationFiles)
+	require.NoError(t, err)
+	return migrations
+}
+
+func newMigrator(t *testing.T, session Session, opts ...MigratorOption) *Migrator {
+	t.Helper()
+	migrator, err := NewMigrator(session, loadMigrations(t), opts...)
+	require.NoError(t, err)
+	return migrator
+}
+
+func failOn(session *FakeSession, prefix string) {
+	session.On(prefix, func([]interface{}) Result { return Result{Err: stderrors.New("unavailable")} })
+}
+
+func TestLoadMigrations(t *testing.T) {
+	migrations := loadMigrations(t)
+
+	require.Len(t, migrations, 3)
+	assert.Equal(t, 1, migrations[0].Version)
+	assert.Equal(t, "create_accounts", migrations[0].Name)
+	assert.Equal(t, []string{
+		"CREATE TABLE accounts (id text PRIMARY KEY, owner text)",
+		"CREATE TABLE accounts_by_owner (owner text, id text, PRIMARY KEY (owner, id))",
+	}, statements(migrations[0].Up))
+	assert.Equal(t, "ALTER TABLE accounts DROP status", migrations[1].Down)
+	assert.Empty(t, migrations[2].Down)
+	assert.Len(t, migrations[0].Checksum(), 64)
+	assert.NotEqual(t, migrations[0].Checksum(), migrations[1].Checksum())
+}
+
+type unreadableFS struct {
+	fstest.MapFS
+}
+
+func (unreadableFS) ReadFile(name string) ([]byte, error) {
+	return nil, fs.ErrPermission
+}
+
+func TestLoadMigrationsErrors(t *testing.T) {
+	_, err := LoadMigrations(os.DirFS("/nonexistent"))
+	assert.Error(t, err)
+
+	_, err = LoadMigrations(unreadableFS{migrationFiles})
+	assert.ErrorIs(t, err, fs.ErrPermission)
+
+	for name, files := range map[string]fstest.MapFS{
+		"version overflow": {"99999999999999999999_big.up.cql": {Data: []byte("SELECT 1")}},
+		"version reused":   {"0001_a.up.cql": {Data: []byte("SELECT 1")}, "0001_b.up.cql": {Data: []byte("SELECT 1")}},
+		"no up script":     {"0001_a.down.cql": {Data: []byte("SELECT 1")}},
+		"empty up script":  {"0001_a.up.cql": {Data: []byte("-- nothing yet\n;")}},
+	} {
+		_, err := LoadMigrations(files)
+		assert.ErrorIs(t, err, ErrInvalidMigration, name)
+	}
+}
+
+func TestNewMigratorErrors(t *testing.T) {
+	_, err := NewMigrator(NewFakeSession(), nil, WithSchemaTable("schema-migrations"))
+	assert.ErrorIs(t, err, ErrInvalidIdentifier)
+
+	_, err = NewMigrator(NewFakeSession(), []Migration{{Version: 2, Up: "a"}, {Version: 1, Up: "b"}, {Version: 2, Up: "c"}})
+	assert.ErrorIs(t, err, ErrInvalidMigration)
+
+	_, err = NewMigrator(NewFakeSession(), nil, WithLockTTL(500*time.Millisecond))
+	assert.ErrorIs(t, err, ErrInvalidLockTTL, "would be USING TTL 0")
+}
+
+func TestStatementsSkipLiteralsAndComments(t *testing.T) {
+	script := `INSERT INTO notes (id, body) VALUES ('a', 'one; it''s two'); -- trailing; comment
+/* block; comment */ UPDATE "odd;name" SET body = $$x; y$$ WHERE id = 'a';
+// line; comment
+SELECT 1 /* unterminated;`
+
+	assert.Equal(t, []string{
+		"INSERT INTO notes (id, body) VALUES ('a', 'one; it''s two')",
+		`UPDATE "odd;name" SET body = $$x; y$$ WHERE id = 'a'`,
+		"SELECT 1",
+	}, statements(script))
+	assert.Equal(t, []string{"SELECT 'open;"}, statements("SELECT 'open;"))
+	assert.Equal(t, []string{"SELECT $$open;"}, statements("SELECT $$open;"))
+}
+
+func TestMigratorUp(t *testing.T) {
+	session := newCluster()
+	migrator := newMigrator(t, session, WithLockOwner("runner-1"), WithLockTTL(30*time.Second))
+
+	done, err := migrator.Up(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, done, 3)
+	assert.Equal(t, []int{1, 2, 3}, session.versions())
+	assert.Equal(t, []string{
+		"CREATE TABLE accounts (id text PRIMARY KEY, owner text)",
+		"CREATE TABLE accounts_by_owner (owner text, id text, PRIMARY KEY (owner, id))",
+		"ALTER TABLE accounts ADD status text",
+		"UPDATE accounts SET status = 'active' WHERE id = 'seed'",
+	}, session.migrationsRun())
+	assert.Empty(t, session.owner, "lock released")
+
+	lock := session.Executed()[2]
+	assert.Equal(t, "INSERT INTO schema_migrations_lock (id, owner, acquired_at) VALUES (?, ?, ?) IF NOT EXISTS USING TTL ?", lock.Stmt)
+	assert.Equal(t, "runner-1", lock.Values[1])
+	assert.Equal(t, 30, lock.Values[3])
+	assert.Equal(t, gocql.Serial, lock.SerialConsistency)
+
+	applied, err := migrator.Applied(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, done[1].Checksum(), applied[1].Checksum)
+	assert.Equal(t, "add_status", applied[1].Name)
+
+	done, err = migrator.Up(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, done, "nothing pending")
+}
+
+func TestMigratorUpAppliesMissingVersions(t *testing.T) {
+	migrations := loadMigrations(t)
+	session := newCluster(AppliedMigration{Version: 2, Name: "add_status", Checksum: migrations[1].Checksum()})
+	migrator := newMigrator(t, session)
+
+	done, err := migrator.Up(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, done, 2)
+	assert.Equal(t, 1, done[0].Version)
+	assert.Equal(t, 3, done[1].Version)
+}
+
+func TestMigratorLocked(t *testing.T) {
+	session := newCluster()
+	session.owner = "runner-2"
+
+	_, err := newMigrator(t, session).Up(context.Background())
+
+	assert.ErrorIs(t, err, ErrLocked)
+	assert.ErrorContains(t, err, "runner-2")
+	assert.Empty(t, session.migrationsRun())
+	assert.Equal(t, "runner-2", session.owner, "another runner's lock is kept")
+}
+
+func TestMigratorRejectsChangedHistory(t *testing.T) {
+	for name, tc := range map[string]struct {
+		applied AppliedMigration
+		err     error
+	}{
+		"edited":  {AppliedMigration{Version: 1, Name: "create_accounts", Checksum: "stale"}, ErrChecksumMismatch},
+		"removed": {AppliedMigration{Version: 9, Name: "gone"}, ErrUnknownMigration},
+	} {
+		session := newCluster(tc.applied)
+
+		_, err := newMigrator(t, session).Up(context.Background())
+
+		assert.ErrorIs(t, err, tc.err, name)
+		assert.Empty(t, session.migrationsRun(), name)
+		assert.Empty(t, session.owner, name)
+	}
+}
+
+func TestMigratorDown(t *testing.T) {
+	session := newCluster()
+	migrations := loadMigrations(t)[:2]
+	migrator, err := NewMigrator(session, migrations)
+	require.NoError(t, err)
+	_, err = migrator.Up(context.Background())
+	require.NoError(t, err)
+
+	done, err := migrator.Down(context.Background(), 1)
+	require.NoError(t, err)
+	require.Len(t, done, 1)
+	assert.Equal(t, 2, done[0].Version)
+	assert.Equal(t, []int{1}, session.versions())
+
+	done, err = migrator.Down(context.Background(), 5)
+	require.NoError(t, err)
+	require.Len(t, done, 1)
+	assert.Empty(t, session.versions())
+	assert.Equal(t, []string{
+		"ALTER TABLE accounts DROP status",
+		"DROP TABLE accounts_by_owner",
+		"DROP TABLE accounts",
+	}, session.migrationsRun()[3:])
+}
+
+func TestMigratorDownIrreversible(t *testing.T) {
+	session := newCluster()
+	migrator := newMigrator(t, session)
+	_, err := migrator.Up(context.Background())
+	require.NoError(t, err)
+
+	_, err = migrator.Down(context.Background(), 1)
+
+	assert.ErrorIs(t, err, ErrIrreversible)
+	assert.Equal(t, []int{1, 2, 3}, session.versions())
+}
+
+func TestMigratorUpFailures(t *testing.T) {
+	for _, prefix := range []string{
+		"CREATE TABLE IF NOT EXISTS schema_migrations_lock",
+		"INSERT INTO schema_migrations_lock",
+		"SELECT version",
+		"ALTER TABLE accounts ADD",
+		"INSERT INTO schema_migrations (",
+		"DELETE FROM schema_migrations_lock",
+	} {
+		session := newCluster()
+		failOn(session.FakeSession, prefix)
+
+		_, err := newMigrator(t, session).Up(context.Background())
+
+		assert.ErrorContains(t, err, "unavailable", prefix)
+	}
+}
+
+func TestMigratorDownFailures(t *testing.T) {
+	for _, prefix := range []string{"ALTER TABLE accounts DROP", "DELETE FROM schema_migrations WHERE"} {
+		session := newCluster()
+		migrator, err := NewMigrator(session, loadMigrations(t)[:2])
+		require.NoError(t, err)
+		_, err = migrator.Up(context.Background())
+		require.NoError(t, err)
+		failOn(session.FakeSession, prefix)
+
+		_, err = migrator.Down(context.Background(), 1)
+
+		assert.ErrorContains(t, err, "unavailable", prefix)
+		assert.Empty(t, session.owner, prefix)
+	}
+}
+
+func TestMigratorStatementErrorNamesMigration(t *testing.T) {
+	session := newCluster()
+	failOn(session.FakeSession, "ALTER TABLE accounts ADD")
+
+	_, err := newMigrator(t, session).Up(context.Background())
+
+	assert.EqualError(t, err, "migration 2_add_status: unavailable")
+	assert.Equal(t, []int{1}, session.versions(), "earlier migrations stay applied")
+}
+
+func TestMigratorAppliedError(t *testing.T) {
+	session := newCluster()
+	migrator := newMigrator(t, session)
+
+	failOn(session.FakeSession, "SELECT version")
+	_, err := migrator.Applied(context.Background())
+	assert.Error(t, err)
+
+	failOn(session.FakeSession, "CREATE TABLE IF NOT EXISTS")
+	_, err = migrator.Applied(context.Background())
+	assert.Error(t, err)
+}
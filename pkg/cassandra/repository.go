@@ -0,0 +1,190 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package cassandra - Generic repository
+// ═══════════════════════════════════════════════════════════════════════════
+
+package cassandra
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gocql/gocql"
+)
+
+var (
+	ErrNotFound     = stderrors.New("cassandra: not found")
+	ErrInvalidTable = stderrors.New("cassandra: invalid table")
+)
+
+// Table maps T onto a CQL table
+type Table[T any] struct {
+	Name    string
+	Columns []string
+	// Key lists the primary key columns, in the order Get and Delete take
+	// their values
+	Key []string
+	// Fields returns pointers to the fields of v in Columns order
+	Fields func(v *T) []interface{}
+	// NotFound is returned by Get when no row matches (ErrNotFound by
+	// default), typically a domain AppError
+	NotFound error
+}
+
+type consistency struct {
+	read   gocql.Consistency
+	write  gocql.Consistency
+	serial gocql.SerialConsistency
+}
+
+// RepositoryOption configures the consistency levels of a Repository
+type RepositoryOption func(*consistency)
+
+// WithReadConsistency sets the consistency of Get and Find (LOCAL_QUORUM
+// by default)
+func WithReadConsistency(c gocql.Consistency) RepositoryOption {
+	return func(o *consistency) {
+		o.read = c
+	}
+}
+
+// WithWriteConsistency sets the consistency of Insert and Delete
+// (LOCAL_QUORUM by default)
+func WithWriteConsistency(c gocql.Consistency) RepositoryOption {
+	return func(o *consistency) {
+		o.write = c
+	}
+}
+
+// WithSerialConsistency sets the consistency of the Paxos phase of
+// InsertIfNotExists (LOCAL_SERIAL by default)
+func WithSerialConsistency(c gocql.SerialConsistency) RepositoryOption {
+	return func(o *consistency) {
+		o.serial = c
+	}
+}
+
+// Repository reads and writes rows of a table as values of T
+type Repository[T any] struct {
+	session     Session
+	table       Table[T]
+	consistency consistency
+
+	insertStmt string
+	selectStmt string
+	getStmt    string
+	deleteStmt string
+}
+
+// NewRepository validates table and prepares its statements
+func NewRepository[T any](session Session, table Table[T], opts ...RepositoryOption) (*Repository[T], error) {
+	for _, name := range append(append([]string{table.Name}, table.Columns...), table.Key...) {
+		if !identifier.MatchString(name) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidIdentifier, name)
+		}
+	}
+	if len(table.Columns) == 0 || len(table.Key) == 0 || table.Fields == nil {
+		return nil, fmt.Errorf("%w: %s needs columns, key and fields", ErrInvalidTable, table.Name)
+	}
+	if table.NotFound == nil {
+		table.NotFound = ErrNotFound
+	}
+
+	r := &Repository[T]{
+		session:     session,
+		table:       table,
+		consistency: consistency{read: gocql.LocalQuorum, write: gocql.LocalQuorum, serial: gocql.LocalSerial},
+	}
+	for _, opt := range opts {
+		opt(&r.consistency)
+	}
+
+	columns := strings.Join(table.Columns, ", ")
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(table.Columns)), ", ")
+	where := strings.Join(table.Key, " = ? AND ") + " = ?"
+	r.insertStmt = fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table.Name, columns, placeholders)
+	r.selectStmt = fmt.Sprintf("SELECT %s FROM %s", columns, table.Name)
+	r.getStmt = r.selectStmt + " WHERE " + where
+	r.deleteStmt = fmt.Sprintf("DELETE FROM %s WHERE %s", table.Name, where)
+	return r, nil
+}
+
+// Insert writes v, overwriting any row with the same key
+func (r *Repository[T]) Insert(ctx context.Context, v *T) error {
+	return r.session.Query(r.insertStmt, r.values(v)...).WithContext(ctx).Consistency(r.consistency.write).Exec()
+}
+
+// InsertIfNotExists writes v unless its key exists, reporting whether it
+// was written
+func (r *Repository[T]) InsertIfNotExists(ctx context.Context, v *T) (bool, error) {
+	return r.session.Query(r.insertStmt+" IF NOT EXISTS", r.values(v)...).WithContext(ctx).
+		Consistency(r.consistency.write).SerialConsistency(r.consistency.serial).MapScanCAS(make(map[string]interface{}))
+}
+
+// Get returns the row with the given primary key values
+func (r *Repository[T]) Get(ctx context.Context, key ...interface{}) (*T, error) {
+	if err := r.checkKey(key); err != nil {
+		return nil, err
+	}
+
+	var v T
+	err := r.session.Query(r.getStmt, key...).WithContext(ctx).Consistency(r.consistency.read).Scan(r.table.Fields(&v)...)
+	if stderrors.Is(err, gocql.ErrNotFound) {
+		return nil, r.table.NotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// Find returns the rows matching where, a CQL condition with placeholders
+// for values such as "account_id = ? LIMIT 50". An empty where reads the
+// whole table.
+func (r *Repository[T]) Find(ctx context.Context, where string, values ...interface{}) ([]T, error) {
+	stmt := r.selectStmt
+	if where != "" {
+		stmt += " WHERE " + where
+	}
+	iter := r.session.Query(stmt, values...).WithContext(ctx).Consistency(r.consistency.read).Iter()
+
+	var rows []T
+	for {
+		var v T
+		if !iter.Scan(r.table.Fields(&v)...) {
+			break
+		}
+		rows = append(rows, v)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// Delete removes the row with the given primary key values
+func (r *Repository[T]) Delete(ctx context.Context, key ...interface{}) error {
+	if err := r.checkKey(key); err != nil {
+		return err
+	}
+	return r.session.Query(r.deleteStmt, key...).WithContext(ctx).Consistency(r.consistency.write).Exec()
+}
+
+func (r *Repository[T]) checkKey(key []interface{}) error {
+	if len(key) != len(r.table.Key) {
+		return fmt.Errorf("%w: %s key has %d columns, got %d values", ErrInvalidTable, r.table.Name, len(r.table.Key), len(key))
+	}
+	return nil
+}
+
+// values dereferences the field pointers of v
+func (r *Repository[T]) values(v *T) []interface{} {
+	fields := r.table.Fields(v)
+	values := make([]interface{}, len(fields))
+	for i, field := range fields {
+		values[i] = reflect.ValueOf(field).Elem().Interface()
+	}
+	return values
+}
@@ -0,0 +1,167 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package cassandra - Repository tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package cassandra
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type account struct {
+	ID      string
+	Owner   string
+	Balance float64
+}
+
+var errAccountNotFound = stderrors.New("account not found")
+
+var accounts = Table[account]{
+	Name:    "accounts",
+	Columns: []string{"owner", "id", "balance"},
+	Key:     []string{"owner", "id"},
+	Fields: func(a *account) []interface{} {
+		return []interface{}{&a.Owner, &a.ID, &a.Balance}
+	},
+	NotFound: errAccountNotFound,
+}
+
+func newRepository(t *testing.T, session Session, opts ...RepositoryOption) *Repository[account] {
+	t.Helper()
+	repo, err := NewRepository(session, accounts, opts...)
+	require.NoError(t, err)
+	return repo
+}
+
+func TestRepositoryInsert(t *testing.T) {
+	session := NewFakeSession()
+	repo := newRepository(t, session, WithWriteConsistency(gocql.EachQuorum))
+
+	require.NoError(t, repo.Insert(context.Background(), &account{ID: "acc-1", Owner: "ana", Balance: 10}))
+
+	assert.Equal(t, []Statement{{
+		Stmt:        "INSERT INTO accounts (owner, id, balance) VALUES (?, ?, ?)",
+		Values:      []interface{}{"ana", "acc-1", 10.0},
+		Consistency: gocql.EachQuorum,
+	}}, session.Executed())
+}
+
+func TestRepositoryInsertIfNotExists(t *testing.T) {
+	session := NewFakeSession()
+	session.On("INSERT", func([]interface{}) Result { return Result{Applied: true} })
+	repo := newRepository(t, session, WithSerialConsistency(gocql.Serial))
+
+	applied, err := repo.InsertIfNotExists(context.Background(), &account{ID: "acc-1", Owner: "ana"})
+
+	require.NoError(t, err)
+	assert.True(t, applied)
+	executed := session.Executed()[0]
+	assert.Equal(t, "INSERT INTO accounts (owner, id, balance) VALUES (?, ?, ?) IF NOT EXISTS", executed.Stmt)
+	assert.Equal(t, gocql.LocalQuorum, executed.Consistency)
+	assert.Equal(t, gocql.Serial, executed.SerialConsistency)
+}
+
+func TestRepositoryGet(t *testing.T) {
+	session := NewFakeSession()
+	session.On("SELECT", func(values []interface{}) Result {
+		if values[1] != "acc-1" {
+			return Result{}
+		}
+		return Result{Rows: [][]interface{}{{values[0], values[1], 25.5}}}
+	})
+	repo := newRepository(t, session, WithReadConsistency(gocql.One))
+
+	found, err := repo.Get(context.Background(), "ana", "acc-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, &account{ID: "acc-1", Owner: "ana", Balance: 25.5}, found)
+	executed := session.Executed()[0]
+	assert.Equal(t, "SELECT owner, id, balance FROM accounts WHERE owner = ? AND id = ?", executed.Stmt)
+	assert.Equal(t, gocql.One, executed.Consistency)
+
+	_, err = repo.Get(context.Background(), "ana", "acc-2")
+	assert.ErrorIs(t, err, errAccountNotFound)
+
+	_, err = repo.Get(context.Background(), "ana")
+	assert.ErrorIs(t, err, ErrInvalidTable)
+}
+
+func TestRepositoryGetDefaultNotFound(t *testing.T) {
+	table := accounts
+	table.NotFound = nil
+	repo, err := NewRepository(NewFakeSession(), table)
+	require.NoError(t, err)
+
+	_, err = repo.Get(context.Background(), "ana", "acc-1")
+
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestRepositoryFind(t *testing.T) {
+	session := NewFakeSession()
+	session.On("SELECT", func([]interface{}) Result {
+		return Result{Rows: [][]interface{}{{"ana", "acc-1", 1.0}, {"ana", "acc-2", 2.0}}}
+	})
+	repo := newRepository(t, session)
+
+	found, err := repo.Find(context.Background(), "owner = ? LIMIT 10", "ana")
+
+	require.NoError(t, err)
+	assert.Equal(t, []account{{ID: "acc-1", Owner: "ana", Balance: 1}, {ID: "acc-2", Owner: "ana", Balance: 2}}, found)
+	assert.Equal(t, "SELECT owner, id, balance FROM accounts WHERE owner = ? LIMIT 10", session.Executed()[0].Stmt)
+
+	_, err = repo.Find(context.Background(), "")
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT owner, id, balance FROM accounts", session.Executed()[1].Stmt)
+}
+
+func TestRepositoryDelete(t *testing.T) {
+	session := NewFakeSession()
+	repo := newRepository(t, session)
+
+	require.NoError(t, repo.Delete(context.Background(), "ana", "acc-1"))
+
+	assert.Equal(t, "DELETE FROM accounts WHERE owner = ? AND id = ?", session.Executed()[0].Stmt)
+	assert.Equal(t, []interface{}{"ana", "acc-1"}, session.Executed()[0].Values)
+	assert.ErrorIs(t, repo.Delete(context.Background()), ErrInvalidTable)
+}
+
+func TestRepositoryQueryErrors(t *testing.T) {
+	session := NewFakeSession()
+	failOn(session, "")
+	repo := newRepository(t, session)
+	ctx := context.Background()
+
+	_, err := repo.Get(ctx, "ana", "acc-1")
+	assert.Error(t, err)
+	_, err = repo.Find(ctx, "")
+	assert.Error(t, err)
+	assert.Error(t, repo.Insert(ctx, &account{}))
+	assert.Error(t, repo.Delete(ctx, "ana", "acc-1"))
+}
+
+func TestNewRepositoryInvalidTable(t *testing.T) {
+	for name, tc := range map[string]struct {
+		mutate func(*Table[account])
+		err    error
+	}{
+		"table name": {func(t *Table[account]) { t.Name = "accounts; DROP" }, ErrInvalidIdentifier},
+		"column":     {func(t *Table[account]) { t.Columns = []string{"id", "1st"} }, ErrInvalidIdentifier},
+		"no columns": {func(t *Table[account]) { t.Columns = nil }, ErrInvalidTable},
+		"no key":     {func(t *Table[account]) { t.Key = nil }, ErrInvalidTable},
+		"no fields":  {func(t *Table[account]) { t.Fields = nil }, ErrInvalidTable},
+	} {
+		table := accounts
+		tc.mutate(&table)
+
+		_, err := NewRepository(NewFakeSession(), table)
+
+		assert.ErrorIs(t, err, tc.err, name)
+	}
+}
@@ -0,0 +1,464 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package config - Typed configuration from defaults, YAML, .env and env
+// ═══════════════════════════════════════════════════════════════════════════
+
+package config
+
+import (
+	stderrors "errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fintech-bank-platform/pkg/errors"
+	"github.com/fintech-bank-platform/pkg/validation"
+	"github.com/go-playground/validator/v10"
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// Redacted replaces the value of secret fields in Dump
+const Redacted = "[REDACTED]"
+
+// FileSuffix marks a variable holding the path of a file with the value,
+// as used by Docker secrets (DB_PASSWORD_FILE=/run/secrets/db_password)
+const FileSuffix = "_FILE"
+
+// Error lists every problem found by Load, so all of them can be fixed
+// before the next start
+type Error struct {
+	Problems []string
+}
+
+func (e *Error) Error() string {
+	return "config: " + strings.Join(e.Problems, "; ")
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// OPTIONS
+// ═══════════════════════════════════════════════════════════════════════════
+
+type options struct {
+	files  []string
+	dotenv []string
+}
+
+// Option configures Load
+type Option func(*options)
+
+// WithFile layers YAML files over the defaults, later files overriding
+// earlier ones. Empty paths are skipped, so WithFile(os.Getenv("CONFIG_FILE"))
+// makes the file optional; a named file that does not exist is a problem.
+func WithFile(paths ...string) Option {
+	return func(o *options) {
+		o.files = append(o.files, paths...)
+	}
+}
+
+// WithDotEnv replaces the .env files read (".env" by default). Missing
+// files are skipped. Their variables are not exported to the process.
+func WithDotEnv(paths ...string) Option {
+	return func(o *options) {
+		o.dotenv = paths
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// LOAD
+// ═══════════════════════════════════════════════════════════════════════════
+
+// field is a settable leaf of the configuration struct
+type field struct {
+	path       string // Go path, e.g. "Server.Port"
+	yaml       string // dotted YAML path, e.g. "server.port"
+	env        string
+	def        string
+	hasDefault bool
+	secret     bool
+	value      reflect.Value
+}
+
+// name identifies the field in problems and dumps
+func (f field) name() string {
+	if f.env != "" {
+		return f.env
+	}
+	return f.yaml
+}
+
+// Load fills dst, a pointer to a struct, from the sources below; each
+// overrides the previous ones:
+//
+//   - the default tag
+//   - YAML files (WithFile), keyed by the yaml tags of nested fields
+//   - .env files (WithDotEnv)
+//   - the environment
+//
+// Fields are named by their env tag, and NAME_FILE reads the value of NAME
+// from a file. Slices take comma separated values. The result is then
+// checked with the validate tags. Every parse and validation failure is
+// reported in a single *Error.
+//
+//	type ServerConfig struct {
+//	    Port    string        `env:"SERVER_PORT" yaml:"port" default:"8080" validate:"required"`
+//	    Timeout time.Duration `env:"SERVER_TIMEOUT" yaml:"timeout" default:"30s" validate:"gt=0"`
+//	    Secret  string        `env:"SERVER_SECRET" yaml:"secret" secret:"true"`
+//	}
+func Load(dst interface{}, opts ...Option) error {
+	o := options{dotenv: []string{".env"}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	root := reflect.ValueOf(dst)
+	if root.Kind() != reflect.Ptr || root.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("config: Load needs a pointer to a struct, got %T", dst)
+	}
+	fields := collect(root.Elem(), "", "")
+
+	var problems []string
+	yamlValues := make(map[string]interface{})
+	for _, path := range o.files {
+		if path != "" {
+			problems = append(problems, readYAML(path, fields, yamlValues)...)
+		}
+	}
+	dotenv, dotenvProblems := readDotEnv(o.dotenv)
+	problems = append(problems, dotenvProblems...)
+
+	failed := make(map[string]bool)
+	for _, f := range fields {
+		if problem := f.load(yamlValues, dotenv); problem != "" {
+			problems = append(problems, problem)
+			failed[f.path] = true
+		}
+	}
+	problems = append(problems, validate(dst, fields, failed)...)
+
+	if len(problems) > 0 {
+		return &Error{Problems: problems}
+	}
+	return nil
+}
+
+// collect lists the leaves of v, recursing into nested structs without an
+// env tag. Fields excluded from YAML without an env tag are skipped.
+func collect(v reflect.Value, path, yamlPath string) []field {
+	var fields []field
+	for i := 0; i < v.NumField(); i++ {
+		sf := v.Type().Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		env := sf.Tag.Get("env")
+		yamlName, _, _ := strings.Cut(sf.Tag.Get("yaml"), ",")
+		switch {
+		case yamlName == "-" && env == "":
+			continue
+		case yamlName == "":
+			yamlName = strings.ToLower(sf.Name)
+		}
+		f := field{
+			path:   join(path, sf.Name),
+			yaml:   join(yamlPath, yamlName),
+			env:    env,
+			secret: sf.Tag.Get("secret") == "true",
+			value:  v.Field(i),
+		}
+		f.def, f.hasDefault = sf.Tag.Lookup("default")
+
+		if sf.Type.Kind() == reflect.Struct && f.env == "" {
+			fields = append(fields, collect(f.value, f.path, f.yaml)...)
+			continue
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+func join(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// load sets f from its highest precedence source and returns a problem
+// description on failure
+func (f field) load(yamlValues map[string]interface{}, dotenv map[string]string) string {
+	if f.env != "" {
+		for _, source := range []struct {
+			name   string
+			lookup func(string) (string, bool)
+		}{
+			{"environment", os.LookupEnv},
+			{".env", func(key string) (string, bool) { value, ok := dotenv[key]; return value, ok }},
+		} {
+			value, found, err := lookup(f.env, source.lookup)
+			if err != nil {
+				return fmt.Sprintf("%s: %v", f.env, err)
+			}
+			if found {
+				return f.set(value, source.name)
+			}
+		}
+	}
+
+	if value, found := yamlValues[f.yaml]; found {
+		if list, ok := value.([]interface{}); ok {
+			values := make([]string, len(list))
+			for i, item := range list {
+				values[i] = fmt.Sprint(item)
+			}
+			if f.value.Kind() != reflect.Slice {
+				return fmt.Sprintf("%s: expected a single value in YAML", f.name())
+			}
+			return f.setEach(values, "YAML")
+		}
+		return f.set(fmt.Sprint(value), "YAML")
+	}
+
+	if f.hasDefault {
+		return f.set(f.def, "default")
+	}
+	return ""
+}
+
+// lookup reads name, or the file named by name_FILE
+func lookup(name string, get func(string) (string, bool)) (string, bool, error) {
+	value, found := get(name)
+	path, fromFile := get(name + FileSuffix)
+	if !fromFile {
+		return value, found, nil
+	}
+	if found {
+		return "", false, fmt.Errorf("both %s and %s%s are set", name, name, FileSuffix)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false, fmt.Errorf("reading %s%s: %w", name, FileSuffix, err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), true, nil
+}
+
+// set parses a text value, splitting it on commas for slices
+func (f field) set(value, source string) string {
+	if f.value.Kind() == reflect.Slice {
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return f.setEach(items, source)
+	}
+	if err := parse(f.value, value); err != nil {
+		return f.problem(value, source, err)
+	}
+	return ""
+}
+
+// setEach parses one value per element of a slice
+func (f field) setEach(values []string, source string) string {
+	slice := reflect.MakeSlice(f.value.Type(), len(values), len(values))
+	for i, value := range values {
+		if err := parse(slice.Index(i), value); err != nil {
+			return f.problem(value, source, err)
+		}
+	}
+	f.value.Set(slice)
+	return ""
+}
+
+func (f field) problem(value, source string, err error) string {
+	if f.secret {
+		value = Redacted
+	}
+	return fmt.Sprintf("%s: %v %q (from %s)", f.name(), err, value, source)
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// parse sets a scalar from text
+func parse(v reflect.Value, text string) error {
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(text)
+		if err != nil {
+			return stderrors.New("invalid duration")
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(text)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return stderrors.New("invalid boolean")
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(text, 10, v.Type().Bits())
+		if err != nil {
+			return stderrors.New("invalid integer")
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(text, 10, v.Type().Bits())
+		if err != nil {
+			return stderrors.New("invalid unsigned integer")
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(text, v.Type().Bits())
+		if err != nil {
+			return stderrors.New("invalid number")
+		}
+		v.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// SOURCES
+// ═══════════════════════════════════════════════════════════════════════════
+
+// readYAML flattens a YAML file into values keyed by dotted path, reporting
+// keys that match no field
+func readYAML(path string, fields []field, values map[string]interface{}) []string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return []string{fmt.Sprintf("%s: %v", path, err)}
+	}
+
+	leaves := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		leaves[f.yaml] = true
+	}
+	var unknown []string
+	flatten(doc, "", leaves, values, &unknown)
+	sort.Strings(unknown)
+
+	problems := make([]string, len(unknown))
+	for i, key := range unknown {
+		problems[i] = fmt.Sprintf("%s: unknown key %q", path, key)
+	}
+	return problems
+}
+
+func flatten(node map[string]interface{}, prefix string, leaves map[string]bool, values map[string]interface{}, unknown *[]string) {
+	for key, value := range node {
+		path := join(prefix, key)
+		nested, isMap := value.(map[string]interface{})
+		switch {
+		case leaves[path]:
+			if value != nil {
+				values[path] = value
+			}
+		case isMap && hasPrefix(leaves, path+"."):
+			flatten(nested, path, leaves, values, unknown)
+		default:
+			*unknown = append(*unknown, path)
+		}
+	}
+}
+
+func hasPrefix(leaves map[string]bool, prefix string) bool {
+	for leaf := range leaves {
+		if strings.HasPrefix(leaf, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// readDotEnv merges the existing .env files, later files overriding
+// earlier ones
+func readDotEnv(paths []string) (map[string]string, []string) {
+	values := make(map[string]string)
+	var problems []string
+	for _, path := range paths {
+		file, err := godotenv.Read(path)
+		if stderrors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", path, err))
+			continue
+		}
+		for key, value := range file {
+			values[key] = value
+		}
+	}
+	return values, problems
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// VALIDATION AND DUMP
+// ═══════════════════════════════════════════════════════════════════════════
+
+// validate checks the validate tags, naming failures after the env names.
+// Fields that failed to load are not reported again.
+func validate(dst interface{}, fields []field, failed map[string]bool) []string {
+	var validationErrors validator.ValidationErrors
+	if !stderrors.As(validation.Validate(dst), &validationErrors) {
+		return nil
+	}
+
+	names := make(map[string]string, len(fields))
+	for _, f := range fields {
+		names[f.path] = f.name()
+	}
+	var problems []string
+	for _, fieldError := range validationErrors {
+		// Failures are reported on leaves, or on their elements with dive
+		_, path, _ := strings.Cut(fieldError.StructNamespace(), ".")
+		base, index, indexed := strings.Cut(path, "[")
+		if failed[base] {
+			continue
+		}
+		name := names[base]
+		if indexed {
+			name += "[" + index
+		}
+		problems = append(problems, name+": "+validation.FieldMessage(fieldError, errors.LocaleEN))
+	}
+	return problems
+}
+
+// Dump returns the effective value of every field of cfg (a struct or a
+// pointer to one) keyed by env name, with secret fields redacted, for
+// logging at startup
+func Dump(cfg interface{}) map[string]string {
+	v := reflect.Indirect(reflect.ValueOf(cfg))
+	dump := make(map[string]string)
+	for _, f := range collect(v, "", "") {
+		switch {
+		case f.secret && !f.value.IsZero():
+			dump[f.name()] = Redacted
+		case f.value.Kind() == reflect.Slice:
+			items := make([]string, f.value.Len())
+			for i := range items {
+				items[i] = fmt.Sprint(f.value.Index(i).Interface())
+			}
+			dump[f.name()] = strings.Join(items, ",")
+		default:
+			dump[f.name()] = fmt.Sprint(f.value.Interface())
+		}
+	}
+	return dump
+}
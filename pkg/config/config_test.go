@@ -0,0 +1,249 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package config - Loader tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type serverConfig struct {
+	Host    string        `env:"TEST_HOST" yaml:"host" default:"0.0.0.0" validate:"required"`
+	Port    int           `env:"TEST_PORT" yaml:"port" default:"8080" validate:"gt=0"`
+	Timeout time.Duration `env:"TEST_TIMEOUT" yaml:"timeout" default:"30s" validate:"gt=0"`
+}
+
+type testConfig struct {
+	Server  serverConfig `yaml:"server"`
+	Origins []string     `env:"TEST_ORIGINS" yaml:"origins" default:"*"`
+	Debug   bool         `env:"TEST_DEBUG" yaml:"debug"`
+	Ratio   float64      `env:"TEST_RATIO" yaml:"ratio" default:"0.5"`
+	Retries uint8        `env:"TEST_RETRIES" yaml:"retries" default:"3"`
+	Limits  []int        `env:"TEST_LIMITS" yaml:"limits" validate:"dive,gt=0"`
+	Secret  string       `env:"TEST_SECRET" yaml:"secret" secret:"true"`
+	Pin     int          `env:"TEST_PIN" secret:"true"`
+	Label   string
+	Ignored string `yaml:"-"`
+	hidden  string
+}
+
+func write(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func load(t *testing.T, opts ...Option) (testConfig, error) {
+	t.Helper()
+	var cfg testConfig
+	err := Load(&cfg, append([]Option{WithDotEnv()}, opts...)...)
+	return cfg, err
+}
+
+func problems(t *testing.T, err error) []string {
+	t.Helper()
+	var configErr *Error
+	require.ErrorAs(t, err, &configErr)
+	return configErr.Problems
+}
+
+func TestLoadDefaults(t *testing.T) {
+	cfg, err := load(t)
+
+	require.NoError(t, err)
+	assert.Equal(t, testConfig{
+		Server:  serverConfig{Host: "0.0.0.0", Port: 8080, Timeout: 30 * time.Second},
+		Origins: []string{"*"},
+		Ratio:   0.5,
+		Retries: 3,
+	}, cfg)
+}
+
+func TestLoadYAML(t *testing.T) {
+	base := write(t, "base.yaml", `
+server:
+  port: 9000
+  timeout: 1m
+origins: [https://app.fintech.local, https://admin.fintech.local]
+debug: true
+label: blue
+limits: "10, 20"
+secret: ~
+`)
+	override := write(t, "override.yaml", "server:\n  port: 9100\n")
+
+	cfg, err := load(t, WithFile(base, "", override))
+
+	require.NoError(t, err)
+	assert.Equal(t, 9100, cfg.Server.Port, "later files override earlier ones")
+	assert.Equal(t, time.Minute, cfg.Server.Timeout)
+	assert.Equal(t, "0.0.0.0", cfg.Server.Host)
+	assert.Equal(t, []string{"https://app.fintech.local", "https://admin.fintech.local"}, cfg.Origins)
+	assert.Equal(t, []int{10, 20}, cfg.Limits)
+	assert.True(t, cfg.Debug)
+	assert.Equal(t, "blue", cfg.Label)
+	assert.Empty(t, cfg.Secret)
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	file := write(t, "config.yaml", "server:\n  host: yaml-host\n  port: 1\n")
+	dotenv := write(t, ".env", "TEST_HOST=dotenv-host\nTEST_PORT=2\n")
+	t.Setenv("TEST_PORT", "3")
+
+	var cfg testConfig
+	err := Load(&cfg, WithFile(file), WithDotEnv(dotenv, filepath.Join(t.TempDir(), "missing.env")))
+
+	require.NoError(t, err)
+	assert.Equal(t, "dotenv-host", cfg.Server.Host)
+	assert.Equal(t, 3, cfg.Server.Port)
+	_, exported := os.LookupEnv("TEST_HOST")
+	assert.False(t, exported, ".env is not exported")
+}
+
+func TestLoadReadsDotEnvByDefault(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".env"), []byte("TEST_RETRIES=7\n"), 0o600))
+	t.Chdir(dir)
+
+	var cfg testConfig
+	require.NoError(t, Load(&cfg))
+
+	assert.EqualValues(t, 7, cfg.Retries)
+}
+
+func TestLoadFileSuffix(t *testing.T) {
+	t.Setenv("TEST_SECRET_FILE", write(t, "secret", "s3cr3t\n"))
+	dotenv := write(t, ".env", "TEST_HOST_FILE="+write(t, "host", "db.internal"))
+
+	cfg, err := load(t, WithDotEnv(dotenv))
+
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", cfg.Secret)
+	assert.Equal(t, "db.internal", cfg.Server.Host)
+}
+
+func TestLoadFileSuffixErrors(t *testing.T) {
+	t.Setenv("TEST_SECRET", "inline")
+	t.Setenv("TEST_SECRET_FILE", "/run/secrets/test_secret")
+	t.Setenv("TEST_HOST_FILE", filepath.Join(t.TempDir(), "missing"))
+
+	_, err := load(t)
+
+	found := problems(t, err)
+	require.Len(t, found, 2)
+	assert.Contains(t, found[0], "TEST_HOST: reading TEST_HOST_FILE:")
+	assert.Equal(t, "TEST_SECRET: both TEST_SECRET and TEST_SECRET_FILE are set", found[1])
+}
+
+func TestLoadReportsEveryProblem(t *testing.T) {
+	t.Setenv("TEST_HOST", "")
+	t.Setenv("TEST_PORT", "abc")
+	t.Setenv("TEST_TIMEOUT", "1 min")
+	t.Setenv("TEST_DEBUG", "maybe")
+	t.Setenv("TEST_RATIO", "half")
+	t.Setenv("TEST_RETRIES", "-1")
+	t.Setenv("TEST_LIMITS", "10,x")
+	t.Setenv("TEST_PIN", "12a4")
+
+	_, err := load(t)
+
+	assert.Equal(t, []string{
+		`TEST_PORT: invalid integer "abc" (from environment)`,
+		`TEST_TIMEOUT: invalid duration "1 min" (from environment)`,
+		`TEST_DEBUG: invalid boolean "maybe" (from environment)`,
+		`TEST_RATIO: invalid number "half" (from environment)`,
+		`TEST_RETRIES: invalid unsigned integer "-1" (from environment)`,
+		`TEST_LIMITS: invalid integer "x" (from environment)`,
+		`TEST_PIN: invalid integer "[REDACTED]" (from environment)`,
+		"TEST_HOST: is required",
+	}, problems(t, err))
+	assert.Contains(t, err.Error(), "config: TEST_PORT: invalid integer")
+}
+
+func TestLoadValidatesElements(t *testing.T) {
+	t.Setenv("TEST_LIMITS", "10,0")
+	t.Setenv("TEST_TIMEOUT", "0s")
+
+	_, err := load(t)
+
+	assert.Equal(t, []string{"TEST_TIMEOUT: must be greater than 0", "TEST_LIMITS[1]: must be greater than 0"}, problems(t, err))
+}
+
+func TestLoadYAMLProblems(t *testing.T) {
+	file := write(t, "config.yaml", `
+server:
+  hots: typo
+  port: [1, 2]
+retries: 300
+unknown:
+  nested: true
+`)
+
+	_, err := load(t, WithFile(file, filepath.Join(t.TempDir(), "missing.yaml"), write(t, "broken.yaml", "server: [")))
+
+	found := problems(t, err)
+	require.Len(t, found, 6)
+	assert.Equal(t, file+`: unknown key "server.hots"`, found[0])
+	assert.Equal(t, file+`: unknown key "unknown"`, found[1])
+	assert.Contains(t, found[2], "missing.yaml: no such file or directory")
+	assert.Contains(t, found[3], "broken.yaml: yaml:")
+	assert.Equal(t, "TEST_PORT: expected a single value in YAML", found[4])
+	assert.Equal(t, `TEST_RETRIES: invalid unsigned integer "300" (from YAML)`, found[5])
+}
+
+func TestLoadDotEnvProblems(t *testing.T) {
+	_, err := load(t, WithDotEnv(t.TempDir()))
+
+	assert.Len(t, problems(t, err), 1)
+}
+
+func TestLoadUnsupportedType(t *testing.T) {
+	var cfg struct {
+		Weights map[string]int `env:"TEST_WEIGHTS" default:"a=1"`
+	}
+
+	err := Load(&cfg, WithDotEnv())
+
+	assert.EqualError(t, err, `config: TEST_WEIGHTS: unsupported type map[string]int "a=1" (from default)`)
+}
+
+func TestLoadNeedsStructPointer(t *testing.T) {
+	assert.EqualError(t, Load(testConfig{}), "config: Load needs a pointer to a struct, got config.testConfig")
+	var n int
+	assert.Error(t, Load(&n))
+}
+
+func TestDump(t *testing.T) {
+	cfg := testConfig{
+		Server:  serverConfig{Host: "0.0.0.0", Port: 8080, Timeout: 30 * time.Second},
+		Origins: []string{"a", "b"},
+		Limits:  []int{1, 2},
+		Secret:  "s3cr3t",
+		Label:   "blue",
+	}
+
+	dump := Dump(cfg)
+
+	assert.Equal(t, map[string]string{
+		"TEST_HOST":    "0.0.0.0",
+		"TEST_PORT":    "8080",
+		"TEST_TIMEOUT": "30s",
+		"TEST_ORIGINS": "a,b",
+		"TEST_DEBUG":   "false",
+		"TEST_RATIO":   "0",
+		"TEST_RETRIES": "0",
+		"TEST_LIMITS":  "1,2",
+		"TEST_SECRET":  Redacted,
+		"TEST_PIN":     "0",
+		"label":        "blue",
+	}, dump)
+	assert.Equal(t, dump, Dump(&cfg))
+}
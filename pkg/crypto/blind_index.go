@@ -0,0 +1,88 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package crypto - Blind indexes for lookups on encrypted fields
+// ═══════════════════════════════════════════════════════════════════════════
+
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+)
+
+// BlindIndex computes keyed, deterministic digests of sensitive values, so
+// an encrypted column can be looked up by equality (accounts by CPF) through
+// a digest column. Its key must differ from the KMS keys; changing it means
+// recomputing every digest.
+type BlindIndex struct {
+	key       []byte
+	normalize func(string) string
+	size      int
+}
+
+// IndexOption configures a BlindIndex
+type IndexOption func(*BlindIndex)
+
+// WithNormalizer normalizes values before digesting them, so that
+// "529.982.247-25" and "52998224725" find the same row
+func WithNormalizer(normalize func(string) string) IndexOption {
+	return func(b *BlindIndex) {
+		b.normalize = normalize
+	}
+}
+
+// WithIndexSize truncates digests to size bytes. Shorter digests collide
+// more, which leaks less about low-entropy values but needs the lookup to
+// filter the decrypted candidates.
+func WithIndexSize(size int) IndexOption {
+	return func(b *BlindIndex) {
+		b.size = size
+	}
+}
+
+// NewBlindIndex creates a BlindIndex with a key of at least KeySize bytes
+func NewBlindIndex(key []byte, opts ...IndexOption) (*BlindIndex, error) {
+	if len(key) < KeySize {
+		return nil, fmt.Errorf("%w: blind index keys need %d bytes, got %d", ErrInvalidKey, KeySize, len(key))
+	}
+	b := &BlindIndex{
+		key:       append([]byte(nil), key...),
+		normalize: func(s string) string { return s },
+		size:      sha256.Size,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	if b.size <= 0 || b.size > sha256.Size {
+		b.size = sha256.Size
+	}
+	return b, nil
+}
+
+// Compute returns the digest of value, base64url-encoded. Empty values,
+// also after normalization, have an empty digest.
+func (b *BlindIndex) Compute(value string) string {
+	value = b.normalize(value)
+	if value == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, b.key)
+	mac.Write([]byte(value))
+	return b64.EncodeToString(mac.Sum(nil)[:b.size])
+}
+
+// Digits keeps only the digits of documents and phones
+func Digits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < '0' || r > '9' {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// Email trims and lowercases e-mail addresses
+func Email(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
@@ -0,0 +1,44 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package crypto - Blind index tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlindIndex(t *testing.T) {
+	index, err := NewBlindIndex(randomKey(), WithNormalizer(Digits))
+	require.NoError(t, err)
+
+	digest := index.Compute("529.982.247-25")
+	assert.Equal(t, digest, index.Compute("52998224725"), "digests are deterministic after normalization")
+	assert.NotEqual(t, digest, index.Compute("11144477735"))
+	assert.Len(t, digest, 43)
+	assert.Empty(t, index.Compute("---"))
+
+	other, err := NewBlindIndex(randomKey(), WithNormalizer(Digits))
+	require.NoError(t, err)
+	assert.NotEqual(t, digest, other.Compute("52998224725"), "digests depend on the key")
+}
+
+func TestBlindIndexOptions(t *testing.T) {
+	key := randomKey()
+
+	index, err := NewBlindIndex(key, WithNormalizer(Email), WithIndexSize(8))
+	require.NoError(t, err)
+	assert.Len(t, index.Compute("joao@example.com"), 11)
+	assert.Equal(t, index.Compute("joao@example.com"), index.Compute(" Joao@Example.com "))
+
+	index, err = NewBlindIndex(key, WithIndexSize(64))
+	require.NoError(t, err)
+	assert.Len(t, index.Compute("Joao@example.com"), 43)
+	assert.NotEqual(t, index.Compute("Joao@example.com"), index.Compute("joao@example.com"), "values are not normalized by default")
+
+	_, err = NewBlindIndex([]byte("short"))
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
@@ -0,0 +1,268 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package crypto - Envelope encryption of fields at rest
+// ═══════════════════════════════════════════════════════════════════════════
+
+package crypto
+
+import (
+	"container/list"
+	"context"
+	"crypto/cipher"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// DefaultDataKeyUses is how many values one data key encrypts before the
+// Encryptor generates a new one
+const DefaultDataKeyUses = 1 << 20
+
+// DefaultKeyCacheSize is how many unwrapped data keys an Encryptor keeps
+const DefaultKeyCacheSize = 1024
+
+// formatV1 prefixes ciphertexts: enc1.<kek id>.<wrapped data key>.<nonce+ciphertext>
+const formatV1 = "enc1"
+
+var b64 = base64.RawURLEncoding
+
+// Encryptor encrypts fields with AES-256-GCM data keys, which are wrapped by
+// the KMS and stored with every ciphertext (envelope encryption). A data key
+// is reused until the KMS rotates or DefaultDataKeyUses values were
+// encrypted, so the KMS is not called per value. Unwrapped data keys are
+// cached for Decrypt until the KMS version changes, so a retired KEK stops
+// decrypting at once.
+type Encryptor struct {
+	kms       KMS
+	maxUses   int
+	cacheSize int
+
+	mu        sync.Mutex
+	current   *dataKey
+	unwrapped *keyCache
+}
+
+type dataKey struct {
+	aead    cipher.AEAD
+	wrapped []byte
+	keyID   string
+	uses    int
+}
+
+// EncryptorOption configures an Encryptor
+type EncryptorOption func(*Encryptor)
+
+// WithDataKeyUses sets how many values one data key encrypts
+func WithDataKeyUses(n int) EncryptorOption {
+	return func(e *Encryptor) {
+		e.maxUses = n
+	}
+}
+
+// WithKeyCacheSize sets how many unwrapped data keys are kept for Decrypt
+func WithKeyCacheSize(n int) EncryptorOption {
+	return func(e *Encryptor) {
+		e.cacheSize = n
+	}
+}
+
+// NewEncryptor creates an Encryptor whose data keys are wrapped by kms
+func NewEncryptor(kms KMS, opts ...EncryptorOption) *Encryptor {
+	e := &Encryptor{
+		kms:       kms,
+		maxUses:   DefaultDataKeyUses,
+		cacheSize: DefaultKeyCacheSize,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	e.unwrapped = newKeyCache(e.cacheSize)
+	return e
+}
+
+// Encrypt seals plaintext under the current KEK. associatedData, such as the
+// account ID, is authenticated but not stored: Decrypt needs the same value,
+// so a ciphertext cannot be copied to another row.
+func (e *Encryptor) Encrypt(ctx context.Context, plaintext, associatedData []byte) (string, error) {
+	key, err := e.dataKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	sealed := seal(key.aead, plaintext, associatedData)
+	return strings.Join([]string{formatV1, key.keyID, b64.EncodeToString(key.wrapped), b64.EncodeToString(sealed)}, "."), nil
+}
+
+// Decrypt opens a ciphertext returned by Encrypt with the same associatedData
+func (e *Encryptor) Decrypt(ctx context.Context, ciphertext string, associatedData []byte) ([]byte, error) {
+	keyID, wrapped, sealed, err := parse(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+
+	// the version is read before unwrapping, so a key unwrapped while a KEK
+	// is retired is cached under the old version and dropped
+	version := e.kms.Version()
+	e.mu.Lock()
+	aead, ok := e.unwrapped.get(version, string(wrapped))
+	e.mu.Unlock()
+	if !ok {
+		plain, err := e.kms.UnwrapKey(ctx, keyID, wrapped)
+		if err != nil {
+			return nil, err
+		}
+		if aead, err = newGCM(plain); err != nil {
+			return nil, err
+		}
+		e.mu.Lock()
+		e.unwrapped.put(version, string(wrapped), aead)
+		e.mu.Unlock()
+	}
+	return open(aead, sealed, associatedData)
+}
+
+// EncryptString encrypts value, keeping empty values empty so optional
+// columns stay optional
+func (e *Encryptor) EncryptString(ctx context.Context, value, associatedData string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	return e.Encrypt(ctx, []byte(value), []byte(associatedData))
+}
+
+// DecryptString decrypts a ciphertext returned by EncryptString
+func (e *Encryptor) DecryptString(ctx context.Context, ciphertext, associatedData string) (string, error) {
+	if ciphertext == "" {
+		return "", nil
+	}
+	plaintext, err := e.Decrypt(ctx, ciphertext, []byte(associatedData))
+	return string(plaintext), err
+}
+
+// KeyID returns the KEK version that wraps the data key of ciphertext
+func KeyID(ciphertext string) (string, error) {
+	keyID, _, _, err := parse(ciphertext)
+	return keyID, err
+}
+
+// NeedsRotation reports whether ciphertext was encrypted under a KEK version
+// other than the current one
+func (e *Encryptor) NeedsRotation(ciphertext string) bool {
+	keyID, err := KeyID(ciphertext)
+	return err == nil && keyID != e.kms.CurrentKeyID()
+}
+
+// Reencrypt encrypts ciphertext again under the current KEK, reporting
+// whether it changed. Ciphertexts already under the current KEK are returned
+// as they are.
+func (e *Encryptor) Reencrypt(ctx context.Context, ciphertext string, associatedData []byte) (string, bool, error) {
+	keyID, err := KeyID(ciphertext)
+	if err != nil {
+		return "", false, err
+	}
+	if keyID == e.kms.CurrentKeyID() {
+		return ciphertext, false, nil
+	}
+	plaintext, err := e.Decrypt(ctx, ciphertext, associatedData)
+	if err != nil {
+		return "", false, err
+	}
+	reencrypted, err := e.Encrypt(ctx, plaintext, associatedData)
+	if err != nil {
+		return "", false, err
+	}
+	return reencrypted, true, nil
+}
+
+// dataKey returns the data key for the next value, generating one when the
+// KEK rotated or the current key reached its uses
+func (e *Encryptor) dataKey(ctx context.Context) (*dataKey, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if key := e.current; key != nil && key.keyID == e.kms.CurrentKeyID() && key.uses < e.maxUses {
+		key.uses++
+		return key, nil
+	}
+
+	version := e.kms.Version()
+	plain := randomKey()
+	wrapped, keyID, err := e.kms.WrapKey(ctx, plain)
+	if err != nil {
+		return nil, err
+	}
+	// 32-byte keys are always valid AES-256 keys
+	aead, _ := newGCM(plain)
+
+	e.current = &dataKey{aead: aead, wrapped: wrapped, keyID: keyID, uses: 1}
+	e.unwrapped.put(version, string(wrapped), aead)
+	return e.current, nil
+}
+
+// keyCache keeps up to size unwrapped data keys by their wrapped form,
+// evicting the least recently used one when full. It empties itself when it
+// sees another KMS version. The Encryptor's mutex guards it.
+type keyCache struct {
+	size    int
+	version uint64
+	items   map[string]*list.Element
+	order   *list.List
+}
+
+type cachedKey struct {
+	wrapped string
+	aead    cipher.AEAD
+}
+
+func newKeyCache(size int) *keyCache {
+	if size < 1 {
+		size = DefaultKeyCacheSize
+	}
+	return &keyCache{size: size, items: make(map[string]*list.Element), order: list.New()}
+}
+
+func (c *keyCache) get(version uint64, wrapped string) (cipher.AEAD, bool) {
+	c.sync(version)
+	element, ok := c.items[wrapped]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*cachedKey).aead, true
+}
+
+func (c *keyCache) put(version uint64, wrapped string, aead cipher.AEAD) {
+	c.sync(version)
+	if element, ok := c.items[wrapped]; ok {
+		c.order.MoveToFront(element)
+		return
+	}
+	c.items[wrapped] = c.order.PushFront(&cachedKey{wrapped: wrapped, aead: aead})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*cachedKey).wrapped)
+	}
+}
+
+// sync drops every key cached under another KMS version
+func (c *keyCache) sync(version uint64) {
+	if version != c.version {
+		c.version = version
+		clear(c.items)
+		c.order.Init()
+	}
+}
+
+func parse(ciphertext string) (keyID string, wrapped, sealed []byte, err error) {
+	parts := strings.Split(ciphertext, ".")
+	if len(parts) != 4 || parts[0] != formatV1 || parts[1] == "" {
+		return "", nil, nil, ErrInvalidCiphertext
+	}
+	if wrapped, err = b64.DecodeString(parts[2]); err != nil {
+		return "", nil, nil, fmt.Errorf("%w: data key: %v", ErrInvalidCiphertext, err)
+	}
+	if sealed, err = b64.DecodeString(parts[3]); err != nil {
+		return "", nil, nil, fmt.Errorf("%w: %v", ErrInvalidCiphertext, err)
+	}
+	return parts[1], wrapped, sealed, nil
+}
@@ -0,0 +1,244 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package crypto - Envelope encryption tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package crypto
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errBoom = errors.New("boom")
+
+// stubKMS wraps a FileKMS, counting and optionally failing its calls
+type stubKMS struct {
+	*FileKMS
+	wraps     int
+	unwraps   int
+	wrapErr   error
+	unwrapKey []byte
+}
+
+func (s *stubKMS) WrapKey(ctx context.Context, dataKey []byte) ([]byte, string, error) {
+	s.wraps++
+	if s.wrapErr != nil {
+		return nil, "", s.wrapErr
+	}
+	return s.FileKMS.WrapKey(ctx, dataKey)
+}
+
+func (s *stubKMS) UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	s.unwraps++
+	if s.unwrapKey != nil {
+		return s.unwrapKey, nil
+	}
+	return s.FileKMS.UnwrapKey(ctx, keyID, wrapped)
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	ctx := context.Background()
+	kms := newFileKMS(t)
+	encryptor := NewEncryptor(kms)
+
+	ciphertext, err := encryptor.Encrypt(ctx, []byte("52998224725"), []byte("account-1"))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(ciphertext, "enc1.v1."))
+	assert.NotContains(t, ciphertext, "52998224725")
+
+	plaintext, err := encryptor.Decrypt(ctx, ciphertext, []byte("account-1"))
+	require.NoError(t, err)
+	assert.Equal(t, "52998224725", string(plaintext))
+
+	_, err = encryptor.Decrypt(ctx, ciphertext, []byte("account-2"))
+	assert.ErrorIs(t, err, ErrInvalidCiphertext, "ciphertexts are bound to their associated data")
+
+	plaintext, err = NewEncryptor(kms).Decrypt(ctx, ciphertext, []byte("account-1"))
+	require.NoError(t, err)
+	assert.Equal(t, "52998224725", string(plaintext), "another instance unwraps the data key")
+}
+
+func TestEncryptString(t *testing.T) {
+	ctx := context.Background()
+	encryptor := NewEncryptor(newFileKMS(t))
+
+	ciphertext, err := encryptor.EncryptString(ctx, "joao@example.com", "account-1")
+	require.NoError(t, err)
+	value, err := encryptor.DecryptString(ctx, ciphertext, "account-1")
+	require.NoError(t, err)
+	assert.Equal(t, "joao@example.com", value)
+
+	ciphertext, err = encryptor.EncryptString(ctx, "", "account-1")
+	require.NoError(t, err)
+	assert.Empty(t, ciphertext)
+	value, err = encryptor.DecryptString(ctx, "", "account-1")
+	require.NoError(t, err)
+	assert.Empty(t, value)
+}
+
+func TestEncryptorReusesDataKeys(t *testing.T) {
+	ctx := context.Background()
+	kms := &stubKMS{FileKMS: newFileKMS(t)}
+	encryptor := NewEncryptor(kms, WithDataKeyUses(2))
+
+	for i := 0; i < 3; i++ {
+		_, err := encryptor.Encrypt(ctx, []byte("value"), nil)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, kms.wraps, "a new data key after two uses")
+
+	_, err := kms.Rotate()
+	require.NoError(t, err)
+	ciphertext, err := encryptor.Encrypt(ctx, []byte("value"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, kms.wraps, "a new data key after the KEK rotates")
+	assert.True(t, strings.HasPrefix(ciphertext, "enc1.v2."))
+}
+
+func TestDecryptAfterRetireFails(t *testing.T) {
+	ctx := context.Background()
+	kms := newFileKMS(t)
+	encryptor := NewEncryptor(kms)
+	ciphertext, err := encryptor.Encrypt(ctx, []byte("value"), nil)
+	require.NoError(t, err)
+	_, err = encryptor.Decrypt(ctx, ciphertext, nil)
+	require.NoError(t, err)
+
+	_, err = kms.Rotate()
+	require.NoError(t, err)
+	_, err = encryptor.Decrypt(ctx, ciphertext, nil)
+	require.NoError(t, err, "rotating keeps older versions")
+	require.NoError(t, kms.Retire("v1"))
+
+	_, err = encryptor.Decrypt(ctx, ciphertext, nil)
+	assert.ErrorIs(t, err, ErrUnknownKey, "cached data keys of a retired KEK are dropped")
+}
+
+func TestEncryptorKeyCacheIsBounded(t *testing.T) {
+	ctx := context.Background()
+	kms := &stubKMS{FileKMS: newFileKMS(t)}
+	encryptor := NewEncryptor(kms, WithDataKeyUses(1), WithKeyCacheSize(1))
+	first, err := encryptor.Encrypt(ctx, []byte("first"), nil)
+	require.NoError(t, err)
+	second, err := encryptor.Encrypt(ctx, []byte("second"), nil)
+	require.NoError(t, err)
+
+	for _, ciphertext := range []string{second, first, first, second} {
+		_, err := encryptor.Decrypt(ctx, ciphertext, nil)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 2, kms.unwraps, "one key fits, so switching keys unwraps again")
+}
+
+func TestKeyCache(t *testing.T) {
+	cache := newKeyCache(0)
+	assert.Equal(t, DefaultKeyCacheSize, cache.size)
+
+	aead, _ := newGCM(randomKey())
+	cache.put(1, "a", aead)
+	cache.put(1, "a", aead)
+	assert.Equal(t, 1, cache.order.Len())
+
+	_, ok := cache.get(1, "a")
+	assert.True(t, ok)
+	_, ok = cache.get(2, "a")
+	assert.False(t, ok, "another KMS version empties the cache")
+}
+
+func TestEncryptorKMSErrors(t *testing.T) {
+	ctx := context.Background()
+	kms := &stubKMS{FileKMS: newFileKMS(t)}
+	ciphertext, err := NewEncryptor(kms).Encrypt(ctx, []byte("value"), nil)
+	require.NoError(t, err)
+
+	kms.wrapErr = errBoom
+	_, err = NewEncryptor(kms).Encrypt(ctx, []byte("value"), nil)
+	assert.ErrorIs(t, err, errBoom)
+
+	kms.unwrapKey = []byte("short")
+	_, err = NewEncryptor(kms).Decrypt(ctx, ciphertext, nil)
+	assert.ErrorIs(t, err, ErrInvalidKey)
+
+	kms.unwrapKey = nil
+	_, err = kms.Rotate()
+	require.NoError(t, err)
+	require.NoError(t, kms.Retire("v1"))
+	_, err = NewEncryptor(kms).Decrypt(ctx, ciphertext, nil)
+	assert.ErrorIs(t, err, ErrUnknownKey)
+}
+
+func TestDecryptInvalidCiphertexts(t *testing.T) {
+	encryptor := NewEncryptor(newFileKMS(t))
+
+	for _, ciphertext := range []string{
+		"52998224725",
+		"enc2.v1.AAAA.AAAA",
+		"enc1..AAAA.AAAA",
+		"enc1.v1.!!!!.AAAA",
+		"enc1.v1.AAAA.!!!!",
+	} {
+		_, err := encryptor.Decrypt(context.Background(), ciphertext, nil)
+		assert.ErrorIs(t, err, ErrInvalidCiphertext, ciphertext)
+	}
+}
+
+func TestKeyIDAndNeedsRotation(t *testing.T) {
+	ctx := context.Background()
+	kms := newFileKMS(t)
+	encryptor := NewEncryptor(kms)
+	ciphertext, err := encryptor.Encrypt(ctx, []byte("value"), nil)
+	require.NoError(t, err)
+
+	keyID, err := KeyID(ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", keyID)
+	assert.False(t, encryptor.NeedsRotation(ciphertext))
+
+	_, err = kms.Rotate()
+	require.NoError(t, err)
+	assert.True(t, encryptor.NeedsRotation(ciphertext))
+	assert.False(t, encryptor.NeedsRotation("garbage"))
+}
+
+func TestReencrypt(t *testing.T) {
+	ctx := context.Background()
+	kms := &stubKMS{FileKMS: newFileKMS(t)}
+	encryptor := NewEncryptor(kms)
+	ciphertext, err := encryptor.Encrypt(ctx, []byte("52998224725"), []byte("account-1"))
+	require.NoError(t, err)
+
+	same, changed, err := encryptor.Reencrypt(ctx, ciphertext, []byte("account-1"))
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, ciphertext, same)
+
+	_, err = kms.Rotate()
+	require.NoError(t, err)
+
+	_, _, err = encryptor.Reencrypt(ctx, "garbage", nil)
+	assert.ErrorIs(t, err, ErrInvalidCiphertext)
+
+	_, _, err = encryptor.Reencrypt(ctx, ciphertext, []byte("account-2"))
+	assert.ErrorIs(t, err, ErrInvalidCiphertext)
+
+	kms.wrapErr = errBoom
+	_, _, err = encryptor.Reencrypt(ctx, ciphertext, []byte("account-1"))
+	assert.ErrorIs(t, err, errBoom)
+	kms.wrapErr = nil
+
+	reencrypted, changed, err := encryptor.Reencrypt(ctx, ciphertext, []byte("account-1"))
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.True(t, strings.HasPrefix(reencrypted, "enc1.v2."))
+
+	require.NoError(t, kms.Retire("v1"))
+	plaintext, err := NewEncryptor(kms).Decrypt(ctx, reencrypted, []byte("account-1"))
+	require.NoError(t, err)
+	assert.Equal(t, "52998224725", string(plaintext))
+}
@@ -0,0 +1,244 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package crypto - Key management
+// ═══════════════════════════════════════════════════════════════════════════
+
+package crypto
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrInvalidKey        = stderrors.New("crypto: invalid key")
+	ErrUnknownKey        = stderrors.New("crypto: unknown key")
+	ErrKeyInUse          = stderrors.New("crypto: key is current")
+	ErrInvalidCiphertext = stderrors.New("crypto: invalid ciphertext")
+)
+
+// KeySize is the size of every AES-256 key: key-encryption keys, data keys
+// and the minimum for blind index keys
+const KeySize = 32
+
+// KMS wraps data keys with versioned key-encryption keys (KEKs). The KEKs
+// never leave the KMS.
+type KMS interface {
+	// CurrentKeyID returns the KEK version WrapKey uses
+	CurrentKeyID() string
+	// WrapKey encrypts dataKey with the current KEK and returns its version
+	WrapKey(ctx context.Context, dataKey []byte) (wrapped []byte, keyID string, err error)
+	// UnwrapKey decrypts a data key wrapped by the KEK version keyID
+	UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+	// Version changes whenever KEK versions are added or retired, so callers
+	// know to drop the data keys they unwrapped before
+	Version() uint64
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// FILE KMS
+// ═══════════════════════════════════════════════════════════════════════════
+
+// keyring is the file format of FileKMS. Keys are base64 in JSON.
+type keyring struct {
+	Current string            `json:"current"`
+	Keys    map[string][]byte `json:"keys"`
+}
+
+// FileKMS is a KMS over a local JSON keyring, for development and
+// single-host deployments. The file holds the KEKs in clear, so it must be
+// readable by the service only (it is written with mode 0600).
+type FileKMS struct {
+	path    string
+	mu      sync.RWMutex
+	ring    keyring
+	keks    map[string]cipher.AEAD
+	version uint64
+}
+
+// OpenFileKMS loads the keyring at path, creating it with a first KEK ("v1")
+// when the file does not exist
+func OpenFileKMS(path string) (*FileKMS, error) {
+	k := &FileKMS{path: path}
+
+	data, err := os.ReadFile(path)
+	if stderrors.Is(err, fs.ErrNotExist) {
+		if _, err := k.Rotate(); err != nil {
+			return nil, err
+		}
+		return k, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var ring keyring
+	if err := json.Unmarshal(data, &ring); err != nil {
+		return nil, fmt.Errorf("crypto: reading keyring %s: %w", path, err)
+	}
+	if _, ok := ring.Keys[ring.Current]; !ok {
+		return nil, fmt.Errorf("%w: current key %q is not in %s", ErrUnknownKey, ring.Current, path)
+	}
+	if err := k.use(ring); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// use validates ring and makes it the active keyring
+func (k *FileKMS) use(ring keyring) error {
+	keks := make(map[string]cipher.AEAD, len(ring.Keys))
+	for id, key := range ring.Keys {
+		aead, err := newGCM(key)
+		if err != nil {
+			return fmt.Errorf("%w: key %s", err, id)
+		}
+		keks[id] = aead
+	}
+	k.ring, k.keks = ring, keks
+	k.version++
+	return nil
+}
+
+// CurrentKeyID implements KMS
+func (k *FileKMS) CurrentKeyID() string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.ring.Current
+}
+
+// Version implements KMS. It increases every time the keyring is loaded,
+// rotated or has a version retired.
+func (k *FileKMS) Version() uint64 {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.version
+}
+
+// WrapKey implements KMS. The KEK version is authenticated with the data key.
+func (k *FileKMS) WrapKey(_ context.Context, dataKey []byte) ([]byte, string, error) {
+	k.mu.RLock()
+	id := k.ring.Current
+	kek := k.keks[id]
+	k.mu.RUnlock()
+
+	return seal(kek, dataKey, []byte(id)), id, nil
+}
+
+// UnwrapKey implements KMS
+func (k *FileKMS) UnwrapKey(_ context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	k.mu.RLock()
+	kek, ok := k.keks[keyID]
+	k.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, keyID)
+	}
+	return open(kek, wrapped, []byte(keyID))
+}
+
+// Rotate adds a KEK version and makes it current. Data keys wrapped by
+// previous versions keep unwrapping until those versions are retired.
+func (k *FileKMS) Rotate() (string, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	latest := 0
+	for id := range k.ring.Keys {
+		if n, err := strconv.Atoi(strings.TrimPrefix(id, "v")); err == nil && n > latest {
+			latest = n
+		}
+	}
+	id := "v" + strconv.Itoa(latest+1)
+
+	next := keyring{Current: id, Keys: map[string][]byte{id: randomKey()}}
+	for existing, key := range k.ring.Keys {
+		next.Keys[existing] = key
+	}
+	if err := k.save(next); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// Retire removes a KEK version once no stored data key is wrapped by it,
+// that is after a re-encryption job (see Encryptor.ReencryptAll) finished
+func (k *FileKMS) Retire(keyID string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if keyID == k.ring.Current {
+		return fmt.Errorf("%w: %s", ErrKeyInUse, keyID)
+	}
+	if _, ok := k.ring.Keys[keyID]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownKey, keyID)
+	}
+
+	next := keyring{Current: k.ring.Current, Keys: make(map[string][]byte, len(k.ring.Keys)-1)}
+	for id, key := range k.ring.Keys {
+		if id != keyID {
+			next.Keys[id] = key
+		}
+	}
+	return k.save(next)
+}
+
+// save writes ring through a temporary file, so a crash never leaves a
+// truncated keyring, and then makes it active
+func (k *FileKMS) save(ring keyring) error {
+	data, _ := json.MarshalIndent(ring, "", "  ")
+	tmp := k.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, k.path); err != nil {
+		return err
+	}
+	return k.use(ring)
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// AES-GCM
+// ═══════════════════════════════════════════════════════════════════════════
+
+// randomKey returns a new AES-256 key. crypto/rand.Read never fails since
+// Go 1.24.
+func randomKey() []byte {
+	key := make([]byte, KeySize)
+	rand.Read(key)
+	return key
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("%w: want %d bytes, got %d", ErrInvalidKey, KeySize, len(key))
+	}
+	block, _ := aes.NewCipher(key)
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts plaintext with a random nonce, which it prepends
+func seal(aead cipher.AEAD, plaintext, associatedData []byte) []byte {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	rand.Read(nonce)
+	return aead.Seal(nonce, nonce, plaintext, associatedData)
+}
+
+func open(aead cipher.AEAD, sealed, associatedData []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, ErrInvalidCiphertext
+	}
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], associatedData)
+	if err != nil {
+		return nil, ErrInvalidCiphertext
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,151 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package crypto - Key management tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package crypto
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════
+// TEST HELPERS
+// ═══════════════════════════════════════════════════════════════════════════
+
+func newFileKMS(t *testing.T) *FileKMS {
+	t.Helper()
+	kms, err := OpenFileKMS(filepath.Join(t.TempDir(), "keyring.json"))
+	require.NoError(t, err)
+	return kms
+}
+
+func writeKeyring(t *testing.T, ring interface{}) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "keyring.json")
+	data, err := json.Marshal(ring)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// FILE KMS
+// ═══════════════════════════════════════════════════════════════════════════
+
+func TestOpenFileKMSCreatesKeyring(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keyring.json")
+
+	kms, err := OpenFileKMS(path)
+
+	require.NoError(t, err)
+	assert.Equal(t, "v1", kms.CurrentKeyID())
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	reopened, err := OpenFileKMS(path)
+	require.NoError(t, err)
+	wrapped, keyID, err := kms.WrapKey(context.Background(), randomKey())
+	require.NoError(t, err)
+	_, err = reopened.UnwrapKey(context.Background(), keyID, wrapped)
+	assert.NoError(t, err, "the keyring is persisted")
+}
+
+func TestOpenFileKMSErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := OpenFileKMS(filepath.Join(dir, "missing", "keyring.json"))
+	assert.Error(t, err, "the keyring cannot be created")
+
+	_, err = OpenFileKMS(dir)
+	assert.Error(t, err, "the keyring cannot be read")
+
+	_, err = OpenFileKMS(writeKeyring(t, "not a keyring"))
+	assert.ErrorContains(t, err, "crypto: reading keyring")
+
+	_, err = OpenFileKMS(writeKeyring(t, keyring{Current: "v2", Keys: map[string][]byte{"v1": randomKey()}}))
+	assert.ErrorIs(t, err, ErrUnknownKey)
+
+	_, err = OpenFileKMS(writeKeyring(t, keyring{Current: "v1", Keys: map[string][]byte{"v1": []byte("short")}}))
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
+
+func TestFileKMSWrapKey(t *testing.T) {
+	ctx := context.Background()
+	kms := newFileKMS(t)
+	dataKey := randomKey()
+
+	wrapped, keyID, err := kms.WrapKey(ctx, dataKey)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", keyID)
+	assert.NotContains(t, string(wrapped), string(dataKey))
+
+	unwrapped, err := kms.UnwrapKey(ctx, keyID, wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, dataKey, unwrapped)
+
+	_, err = kms.UnwrapKey(ctx, "v9", wrapped)
+	assert.ErrorIs(t, err, ErrUnknownKey)
+
+	_, err = kms.UnwrapKey(ctx, keyID, wrapped[:4])
+	assert.ErrorIs(t, err, ErrInvalidCiphertext)
+
+	wrapped[len(wrapped)-1] ^= 1
+	_, err = kms.UnwrapKey(ctx, keyID, wrapped)
+	assert.ErrorIs(t, err, ErrInvalidCiphertext)
+}
+
+func TestFileKMSRotateAndRetire(t *testing.T) {
+	ctx := context.Background()
+	kms := newFileKMS(t)
+	old, _, err := kms.WrapKey(ctx, randomKey())
+	require.NoError(t, err)
+
+	id, err := kms.Rotate()
+	require.NoError(t, err)
+	assert.Equal(t, "v2", id)
+	assert.Equal(t, "v2", kms.CurrentKeyID())
+
+	_, err = kms.UnwrapKey(ctx, "v1", old)
+	assert.NoError(t, err, "older versions keep unwrapping")
+
+	assert.ErrorIs(t, kms.Retire("v2"), ErrKeyInUse)
+	assert.ErrorIs(t, kms.Retire("v9"), ErrUnknownKey)
+	require.NoError(t, kms.Retire("v1"))
+
+	_, err = kms.UnwrapKey(ctx, "v1", old)
+	assert.ErrorIs(t, err, ErrUnknownKey)
+
+	reopened, err := OpenFileKMS(kms.path)
+	require.NoError(t, err)
+	assert.Equal(t, "v2", reopened.CurrentKeyID())
+	assert.Len(t, reopened.ring.Keys, 1)
+}
+
+func TestFileKMSRotateNumbersAfterLatestVersion(t *testing.T) {
+	kms, err := OpenFileKMS(writeKeyring(t, keyring{Current: "v3", Keys: map[string][]byte{"legacy": randomKey(), "v3": randomKey()}}))
+	require.NoError(t, err)
+
+	id, err := kms.Rotate()
+
+	require.NoError(t, err)
+	assert.Equal(t, "v4", id)
+	assert.Len(t, kms.ring.Keys, 3)
+}
+
+func TestFileKMSSaveErrors(t *testing.T) {
+	dir := t.TempDir()
+	kms := &FileKMS{path: dir}
+
+	_, err := kms.Rotate()
+
+	assert.Error(t, err, "the keyring cannot replace a directory")
+	assert.Empty(t, kms.CurrentKeyID())
+}
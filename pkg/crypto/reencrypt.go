@@ -0,0 +1,80 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package crypto - Re-encryption after key rotation
+// ═══════════════════════════════════════════════════════════════════════════
+
+package crypto
+
+import "context"
+
+// DefaultBatchSize is the page size of ReencryptAll
+const DefaultBatchSize = 500
+
+// Field is an encrypted value in a store, such as a Cassandra column
+type Field struct {
+	// ID is whatever the store needs to update the value
+	ID             string
+	Ciphertext     string
+	AssociatedData []byte
+}
+
+// FieldStore lists and updates the encrypted fields of a table
+type FieldStore interface {
+	// Fields returns up to limit fields after cursor ("" for the first page)
+	// and the cursor of the next page, "" after the last one
+	Fields(ctx context.Context, cursor string, limit int) ([]Field, string, error)
+	// Replace stores ciphertext unless the field changed since it was read
+	// (a lightweight transaction in Cassandra), reporting whether it did
+	Replace(ctx context.Context, field Field, ciphertext string) (bool, error)
+}
+
+// ReencryptResult summarizes a ReencryptAll run
+type ReencryptResult struct {
+	Scanned     int
+	Reencrypted int
+	// Conflicts counts fields updated by someone else meanwhile, which are
+	// already under the current KEK
+	Conflicts int
+}
+
+// ReencryptAll moves every field of store to the current KEK. Run it after
+// the KMS rotates; once it finishes, older KEK versions can be retired. Runs
+// are idempotent, so a failed run is resumed by running it again.
+func (e *Encryptor) ReencryptAll(ctx context.Context, store FieldStore, batchSize int) (ReencryptResult, error) {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	var result ReencryptResult
+	cursor := ""
+	for {
+		fields, next, err := store.Fields(ctx, cursor, batchSize)
+		if err != nil {
+			return result, err
+		}
+
+		for _, field := range fields {
+			result.Scanned++
+			ciphertext, changed, err := e.Reencrypt(ctx, field.Ciphertext, field.AssociatedData)
+			if err != nil {
+				return result, err
+			}
+			if !changed {
+				continue
+			}
+			replaced, err := store.Replace(ctx, field, ciphertext)
+			if err != nil {
+				return result, err
+			}
+			if replaced {
+				result.Reencrypted++
+			} else {
+				result.Conflicts++
+			}
+		}
+
+		if next == "" {
+			return result, nil
+		}
+		cursor = next
+	}
+}
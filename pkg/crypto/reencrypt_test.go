@@ -0,0 +1,120 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package crypto - Re-encryption tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package crypto
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryFieldStore pages through fields by index
+type memoryFieldStore struct {
+	fields     []Field
+	pages      int
+	fieldsErr  error
+	replaceErr error
+	// conflicts makes Replace fail the compare-and-set for these IDs
+	conflicts map[string]bool
+}
+
+func (s *memoryFieldStore) Fields(_ context.Context, cursor string, limit int) ([]Field, string, error) {
+	if s.fieldsErr != nil {
+		return nil, "", s.fieldsErr
+	}
+	s.pages++
+	start, _ := strconv.Atoi(cursor)
+	end := start + limit
+	if end >= len(s.fields) {
+		return s.fields[start:], "", nil
+	}
+	return s.fields[start:end], strconv.Itoa(end), nil
+}
+
+func (s *memoryFieldStore) Replace(_ context.Context, field Field, ciphertext string) (bool, error) {
+	if s.replaceErr != nil {
+		return false, s.replaceErr
+	}
+	if s.conflicts[field.ID] {
+		return false, nil
+	}
+	for i := range s.fields {
+		if s.fields[i].ID == field.ID && s.fields[i].Ciphertext == field.Ciphertext {
+			s.fields[i].Ciphertext = ciphertext
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func seedFields(t *testing.T, encryptor *Encryptor, n int) *memoryFieldStore {
+	t.Helper()
+	store := &memoryFieldStore{}
+	for i := 0; i < n; i++ {
+		id := "account-" + strconv.Itoa(i)
+		ciphertext, err := encryptor.EncryptString(context.Background(), "5299822472"+strconv.Itoa(i), id)
+		require.NoError(t, err)
+		store.fields = append(store.fields, Field{ID: id, Ciphertext: ciphertext, AssociatedData: []byte(id)})
+	}
+	return store
+}
+
+func TestReencryptAll(t *testing.T) {
+	ctx := context.Background()
+	kms := newFileKMS(t)
+	encryptor := NewEncryptor(kms)
+	store := seedFields(t, encryptor, 5)
+	_, err := kms.Rotate()
+	require.NoError(t, err)
+	store.conflicts = map[string]bool{"account-4": true}
+
+	result, err := encryptor.ReencryptAll(ctx, store, 2)
+
+	require.NoError(t, err)
+	assert.Equal(t, ReencryptResult{Scanned: 5, Reencrypted: 4, Conflicts: 1}, result)
+	assert.Equal(t, 3, store.pages)
+	for _, field := range store.fields[:4] {
+		assert.True(t, strings.HasPrefix(field.Ciphertext, "enc1.v2."), field.ID)
+	}
+
+	store.conflicts = nil
+	result, err = encryptor.ReencryptAll(ctx, store, 0)
+	require.NoError(t, err)
+	assert.Equal(t, ReencryptResult{Scanned: 5, Reencrypted: 1}, result, "runs are idempotent")
+
+	require.NoError(t, kms.Retire("v1"))
+	value, err := NewEncryptor(kms).DecryptString(ctx, store.fields[4].Ciphertext, "account-4")
+	require.NoError(t, err)
+	assert.Equal(t, "52998224724", value)
+}
+
+func TestReencryptAllErrors(t *testing.T) {
+	ctx := context.Background()
+	kms := newFileKMS(t)
+	encryptor := NewEncryptor(kms)
+	_, err := kms.Rotate()
+	require.NoError(t, err)
+
+	store := seedFields(t, NewEncryptor(newFileKMS(t)), 1)
+	store.fieldsErr = errBoom
+	_, err = encryptor.ReencryptAll(ctx, store, 10)
+	assert.ErrorIs(t, err, errBoom)
+
+	store.fieldsErr = nil
+	result, err := encryptor.ReencryptAll(ctx, store, 10)
+	assert.ErrorIs(t, err, ErrInvalidCiphertext, "the data key was wrapped by another KMS")
+	assert.Equal(t, 1, result.Scanned)
+
+	store = seedFields(t, encryptor, 1)
+	_, err = kms.Rotate()
+	require.NoError(t, err)
+	store.replaceErr = errBoom
+	_, err = encryptor.ReencryptAll(ctx, store, 10)
+	assert.ErrorIs(t, err, errBoom)
+}
@@ -0,0 +1,239 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package currency - ISO 4217 registry and display formatting
+// ═══════════════════════════════════════════════════════════════════════════
+
+package currency
+
+//go:generate go test -run TestGeneratedTable -update
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// NoMinorUnits marks funds and precious metals, which ISO 4217 lists
+// without minor units ("N.A.")
+const NoMinorUnits = -1
+
+// ErrUnknownCurrency is returned for codes outside ISO 4217
+var ErrUnknownCurrency = errors.New("currency: unknown code")
+
+// Currency is one ISO 4217 entry. Symbol is empty when the currency has
+// no usual symbol; displays fall back to the code.
+type Currency struct {
+	Code       string
+	Numeric    string
+	Name       string
+	MinorUnits int
+	Symbol     string
+}
+
+var (
+	byCode    = make(map[string]Currency, len(table))
+	byNumeric = make(map[string]Currency, len(table))
+)
+
+func init() {
+	for _, c := range table {
+		byCode[c.Code] = c
+		byNumeric[c.Numeric] = c
+	}
+	supported = make(map[string]Currency, len(DefaultSupported))
+	for _, code := range DefaultSupported {
+		supported[code] = MustLookup(code)
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// LOOKUP
+// ═══════════════════════════════════════════════════════════════════════════
+
+// Lookup finds a currency by its alphabetic code, ignoring case
+func Lookup(code string) (Currency, bool) {
+	c, ok := byCode[strings.ToUpper(strings.TrimSpace(code))]
+	return c, ok
+}
+
+// MustLookup is Lookup for codes known at compile time
+func MustLookup(code string) Currency {
+	c, ok := Lookup(code)
+	if !ok {
+		panic(fmt.Errorf("%w: %s", ErrUnknownCurrency, code))
+	}
+	return c
+}
+
+// LookupNumeric finds a currency by its three-digit numeric code ("986")
+func LookupNumeric(numeric string) (Currency, bool) {
+	c, ok := byNumeric[numeric]
+	return c, ok
+}
+
+// IsValid reports whether code is an ISO 4217 currency, supported or not
+func IsValid(code string) bool {
+	_, ok := Lookup(code)
+	return ok
+}
+
+// All returns every ISO 4217 currency ordered by code
+func All() []Currency {
+	return append([]Currency(nil), table...)
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// SUPPORTED CURRENCIES
+// ═══════════════════════════════════════════════════════════════════════════
+
+// DefaultSupported are the currencies the platform operates with until
+// SetSupported is called
+var DefaultSupported = []string{
+	"BRL", "USD", "EUR", "GBP", "JPY", "CNY",
+	"ARS", "CLP", "COP", "MXN", "PEN", "UYU",
+}
+
+var (
+	supportedMu sync.RWMutex
+	supported   map[string]Currency
+)
+
+// SetSupported replaces the allow-list of currencies the platform accepts.
+// Every code must be in ISO 4217; on error the current list is kept.
+func SetSupported(codes ...string) error {
+	next := make(map[string]Currency, len(codes))
+	for _, code := range codes {
+		c, ok := Lookup(code)
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownCurrency, code)
+		}
+		next[c.Code] = c
+	}
+
+	supportedMu.Lock()
+	supported = next
+	supportedMu.Unlock()
+	return nil
+}
+
+// IsSupported reports whether code is in the allow-list, ignoring case
+func IsSupported(code string) bool {
+	supportedMu.RLock()
+	defer supportedMu.RUnlock()
+	_, ok := supported[strings.ToUpper(strings.TrimSpace(code))]
+	return ok
+}
+
+// Supported returns the allow-list ordered by code
+func Supported() []Currency {
+	supportedMu.RLock()
+	list := make([]Currency, 0, len(supported))
+	for _, c := range supported {
+		list = append(list, c)
+	}
+	supportedMu.RUnlock()
+
+	sort.Slice(list, func(i, j int) bool { return list[i].Code < list[j].Code })
+	return list
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// FORMATTING
+// ═══════════════════════════════════════════════════════════════════════════
+
+// Locale selects the separators and symbol placement of a display
+type Locale string
+
+// Locales with display conventions; any other locale is formatted as en-US
+const (
+	LocalePtBR Locale = "pt-BR"
+	LocaleEnUS Locale = "en-US"
+)
+
+type separators struct {
+	group, decimal string
+}
+
+func (l Locale) separators() separators {
+	if l == LocalePtBR {
+		return separators{group: ".", decimal: ","}
+	}
+	return separators{group: ",", decimal: "."}
+}
+
+// Format renders amount with the currency symbol, rounded to its minor
+// units: R$ 1.234,56 in pt-BR and R$1,234.56 in en-US
+func (c Currency) Format(amount float64, locale Locale) string {
+	number := c.FormatNumber(math.Abs(amount), locale)
+	sign := ""
+	if strings.HasPrefix(c.Decimal(amount), "-") {
+		sign = "-"
+	}
+
+	symbol := c.Symbol
+	if symbol == "" {
+		symbol = c.Code
+	}
+	last, _ := utf8.DecodeLastRuneInString(symbol)
+	if locale == LocalePtBR || unicode.IsLetter(last) {
+		return sign + symbol + " " + number
+	}
+	return sign + symbol + number
+}
+
+// FormatNumber renders amount without symbol, rounded to the currency's
+// minor units: 1.234,56 in pt-BR and 1,234.56 in en-US
+func (c Currency) FormatNumber(amount float64, locale Locale) string {
+	digits := c.Decimal(amount)
+	if math.IsInf(amount, 0) || math.IsNaN(amount) {
+		return digits
+	}
+	sep := locale.separators()
+
+	sign := ""
+	if unsigned, ok := strings.CutPrefix(digits, "-"); ok {
+		sign, digits = "-", unsigned
+	}
+	integer, fraction, _ := strings.Cut(digits, ".")
+
+	var b strings.Builder
+	b.WriteString(sign)
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteString(sep.group)
+		}
+		b.WriteRune(digit)
+	}
+	if fraction != "" {
+		b.WriteString(sep.decimal)
+		b.WriteString(fraction)
+	}
+	return b.String()
+}
+
+// Decimal renders amount as a plain decimal rounded to the currency's minor
+// units (-1234.56), for machine-readable outputs. Halves round away from
+// zero and amounts that round to zero lose their sign.
+func (c Currency) Decimal(amount float64) string {
+	if math.IsInf(amount, 0) || math.IsNaN(amount) {
+		return strconv.FormatFloat(amount, 'f', -1, 64)
+	}
+
+	// rounding the shortest decimal form keeps 1.005 at 1.01 instead of the
+	// binary 1.00499...
+	digits := strconv.FormatFloat(math.Abs(amount), 'f', -1, 64)
+	if c.MinorUnits != NoMinorUnits {
+		exact, _ := new(big.Rat).SetString(digits)
+		digits = exact.FloatString(c.MinorUnits)
+	}
+	if amount < 0 && strings.Trim(digits, "0.") != "" {
+		return "-" + digits
+	}
+	return digits
+}
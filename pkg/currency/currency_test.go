@@ -0,0 +1,203 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package currency - Tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package currency
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════
+// Generated Table
+// ═══════════════════════════════════════════════════════════════════════════
+
+//go:embed iso4217.csv
+var iso4217CSV []byte
+
+var update = flag.Bool("update", false, "regenerate iso4217.go")
+
+// iso4217.go is generated from iso4217.csv; regenerate it with
+// go generate ./currency (go test ./currency -run TestGeneratedTable -update)
+func TestGeneratedTable(t *testing.T) {
+	records, err := csv.NewReader(bytes.NewReader(iso4217CSV)).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, []string{"code", "numeric", "minor_units", "name", "symbol"}, records[0])
+
+	var b strings.Builder
+	b.WriteString("// Code generated by go test -run TestGeneratedTable -update; DO NOT EDIT.\n\n")
+	b.WriteString("package currency\n\n")
+	b.WriteString("// table lists ISO 4217 ordered by code, from iso4217.csv\n")
+	b.WriteString("var table = []Currency{\n")
+	for _, record := range records[1:] {
+		minorUnits := NoMinorUnits
+		if record[2] != "N.A." {
+			minorUnits, err = strconv.Atoi(record[2])
+			require.NoError(t, err, record[0])
+		}
+		fmt.Fprintf(&b, "{Code: %q, Numeric: %q, Name: %q, MinorUnits: %d, Symbol: %q},\n",
+			record[0], record[1], record[3], minorUnits, record[4])
+	}
+	b.WriteString("}\n")
+
+	source, err := format.Source([]byte(b.String()))
+	require.NoError(t, err)
+
+	if *update {
+		require.NoError(t, os.WriteFile("iso4217.go", source, 0o644))
+	}
+
+	generated, err := os.ReadFile("iso4217.go")
+	require.NoError(t, err)
+	assert.Equal(t, string(source), string(generated), "iso4217.go is stale; run go generate ./currency")
+}
+
+func TestTableIsConsistent(t *testing.T) {
+	require.NotEmpty(t, table)
+	assert.Len(t, byCode, len(table), "duplicate alphabetic code")
+	assert.Len(t, byNumeric, len(table), "duplicate numeric code")
+
+	for i, c := range table {
+		assert.Len(t, c.Code, 3, c.Code)
+		assert.Len(t, c.Numeric, 3, c.Code)
+		assert.NotEmpty(t, c.Name, c.Code)
+		if i > 0 {
+			assert.Less(t, table[i-1].Code, c.Code)
+		}
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// Lookup
+// ═══════════════════════════════════════════════════════════════════════════
+
+func TestLookup(t *testing.T) {
+	brl, ok := Lookup(" brl ")
+	require.True(t, ok)
+	assert.Equal(t, Currency{Code: "BRL", Numeric: "986", Name: "Brazilian Real", MinorUnits: 2, Symbol: "R$"}, brl)
+
+	jpy, ok := LookupNumeric("392")
+	require.True(t, ok)
+	assert.Equal(t, "JPY", jpy.Code)
+	assert.Equal(t, 0, jpy.MinorUnits)
+
+	assert.Equal(t, 3, MustLookup("KWD").MinorUnits)
+	assert.Equal(t, NoMinorUnits, MustLookup("XAU").MinorUnits)
+
+	_, ok = Lookup("XYZ")
+	assert.False(t, ok)
+	_, ok = LookupNumeric("000")
+	assert.False(t, ok)
+}
+
+func TestMustLookupPanics(t *testing.T) {
+	assert.PanicsWithError(t, "currency: unknown code: XYZ", func() { MustLookup("XYZ") })
+}
+
+func TestIsValid(t *testing.T) {
+	assert.True(t, IsValid("BRL"))
+	assert.True(t, IsValid("chf"))
+	assert.False(t, IsValid("BR"))
+	assert.False(t, IsValid("ABC"))
+}
+
+func TestAllReturnsACopy(t *testing.T) {
+	all := All()
+	require.Len(t, all, len(table))
+
+	all[0].Code = "ZZZ"
+	assert.NotEqual(t, "ZZZ", table[0].Code)
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// Supported Currencies
+// ═══════════════════════════════════════════════════════════════════════════
+
+func TestDefaultSupported(t *testing.T) {
+	for _, code := range DefaultSupported {
+		assert.True(t, IsSupported(code), code)
+	}
+	assert.True(t, IsSupported("brl"))
+	assert.False(t, IsSupported("CHF"))
+	assert.Len(t, Supported(), len(DefaultSupported))
+}
+
+func TestSetSupported(t *testing.T) {
+	t.Cleanup(func() { require.NoError(t, SetSupported(DefaultSupported...)) })
+
+	require.NoError(t, SetSupported("usd", "BRL"))
+	assert.True(t, IsSupported("BRL"))
+	assert.False(t, IsSupported("EUR"))
+	assert.Equal(t, []Currency{MustLookup("BRL"), MustLookup("USD")}, Supported())
+
+	err := SetSupported("EUR", "XYZ")
+	assert.True(t, errors.Is(err, ErrUnknownCurrency))
+	assert.False(t, IsSupported("EUR"), "a failed update keeps the current list")
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// Formatting
+// ═══════════════════════════════════════════════════════════════════════════
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		code   string
+		amount float64
+		locale Locale
+		want   string
+	}{
+		{"BRL", 1234.56, LocalePtBR, "R$ 1.234,56"},
+		{"BRL", 1234.56, LocaleEnUS, "R$1,234.56"},
+		{"BRL", -1234567.891, LocalePtBR, "-R$ 1.234.567,89"},
+		{"BRL", -0.001, LocalePtBR, "R$ 0,00"},
+		{"USD", 0.5, LocaleEnUS, "$0.50"},
+		{"USD", -12, "fr-FR", "-$12.00"},
+		{"JPY", 1234.5, LocalePtBR, "¥ 1.235"},
+		{"KWD", 1.2345, LocaleEnUS, "KD 1.235"},
+		{"CHF", 100, LocaleEnUS, "CHF 100.00"},
+		{"XAU", 1.25, LocaleEnUS, "XAU 1.25"},
+		{"BOV", 10, LocalePtBR, "BOV 10,00"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, MustLookup(tt.code).Format(tt.amount, tt.locale), "%s %v %s", tt.code, tt.amount, tt.locale)
+	}
+}
+
+func TestFormatNumber(t *testing.T) {
+	brl := MustLookup("BRL")
+
+	assert.Equal(t, "1.234,56", brl.FormatNumber(1234.56, LocalePtBR))
+	assert.Equal(t, "-1.234,56", brl.FormatNumber(-1234.56, LocalePtBR))
+	assert.Equal(t, "0,00", brl.FormatNumber(-0.001, LocalePtBR))
+	assert.Equal(t, "1,01", brl.FormatNumber(1.005, LocalePtBR))
+	assert.Equal(t, "+Inf", brl.FormatNumber(math.Inf(1), LocalePtBR))
+	assert.Equal(t, "100", MustLookup("JPY").FormatNumber(100, LocaleEnUS))
+	assert.Equal(t, "1,000.125", MustLookup("XAG").FormatNumber(1000.125, LocaleEnUS))
+}
+
+func TestDecimal(t *testing.T) {
+	brl := MustLookup("BRL")
+
+	assert.Equal(t, "1234.56", brl.Decimal(1234.56))
+	assert.Equal(t, "-1234.57", brl.Decimal(-1234.565))
+	assert.Equal(t, "0.00", brl.Decimal(-0.004))
+	assert.Equal(t, "1235", MustLookup("JPY").Decimal(1234.5))
+	assert.Equal(t, "0.1235", MustLookup("CLF").Decimal(0.12345))
+	assert.Equal(t, "-0.5", MustLookup("XAU").Decimal(-0.5))
+	assert.Equal(t, "NaN", brl.Decimal(math.NaN()))
+}
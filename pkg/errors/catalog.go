@@ -0,0 +1,184 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package errors - Localized error catalog
+// ═══════════════════════════════════════════════════════════════════════════
+
+package errors
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Locale identifies a catalog language
+type Locale string
+
+const (
+	LocalePtBR Locale = "pt-BR"
+	LocaleEN   Locale = "en"
+)
+
+// Locales lists the languages every catalog entry must translate
+var Locales = []Locale{LocalePtBR, LocaleEN}
+
+// Translation holds the messages of a code in one locale. Detailed is an
+// optional template whose {key} placeholders are filled from
+// AppError.Details; Message is used when any of them is missing.
+type Translation struct {
+	Message  string
+	Detailed string
+}
+
+// Translations maps each locale to its messages
+type Translations map[Locale]Translation
+
+var catalog = struct {
+	sync.RWMutex
+	entries map[string]Translations
+}{entries: make(map[string]Translations)}
+
+// Register adds or replaces the translations of a code, so services can
+// localize their own codes
+func Register(code string, translations Translations) {
+	catalog.Lock()
+	defer catalog.Unlock()
+	catalog.entries[code] = translations
+}
+
+// Lookup returns the translations of a code
+func Lookup(code string) (Translations, bool) {
+	catalog.RLock()
+	defer catalog.RUnlock()
+	translations, ok := catalog.entries[code]
+	return translations, ok
+}
+
+// Codes returns every code in the catalog, sorted
+func Codes() []string {
+	catalog.RLock()
+	defer catalog.RUnlock()
+
+	codes := make([]string, 0, len(catalog.entries))
+	for code := range catalog.entries {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// WriteCatalog documents the catalog as a Markdown table with one column
+// per locale
+func WriteCatalog(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("| Code |")
+	for _, locale := range Locales {
+		fmt.Fprintf(&b, " %s |", locale)
+	}
+	b.WriteString("\n|------|")
+	b.WriteString(strings.Repeat("------|", len(Locales)))
+	b.WriteString("\n")
+
+	for _, code := range Codes() {
+		translations, _ := Lookup(code)
+		fmt.Fprintf(&b, "| `%s` |", code)
+		for _, locale := range Locales {
+			fmt.Fprintf(&b, " %s |", translations[locale].Message)
+		}
+		b.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// Localization
+// ═══════════════════════════════════════════════════════════════════════════
+
+// Translate returns the message of err in locale, interpolating its details
+// into the detailed template when they are all present. Codes or locales
+// missing from the catalog keep the original message, and so do errors
+// whose message is not the default (English) one of their code: a specific
+// message from the constructor says more than the generic translation.
+func Translate(err *AppError, locale Locale) string {
+	translations, ok := Lookup(err.Code)
+	if !ok || err.Message != translations[LocaleEN].Message {
+		return err.Message
+	}
+	translation, ok := translations[locale]
+	if !ok {
+		return err.Message
+	}
+
+	if translation.Detailed != "" {
+		if message, ok := interpolate(translation.Detailed, err.Details); ok {
+			return message
+		}
+	}
+	return translation.Message
+}
+
+// In returns a copy of the error with its message translated to locale
+func (e *AppError) In(locale Locale) *AppError {
+	localized := *e
+	localized.Message = Translate(e, locale)
+	return &localized
+}
+
+// interpolate replaces the {key} placeholders of template and reports
+// whether every one of them had a value
+func interpolate(template string, details map[string]string) (string, bool) {
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(template, '{')
+		if start < 0 {
+			b.WriteString(template)
+			return b.String(), true
+		}
+		end := strings.IndexByte(template[start:], '}')
+		if end < 0 {
+			b.WriteString(template)
+			return b.String(), true
+		}
+
+		value, ok := details[template[start+1:start+end]]
+		if !ok {
+			return "", false
+		}
+		b.WriteString(template[:start])
+		b.WriteString(value)
+		template = template[start+end+1:]
+	}
+}
+
+// MatchLocale picks the catalog locale preferred by an Accept-Language
+// header, matching on the primary language so pt-PT and en-US also match.
+// It returns false when no catalog locale is acceptable.
+func MatchLocale(acceptLanguage string) (Locale, bool) {
+	var best Locale
+	bestQuality := 0.0
+
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+
+		primary, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		for _, locale := range Locales {
+			localePrimary, _, _ := strings.Cut(strings.ToLower(string(locale)), "-")
+			if primary == localePrimary && quality > bestQuality {
+				best, bestQuality = locale, quality
+			}
+		}
+	}
+	return best, bestQuality > 0
+}
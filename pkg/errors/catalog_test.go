@@ -0,0 +1,221 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package errors - Catalog tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package errors
+
+import (
+	"flag"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sentinelMessages parses errors.go and returns the code and message of
+// every Err* variable, so new variables are checked without being listed
+func sentinelMessages(t *testing.T) map[string]string {
+	t.Helper()
+	file, err := parser.ParseFile(token.NewFileSet(), "errors.go", nil, 0)
+	require.NoError(t, err)
+
+	sentinels := make(map[string]string)
+	ast.Inspect(file, func(node ast.Node) bool {
+		spec, ok := node.(*ast.ValueSpec)
+		if !ok {
+			return true
+		}
+		for i, name := range spec.Names {
+			if !strings.HasPrefix(name.Name, "Err") {
+				continue
+			}
+			call, ok := spec.Values[i].(*ast.CallExpr)
+			require.True(t, ok, "%s must be built with a constructor", name.Name)
+			code, err := strconv.Unquote(call.Args[0].(*ast.BasicLit).Value)
+			require.NoError(t, err)
+			message, err := strconv.Unquote(call.Args[1].(*ast.BasicLit).Value)
+			require.NoError(t, err)
+			sentinels[code] = message
+		}
+		return true
+	})
+	return sentinels
+}
+
+func TestCatalogTranslatesEverySentinel(t *testing.T) {
+	sentinels := sentinelMessages(t)
+	require.NotEmpty(t, sentinels)
+
+	for code, message := range sentinels {
+		translations, ok := Lookup(code)
+		if !assert.True(t, ok, "%s has no catalog entry in messages.go", code) {
+			continue
+		}
+		for _, locale := range Locales {
+			assert.NotEmpty(t, translations[locale].Message, "%s lacks a %s translation", code, locale)
+		}
+		assert.Equal(t, message, translations[LocaleEN].Message, "%s English message differs from errors.go", code)
+	}
+}
+
+func TestCatalogHasNoStaleEntries(t *testing.T) {
+	sentinels := sentinelMessages(t)
+
+	for code := range messages {
+		assert.Contains(t, sentinels, code, "%s is not an Err* variable", code)
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	assert.Equal(t, "Conta não encontrada", Translate(ErrAccountNotFound, LocalePtBR))
+	assert.Equal(t, "Account not found", Translate(ErrAccountNotFound, LocaleEN))
+}
+
+func TestTranslateInterpolatesDetails(t *testing.T) {
+	err := BadRequest("MISSING_FIELD", "Required field is missing").WithDetail("field", "method")
+
+	assert.Equal(t, "O campo method é obrigatório", Translate(err, LocalePtBR))
+	assert.Equal(t, "Field method is required", Translate(err, LocaleEN))
+}
+
+func TestTranslateFallsBackWithoutEveryDetail(t *testing.T) {
+	err := UnprocessableEntity(ErrLimitExceeded.Code, ErrLimitExceeded.Message).WithDetail("max", "100.00")
+
+	assert.Equal(t, "Limite de transação excedido", Translate(err, LocalePtBR))
+
+	err.WithDetail("available", "20.00")
+	assert.Equal(t, "Limite de transação excedido: disponível 20.00 de 100.00", Translate(err, LocalePtBR))
+}
+
+func TestTranslateUnknownCodeOrLocale(t *testing.T) {
+	custom := BadRequest("CUSTOM_CODE", "Custom message")
+
+	assert.Equal(t, "Custom message", Translate(custom, LocalePtBR))
+	assert.Equal(t, "Access denied", Translate(ErrForbidden, Locale("fr")))
+}
+
+func TestTranslateKeepsSpecificMessages(t *testing.T) {
+	err := BadRequest(ErrInvalidField.Code, "daily limit must not be negative").WithDetail("field", "daily")
+
+	assert.Equal(t, "daily limit must not be negative", Translate(err, LocalePtBR))
+	assert.Equal(t, "daily limit must not be negative", err.In(LocaleEN).Message)
+	assert.Equal(t, "O valor do campo daily é inválido", Translate(BadRequest(ErrInvalidField.Code, ErrInvalidField.Message).WithDetail("field", "daily"), LocalePtBR))
+}
+
+func TestRegister(t *testing.T) {
+	Register("STATEMENT_UNAVAILABLE", Translations{
+		LocalePtBR: {Message: "Extrato indisponível"},
+		LocaleEN:   {Message: "Statement unavailable"},
+	})
+	t.Cleanup(func() {
+		catalog.Lock()
+		delete(catalog.entries, "STATEMENT_UNAVAILABLE")
+		catalog.Unlock()
+	})
+
+	err := ServiceUnavailable("STATEMENT_UNAVAILABLE", "Statement unavailable")
+	assert.Equal(t, "Extrato indisponível", Translate(err, LocalePtBR))
+	assert.Contains(t, Codes(), "STATEMENT_UNAVAILABLE")
+}
+
+func TestInterpolate(t *testing.T) {
+	message, ok := interpolate("{a} and {b}", map[string]string{"a": "1", "b": "2"})
+	assert.True(t, ok)
+	assert.Equal(t, "1 and 2", message)
+
+	message, ok = interpolate("unclosed {a", nil)
+	assert.True(t, ok)
+	assert.Equal(t, "unclosed {a", message)
+
+	_, ok = interpolate("{missing}", map[string]string{})
+	assert.False(t, ok)
+}
+
+func TestAppErrorIn(t *testing.T) {
+	cause := assert.AnError
+	err := NotFound("ACCOUNT_NOT_FOUND", "Account not found").WithDetail("id", "acc-1").Wrap(cause)
+
+	localized := err.In(LocalePtBR)
+
+	assert.Equal(t, "Conta não encontrada", localized.Message)
+	assert.Equal(t, "Account not found", err.Message, "the original is not modified")
+	assert.Equal(t, err.Details, localized.Details)
+	assert.ErrorIs(t, localized, cause)
+	assert.ErrorIs(t, localized, ErrAccountNotFound)
+}
+
+func TestCodesAreSorted(t *testing.T) {
+	codes := Codes()
+
+	assert.IsNonDecreasing(t, codes)
+	assert.Contains(t, codes, "LIMIT_EXCEEDED")
+	assert.Len(t, codes, len(messages))
+}
+
+func TestWriteCatalog(t *testing.T) {
+	var b strings.Builder
+
+	require.NoError(t, WriteCatalog(&b))
+
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	assert.Equal(t, "| Code | pt-BR | en |", lines[0])
+	assert.Equal(t, "|------|------|------|", lines[1])
+	assert.Len(t, lines, len(Codes())+2)
+	assert.Contains(t, b.String(), "| `ACCOUNT_NOT_FOUND` | Conta não encontrada | Account not found |")
+}
+
+func TestWriteCatalogError(t *testing.T) {
+	assert.Error(t, WriteCatalog(failingWriter{}))
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, assert.AnError
+}
+
+func TestMatchLocale(t *testing.T) {
+	cases := map[string]Locale{
+		"pt-BR":                     LocalePtBR,
+		"pt-PT,pt;q=0.9":            LocalePtBR,
+		"en-US,en;q=0.9":            LocaleEN,
+		"EN":                        LocaleEN,
+		"fr-FR, en;q=0.5, pt;q=0.8": LocalePtBR,
+		"pt;q=0.3, en;q=0.7":        LocaleEN,
+		"pt;q=abc, en;q=0.1":        LocaleEN,
+	}
+	for header, expected := range cases {
+		locale, ok := MatchLocale(header)
+		assert.True(t, ok, header)
+		assert.Equal(t, expected, locale, header)
+	}
+
+	for _, header := range []string{"", "*", "fr-FR, de", "pt;q=0"} {
+		_, ok := MatchLocale(header)
+		assert.False(t, ok, header)
+	}
+}
+
+var update = flag.Bool("update", false, "regenerate CODES.md")
+
+// CODES.md documents the catalog; regenerate it with
+// go test ./errors -run TestCodesDocument -update
+func TestCodesDocument(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("# Error Codes\n\nGerado por `go test ./errors -run TestCodesDocument -update`.\n\n")
+	require.NoError(t, WriteCatalog(&b))
+
+	if *update {
+		require.NoError(t, os.WriteFile("CODES.md", []byte(b.String()), 0o644))
+	}
+
+	document, err := os.ReadFile("CODES.md")
+	require.NoError(t, err)
+	assert.Equal(t, b.String(), string(document), "CODES.md is stale; run go test ./errors -run TestCodesDocument -update")
+}
@@ -7,6 +7,9 @@ package errors
 import (
 	"fmt"
 	"net/http"
+
+	"github.com/fintech-bank-platform/pkg/pii"
+	"github.com/rs/zerolog"
 )
 
 // AppError represents a standardized application error
@@ -46,6 +49,25 @@ func (e *AppError) WithDetail(key, value string) *AppError {
 	return e
 }
 
+// MarshalZerologObject logs the error with personal data in Details masked,
+// so logger.Error().Err(appErr) can carry the details safely
+func (e *AppError) MarshalZerologObject(log *zerolog.Event) {
+	log.Str("code", e.Code).Str("message", e.Message)
+	if len(e.Details) > 0 {
+		log.Interface("details", pii.RedactMap(e.Details))
+	}
+	if e.Err != nil {
+		log.Str("cause", e.Err.Error())
+	}
+}
+
+// Is reports whether target is an AppError with the same code, so errors.Is
+// matches copies of the sentinel errors that carry their own details
+func (e *AppError) Is(target error) bool {
+	t, ok := target.(*AppError)
+	return ok && t.Code == e.Code
+}
+
 // Wrap wraps an underlying error
 func (e *AppError) Wrap(err error) *AppError {
 	e.Err = err
@@ -133,6 +155,42 @@ var (
 	ErrInternalServer     = InternalServer("INTERNAL_ERROR", "Internal server error")
 	ErrDatabaseError      = InternalServer("DATABASE_ERROR", "Database operation failed")
 	ErrServiceUnavailable = ServiceUnavailable("SERVICE_UNAVAILABLE", "Service temporarily unavailable")
+
+	ErrInvalidAmount       = BadRequest("INVALID_AMOUNT", "Amount must be greater than zero")
+	ErrPaymentNotFound     = NotFound("PAYMENT_NOT_FOUND", "Payment not found")
+	ErrDuplicateRefund     = Conflict("DUPLICATE_REFUND", "Refund already processed")
+	ErrInvalidPaymentState = UnprocessableEntity("INVALID_PAYMENT_STATE", "Operation not allowed in the current payment state")
+	ErrRefundExceedsAmount = UnprocessableEntity("REFUND_EXCEEDS_AMOUNT", "Refund exceeds the remaining payment amount")
+
+	ErrTransactionNotFound        = NotFound("TRANSACTION_NOT_FOUND", "Transaction not found")
+	ErrTransactionAlreadyReversed = Conflict("TRANSACTION_ALREADY_REVERSED", "Transaction has already been reversed")
+	ErrReversalNotAllowed         = UnprocessableEntity("REVERSAL_NOT_ALLOWED", "Transaction cannot be reversed")
+	ErrInsufficientFunds          = UnprocessableEntity("INSUFFICIENT_FUNDS", "Insufficient funds")
+
+	ErrRiskDenied = UnprocessableEntity("RISK_DENIED", "Operation denied by risk analysis")
+
+	ErrLimitExceeded = UnprocessableEntity("LIMIT_EXCEEDED", "Transaction limit exceeded")
+
+	ErrInvalidCursor = BadRequest("INVALID_CURSOR", "Invalid pagination cursor")
+
+	ErrUnsupportedMediaType = New("UNSUPPORTED_MEDIA_TYPE", "Content-Type must be application/json", http.StatusUnsupportedMediaType)
+	ErrPayloadTooLarge      = New("PAYLOAD_TOO_LARGE", "Request body is too large", http.StatusRequestEntityTooLarge)
+
+	ErrCurrencyNotSupported = BadRequest("CURRENCY_NOT_SUPPORTED", "Currency is not supported")
+	ErrRateUnavailable      = ServiceUnavailable("RATE_UNAVAILABLE", "Exchange rate unavailable")
+	ErrQuoteNotFound        = NotFound("QUOTE_NOT_FOUND", "Exchange quote not found")
+	ErrQuoteExpired         = UnprocessableEntity("QUOTE_EXPIRED", "Exchange quote has expired")
+	ErrQuoteMismatch        = UnprocessableEntity("QUOTE_MISMATCH", "Exchange quote does not match the operation")
+
+	ErrAPIKeyNotFound    = NotFound("API_KEY_NOT_FOUND", "API key not found")
+	ErrAPIKeyExpired     = Unauthorized("API_KEY_EXPIRED", "API key has expired")
+	ErrAPIKeyRevoked     = Unauthorized("API_KEY_REVOKED", "API key has been revoked")
+	ErrInsufficientScope = Forbidden("INSUFFICIENT_SCOPE", "Credentials lack the required scope")
+
+	ErrSignatureRequired = Unauthorized("SIGNATURE_REQUIRED", "Request signature is required")
+	ErrInvalidSignature  = Unauthorized("INVALID_SIGNATURE", "Request signature is invalid")
+	ErrSignatureExpired  = Unauthorized("SIGNATURE_EXPIRED", "Request signature timestamp is outside the allowed clock skew")
+	ErrSignatureReplayed = Unauthorized("SIGNATURE_REPLAYED", "Request signature has already been used")
 )
 
 // ═══════════════════════════════════════════════════════════════════════════
@@ -5,10 +5,12 @@
 package errors
 
 import (
+	"bytes"
 	"errors"
 	"net/http"
 	"testing"
 
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -57,6 +59,15 @@ func TestAppError_WithDetail(t *testing.T) {
 	assert.Equal(t, "value2", err.Details["field2"])
 }
 
+func TestAppError_Is(t *testing.T) {
+	copied := UnprocessableEntity(ErrLimitExceeded.Code, "Daily limit exceeded").WithDetail("limit", "daily")
+
+	assert.True(t, errors.Is(copied, ErrLimitExceeded))
+	assert.True(t, errors.Is(New("X", "x", 0).Wrap(copied), ErrLimitExceeded))
+	assert.False(t, errors.Is(copied, ErrRiskDenied))
+	assert.False(t, errors.Is(copied, errors.New("LIMIT_EXCEEDED")))
+}
+
 func TestBadRequest(t *testing.T) {
 	err := BadRequest("BAD_REQUEST", "Bad request")
 	assert.Equal(t, http.StatusBadRequest, err.HTTPStatus)
@@ -140,3 +151,73 @@ func TestCommonErrors(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, ErrInternalServer.HTTPStatus)
 	assert.Equal(t, http.StatusServiceUnavailable, ErrServiceUnavailable.HTTPStatus)
 }
+
+func TestPaymentErrors(t *testing.T) {
+	assert.Equal(t, http.StatusBadRequest, ErrInvalidAmount.HTTPStatus)
+	assert.Equal(t, http.StatusNotFound, ErrPaymentNotFound.HTTPStatus)
+	assert.Equal(t, http.StatusConflict, ErrDuplicateRefund.HTTPStatus)
+	assert.Equal(t, http.StatusUnprocessableEntity, ErrInvalidPaymentState.HTTPStatus)
+	assert.Equal(t, http.StatusUnprocessableEntity, ErrRefundExceedsAmount.HTTPStatus)
+}
+
+func TestTransactionErrors(t *testing.T) {
+	assert.Equal(t, http.StatusNotFound, ErrTransactionNotFound.HTTPStatus)
+	assert.Equal(t, http.StatusConflict, ErrTransactionAlreadyReversed.HTTPStatus)
+	assert.Equal(t, http.StatusUnprocessableEntity, ErrReversalNotAllowed.HTTPStatus)
+	assert.Equal(t, http.StatusUnprocessableEntity, ErrInsufficientFunds.HTTPStatus)
+}
+
+func TestRiskErrors(t *testing.T) {
+	assert.Equal(t, http.StatusUnprocessableEntity, ErrRiskDenied.HTTPStatus)
+	assert.Equal(t, "RISK_DENIED", ErrRiskDenied.Code)
+}
+
+func TestLimitErrors(t *testing.T) {
+	assert.Equal(t, http.StatusUnprocessableEntity, ErrLimitExceeded.HTTPStatus)
+	assert.Equal(t, "LIMIT_EXCEEDED", ErrLimitExceeded.Code)
+}
+
+func TestPaginationErrors(t *testing.T) {
+	assert.Equal(t, http.StatusBadRequest, ErrInvalidCursor.HTTPStatus)
+	assert.Equal(t, "INVALID_CURSOR", ErrInvalidCursor.Code)
+}
+
+func TestRequestErrors(t *testing.T) {
+	assert.Equal(t, http.StatusUnsupportedMediaType, ErrUnsupportedMediaType.HTTPStatus)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, ErrPayloadTooLarge.HTTPStatus)
+}
+
+func TestFXErrors(t *testing.T) {
+	assert.Equal(t, http.StatusBadRequest, ErrCurrencyNotSupported.HTTPStatus)
+	assert.Equal(t, http.StatusServiceUnavailable, ErrRateUnavailable.HTTPStatus)
+	assert.Equal(t, http.StatusNotFound, ErrQuoteNotFound.HTTPStatus)
+	assert.Equal(t, http.StatusUnprocessableEntity, ErrQuoteExpired.HTTPStatus)
+	assert.Equal(t, http.StatusUnprocessableEntity, ErrQuoteMismatch.HTTPStatus)
+}
+
+func TestAPIKeyErrors(t *testing.T) {
+	assert.Equal(t, http.StatusNotFound, ErrAPIKeyNotFound.HTTPStatus)
+	assert.Equal(t, http.StatusUnauthorized, ErrAPIKeyExpired.HTTPStatus)
+	assert.Equal(t, http.StatusUnauthorized, ErrAPIKeyRevoked.HTTPStatus)
+	assert.Equal(t, http.StatusForbidden, ErrInsufficientScope.HTTPStatus)
+}
+
+func TestSignatureErrors(t *testing.T) {
+	for _, err := range []*AppError{ErrSignatureRequired, ErrInvalidSignature, ErrSignatureExpired, ErrSignatureReplayed} {
+		assert.Equal(t, http.StatusUnauthorized, err.HTTPStatus, err.Code)
+	}
+}
+
+func TestAppErrorLogMasksDetails(t *testing.T) {
+	var buf bytes.Buffer
+	err := ErrDuplicateEmail.WithDetails(map[string]string{"email": "joao@example.com"}).Wrap(errors.New("unique violation"))
+
+	log := zerolog.New(&buf)
+	log.Error().Err(err).Msg("Create account failed")
+
+	assert.Contains(t, buf.String(), `"error":{"code":"DUPLICATE_EMAIL","message":"Email already registered","details":{"email":"j***@example.com"},"cause":"unique violation"}`)
+
+	buf.Reset()
+	log.Error().Err(New("PLAIN", "Plain error", http.StatusBadRequest)).Msg("failed")
+	assert.Contains(t, buf.String(), `"error":{"code":"PLAIN","message":"Plain error"}`)
+}
@@ -0,0 +1,213 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package errors - Catalog messages
+// ═══════════════════════════════════════════════════════════════════════════
+
+package errors
+
+// Every Err* code must have an entry here in all Locales; the English
+// message matches the one of the variable
+func init() {
+	for code, translations := range messages {
+		Register(code, translations)
+	}
+}
+
+var messages = map[string]Translations{
+	// Common
+	"VALIDATION_ERROR": {
+		LocalePtBR: {Message: "Falha na validação"},
+		LocaleEN:   {Message: "Validation failed"},
+	},
+	"INVALID_JSON": {
+		LocalePtBR: {Message: "Payload JSON inválido", Detailed: "Payload JSON inválido na posição {offset}"},
+		LocaleEN:   {Message: "Invalid JSON payload", Detailed: "Invalid JSON payload at offset {offset}"},
+	},
+	"MISSING_FIELD": {
+		LocalePtBR: {Message: "Campo obrigatório ausente", Detailed: "O campo {field} é obrigatório"},
+		LocaleEN:   {Message: "Required field is missing", Detailed: "Field {field} is required"},
+	},
+	"INVALID_FIELD": {
+		LocalePtBR: {Message: "Valor de campo inválido", Detailed: "O valor do campo {field} é inválido"},
+		LocaleEN:   {Message: "Field value is invalid", Detailed: "Field {field} value is invalid"},
+	},
+	"UNAUTHORIZED": {
+		LocalePtBR: {Message: "Autenticação necessária"},
+		LocaleEN:   {Message: "Authentication required"},
+	},
+	"INVALID_TOKEN": {
+		LocalePtBR: {Message: "Token de autenticação inválido"},
+		LocaleEN:   {Message: "Invalid authentication token"},
+	},
+	"EXPIRED_TOKEN": {
+		LocalePtBR: {Message: "O token de autenticação expirou"},
+		LocaleEN:   {Message: "Authentication token has expired"},
+	},
+	"FORBIDDEN": {
+		LocalePtBR: {Message: "Acesso negado"},
+		LocaleEN:   {Message: "Access denied"},
+	},
+	"NOT_FOUND": {
+		LocalePtBR: {Message: "Recurso não encontrado"},
+		LocaleEN:   {Message: "Resource not found"},
+	},
+	"ACCOUNT_NOT_FOUND": {
+		LocalePtBR: {Message: "Conta não encontrada"},
+		LocaleEN:   {Message: "Account not found"},
+	},
+	"USER_NOT_FOUND": {
+		LocalePtBR: {Message: "Usuário não encontrado"},
+		LocaleEN:   {Message: "User not found"},
+	},
+	"CONFLICT": {
+		LocalePtBR: {Message: "O recurso já existe"},
+		LocaleEN:   {Message: "Resource already exists"},
+	},
+	"DUPLICATE_EMAIL": {
+		LocalePtBR: {Message: "E-mail já cadastrado"},
+		LocaleEN:   {Message: "Email already registered"},
+	},
+	"DUPLICATE_ACCOUNT": {
+		LocalePtBR: {Message: "A conta já existe"},
+		LocaleEN:   {Message: "Account already exists"},
+	},
+	"RATE_LIMIT_EXCEEDED": {
+		LocalePtBR: {Message: "Muitas requisições"},
+		LocaleEN:   {Message: "Too many requests"},
+	},
+	"INTERNAL_ERROR": {
+		LocalePtBR: {Message: "Erro interno do servidor"},
+		LocaleEN:   {Message: "Internal server error"},
+	},
+	"DATABASE_ERROR": {
+		LocalePtBR: {Message: "Falha na operação de banco de dados"},
+		LocaleEN:   {Message: "Database operation failed"},
+	},
+	"SERVICE_UNAVAILABLE": {
+		LocalePtBR: {Message: "Serviço temporariamente indisponível"},
+		LocaleEN:   {Message: "Service temporarily unavailable"},
+	},
+
+	// Payments
+	"INVALID_AMOUNT": {
+		LocalePtBR: {Message: "O valor deve ser maior que zero"},
+		LocaleEN:   {Message: "Amount must be greater than zero"},
+	},
+	"PAYMENT_NOT_FOUND": {
+		LocalePtBR: {Message: "Pagamento não encontrado"},
+		LocaleEN:   {Message: "Payment not found"},
+	},
+	"DUPLICATE_REFUND": {
+		LocalePtBR: {Message: "Reembolso já processado"},
+		LocaleEN:   {Message: "Refund already processed"},
+	},
+	"INVALID_PAYMENT_STATE": {
+		LocalePtBR: {Message: "Operação não permitida no estado atual do pagamento"},
+		LocaleEN:   {Message: "Operation not allowed in the current payment state"},
+	},
+	"REFUND_EXCEEDS_AMOUNT": {
+		LocalePtBR: {Message: "O reembolso excede o valor restante do pagamento"},
+		LocaleEN:   {Message: "Refund exceeds the remaining payment amount"},
+	},
+
+	// Transactions
+	"TRANSACTION_NOT_FOUND": {
+		LocalePtBR: {Message: "Transação não encontrada"},
+		LocaleEN:   {Message: "Transaction not found"},
+	},
+	"TRANSACTION_ALREADY_REVERSED": {
+		LocalePtBR: {Message: "A transação já foi estornada"},
+		LocaleEN:   {Message: "Transaction has already been reversed"},
+	},
+	"REVERSAL_NOT_ALLOWED": {
+		LocalePtBR: {Message: "A transação não pode ser estornada"},
+		LocaleEN:   {Message: "Transaction cannot be reversed"},
+	},
+	"INSUFFICIENT_FUNDS": {
+		LocalePtBR: {Message: "Saldo insuficiente"},
+		LocaleEN:   {Message: "Insufficient funds"},
+	},
+
+	// Risk and limits
+	"RISK_DENIED": {
+		LocalePtBR: {Message: "Operação negada pela análise de risco"},
+		LocaleEN:   {Message: "Operation denied by risk analysis"},
+	},
+	"LIMIT_EXCEEDED": {
+		LocalePtBR: {Message: "Limite de transação excedido", Detailed: "Limite de transação excedido: disponível {available} de {max}"},
+		LocaleEN:   {Message: "Transaction limit exceeded", Detailed: "Transaction limit exceeded: {available} available of {max}"},
+	},
+
+	// Pagination
+	"INVALID_CURSOR": {
+		LocalePtBR: {Message: "Cursor de paginação inválido"},
+		LocaleEN:   {Message: "Invalid pagination cursor"},
+	},
+
+	// Request decoding
+	"UNSUPPORTED_MEDIA_TYPE": {
+		LocalePtBR: {Message: "O Content-Type deve ser application/json"},
+		LocaleEN:   {Message: "Content-Type must be application/json"},
+	},
+	"PAYLOAD_TOO_LARGE": {
+		LocalePtBR: {Message: "O corpo da requisição é muito grande", Detailed: "O corpo da requisição excede {max_bytes} bytes"},
+		LocaleEN:   {Message: "Request body is too large", Detailed: "Request body exceeds {max_bytes} bytes"},
+	},
+
+	// Foreign exchange
+	"CURRENCY_NOT_SUPPORTED": {
+		LocalePtBR: {Message: "Moeda não suportada", Detailed: "Moeda não suportada: {currency}"},
+		LocaleEN:   {Message: "Currency is not supported", Detailed: "Currency is not supported: {currency}"},
+	},
+	"RATE_UNAVAILABLE": {
+		LocalePtBR: {Message: "Cotação de câmbio indisponível", Detailed: "Cotação de câmbio {pair} indisponível"},
+		LocaleEN:   {Message: "Exchange rate unavailable", Detailed: "Exchange rate {pair} unavailable"},
+	},
+	"QUOTE_NOT_FOUND": {
+		LocalePtBR: {Message: "Cotação de câmbio não encontrada"},
+		LocaleEN:   {Message: "Exchange quote not found"},
+	},
+	"QUOTE_EXPIRED": {
+		LocalePtBR: {Message: "A cotação de câmbio expirou"},
+		LocaleEN:   {Message: "Exchange quote has expired"},
+	},
+	"QUOTE_MISMATCH": {
+		LocalePtBR: {Message: "A cotação de câmbio não corresponde à operação"},
+		LocaleEN:   {Message: "Exchange quote does not match the operation"},
+	},
+
+	// API keys
+	"API_KEY_NOT_FOUND": {
+		LocalePtBR: {Message: "Chave de API não encontrada"},
+		LocaleEN:   {Message: "API key not found"},
+	},
+	"API_KEY_EXPIRED": {
+		LocalePtBR: {Message: "A chave de API expirou"},
+		LocaleEN:   {Message: "API key has expired"},
+	},
+	"API_KEY_REVOKED": {
+		LocalePtBR: {Message: "A chave de API foi revogada"},
+		LocaleEN:   {Message: "API key has been revoked"},
+	},
+	"INSUFFICIENT_SCOPE": {
+		LocalePtBR: {Message: "As credenciais não têm o escopo necessário", Detailed: "As credenciais não têm o escopo {scope}"},
+		LocaleEN:   {Message: "Credentials lack the required scope", Detailed: "Credentials lack the {scope} scope"},
+	},
+
+	// Request signatures
+	"SIGNATURE_REQUIRED": {
+		LocalePtBR: {Message: "A assinatura da requisição é obrigatória"},
+		LocaleEN:   {Message: "Request signature is required"},
+	},
+	"INVALID_SIGNATURE": {
+		LocalePtBR: {Message: "A assinatura da requisição é inválida"},
+		LocaleEN:   {Message: "Request signature is invalid"},
+	},
+	"SIGNATURE_EXPIRED": {
+		LocalePtBR: {Message: "O horário da assinatura está fora da tolerância permitida"},
+		LocaleEN:   {Message: "Request signature timestamp is outside the allowed clock skew"},
+	},
+	"SIGNATURE_REPLAYED": {
+		LocalePtBR: {Message: "A assinatura da requisição já foi utilizada"},
+		LocaleEN:   {Message: "Request signature has already been used"},
+	},
+}
@@ -0,0 +1,75 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package events - Publish/subscribe abstractions and in-memory bus
+// ═══════════════════════════════════════════════════════════════════════════
+
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Handler processes a consumed event
+type Handler func(ctx context.Context, event *Event) error
+
+// Publisher publishes events to a topic (Kafka in production)
+type Publisher interface {
+	Publish(ctx context.Context, topic string, event *Event) error
+}
+
+// Subscriber registers handlers for the events of a topic
+type Subscriber interface {
+	Subscribe(topic string, handler Handler)
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// IN-MEMORY BUS
+// ═══════════════════════════════════════════════════════════════════════════
+
+// MemoryBus is a synchronous in-memory Publisher/Subscriber used in tests
+// and local development. Every published event is also recorded.
+type MemoryBus struct {
+	mu        sync.RWMutex
+	handlers  map[string][]Handler
+	published map[string][]*Event
+}
+
+// NewMemoryBus creates an empty in-memory bus
+func NewMemoryBus() *MemoryBus {
+	return &MemoryBus{
+		handlers:  make(map[string][]Handler),
+		published: make(map[string][]*Event),
+	}
+}
+
+// Subscribe registers a handler for a topic
+func (b *MemoryBus) Subscribe(topic string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[topic] = append(b.handlers[topic], handler)
+}
+
+// Publish records the event and delivers it to every handler of the topic.
+// Handler errors are joined and returned.
+func (b *MemoryBus) Publish(ctx context.Context, topic string, event *Event) error {
+	b.mu.Lock()
+	b.published[topic] = append(b.published[topic], event)
+	handlers := append([]Handler(nil), b.handlers[topic]...)
+	b.mu.Unlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Published returns the events published to a topic, in order
+func (b *MemoryBus) Published(topic string) []*Event {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return append([]*Event(nil), b.published[topic]...)
+}
@@ -0,0 +1,66 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package events - Bus Tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryBusImplementsInterfaces(t *testing.T) {
+	var _ Publisher = NewMemoryBus()
+	var _ Subscriber = NewMemoryBus()
+}
+
+func TestMemoryBusDeliversToSubscribers(t *testing.T) {
+	bus := NewMemoryBus()
+	var received []string
+
+	bus.Subscribe(Topics.PaymentEvents, func(ctx context.Context, event *Event) error {
+		received = append(received, "first:"+event.Type)
+		return nil
+	})
+	bus.Subscribe(Topics.PaymentEvents, func(ctx context.Context, event *Event) error {
+		received = append(received, "second:"+event.Type)
+		return nil
+	})
+	bus.Subscribe(Topics.AccountEvents, func(ctx context.Context, event *Event) error {
+		received = append(received, "account:"+event.Type)
+		return nil
+	})
+
+	err := bus.Publish(context.Background(), Topics.PaymentEvents, NewPaymentEvent(EventTypes.PaymentCompleted, nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"first:payment.completed", "second:payment.completed"}, received)
+}
+
+func TestMemoryBusRecordsPublishedEvents(t *testing.T) {
+	bus := NewMemoryBus()
+	first := NewPaymentEvent(EventTypes.PaymentCompleted, nil)
+	second := NewPaymentEvent(EventTypes.PaymentRefunded, nil)
+
+	_ = bus.Publish(context.Background(), Topics.PaymentEvents, first)
+	_ = bus.Publish(context.Background(), Topics.PaymentEvents, second)
+
+	assert.Equal(t, []*Event{first, second}, bus.Published(Topics.PaymentEvents))
+	assert.Empty(t, bus.Published(Topics.AccountEvents))
+}
+
+func TestMemoryBusJoinsHandlerErrors(t *testing.T) {
+	bus := NewMemoryBus()
+	errFirst := errors.New("first failed")
+	errSecond := errors.New("second failed")
+	bus.Subscribe("topic", func(ctx context.Context, event *Event) error { return errFirst })
+	bus.Subscribe("topic", func(ctx context.Context, event *Event) error { return errSecond })
+
+	err := bus.Publish(context.Background(), "topic", NewEvent("test", "test", nil))
+
+	assert.ErrorIs(t, err, errFirst)
+	assert.ErrorIs(t, err, errSecond)
+}
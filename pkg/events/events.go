@@ -8,7 +8,9 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/fintech-bank-platform/pkg/pii"
 	"github.com/google/uuid"
+	"github.com/rs/zerolog"
 )
 
 // ═══════════════════════════════════════════════════════════════════════════
@@ -59,6 +61,34 @@ func (e *Event) ToJSON() ([]byte, error) {
 	return json.Marshal(e)
 }
 
+// MarshalZerologObject logs the event with personal data in Metadata and
+// Payload masked: logger.Info().Object("event", event). ToJSON, used on the
+// wire, is not redacted.
+func (e *Event) MarshalZerologObject(log *zerolog.Event) {
+	log.Str("id", e.ID).
+		Str("type", e.Type).
+		Str("version", e.Version).
+		Str("source", e.Source).
+		Time("timestamp", e.Timestamp)
+	if e.TraceID != "" {
+		log.Str("trace_id", e.TraceID)
+	}
+	if len(e.Metadata) > 0 {
+		log.Interface("metadata", pii.RedactMap(e.Metadata))
+	}
+	log.Interface("payload", pii.Redact(e.Payload))
+}
+
+// DecodePayload decodes the payload into v. It works both for typed payloads
+// (in-process) and for generic maps produced by FromJSON.
+func (e *Event) DecodePayload(v interface{}) error {
+	data, err := json.Marshal(e.Payload)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
 // FromJSON deserializes JSON to an event
 func FromJSON(data []byte) (*Event, error) {
 	var event Event
@@ -87,6 +117,9 @@ var Topics = struct {
 	// Notifications
 	NotificationEvents string
 
+	// Risk decisions
+	RiskEvents string
+
 	// Dead Letter Queues
 	AccountDLQ     string
 	TransactionDLQ string
@@ -102,6 +135,8 @@ var Topics = struct {
 
 	NotificationEvents: "notification.events",
 
+	RiskEvents: "risk.events",
+
 	AccountDLQ:     "account.dlq",
 	TransactionDLQ: "transaction.dlq",
 	PaymentDLQ:     "payment.dlq",
@@ -156,6 +191,9 @@ var EventTypes = struct {
 	SendEmail string
 	SendSMS   string
 	SendPush  string
+
+	// Risk Events
+	RiskAssessed string
 }{
 	// Account Commands
 	CreateAccount: "account.create",
@@ -200,6 +238,9 @@ var EventTypes = struct {
 	SendEmail: "notification.email",
 	SendSMS:   "notification.sms",
 	SendPush:  "notification.push",
+
+	// Risk Events
+	RiskAssessed: "risk.assessed",
 }
 
 // ═══════════════════════════════════════════════════════════════════════════
@@ -211,17 +252,17 @@ type CreateAccountPayload struct {
 	UserID      string `json:"user_id"`
 	AccountType string `json:"account_type"`
 	Name        string `json:"name"`
-	Email       string `json:"email"`
-	Document    string `json:"document"`
-	Phone       string `json:"phone,omitempty"`
+	Email       string `json:"email" pii:"email"`
+	Document    string `json:"document" pii:"document"`
+	Phone       string `json:"phone,omitempty" pii:"phone"`
 }
 
 // UpdateAccountPayload represents the payload for updating an account
 type UpdateAccountPayload struct {
 	AccountID string  `json:"account_id"`
 	Name      *string `json:"name,omitempty"`
-	Email     *string `json:"email,omitempty"`
-	Phone     *string `json:"phone,omitempty"`
+	Email     *string `json:"email,omitempty" pii:"email"`
+	Phone     *string `json:"phone,omitempty" pii:"phone"`
 	Status    *string `json:"status,omitempty"`
 }
 
@@ -236,6 +277,16 @@ type AccountCreatedPayload struct {
 	CreatedAt     time.Time `json:"created_at"`
 }
 
+// KYCCompletedPayload represents the payload for KYC completed event
+type KYCCompletedPayload struct {
+	AccountID   string    `json:"account_id"`
+	UserID      string    `json:"user_id"`
+	Name        string    `json:"name"`
+	Email       string    `json:"email" pii:"email"`
+	Level       string    `json:"level"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
 // ═══════════════════════════════════════════════════════════════════════════
 // TRANSACTION PAYLOADS
 // ═══════════════════════════════════════════════════════════════════════════
@@ -250,14 +301,44 @@ type CreateTransactionPayload struct {
 	IdempotencyKey string  `json:"idempotency_key"`
 }
 
-// ProcessTransferPayload represents the payload for processing a transfer
+// ProcessTransferPayload represents the payload for processing a transfer.
+// Amount and Currency are debited from the source; FX is set when the
+// destination is credited in another currency.
 type ProcessTransferPayload struct {
-	FromAccountID  string  `json:"from_account_id"`
-	ToAccountID    string  `json:"to_account_id"`
-	Amount         float64 `json:"amount"`
-	Currency       string  `json:"currency"`
-	Description    string  `json:"description,omitempty"`
-	IdempotencyKey string  `json:"idempotency_key"`
+	FromAccountID  string        `json:"from_account_id"`
+	ToAccountID    string        `json:"to_account_id"`
+	Amount         float64       `json:"amount"`
+	Currency       string        `json:"currency"`
+	FX             *FXConversion `json:"fx,omitempty"`
+	Description    string        `json:"description,omitempty"`
+	IdempotencyKey string        `json:"idempotency_key"`
+}
+
+// FXConversion records the exchange applied to a cross-currency transfer:
+// TargetAmount in TargetCurrency is the source amount converted at Rate
+// (a decimal string, target units per source unit, spread included)
+type FXConversion struct {
+	QuoteID        string  `json:"quote_id,omitempty"`
+	Rate           string  `json:"rate"`
+	TargetAmount   float64 `json:"target_amount"`
+	TargetCurrency string  `json:"target_currency"`
+}
+
+// Credited returns the amount and currency credited to the destination
+func (p ProcessTransferPayload) Credited() (float64, string) {
+	if p.FX != nil {
+		return p.FX.TargetAmount, p.FX.TargetCurrency
+	}
+	return p.Amount, p.Currency
+}
+
+// ReverseTransactionPayload represents the payload for reversing a transaction
+type ReverseTransactionPayload struct {
+	TransactionID  string `json:"transaction_id"`
+	ReasonCode     string `json:"reason_code"`
+	OperatorID     string `json:"operator_id"`
+	Description    string `json:"description,omitempty"`
+	IdempotencyKey string `json:"idempotency_key"`
 }
 
 // TransactionCompletedPayload represents the payload for transaction completed event
@@ -272,16 +353,43 @@ type TransactionCompletedPayload struct {
 	CompletedAt   time.Time `json:"completed_at"`
 }
 
+// TransactionReversedPayload represents the payload for transaction reversed event
+type TransactionReversedPayload struct {
+	ReversalID            string    `json:"reversal_id"`
+	OriginalTransactionID string    `json:"original_transaction_id"`
+	AccountID             string    `json:"account_id"`
+	Amount                float64   `json:"amount"`
+	Currency              string    `json:"currency"`
+	ReasonCode            string    `json:"reason_code"`
+	OperatorID            string    `json:"operator_id"`
+	ReversedAt            time.Time `json:"reversed_at"`
+}
+
 // TransferCompletedPayload represents the payload for transfer completed event
 type TransferCompletedPayload struct {
-	TransferID       string    `json:"transfer_id"`
-	FromAccountID    string    `json:"from_account_id"`
-	ToAccountID      string    `json:"to_account_id"`
-	Amount           float64   `json:"amount"`
-	Currency         string    `json:"currency"`
-	FromBalanceAfter float64   `json:"from_balance_after"`
-	ToBalanceAfter   float64   `json:"to_balance_after"`
-	CompletedAt      time.Time `json:"completed_at"`
+	TransferID       string        `json:"transfer_id"`
+	FromAccountID    string        `json:"from_account_id"`
+	ToAccountID      string        `json:"to_account_id"`
+	Amount           float64       `json:"amount"`
+	Currency         string        `json:"currency"`
+	FX               *FXConversion `json:"fx,omitempty"`
+	FromBalanceAfter float64       `json:"from_balance_after"`
+	ToBalanceAfter   float64       `json:"to_balance_after"`
+	CompletedAt      time.Time     `json:"completed_at"`
+}
+
+// TransferFailedPayload represents the payload for transfer failed event
+type TransferFailedPayload struct {
+	TransferID       string        `json:"transfer_id"`
+	FromAccountID    string        `json:"from_account_id"`
+	ToAccountID      string        `json:"to_account_id"`
+	Amount           float64       `json:"amount"`
+	Currency         string        `json:"currency"`
+	FX               *FXConversion `json:"fx,omitempty"`
+	FailedStep       string        `json:"failed_step"`
+	Reason           string        `json:"reason"`
+	CompensatedSteps []string      `json:"compensated_steps,omitempty"`
+	FailedAt         time.Time     `json:"failed_at"`
 }
 
 // ═══════════════════════════════════════════════════════════════════════════
@@ -295,12 +403,38 @@ type ProcessPaymentPayload struct {
 	Amount         float64 `json:"amount"`
 	Currency       string  `json:"currency"`
 	Recipient      string  `json:"recipient"`
-	PixKey         string  `json:"pix_key,omitempty"`
+	PixKey         string  `json:"pix_key,omitempty" pii:"pix_key"`
 	BoletoCode     string  `json:"boleto_code,omitempty"`
 	Description    string  `json:"description,omitempty"`
 	IdempotencyKey string  `json:"idempotency_key"`
 }
 
+// RefundPaymentPayload represents the payload for refunding (fully or partially) a payment
+type RefundPaymentPayload struct {
+	PaymentID      string  `json:"payment_id"`
+	Amount         float64 `json:"amount"`
+	Reason         string  `json:"reason,omitempty"`
+	IdempotencyKey string  `json:"idempotency_key"`
+}
+
+// CancelPaymentPayload represents the payload for cancelling a payment before completion
+type CancelPaymentPayload struct {
+	PaymentID      string `json:"payment_id"`
+	Reason         string `json:"reason,omitempty"`
+	IdempotencyKey string `json:"idempotency_key"`
+}
+
+// PaymentProcessedPayload represents the payload for payment processed event
+type PaymentProcessedPayload struct {
+	PaymentID     string    `json:"payment_id"`
+	AccountID     string    `json:"account_id"`
+	PaymentMethod string    `json:"payment_method"`
+	Amount        float64   `json:"amount"`
+	Currency      string    `json:"currency"`
+	Status        string    `json:"status"`
+	ProcessedAt   time.Time `json:"processed_at"`
+}
+
 // PaymentCompletedPayload represents the payload for payment completed event
 type PaymentCompletedPayload struct {
 	PaymentID     string    `json:"payment_id"`
@@ -313,35 +447,104 @@ type PaymentCompletedPayload struct {
 	CompletedAt   time.Time `json:"completed_at"`
 }
 
+// PaymentFailedPayload represents the payload for payment failed event
+type PaymentFailedPayload struct {
+	PaymentID    string    `json:"payment_id"`
+	AccountID    string    `json:"account_id"`
+	Status       string    `json:"status"`
+	ErrorCode    string    `json:"error_code"`
+	ErrorMessage string    `json:"error_message"`
+	FailedAt     time.Time `json:"failed_at"`
+}
+
+// PaymentRefundedPayload represents the payload for payment refunded event
+type PaymentRefundedPayload struct {
+	RefundID        string    `json:"refund_id"`
+	PaymentID       string    `json:"payment_id"`
+	AccountID       string    `json:"account_id"`
+	Amount          float64   `json:"amount"`
+	Currency        string    `json:"currency"`
+	RefundedTotal   float64   `json:"refunded_total"`
+	RemainingAmount float64   `json:"remaining_amount"`
+	Status          string    `json:"status"`
+	Reason          string    `json:"reason,omitempty"`
+	RefundedAt      time.Time `json:"refunded_at"`
+}
+
+// PaymentCancelledPayload represents the payload for payment cancelled event
+type PaymentCancelledPayload struct {
+	PaymentID   string    `json:"payment_id"`
+	AccountID   string    `json:"account_id"`
+	Status      string    `json:"status"`
+	Reason      string    `json:"reason,omitempty"`
+	CancelledAt time.Time `json:"cancelled_at"`
+}
+
 // ═══════════════════════════════════════════════════════════════════════════
 // NOTIFICATION PAYLOADS
 // ═══════════════════════════════════════════════════════════════════════════
 
 // SendEmailPayload represents the payload for sending an email
 type SendEmailPayload struct {
-	To       string            `json:"to"`
+	UserID   string            `json:"user_id,omitempty"`
+	To       string            `json:"to" pii:"email"`
 	Subject  string            `json:"subject"`
 	Template string            `json:"template"`
+	Locale   string            `json:"locale,omitempty"`
 	Data     map[string]string `json:"data,omitempty"`
 	Priority string            `json:"priority,omitempty"`
 }
 
-// SendSMSPayload represents the payload for sending an SMS
+// SendSMSPayload represents the payload for sending an SMS.
+// When Template is set the message is rendered from it instead of Message.
 type SendSMSPayload struct {
-	To       string `json:"to"`
-	Message  string `json:"message"`
-	Priority string `json:"priority,omitempty"`
+	UserID   string            `json:"user_id,omitempty"`
+	To       string            `json:"to" pii:"phone"`
+	Message  string            `json:"message"`
+	Template string            `json:"template,omitempty"`
+	Locale   string            `json:"locale,omitempty"`
+	Data     map[string]string `json:"data,omitempty"`
+	Priority string            `json:"priority,omitempty"`
 }
 
-// SendPushPayload represents the payload for sending a push notification
+// SendPushPayload represents the payload for sending a push notification.
+// When Template is set the title and body are rendered from it.
 type SendPushPayload struct {
 	UserID   string            `json:"user_id"`
 	Title    string            `json:"title"`
 	Body     string            `json:"body"`
+	Template string            `json:"template,omitempty"`
+	Locale   string            `json:"locale,omitempty"`
 	Data     map[string]string `json:"data,omitempty"`
 	Priority string            `json:"priority,omitempty"`
 }
 
+// ═══════════════════════════════════════════════════════════════════════════
+// RISK PAYLOADS
+// ═══════════════════════════════════════════════════════════════════════════
+
+// RiskReason explains one contribution to a risk decision
+type RiskReason struct {
+	Rule    string `json:"rule"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Score   int    `json:"score"`
+}
+
+// RiskAssessedPayload represents the payload for risk assessed event
+type RiskAssessedPayload struct {
+	AssessmentID string       `json:"assessment_id"`
+	CommandID    string       `json:"command_id"`
+	CommandType  string       `json:"command_type"`
+	AccountID    string       `json:"account_id"`
+	Amount       float64      `json:"amount"`
+	Currency     string       `json:"currency"`
+	Decision     string       `json:"decision"`
+	Score        int          `json:"score"`
+	Reasons      []RiskReason `json:"reasons,omitempty"`
+	AssessedAt   time.Time    `json:"assessed_at"`
+}
+
 // ═══════════════════════════════════════════════════════════════════════════
 // ERROR PAYLOADS
 // ═══════════════════════════════════════════════════════════════════════════
@@ -389,6 +592,11 @@ func NewPaymentEvent(eventType string, payload interface{}) *Event {
 	return NewEvent(eventType, "payment-service", payload)
 }
 
+// NewRiskEvent creates a new risk event
+func NewRiskEvent(eventType string, payload interface{}) *Event {
+	return NewEvent(eventType, "risk-engine", payload)
+}
+
 // NewNotificationEvent creates a new notification event
 func NewNotificationEvent(eventType string, payload interface{}) *Event {
 	return NewEvent(eventType, "notification-service", payload)
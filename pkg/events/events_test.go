@@ -5,10 +5,12 @@
 package events
 
 import (
+	"bytes"
 	"encoding/json"
 	"testing"
 	"time"
 
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -75,6 +77,28 @@ func TestFromJSON_Invalid(t *testing.T) {
 	assert.Nil(t, event)
 }
 
+func TestEvent_DecodePayload(t *testing.T) {
+	original := NewNotificationEvent(EventTypes.SendSMS, SendSMSPayload{To: "11999887766", Template: "otp"})
+
+	var typed SendSMSPayload
+	assert.NoError(t, original.DecodePayload(&typed))
+	assert.Equal(t, "otp", typed.Template)
+
+	jsonData, _ := original.ToJSON()
+	event, _ := FromJSON(jsonData)
+
+	var decoded SendSMSPayload
+	assert.NoError(t, event.DecodePayload(&decoded))
+	assert.Equal(t, "11999887766", decoded.To)
+}
+
+func TestEvent_DecodePayload_Invalid(t *testing.T) {
+	var payload SendSMSPayload
+
+	assert.Error(t, NewEvent("test", "test", make(chan int)).DecodePayload(&payload))
+	assert.Error(t, NewEvent("test", "test", "text").DecodePayload(&payload))
+}
+
 // ═══════════════════════════════════════════════════════════════════════════
 // TOPICS TESTS
 // ═══════════════════════════════════════════════════════════════════════════
@@ -90,6 +114,7 @@ func TestTopics(t *testing.T) {
 	assert.Equal(t, "account.dlq", Topics.AccountDLQ)
 	assert.Equal(t, "transaction.dlq", Topics.TransactionDLQ)
 	assert.Equal(t, "payment.dlq", Topics.PaymentDLQ)
+	assert.Equal(t, "risk.events", Topics.RiskEvents)
 }
 
 // ═══════════════════════════════════════════════════════════════════════════
@@ -125,6 +150,7 @@ func TestEventTypes(t *testing.T) {
 	// Notification Events
 	assert.Equal(t, "notification.email", EventTypes.SendEmail)
 	assert.Equal(t, "notification.sms", EventTypes.SendSMS)
+	assert.Equal(t, "risk.assessed", EventTypes.RiskAssessed)
 }
 
 // ═══════════════════════════════════════════════════════════════════════════
@@ -151,6 +177,26 @@ func TestCreateAccountPayload(t *testing.T) {
 	assert.Equal(t, payload.Email, result.Email)
 }
 
+func TestKYCCompletedPayload(t *testing.T) {
+	payload := KYCCompletedPayload{
+		AccountID:   "acc-123",
+		UserID:      "user-123",
+		Name:        "Ana",
+		Email:       "ana@example.com",
+		Level:       "full",
+		CompletedAt: time.Now().UTC(),
+	}
+
+	jsonData, err := json.Marshal(payload)
+	assert.NoError(t, err)
+
+	var result KYCCompletedPayload
+	err = json.Unmarshal(jsonData, &result)
+	assert.NoError(t, err)
+	assert.Equal(t, payload.Email, result.Email)
+	assert.Equal(t, payload.Level, result.Level)
+}
+
 func TestProcessTransferPayload(t *testing.T) {
 	payload := ProcessTransferPayload{
 		FromAccountID:  "acc-123",
@@ -169,6 +215,31 @@ func TestProcessTransferPayload(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, payload.Amount, result.Amount)
 	assert.Equal(t, payload.IdempotencyKey, result.IdempotencyKey)
+	assert.NotContains(t, string(jsonData), `"fx"`)
+
+	amount, currency := result.Credited()
+	assert.Equal(t, 100.50, amount)
+	assert.Equal(t, "BRL", currency)
+}
+
+func TestProcessTransferPayloadWithFX(t *testing.T) {
+	payload := ProcessTransferPayload{
+		FromAccountID: "acc-123",
+		ToAccountID:   "acc-456",
+		Amount:        100,
+		Currency:      "BRL",
+		FX:            &FXConversion{QuoteID: "quote-1", Rate: "0.18", TargetAmount: 18, TargetCurrency: "USD"},
+	}
+
+	jsonData, err := json.Marshal(payload)
+	assert.NoError(t, err)
+	assert.Contains(t, string(jsonData), `"fx":{"quote_id":"quote-1","rate":"0.18","target_amount":18,"target_currency":"USD"}`)
+
+	var result ProcessTransferPayload
+	assert.NoError(t, json.Unmarshal(jsonData, &result))
+	amount, currency := result.Credited()
+	assert.Equal(t, 18.0, amount)
+	assert.Equal(t, "USD", currency)
 }
 
 func TestProcessPaymentPayload(t *testing.T) {
@@ -192,6 +263,125 @@ func TestProcessPaymentPayload(t *testing.T) {
 	assert.Equal(t, payload.PixKey, result.PixKey)
 }
 
+func TestReverseTransactionPayload(t *testing.T) {
+	payload := ReverseTransactionPayload{
+		TransactionID:  "txn-123",
+		ReasonCode:     "MISTAKEN_CREDIT",
+		OperatorID:     "operator-1",
+		IdempotencyKey: "idem-rev",
+	}
+
+	jsonData, err := json.Marshal(payload)
+	assert.NoError(t, err)
+
+	var result ReverseTransactionPayload
+	err = json.Unmarshal(jsonData, &result)
+	assert.NoError(t, err)
+	assert.Equal(t, payload, result)
+}
+
+func TestTransactionReversedPayload(t *testing.T) {
+	payload := TransactionReversedPayload{
+		ReversalID:            "txn-456",
+		OriginalTransactionID: "txn-123",
+		AccountID:             "acc-123",
+		Amount:                100,
+		Currency:              "BRL",
+		ReasonCode:            "MISTAKEN_CREDIT",
+		OperatorID:            "operator-1",
+		ReversedAt:            time.Now().UTC(),
+	}
+
+	jsonData, err := json.Marshal(payload)
+	assert.NoError(t, err)
+
+	var result TransactionReversedPayload
+	err = json.Unmarshal(jsonData, &result)
+	assert.NoError(t, err)
+	assert.Equal(t, payload.ReversalID, result.ReversalID)
+	assert.Equal(t, payload.OriginalTransactionID, result.OriginalTransactionID)
+}
+
+func TestTransferFailedPayload(t *testing.T) {
+	payload := TransferFailedPayload{
+		TransferID:       "trf-123",
+		FromAccountID:    "acc-123",
+		ToAccountID:      "acc-456",
+		Amount:           100,
+		Currency:         "BRL",
+		FailedStep:       "credit_destination",
+		Reason:           "account blocked",
+		CompensatedSteps: []string{"debit_source"},
+		FailedAt:         time.Now().UTC(),
+	}
+
+	jsonData, err := json.Marshal(payload)
+	assert.NoError(t, err)
+
+	var result TransferFailedPayload
+	err = json.Unmarshal(jsonData, &result)
+	assert.NoError(t, err)
+	assert.Equal(t, payload.Reason, result.Reason)
+	assert.Equal(t, payload.CompensatedSteps, result.CompensatedSteps)
+}
+
+func TestRefundPaymentPayload(t *testing.T) {
+	payload := RefundPaymentPayload{
+		PaymentID:      "pay-123",
+		Amount:         50.25,
+		Reason:         "customer_request",
+		IdempotencyKey: "idem-789",
+	}
+
+	jsonData, err := json.Marshal(payload)
+	assert.NoError(t, err)
+
+	var result RefundPaymentPayload
+	err = json.Unmarshal(jsonData, &result)
+	assert.NoError(t, err)
+	assert.Equal(t, payload, result)
+}
+
+func TestCancelPaymentPayload(t *testing.T) {
+	payload := CancelPaymentPayload{
+		PaymentID:      "pay-123",
+		Reason:         "duplicate",
+		IdempotencyKey: "idem-790",
+	}
+
+	jsonData, err := json.Marshal(payload)
+	assert.NoError(t, err)
+
+	var result CancelPaymentPayload
+	err = json.Unmarshal(jsonData, &result)
+	assert.NoError(t, err)
+	assert.Equal(t, payload, result)
+}
+
+func TestPaymentRefundedPayload(t *testing.T) {
+	payload := PaymentRefundedPayload{
+		RefundID:        "ref-123",
+		PaymentID:       "pay-123",
+		AccountID:       "acc-123",
+		Amount:          50.00,
+		Currency:        "BRL",
+		RefundedTotal:   50.00,
+		RemainingAmount: 200.00,
+		Status:          "partially_refunded",
+		RefundedAt:      time.Now().UTC(),
+	}
+
+	jsonData, err := json.Marshal(payload)
+	assert.NoError(t, err)
+
+	var result PaymentRefundedPayload
+	err = json.Unmarshal(jsonData, &result)
+	assert.NoError(t, err)
+	assert.Equal(t, payload.PaymentID, result.PaymentID)
+	assert.Equal(t, payload.RemainingAmount, result.RemainingAmount)
+	assert.Contains(t, string(jsonData), `"refunded_total":50`)
+}
+
 func TestTransactionCompletedPayload(t *testing.T) {
 	now := time.Now().UTC()
 	payload := TransactionCompletedPayload{
@@ -268,6 +458,30 @@ func TestSendPushPayload(t *testing.T) {
 	assert.Equal(t, payload.Title, result.Title)
 }
 
+func TestRiskAssessedPayload(t *testing.T) {
+	payload := RiskAssessedPayload{
+		AssessmentID: "risk-123",
+		CommandID:    "cmd-123",
+		CommandType:  EventTypes.ProcessTransfer,
+		AccountID:    "acc-123",
+		Amount:       900,
+		Currency:     "BRL",
+		Decision:     "review",
+		Score:        40,
+		Reasons:      []RiskReason{{Rule: "velocity", Code: "VELOCITY", Message: "too many", Score: 40}},
+		AssessedAt:   time.Now().UTC(),
+	}
+
+	jsonData, err := json.Marshal(payload)
+	assert.NoError(t, err)
+
+	var result RiskAssessedPayload
+	err = json.Unmarshal(jsonData, &result)
+	assert.NoError(t, err)
+	assert.Equal(t, payload.Decision, result.Decision)
+	assert.Equal(t, payload.Reasons, result.Reasons)
+}
+
 func TestErrorPayload(t *testing.T) {
 	originalEvent := NewEvent("test.event", "test-service", nil)
 	payload := ErrorPayload{
@@ -347,3 +561,39 @@ func TestNewNotificationEvent(t *testing.T) {
 	assert.Equal(t, EventTypes.SendEmail, event.Type)
 	assert.Equal(t, "notification-service", event.Source)
 }
+
+func TestNewRiskEvent(t *testing.T) {
+	event := NewRiskEvent(EventTypes.RiskAssessed, RiskAssessedPayload{Decision: "allow"})
+
+	assert.Equal(t, EventTypes.RiskAssessed, event.Type)
+	assert.Equal(t, "risk-engine", event.Source)
+}
+
+func TestEventLogMasksPersonalData(t *testing.T) {
+	var buf bytes.Buffer
+	event := NewAccountCommand(EventTypes.CreateAccount, CreateAccountPayload{
+		UserID:   "user-1",
+		Name:     "João da Silva",
+		Email:    "joao@example.com",
+		Document: "52998224725",
+		Phone:    "11987654321",
+	}).WithTraceID("trace-1").WithMetadata("email", "joao@example.com").WithMetadata("channel", "app")
+
+	log := zerolog.New(&buf)
+	log.Info().Object("event", event).Msg("Publishing")
+
+	output := buf.String()
+	assert.Contains(t, output, `"trace_id":"trace-1"`)
+	assert.Contains(t, output, `"metadata":{"channel":"app","email":"j***@example.com"}`)
+	assert.Contains(t, output, `"document":"***.982.247-**"`)
+	assert.Contains(t, output, `"phone":"(11) *****-4321"`)
+	assert.NotContains(t, output, "joao@example.com")
+
+	data, _ := event.ToJSON()
+	assert.Contains(t, string(data), `"document":"52998224725"`, "the wire format is not redacted")
+
+	buf.Reset()
+	log.Info().Object("event", NewEvent("ping", "test", nil)).Msg("Publishing")
+	assert.NotContains(t, buf.String(), "trace_id")
+	assert.NotContains(t, buf.String(), "metadata")
+}
@@ -0,0 +1,102 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package fx - File rate feed
+// ═══════════════════════════════════════════════════════════════════════════
+
+package fx
+
+import (
+	"context"
+	"encoding/csv"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// ErrInvalidFeed is returned when a rate file cannot be parsed
+var ErrInvalidFeed = stderrors.New("fx: invalid rate feed")
+
+// FileSource serves rates from a CSV file, for offline use and local
+// development. The file has a header and one rate per line:
+//
+//	base,quote,rate,as_of
+//	USD,BRL,5.4321,2026-10-19T12:00:00Z
+//
+// Rates are read on creation and on every Reload.
+type FileSource struct {
+	path string
+
+	mu     sync.RWMutex
+	source *StaticSource
+}
+
+// NewFileSource loads the rate file at path
+func NewFileSource(path string) (*FileSource, error) {
+	f := &FileSource{path: path}
+	if err := f.Reload(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Reload reads the file again. On error the previous rates are kept.
+func (f *FileSource) Reload() error {
+	file, err := os.Open(f.path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	rates, err := parseFeed(file)
+	if err != nil {
+		return fmt.Errorf("%s: %w", f.path, err)
+	}
+
+	f.mu.Lock()
+	f.source = NewStaticSource(rates...)
+	f.mu.Unlock()
+	return nil
+}
+
+// Rate returns the rate of base in quote from the last loaded file
+func (f *FileSource) Rate(ctx context.Context, base, quote string) (Rate, error) {
+	f.mu.RLock()
+	source := f.source
+	f.mu.RUnlock()
+
+	return source.Rate(ctx, base, quote)
+}
+
+func parseFeed(r io.Reader) ([]Rate, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 4
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	var rates []Rate
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rates, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidFeed, err)
+		}
+		if first && record[0] == "base" {
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+		value, ok := ParseRate(record[2])
+		if !ok {
+			return nil, fmt.Errorf("%w: line %d: rate %q", ErrInvalidFeed, line, record[2])
+		}
+		asOf, err := time.Parse(time.RFC3339, record[3])
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: as_of %q", ErrInvalidFeed, line, record[3])
+		}
+		rates = append(rates, Rate{Base: record[0], Quote: record[1], Value: value, AsOf: asOf})
+	}
+}
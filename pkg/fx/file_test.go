@@ -0,0 +1,87 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package fx - File rate feed tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package fx
+
+import (
+	"context"
+	stderrors "errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const feed = `base,quote,rate,as_of
+# published by the treasury desk
+USD,BRL,5.4321,2026-10-19T12:00:00Z
+EUR,BRL, 6.10,2026-10-19T12:00:00Z
+`
+
+func writeFeed(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestFileSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.csv")
+	writeFeed(t, path, feed)
+
+	source, err := NewFileSource(path)
+	require.NoError(t, err)
+
+	usd, err := source.Rate(context.Background(), "USD", "BRL")
+	require.NoError(t, err)
+	assert.Equal(t, "5.4321", FormatRate(usd.Value))
+	assert.Equal(t, asOf, usd.AsOf)
+
+	eur, err := source.Rate(context.Background(), "BRL", "EUR")
+	require.NoError(t, err)
+	assert.Equal(t, "0.1639344262", FormatRate(eur.Value))
+}
+
+func TestFileSourceReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.csv")
+	writeFeed(t, path, feed)
+	source, err := NewFileSource(path)
+	require.NoError(t, err)
+
+	writeFeed(t, path, "USD,BRL,5.5,2026-10-19T13:00:00Z\n")
+	require.NoError(t, source.Reload())
+	usd, _ := source.Rate(context.Background(), "USD", "BRL")
+	assert.Equal(t, "5.5", FormatRate(usd.Value))
+	_, err = source.Rate(context.Background(), "EUR", "BRL")
+	assert.Error(t, err, "rates missing from the new file are dropped")
+
+	writeFeed(t, path, "USD,BRL,abc,2026-10-19T14:00:00Z\n")
+	assert.Error(t, source.Reload())
+	usd, _ = source.Rate(context.Background(), "USD", "BRL")
+	assert.Equal(t, "5.5", FormatRate(usd.Value), "a broken file keeps the previous rates")
+}
+
+func TestFileSourceErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := NewFileSource(filepath.Join(dir, "missing.csv"))
+	assert.True(t, stderrors.Is(err, os.ErrNotExist))
+
+	for name, content := range map[string]string{
+		"fields": "USD,BRL,5.43\n",
+		"rate":   feed + "GBP,BRL,-7,2026-10-19T12:00:00Z\n",
+		"as_of":  "USD,BRL,5.43,yesterday\n",
+	} {
+		path := filepath.Join(dir, name+".csv")
+		writeFeed(t, path, content)
+
+		_, err := NewFileSource(path)
+		assert.True(t, stderrors.Is(err, ErrInvalidFeed), name)
+	}
+
+	path := filepath.Join(dir, "line.csv")
+	writeFeed(t, path, feed+"GBP,BRL,-7,2026-10-19T12:00:00Z\n")
+	_, err = NewFileSource(path)
+	assert.ErrorContains(t, err, `line 5: rate "-7"`)
+}
@@ -0,0 +1,132 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package fx - Exchange rates and exact currency conversion
+// ═══════════════════════════════════════════════════════════════════════════
+
+package fx
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fintech-bank-platform/pkg/currency"
+	"github.com/fintech-bank-platform/pkg/errors"
+)
+
+// RatePrecision is the number of decimals rates are rendered with
+const RatePrecision = 10
+
+// ═══════════════════════════════════════════════════════════════════════════
+// RATES
+// ═══════════════════════════════════════════════════════════════════════════
+
+// Rate is the mid-market price of one Base unit in Quote units
+type Rate struct {
+	Base  string
+	Quote string
+	Value *big.Rat
+	AsOf  time.Time
+}
+
+// Pair returns the rate pair as BASE/QUOTE
+func (r Rate) Pair() string {
+	return pair(r.Base, r.Quote)
+}
+
+// Invert returns the rate of Quote in Base units
+func (r Rate) Invert() Rate {
+	return Rate{Base: r.Quote, Quote: r.Base, Value: new(big.Rat).Inv(r.Value), AsOf: r.AsOf}
+}
+
+// ParseRate parses a positive decimal rate such as "5.4321"
+func ParseRate(value string) (*big.Rat, bool) {
+	rate, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+	if !ok || rate.Sign() <= 0 {
+		return nil, false
+	}
+	return rate, true
+}
+
+// FormatRate renders a rate with up to RatePrecision decimals
+func FormatRate(rate *big.Rat) string {
+	formatted := rate.FloatString(RatePrecision)
+	formatted = strings.TrimRight(formatted, "0")
+	return strings.TrimSuffix(formatted, ".")
+}
+
+// Convert multiplies amount by rate with exact decimal arithmetic and rounds
+// the result to the minor units of target, halves away from zero
+func Convert(amount float64, rate *big.Rat, target currency.Currency) float64 {
+	exact, _ := new(big.Rat).SetString(strconv.FormatFloat(amount, 'f', -1, 64))
+	exact.Mul(exact, rate)
+
+	if target.MinorUnits == currency.NoMinorUnits {
+		converted, _ := exact.Float64()
+		return converted
+	}
+	converted, _ := strconv.ParseFloat(exact.FloatString(target.MinorUnits), 64)
+	return converted
+}
+
+func pair(base, quote string) string {
+	return base + "/" + quote
+}
+
+func rateUnavailable(base, quote string) *errors.AppError {
+	return errors.ServiceUnavailable(errors.ErrRateUnavailable.Code, errors.ErrRateUnavailable.Message).
+		WithDetail("pair", pair(base, quote))
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// SOURCES
+// ═══════════════════════════════════════════════════════════════════════════
+
+// Source provides the latest mid-market rates
+type Source interface {
+	// Rate returns the rate of base in quote or errors.ErrRateUnavailable
+	Rate(ctx context.Context, base, quote string) (Rate, error)
+}
+
+// StaticSource serves rates set in memory. A pair missing in one direction
+// is answered with the inverse of the other.
+type StaticSource struct {
+	mu    sync.RWMutex
+	rates map[string]Rate
+}
+
+// NewStaticSource creates a source with the given rates
+func NewStaticSource(rates ...Rate) *StaticSource {
+	s := &StaticSource{rates: make(map[string]Rate, len(rates))}
+	s.Set(rates...)
+	return s
+}
+
+// Set adds or replaces rates
+func (s *StaticSource) Set(rates ...Rate) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, rate := range rates {
+		rate.Base, rate.Quote = strings.ToUpper(rate.Base), strings.ToUpper(rate.Quote)
+		s.rates[rate.Pair()] = rate
+	}
+}
+
+// Rate returns the stored rate of base in quote, or the inverse of quote in base
+func (s *StaticSource) Rate(_ context.Context, base, quote string) (Rate, error) {
+	base, quote = strings.ToUpper(base), strings.ToUpper(quote)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if rate, ok := s.rates[pair(base, quote)]; ok {
+		return rate, nil
+	}
+	if rate, ok := s.rates[pair(quote, base)]; ok {
+		return rate.Invert(), nil
+	}
+	return Rate{}, rateUnavailable(base, quote)
+}
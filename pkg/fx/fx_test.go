@@ -0,0 +1,83 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package fx - Tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package fx
+
+import (
+	"context"
+	stderrors "errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/fintech-bank-platform/pkg/currency"
+	"github.com/fintech-bank-platform/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var asOf = time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+
+func rate(base, quote, value string) Rate {
+	parsed, ok := ParseRate(value)
+	if !ok {
+		panic("invalid test rate " + value)
+	}
+	return Rate{Base: base, Quote: quote, Value: parsed, AsOf: asOf}
+}
+
+func TestParseRate(t *testing.T) {
+	value, ok := ParseRate(" 5.4321 ")
+	require.True(t, ok)
+	assert.Equal(t, big.NewRat(54321, 10000), value)
+
+	for _, invalid := range []string{"", "abc", "0", "-1.5"} {
+		_, ok := ParseRate(invalid)
+		assert.False(t, ok, invalid)
+	}
+}
+
+func TestFormatRate(t *testing.T) {
+	assert.Equal(t, "5.4321", FormatRate(big.NewRat(54321, 10000)))
+	assert.Equal(t, "2", FormatRate(big.NewRat(2, 1)))
+	assert.Equal(t, "0.3333333333", FormatRate(big.NewRat(1, 3)))
+}
+
+func TestRateInvert(t *testing.T) {
+	inverted := rate("USD", "BRL", "5").Invert()
+
+	assert.Equal(t, "BRL/USD", inverted.Pair())
+	assert.Equal(t, "0.2", FormatRate(inverted.Value))
+	assert.Equal(t, asOf, inverted.AsOf)
+}
+
+func TestConvertIsExact(t *testing.T) {
+	usd, jpy := currency.MustLookup("USD"), currency.MustLookup("JPY")
+
+	// 0.1 * 3 is 0.30000000000000004 in floating point
+	assert.Equal(t, 0.3, Convert(0.1, big.NewRat(3, 1), usd))
+	assert.Equal(t, 1.01, Convert(1.005, big.NewRat(1, 1), usd), "halves round away from zero")
+	assert.Equal(t, 18409.0, Convert(100.0, big.NewRat(18408575, 100000), jpy))
+	assert.Equal(t, 0.25, Convert(1, big.NewRat(1, 4), currency.MustLookup("XAU")))
+}
+
+func TestStaticSource(t *testing.T) {
+	source := NewStaticSource(rate("usd", "brl", "5.25"))
+	source.Set(rate("EUR", "BRL", "6"))
+
+	direct, err := source.Rate(context.Background(), "USD", "BRL")
+	require.NoError(t, err)
+	assert.Equal(t, "5.25", FormatRate(direct.Value))
+
+	inverse, err := source.Rate(context.Background(), "brl", "eur")
+	require.NoError(t, err)
+	assert.Equal(t, "BRL/EUR", inverse.Pair())
+	assert.Equal(t, "0.1666666667", FormatRate(inverse.Value))
+
+	_, err = source.Rate(context.Background(), "USD", "EUR")
+	assert.True(t, stderrors.Is(err, errors.ErrRateUnavailable))
+	appErr, _ := errors.AsAppError(err)
+	assert.Equal(t, "USD/EUR", appErr.Details["pair"])
+	assert.Nil(t, errors.ErrRateUnavailable.Details)
+}
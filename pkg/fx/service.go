@@ -0,0 +1,333 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package fx - Quotes
+// ═══════════════════════════════════════════════════════════════════════════
+
+package fx
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fintech-bank-platform/pkg/currency"
+	"github.com/fintech-bank-platform/pkg/errors"
+	"github.com/fintech-bank-platform/pkg/events"
+	"github.com/google/uuid"
+)
+
+// DefaultQuoteTTL is how long a quote can be used after it is issued
+const DefaultQuoteTTL = 30 * time.Second
+
+// basisPoints is the denominator of spreads (100 bps = 1%)
+const basisPoints = 10000
+
+// ═══════════════════════════════════════════════════════════════════════════
+// QUOTE
+// ═══════════════════════════════════════════════════════════════════════════
+
+// Quote is a firm offer to convert Amount of From into ConvertedAmount of To
+// until ExpiresAt. Rate is the mid rate minus the spread.
+type Quote struct {
+	ID              string    `json:"id"`
+	From            string    `json:"from"`
+	To              string    `json:"to"`
+	Amount          float64   `json:"amount"`
+	ConvertedAmount float64   `json:"converted_amount"`
+	MidRate         string    `json:"mid_rate"`
+	Rate            string    `json:"rate"`
+	SpreadBps       int64     `json:"spread_bps"`
+	RateAsOf        time.Time `json:"rate_as_of"`
+	CreatedAt       time.Time `json:"created_at"`
+	ExpiresAt       time.Time `json:"expires_at"`
+}
+
+// Expired checks if the quote can no longer be used at now
+func (q *Quote) Expired(now time.Time) bool {
+	return !now.Before(q.ExpiresAt)
+}
+
+// Conversion returns the record of a transfer executed with the quote
+func (q *Quote) Conversion() *events.FXConversion {
+	return &events.FXConversion{
+		QuoteID:        q.ID,
+		Rate:           q.Rate,
+		TargetAmount:   q.ConvertedAmount,
+		TargetCurrency: q.To,
+	}
+}
+
+// QuoteStore keeps issued quotes until they are used
+type QuoteStore interface {
+	Save(ctx context.Context, quote *Quote) error
+	// Get returns a quote without using it or errors.ErrQuoteNotFound
+	Get(ctx context.Context, id string) (*Quote, error)
+	// Take removes and returns a quote or errors.ErrQuoteNotFound
+	Take(ctx context.Context, id string) (*Quote, error)
+}
+
+// MemoryQuoteStore is an in-memory QuoteStore used in tests and local
+// development. Expired quotes are swept once there have been as many saves
+// as quotes were left by the previous sweep, which keeps Save amortised O(1)
+// and the map within twice the quotes still valid at the last sweep.
+type MemoryQuoteStore struct {
+	mu         sync.Mutex
+	quotes     map[string]*Quote
+	saves      int
+	sweepAfter int
+}
+
+// NewMemoryQuoteStore creates an empty in-memory quote store
+func NewMemoryQuoteStore() *MemoryQuoteStore {
+	return &MemoryQuoteStore{quotes: make(map[string]*Quote)}
+}
+
+// Save stores a copy of the quote
+func (s *MemoryQuoteStore) Save(_ context.Context, quote *Quote) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.saves++; s.saves >= s.sweepAfter {
+		s.sweep(quote.CreatedAt)
+	}
+	copied := *quote
+	s.quotes[quote.ID] = &copied
+	return nil
+}
+
+// sweep drops the quotes expired at now; s.mu must be held
+func (s *MemoryQuoteStore) sweep(now time.Time) {
+	for id, stored := range s.quotes {
+		if stored.Expired(now) {
+			delete(s.quotes, id)
+		}
+	}
+	s.saves, s.sweepAfter = 0, len(s.quotes)
+}
+
+// Get returns a copy of a quote, leaving it in the store
+func (s *MemoryQuoteStore) Get(_ context.Context, id string) (*Quote, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	quote, ok := s.quotes[id]
+	if !ok {
+		return nil, errors.ErrQuoteNotFound
+	}
+	copied := *quote
+	return &copied, nil
+}
+
+// Take removes and returns a quote
+func (s *MemoryQuoteStore) Take(_ context.Context, id string) (*Quote, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	quote, ok := s.quotes[id]
+	if !ok {
+		return nil, errors.ErrQuoteNotFound
+	}
+	delete(s.quotes, id)
+	return quote, nil
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// SERVICE
+// ═══════════════════════════════════════════════════════════════════════════
+
+// Service issues quotes from a rate Source and applies them to transfers
+type Service struct {
+	source      Source
+	quotes      QuoteStore
+	spread      int64
+	pairSpreads map[string]int64
+	ttl         time.Duration
+	maxRateAge  time.Duration
+	now         func() time.Time
+}
+
+// Option configures a Service
+type Option func(*Service)
+
+// WithSpread sets the spread, in basis points, taken from the mid rate
+func WithSpread(bps int64) Option {
+	return func(s *Service) {
+		s.spread = bps
+	}
+}
+
+// WithPairSpread overrides the spread of conversions from one currency to another
+func WithPairSpread(from, to string, bps int64) Option {
+	return func(s *Service) {
+		s.pairSpreads[pair(strings.ToUpper(from), strings.ToUpper(to))] = bps
+	}
+}
+
+// WithQuoteTTL sets how long quotes are valid (DefaultQuoteTTL by default)
+func WithQuoteTTL(ttl time.Duration) Option {
+	return func(s *Service) {
+		s.ttl = ttl
+	}
+}
+
+// WithMaxRateAge refuses rates older than maxAge; zero accepts any age
+func WithMaxRateAge(maxAge time.Duration) Option {
+	return func(s *Service) {
+		s.maxRateAge = maxAge
+	}
+}
+
+// WithQuoteStore sets where quotes are kept (in memory by default)
+func WithQuoteStore(store QuoteStore) Option {
+	return func(s *Service) {
+		s.quotes = store
+	}
+}
+
+// WithClock sets the clock used to issue and expire quotes
+func WithClock(now func() time.Time) Option {
+	return func(s *Service) {
+		s.now = now
+	}
+}
+
+// NewService creates a quote service over source
+func NewService(source Source, opts ...Option) *Service {
+	s := &Service{
+		source:      source,
+		quotes:      NewMemoryQuoteStore(),
+		pairSpreads: make(map[string]int64),
+		ttl:         DefaultQuoteTTL,
+		now:         func() time.Time { return time.Now().UTC() },
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Quote prices the conversion of amount from one supported currency to
+// another and keeps the quote until it expires or is redeemed
+func (s *Service) Quote(ctx context.Context, from, to string, amount float64) (*Quote, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	for _, code := range []string{from, to} {
+		if !currency.IsSupported(code) {
+			return nil, errors.BadRequest(errors.ErrCurrencyNotSupported.Code, errors.ErrCurrencyNotSupported.Message).
+				WithDetail("currency", code)
+		}
+	}
+	if from == to {
+		return nil, errors.BadRequest(errors.ErrInvalidField.Code, errors.ErrInvalidField.Message).
+			WithDetails(map[string]string{"field": "to", "reason": "same_currency"})
+	}
+	if amount <= 0 {
+		return nil, errors.ErrInvalidAmount
+	}
+
+	rate, err := s.source.Rate(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	if s.maxRateAge > 0 && now.Sub(rate.AsOf) > s.maxRateAge {
+		return nil, rateUnavailable(from, to).WithDetail("as_of", rate.AsOf.Format(time.RFC3339))
+	}
+
+	spread := s.spreadFor(from, to)
+	applied := new(big.Rat).Mul(rate.Value, big.NewRat(basisPoints-spread, basisPoints))
+	converted := Convert(amount, applied, currency.MustLookup(to))
+	if converted <= 0 {
+		return nil, errors.ErrInvalidAmount
+	}
+
+	quote := &Quote{
+		ID:              uuid.NewString(),
+		From:            from,
+		To:              to,
+		Amount:          amount,
+		ConvertedAmount: converted,
+		MidRate:         FormatRate(rate.Value),
+		Rate:            FormatRate(applied),
+		SpreadBps:       spread,
+		RateAsOf:        rate.AsOf,
+		CreatedAt:       now,
+		ExpiresAt:       now.Add(s.ttl),
+	}
+	if err := s.quotes.Save(ctx, quote); err != nil {
+		return nil, err
+	}
+	return quote, nil
+}
+
+// Redeem uses a quote. Quotes are single use: a redeemed or expired quote
+// cannot be redeemed again.
+func (s *Service) Redeem(ctx context.Context, id string) (*Quote, error) {
+	quote, err := s.quotes.Take(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if quote.Expired(s.now()) {
+		return nil, errors.ErrQuoteExpired
+	}
+	return quote, nil
+}
+
+// ApplyQuote redeems a quote for transfer, which must debit the quoted
+// amount and currency, and records the conversion in transfer.FX. The quote
+// is checked against transfer before it is redeemed, so a mismatched
+// transfer leaves it usable.
+func (s *Service) ApplyQuote(ctx context.Context, id string, transfer *events.ProcessTransferPayload) error {
+	quote, err := s.quotes.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	source := currency.MustLookup(quote.From)
+	if quote.From != strings.ToUpper(transfer.Currency) || source.Decimal(quote.Amount) != source.Decimal(transfer.Amount) {
+		return errors.ErrQuoteMismatch
+	}
+	if quote, err = s.Redeem(ctx, id); err != nil {
+		return err
+	}
+
+	transfer.FX = quote.Conversion()
+	return nil
+}
+
+// Guard applies the quote named by fx.quote_id to ProcessTransfer commands
+// before next handles them, so the transfer saga credits the quoted amount
+// whatever rate the client sent. next receives a copy of the command with
+// the conversion of the quote; cross-currency transfers without a quote ID
+// are rejected. Other commands pass through untouched.
+func (s *Service) Guard(next events.Handler) events.Handler {
+	return func(ctx context.Context, event *events.Event) error {
+		if event.Type != events.EventTypes.ProcessTransfer {
+			return next(ctx, event)
+		}
+
+		var transfer events.ProcessTransferPayload
+		if err := event.DecodePayload(&transfer); err != nil {
+			return err
+		}
+		if transfer.FX == nil {
+			return next(ctx, event)
+		}
+		if transfer.FX.QuoteID == "" {
+			return errors.BadRequest(errors.ErrMissingField.Code, errors.ErrMissingField.Message).WithDetail("field", "fx.quote_id")
+		}
+		if err := s.ApplyQuote(ctx, transfer.FX.QuoteID, &transfer); err != nil {
+			return err
+		}
+
+		converted := *event
+		converted.Payload = transfer
+		return next(ctx, &converted)
+	}
+}
+
+func (s *Service) spreadFor(from, to string) int64 {
+	if spread, ok := s.pairSpreads[pair(from, to)]; ok {
+		return spread
+	}
+	return s.spread
+}
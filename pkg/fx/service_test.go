@@ -0,0 +1,281 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package fx - Quote tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package fx
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fintech-bank-platform/pkg/errors"
+	"github.com/fintech-bank-platform/pkg/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func newService(t *testing.T, opts ...Option) (*Service, *testClock) {
+	t.Helper()
+	clock := &testClock{now: asOf.Add(time.Minute)}
+	source := NewStaticSource(rate("USD", "BRL", "5"), rate("EUR", "BRL", "6"))
+	return NewService(source, append([]Option{WithClock(clock.Now)}, opts...)...), clock
+}
+
+func TestQuoteAppliesSpread(t *testing.T) {
+	service, clock := newService(t, WithSpread(100), WithQuoteTTL(time.Minute))
+
+	quote, err := service.Quote(context.Background(), "brl", "usd", 1000)
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, quote.ID)
+	assert.Equal(t, "BRL", quote.From)
+	assert.Equal(t, "USD", quote.To)
+	assert.Equal(t, "0.2", quote.MidRate)
+	assert.Equal(t, "0.198", quote.Rate)
+	assert.Equal(t, int64(100), quote.SpreadBps)
+	assert.Equal(t, 198.0, quote.ConvertedAmount)
+	assert.Equal(t, asOf, quote.RateAsOf)
+	assert.Equal(t, clock.now, quote.CreatedAt)
+	assert.Equal(t, clock.now.Add(time.Minute), quote.ExpiresAt)
+}
+
+func TestServiceDefaults(t *testing.T) {
+	service := NewService(NewStaticSource(rate("USD", "BRL", "5")))
+
+	quote, err := service.Quote(context.Background(), "USD", "BRL", 100)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), quote.SpreadBps)
+	assert.Equal(t, 500.0, quote.ConvertedAmount)
+	assert.Equal(t, DefaultQuoteTTL, quote.ExpiresAt.Sub(quote.CreatedAt))
+	assert.WithinDuration(t, time.Now(), quote.CreatedAt, time.Minute)
+}
+
+func TestQuotePairSpread(t *testing.T) {
+	service, _ := newService(t, WithSpread(100), WithPairSpread("usd", "brl", 50))
+
+	quote, err := service.Quote(context.Background(), "USD", "BRL", 100)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(50), quote.SpreadBps)
+	assert.Equal(t, "4.975", quote.Rate)
+	assert.Equal(t, 497.5, quote.ConvertedAmount)
+}
+
+func TestQuoteRejectsInvalidRequests(t *testing.T) {
+	service, _ := newService(t)
+
+	_, err := service.Quote(context.Background(), "BRL", "CHF", 100)
+	assert.True(t, stderrors.Is(err, errors.ErrCurrencyNotSupported))
+	appErr, _ := errors.AsAppError(err)
+	assert.Equal(t, "CHF", appErr.Details["currency"])
+
+	_, err = service.Quote(context.Background(), "BRL", "brl", 100)
+	assert.True(t, stderrors.Is(err, errors.ErrInvalidField))
+
+	_, err = service.Quote(context.Background(), "BRL", "USD", 0)
+	assert.Equal(t, errors.ErrInvalidAmount, err)
+
+	_, err = service.Quote(context.Background(), "BRL", "USD", 0.01)
+	assert.Equal(t, errors.ErrInvalidAmount, err, "converts to less than one cent")
+
+	_, err = service.Quote(context.Background(), "USD", "GBP", 100)
+	assert.True(t, stderrors.Is(err, errors.ErrRateUnavailable))
+}
+
+func TestQuoteRejectsStaleRates(t *testing.T) {
+	service, clock := newService(t, WithMaxRateAge(time.Hour))
+
+	_, err := service.Quote(context.Background(), "USD", "BRL", 100)
+	require.NoError(t, err)
+
+	clock.now = asOf.Add(2 * time.Hour)
+	_, err = service.Quote(context.Background(), "USD", "BRL", 100)
+	assert.True(t, stderrors.Is(err, errors.ErrRateUnavailable))
+	appErr, _ := errors.AsAppError(err)
+	assert.Equal(t, "2026-10-19T12:00:00Z", appErr.Details["as_of"])
+}
+
+type failingQuoteStore struct {
+	*MemoryQuoteStore
+}
+
+func (failingQuoteStore) Save(context.Context, *Quote) error {
+	return errors.ErrDatabaseError
+}
+
+func TestQuoteStoreError(t *testing.T) {
+	service, _ := newService(t, WithQuoteStore(failingQuoteStore{NewMemoryQuoteStore()}))
+
+	_, err := service.Quote(context.Background(), "USD", "BRL", 100)
+	assert.Equal(t, errors.ErrDatabaseError, err)
+}
+
+func TestRedeemIsSingleUse(t *testing.T) {
+	service, _ := newService(t)
+	quote, err := service.Quote(context.Background(), "USD", "BRL", 100)
+	require.NoError(t, err)
+
+	redeemed, err := service.Redeem(context.Background(), quote.ID)
+	require.NoError(t, err)
+	assert.Equal(t, quote, redeemed)
+
+	_, err = service.Redeem(context.Background(), quote.ID)
+	assert.Equal(t, errors.ErrQuoteNotFound, err)
+}
+
+func TestRedeemExpiredQuote(t *testing.T) {
+	service, clock := newService(t, WithQuoteTTL(10*time.Second))
+	quote, err := service.Quote(context.Background(), "USD", "BRL", 100)
+	require.NoError(t, err)
+
+	clock.now = quote.ExpiresAt
+	_, err = service.Redeem(context.Background(), quote.ID)
+	assert.Equal(t, errors.ErrQuoteExpired, err)
+}
+
+func TestApplyQuoteRecordsConversion(t *testing.T) {
+	service, _ := newService(t, WithSpread(20))
+	quote, err := service.Quote(context.Background(), "BRL", "EUR", 600)
+	require.NoError(t, err)
+	transfer := events.ProcessTransferPayload{FromAccountID: "acc-1", ToAccountID: "acc-2", Amount: 600, Currency: "BRL"}
+
+	require.NoError(t, service.ApplyQuote(context.Background(), quote.ID, &transfer))
+
+	assert.Equal(t, &events.FXConversion{QuoteID: quote.ID, Rate: "0.1663333333", TargetAmount: 99.8, TargetCurrency: "EUR"}, transfer.FX)
+	amount, code := transfer.Credited()
+	assert.Equal(t, 99.8, amount)
+	assert.Equal(t, "EUR", code)
+}
+
+func TestApplyQuoteExpired(t *testing.T) {
+	service, clock := newService(t, WithQuoteTTL(10*time.Second))
+	quote, err := service.Quote(context.Background(), "USD", "BRL", 100)
+	require.NoError(t, err)
+
+	clock.now = quote.ExpiresAt
+	assert.Equal(t, errors.ErrQuoteExpired, service.ApplyQuote(context.Background(), quote.ID, &events.ProcessTransferPayload{Amount: 100, Currency: "USD"}))
+}
+
+func TestApplyQuoteMismatch(t *testing.T) {
+	service, _ := newService(t)
+
+	for name, transfer := range map[string]events.ProcessTransferPayload{
+		"currency": {Amount: 100, Currency: "EUR"},
+		"amount":   {Amount: 100.01, Currency: "USD"},
+	} {
+		quote, err := service.Quote(context.Background(), "USD", "BRL", 100)
+		require.NoError(t, err)
+
+		assert.Equal(t, errors.ErrQuoteMismatch, service.ApplyQuote(context.Background(), quote.ID, &transfer), name)
+		assert.Nil(t, transfer.FX)
+		_, err = service.Redeem(context.Background(), quote.ID)
+		assert.NoError(t, err, "a mismatched transfer leaves the quote usable")
+	}
+
+	assert.Equal(t, errors.ErrQuoteNotFound, service.ApplyQuote(context.Background(), "missing", &events.ProcessTransferPayload{}))
+}
+
+func TestMemoryQuoteStoreDropsExpiredQuotes(t *testing.T) {
+	store := NewMemoryQuoteStore()
+	require.NoError(t, store.Save(context.Background(), &Quote{ID: "old", CreatedAt: asOf, ExpiresAt: asOf.Add(time.Second)}))
+	require.NoError(t, store.Save(context.Background(), &Quote{ID: "new", CreatedAt: asOf.Add(time.Minute), ExpiresAt: asOf.Add(2 * time.Minute)}))
+
+	_, err := store.Take(context.Background(), "old")
+	assert.Equal(t, errors.ErrQuoteNotFound, err)
+	quote, err := store.Get(context.Background(), "new")
+	require.NoError(t, err)
+	quote.Rate = "changed"
+	_, err = store.Take(context.Background(), "new")
+	assert.NoError(t, err)
+	_, err = store.Get(context.Background(), "new")
+	assert.Equal(t, errors.ErrQuoteNotFound, err)
+}
+
+func TestMemoryQuoteStoreSweepsAmortised(t *testing.T) {
+	store := NewMemoryQuoteStore()
+	for n := range 8 {
+		require.NoError(t, store.Save(context.Background(), &Quote{ID: fmt.Sprint("valid-", n), CreatedAt: asOf, ExpiresAt: asOf.Add(time.Hour)}))
+	}
+	sweepAfter := store.sweepAfter
+
+	for n := range 100 {
+		createdAt := asOf.Add(time.Duration(n) * time.Minute)
+		require.NoError(t, store.Save(context.Background(), &Quote{ID: fmt.Sprint("short-", n), CreatedAt: createdAt, ExpiresAt: createdAt.Add(time.Second)}))
+		assert.LessOrEqual(t, len(store.quotes), 2*8+1, "expired quotes are swept")
+	}
+	assert.Greater(t, sweepAfter, 1, "saves do not sweep every time")
+}
+
+func TestGuardAppliesQuote(t *testing.T) {
+	service, _ := newService(t, WithSpread(20))
+	quote, err := service.Quote(context.Background(), "BRL", "EUR", 600)
+	require.NoError(t, err)
+	var handled []*events.Event
+	guard := service.Guard(func(_ context.Context, event *events.Event) error {
+		handled = append(handled, event)
+		return nil
+	})
+	command := events.NewTransactionCommand(events.EventTypes.ProcessTransfer, events.ProcessTransferPayload{
+		FromAccountID: "acc-1", ToAccountID: "acc-2", Amount: 600, Currency: "BRL",
+		FX: &events.FXConversion{QuoteID: quote.ID, Rate: "1", TargetAmount: 600, TargetCurrency: "EUR"},
+	})
+
+	require.NoError(t, guard(context.Background(), command))
+
+	require.Len(t, handled, 1)
+	assert.NotSame(t, command, handled[0], "the caller's command is left untouched")
+	assert.Equal(t, command.ID, handled[0].ID)
+	var transfer events.ProcessTransferPayload
+	require.NoError(t, handled[0].DecodePayload(&transfer))
+	assert.Equal(t, &events.FXConversion{QuoteID: quote.ID, Rate: "0.1663333333", TargetAmount: 99.8, TargetCurrency: "EUR"}, transfer.FX)
+
+	assert.Equal(t, errors.ErrQuoteNotFound, guard(context.Background(), command), "quotes are single use")
+	assert.Len(t, handled, 1)
+}
+
+func TestGuardPassesOtherCommands(t *testing.T) {
+	service, _ := newService(t)
+	var handled []*events.Event
+	guard := service.Guard(func(_ context.Context, event *events.Event) error {
+		handled = append(handled, event)
+		return nil
+	})
+	payment := events.NewPaymentCommand(events.EventTypes.ProcessPayment, events.ProcessPaymentPayload{AccountID: "acc-1", Amount: 10})
+	local := events.NewTransactionCommand(events.EventTypes.ProcessTransfer, events.ProcessTransferPayload{
+		FromAccountID: "acc-1", ToAccountID: "acc-2", Amount: 10, Currency: "BRL",
+	})
+
+	require.NoError(t, guard(context.Background(), payment))
+	require.NoError(t, guard(context.Background(), local))
+
+	assert.Equal(t, []*events.Event{payment, local}, handled)
+}
+
+func TestGuardRejectsTransfersWithoutQuote(t *testing.T) {
+	service, _ := newService(t)
+	guard := service.Guard(func(context.Context, *events.Event) error {
+		t.Fatal("next must not run")
+		return nil
+	})
+
+	err := guard(context.Background(), events.NewTransactionCommand(events.EventTypes.ProcessTransfer, events.ProcessTransferPayload{
+		Amount: 10, Currency: "BRL", FX: &events.FXConversion{Rate: "0.2", TargetAmount: 2, TargetCurrency: "USD"},
+	}))
+	assert.ErrorIs(t, err, errors.ErrMissingField)
+
+	assert.Error(t, guard(context.Background(), events.NewTransactionCommand(events.EventTypes.ProcessTransfer, "text")))
+	assert.Equal(t, errors.ErrQuoteNotFound, guard(context.Background(), events.NewTransactionCommand(events.EventTypes.ProcessTransfer,
+		events.ProcessTransferPayload{Amount: 10, Currency: "BRL", FX: &events.FXConversion{QuoteID: "missing"}})))
+}
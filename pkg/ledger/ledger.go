@@ -0,0 +1,120 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package ledger - Double-entry ledger movements
+// ═══════════════════════════════════════════════════════════════════════════
+
+package ledger
+
+import (
+	"math"
+
+	"github.com/fintech-bank-platform/pkg/currency"
+)
+
+// Direction represents the side of a ledger entry
+type Direction string
+
+const (
+	Debit  Direction = "debit"
+	Credit Direction = "credit"
+)
+
+// Opposite returns the other side of the entry
+func (d Direction) Opposite() Direction {
+	if d == Debit {
+		return Credit
+	}
+	return Debit
+}
+
+// Entry represents a single ledger movement on an account
+type Entry struct {
+	AccountID   string    `json:"account_id"`
+	Direction   Direction `json:"direction"`
+	Amount      float64   `json:"amount"`
+	Currency    string    `json:"currency"`
+	Reference   string    `json:"reference"`
+	Description string    `json:"description,omitempty"`
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// MOVEMENTS
+// ═══════════════════════════════════════════════════════════════════════════
+
+// NewTransfer creates the balanced pair of entries moving amount from one account to another
+func NewTransfer(fromAccountID, toAccountID string, amount float64, currency, reference string) []Entry {
+	return []Entry{
+		{AccountID: fromAccountID, Direction: Debit, Amount: amount, Currency: currency, Reference: reference},
+		{AccountID: toAccountID, Direction: Credit, Amount: amount, Currency: currency, Reference: reference},
+	}
+}
+
+// FXPositionAccount is the platform account that buys and sells currencies
+// on cross-currency movements
+const FXPositionAccount = "fx-position"
+
+// NewConversion creates the entries of a cross-currency transfer: the source
+// amount goes to the FX position and the position pays the destination in
+// the target currency, keeping each currency balanced
+func NewConversion(fromAccountID, toAccountID string, amount float64, currency string, targetAmount float64, targetCurrency, reference string) []Entry {
+	return append(
+		NewTransfer(fromAccountID, FXPositionAccount, amount, currency, reference),
+		NewTransfer(FXPositionAccount, toAccountID, targetAmount, targetCurrency, reference)...,
+	)
+}
+
+// Compensate creates the entries that exactly undo the given movements.
+// Entries are emitted in reverse order with opposite directions.
+func Compensate(entries []Entry, reference string) []Entry {
+	compensating := make([]Entry, 0, len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		entry.Direction = entry.Direction.Opposite()
+		entry.Reference = reference
+		compensating = append(compensating, entry)
+	}
+	return compensating
+}
+
+// IsBalanced checks that debits equal credits for every currency
+func IsBalanced(entries []Entry) bool {
+	totals := make(map[string]int64)
+	for _, entry := range entries {
+		amount := ToMinor(entry.Amount, entry.Currency)
+		if entry.Direction == Debit {
+			amount = -amount
+		}
+		totals[entry.Currency] += amount
+	}
+
+	for _, total := range totals {
+		if total != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// AMOUNT HELPERS
+// ═══════════════════════════════════════════════════════════════════════════
+
+// ToMinor converts an amount to the minor units of a currency (cents for
+// BRL, fils for KWD, yen for JPY), rounding half away from zero
+func ToMinor(amount float64, code string) int64 {
+	return int64(math.Round(amount * minorScale(code)))
+}
+
+// FromMinor converts minor units of a currency back to an amount
+func FromMinor(minor int64, code string) float64 {
+	return float64(minor) / minorScale(code)
+}
+
+// minorScale is 10^minor units of the currency. Unknown currencies and
+// those without minor units (funds and metals) use two decimals.
+func minorScale(code string) float64 {
+	c, ok := currency.Lookup(code)
+	if !ok || c.MinorUnits == currency.NoMinorUnits {
+		return 100
+	}
+	return math.Pow10(c.MinorUnits)
+}
@@ -0,0 +1,98 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package ledger - Tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package ledger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDirectionOpposite(t *testing.T) {
+	assert.Equal(t, Credit, Debit.Opposite())
+	assert.Equal(t, Debit, Credit.Opposite())
+}
+
+func TestNewTransfer(t *testing.T) {
+	entries := NewTransfer("acc-1", "acc-2", 100.50, "BRL", "txn-1")
+
+	assert.Len(t, entries, 2)
+	assert.Equal(t, Entry{AccountID: "acc-1", Direction: Debit, Amount: 100.50, Currency: "BRL", Reference: "txn-1"}, entries[0])
+	assert.Equal(t, Entry{AccountID: "acc-2", Direction: Credit, Amount: 100.50, Currency: "BRL", Reference: "txn-1"}, entries[1])
+	assert.True(t, IsBalanced(entries))
+}
+
+func TestNewConversion(t *testing.T) {
+	entries := NewConversion("acc-1", "acc-2", 100, "BRL", 18.25, "USD", "txn-1")
+
+	assert.Equal(t, []Entry{
+		{AccountID: "acc-1", Direction: Debit, Amount: 100, Currency: "BRL", Reference: "txn-1"},
+		{AccountID: FXPositionAccount, Direction: Credit, Amount: 100, Currency: "BRL", Reference: "txn-1"},
+		{AccountID: FXPositionAccount, Direction: Debit, Amount: 18.25, Currency: "USD", Reference: "txn-1"},
+		{AccountID: "acc-2", Direction: Credit, Amount: 18.25, Currency: "USD", Reference: "txn-1"},
+	}, entries)
+	assert.True(t, IsBalanced(entries))
+	assert.True(t, IsBalanced(append(entries, Compensate(entries, "rev-1")...)))
+}
+
+func TestCompensate(t *testing.T) {
+	original := NewTransfer("acc-1", "acc-2", 10, "BRL", "txn-1")
+
+	compensating := Compensate(original, "rev-1")
+
+	assert.Len(t, compensating, 2)
+	assert.Equal(t, "acc-2", compensating[0].AccountID)
+	assert.Equal(t, Debit, compensating[0].Direction)
+	assert.Equal(t, "acc-1", compensating[1].AccountID)
+	assert.Equal(t, Credit, compensating[1].Direction)
+	assert.Equal(t, "rev-1", compensating[0].Reference)
+	assert.Equal(t, "txn-1", original[0].Reference, "original entries must not be modified")
+	assert.True(t, IsBalanced(append(original, compensating...)))
+}
+
+func TestIsBalanced(t *testing.T) {
+	tests := []struct {
+		name     string
+		entries  []Entry
+		expected bool
+	}{
+		{"empty", nil, true},
+		{"balanced", NewTransfer("a", "b", 0.1+0.2, "BRL", "r"), true},
+		{"single debit", []Entry{{AccountID: "a", Direction: Debit, Amount: 1, Currency: "BRL"}}, false},
+		{"different currencies", []Entry{
+			{AccountID: "a", Direction: Debit, Amount: 1, Currency: "BRL"},
+			{AccountID: "b", Direction: Credit, Amount: 1, Currency: "USD"},
+		}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsBalanced(tt.entries))
+		})
+	}
+}
+
+func TestMinorUnits(t *testing.T) {
+	assert.Equal(t, int64(30), ToMinor(0.1+0.2, "BRL"))
+	assert.Equal(t, int64(123456), ToMinor(1234.56, "BRL"))
+	assert.Equal(t, int64(-1), ToMinor(-0.005, "BRL"))
+	assert.Equal(t, 1234.56, FromMinor(123456, "BRL"))
+}
+
+func TestMinorUnitsFollowCurrency(t *testing.T) {
+	assert.Equal(t, int64(1235), ToMinor(1.235, "KWD"), "three decimals")
+	assert.Equal(t, 1.235, FromMinor(1235, "KWD"))
+	assert.Equal(t, int64(1500), ToMinor(1500, "JPY"), "no decimals")
+	assert.Equal(t, int64(1500), ToMinor(1499.6, "JPY"))
+	assert.Equal(t, 1500.0, FromMinor(1500, "JPY"))
+	assert.Equal(t, int64(1050), ToMinor(10.5, "XAU"), "two decimals without minor units")
+	assert.Equal(t, int64(1050), ToMinor(10.5, "???"), "two decimals for unknown currencies")
+
+	assert.True(t, IsBalanced(NewConversion("a", "b", 100, "BRL", 6.174, "KWD", "ref")))
+	assert.False(t, IsBalanced([]Entry{
+		{AccountID: "a", Direction: Debit, Amount: 1.234, Currency: "KWD"},
+		{AccountID: "b", Direction: Credit, Amount: 1.23, Currency: "KWD"},
+	}), "a fils difference unbalances")
+}
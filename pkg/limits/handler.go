@@ -0,0 +1,127 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package limits - Command pipeline integration
+// ═══════════════════════════════════════════════════════════════════════════
+
+package limits
+
+import (
+	"context"
+	stderrors "errors"
+	"strings"
+
+	"github.com/fintech-bank-platform/pkg/errors"
+	"github.com/fintech-bank-platform/pkg/events"
+)
+
+// ReservationFromEvent builds the reservation of a ProcessTransfer or
+// ProcessPayment command, keyed by the command ID. It returns nil for other
+// event types.
+//
+// Limits are kept in Currency, so commands in other currencies are rejected
+// with an errors.ErrCurrencyNotSupported copy; an empty currency is taken as
+// Currency.
+func ReservationFromEvent(event *events.Event) (*Reservation, error) {
+	r := &Reservation{ID: event.ID, At: event.Timestamp}
+	var currency string
+
+	switch event.Type {
+	case events.EventTypes.ProcessTransfer:
+		var cmd events.ProcessTransferPayload
+		if err := event.DecodePayload(&cmd); err != nil {
+			return nil, err
+		}
+		r.AccountID, r.Method, r.Amount = cmd.FromAccountID, MethodTransfer, cmd.Amount
+		currency = cmd.Currency
+	case events.EventTypes.ProcessPayment:
+		var cmd events.ProcessPaymentPayload
+		if err := event.DecodePayload(&cmd); err != nil {
+			return nil, err
+		}
+		r.AccountID, r.Method, r.Amount = cmd.AccountID, cmd.PaymentMethod, cmd.Amount
+		currency = cmd.Currency
+	default:
+		return nil, nil
+	}
+
+	if currency != "" && !strings.EqualFold(currency, Currency) {
+		return nil, errors.BadRequest(errors.ErrCurrencyNotSupported.Code, errors.ErrCurrencyNotSupported.Message).
+			WithDetail("currency", currency)
+	}
+	return r, nil
+}
+
+// Guard reserves the amount of transfer and payment commands before next
+// handles them, and releases it when next fails. Breaches stop the command
+// with an errors.ErrLimitExceeded copy. Other event types pass through.
+//
+// Services must reuse the command ID as the transfer, payment or
+// transaction ID so Handle can match the outcome events.
+func (m *Manager) Guard(next events.Handler) events.Handler {
+	return func(ctx context.Context, event *events.Event) error {
+		r, err := ReservationFromEvent(event)
+		if err != nil {
+			return err
+		}
+		if r == nil {
+			return next(ctx, event)
+		}
+
+		if err := m.Reserve(ctx, *r); err != nil {
+			return err
+		}
+		if err := next(ctx, event); err != nil {
+			return stderrors.Join(err, m.Release(ctx, r.AccountID, r.ID))
+		}
+		return nil
+	}
+}
+
+// Subscribe registers Handle for transaction and payment events
+func (m *Manager) Subscribe(sub events.Subscriber) {
+	sub.Subscribe(events.Topics.TransactionEvents, m.Handle)
+	sub.Subscribe(events.Topics.PaymentEvents, m.Handle)
+}
+
+// Handle consumes the reservation of completed operations and releases the
+// one of failed, cancelled or reversed operations
+func (m *Manager) Handle(ctx context.Context, event *events.Event) error {
+	switch event.Type {
+	case events.EventTypes.TransferCompleted:
+		var p events.TransferCompletedPayload
+		if err := event.DecodePayload(&p); err != nil {
+			return err
+		}
+		return m.Consume(ctx, p.FromAccountID, p.TransferID)
+	case events.EventTypes.TransferFailed:
+		var p events.TransferFailedPayload
+		if err := event.DecodePayload(&p); err != nil {
+			return err
+		}
+		return m.Release(ctx, p.FromAccountID, p.TransferID)
+	case events.EventTypes.PaymentCompleted:
+		var p events.PaymentCompletedPayload
+		if err := event.DecodePayload(&p); err != nil {
+			return err
+		}
+		return m.Consume(ctx, p.AccountID, p.PaymentID)
+	case events.EventTypes.PaymentFailed:
+		var p events.PaymentFailedPayload
+		if err := event.DecodePayload(&p); err != nil {
+			return err
+		}
+		return m.Release(ctx, p.AccountID, p.PaymentID)
+	case events.EventTypes.PaymentCancelled:
+		var p events.PaymentCancelledPayload
+		if err := event.DecodePayload(&p); err != nil {
+			return err
+		}
+		return m.Release(ctx, p.AccountID, p.PaymentID)
+	case events.EventTypes.TransactionReversed:
+		var p events.TransactionReversedPayload
+		if err := event.DecodePayload(&p); err != nil {
+			return err
+		}
+		return m.Release(ctx, p.AccountID, p.OriginalTransactionID)
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package limits - Pipeline Tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package limits
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	apperrors "github.com/fintech-bank-platform/pkg/errors"
+	"github.com/fintech-bank-platform/pkg/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pixCommand(amount float64) *events.Event {
+	event := events.NewPaymentCommand(events.EventTypes.ProcessPayment, events.ProcessPaymentPayload{
+		AccountID:     "acc-1",
+		PaymentMethod: MethodPIX,
+		Amount:        amount,
+		PixKey:        "loja@pix",
+	})
+	event.Timestamp = noon
+	return event
+}
+
+func TestReservationFromEvent(t *testing.T) {
+	transfer := events.NewTransactionCommand(events.EventTypes.ProcessTransfer, events.ProcessTransferPayload{
+		FromAccountID: "acc-1",
+		ToAccountID:   "acc-2",
+		Amount:        150,
+	})
+
+	r, err := ReservationFromEvent(transfer)
+	require.NoError(t, err)
+	assert.Equal(t, &Reservation{ID: transfer.ID, AccountID: "acc-1", Method: MethodTransfer, Amount: 150, At: transfer.Timestamp}, r)
+
+	payment := pixCommand(80)
+	r, err = ReservationFromEvent(payment)
+	require.NoError(t, err)
+	assert.Equal(t, &Reservation{ID: payment.ID, AccountID: "acc-1", Method: MethodPIX, Amount: 80, At: noon}, r)
+
+	r, err = ReservationFromEvent(events.NewAccountCommand(events.EventTypes.CreateAccount, nil))
+	assert.NoError(t, err)
+	assert.Nil(t, r)
+
+	brl := events.NewTransactionCommand(events.EventTypes.ProcessTransfer, events.ProcessTransferPayload{
+		FromAccountID: "acc-1", Amount: 150, Currency: "brl",
+	})
+	r, err = ReservationFromEvent(brl)
+	require.NoError(t, err)
+	assert.Equal(t, 150.0, r.Amount)
+
+	_, err = ReservationFromEvent(events.NewTransactionCommand(events.EventTypes.ProcessTransfer, "text"))
+	assert.Error(t, err)
+	_, err = ReservationFromEvent(events.NewPaymentCommand(events.EventTypes.ProcessPayment, "text"))
+	assert.Error(t, err)
+}
+
+func TestReservationFromEventRejectsOtherCurrencies(t *testing.T) {
+	for _, event := range []*events.Event{
+		events.NewTransactionCommand(events.EventTypes.ProcessTransfer, events.ProcessTransferPayload{
+			FromAccountID: "acc-1", ToAccountID: "acc-2", Amount: 150, Currency: "USD",
+		}),
+		events.NewPaymentCommand(events.EventTypes.ProcessPayment, events.ProcessPaymentPayload{
+			AccountID: "acc-1", PaymentMethod: MethodPIX, Amount: 80, Currency: "EUR",
+		}),
+	} {
+		r, err := ReservationFromEvent(event)
+
+		assert.Nil(t, r)
+		assert.ErrorIs(t, err, apperrors.ErrCurrencyNotSupported, event.Type)
+	}
+}
+
+func TestGuardRejectsOtherCurrencies(t *testing.T) {
+	now := noon
+	manager, store := newManager(&now)
+	ctx := context.Background()
+	handled := false
+	guard := manager.Guard(func(context.Context, *events.Event) error {
+		handled = true
+		return nil
+	})
+
+	err := guard(ctx, events.NewTransactionCommand(events.EventTypes.ProcessTransfer, events.ProcessTransferPayload{
+		FromAccountID: "acc-1", ToAccountID: "acc-2", Amount: 150, Currency: "USD",
+	}))
+
+	assert.ErrorIs(t, err, apperrors.ErrCurrencyNotSupported)
+	assert.False(t, handled)
+	reservations, _ := store.Reservations(ctx, "acc-1")
+	assert.Empty(t, reservations)
+}
+
+func TestGuard(t *testing.T) {
+	now := noon
+	manager, store := newManager(&now)
+	ctx := context.Background()
+	var handled []*events.Event
+	next := func(_ context.Context, event *events.Event) error {
+		handled = append(handled, event)
+		return nil
+	}
+	guard := manager.Guard(next)
+
+	require.NoError(t, guard(ctx, events.NewAccountCommand(events.EventTypes.CreateAccount, nil)))
+	require.NoError(t, guard(ctx, pixCommand(4000)))
+	assert.ErrorIs(t, guard(ctx, pixCommand(6000)), apperrors.ErrLimitExceeded)
+
+	assert.Len(t, handled, 2)
+	reservations, _ := store.Reservations(ctx, "acc-1")
+	assert.Len(t, reservations, 1)
+}
+
+func TestGuardReleasesWhenNextFails(t *testing.T) {
+	now := noon
+	manager, store := newManager(&now)
+	ctx := context.Background()
+	guard := manager.Guard(func(context.Context, *events.Event) error { return errors.New("debit failed") })
+
+	err := guard(ctx, pixCommand(100))
+
+	assert.ErrorContains(t, err, "debit failed")
+	reservations, _ := store.Reservations(ctx, "acc-1")
+	assert.Empty(t, reservations)
+}
+
+func TestGuardErrors(t *testing.T) {
+	now := noon
+	manager, _ := newManager(&now)
+	next := func(context.Context, *events.Event) error { return nil }
+
+	err := manager.Guard(next)(context.Background(), events.NewPaymentCommand(events.EventTypes.ProcessPayment, "text"))
+	assert.Error(t, err)
+}
+
+func TestHandleOutcomeEvents(t *testing.T) {
+	now := noon
+	manager, store := newManager(&now)
+	ctx := context.Background()
+	bus := events.NewMemoryBus()
+	manager.Subscribe(bus)
+
+	for _, id := range []string{"t-ok", "t-fail", "p-ok", "p-fail", "p-cancel", "txn"} {
+		require.NoError(t, manager.Reserve(ctx, reservation(id, 10, noon)))
+	}
+
+	published := []struct {
+		topic string
+		event *events.Event
+	}{
+		{events.Topics.TransactionEvents, events.NewTransactionEvent(events.EventTypes.TransferCompleted, events.TransferCompletedPayload{TransferID: "t-ok", FromAccountID: "acc-1"})},
+		{events.Topics.TransactionEvents, events.NewTransactionEvent(events.EventTypes.TransferFailed, events.TransferFailedPayload{TransferID: "t-fail", FromAccountID: "acc-1"})},
+		{events.Topics.PaymentEvents, events.NewPaymentEvent(events.EventTypes.PaymentCompleted, events.PaymentCompletedPayload{PaymentID: "p-ok", AccountID: "acc-1"})},
+		{events.Topics.PaymentEvents, events.NewPaymentEvent(events.EventTypes.PaymentFailed, events.PaymentFailedPayload{PaymentID: "p-fail", AccountID: "acc-1"})},
+		{events.Topics.PaymentEvents, events.NewPaymentEvent(events.EventTypes.PaymentCancelled, events.PaymentCancelledPayload{PaymentID: "p-cancel", AccountID: "acc-1"})},
+		{events.Topics.TransactionEvents, events.NewTransactionEvent(events.EventTypes.TransactionReversed, events.TransactionReversedPayload{OriginalTransactionID: "txn", AccountID: "acc-1"})},
+		{events.Topics.PaymentEvents, events.NewPaymentEvent(events.EventTypes.PaymentProcessed, nil)},
+	}
+	for _, p := range published {
+		require.NoError(t, bus.Publish(ctx, p.topic, p.event))
+	}
+
+	reservations, _ := store.Reservations(ctx, "acc-1")
+	require.Len(t, reservations, 2)
+	for _, r := range reservations {
+		assert.Equal(t, StatusConsumed, r.Status, r.ID)
+	}
+	assert.ElementsMatch(t, []string{"t-ok", "p-ok"}, []string{reservations[0].ID, reservations[1].ID})
+}
+
+func TestHandleDecodeErrors(t *testing.T) {
+	manager := NewManager(NewMemoryStore(), NewMemoryStore(), WithClock(func() time.Time { return noon }))
+
+	for _, eventType := range []string{
+		events.EventTypes.TransferCompleted,
+		events.EventTypes.TransferFailed,
+		events.EventTypes.PaymentCompleted,
+		events.EventTypes.PaymentFailed,
+		events.EventTypes.PaymentCancelled,
+		events.EventTypes.TransactionReversed,
+	} {
+		err := manager.Handle(context.Background(), events.NewTransactionEvent(eventType, "text"))
+		assert.Error(t, err, eventType)
+	}
+}
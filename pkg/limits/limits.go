@@ -0,0 +1,540 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package limits - Per-account transaction limits
+// ═══════════════════════════════════════════════════════════════════════════
+
+package limits
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/fintech-bank-platform/pkg/calendar"
+	"github.com/fintech-bank-platform/pkg/errors"
+	"github.com/fintech-bank-platform/pkg/ledger"
+	"github.com/google/uuid"
+)
+
+// Methods with their own policies. Payments use their payment method and
+// transfers between accounts use MethodTransfer; MethodAny is the fallback.
+const (
+	MethodPIX      = "pix"
+	MethodTED      = "ted"
+	MethodBoleto   = "boleto"
+	MethodTransfer = "transfer"
+	MethodAny      = "*"
+)
+
+// Defaults of the night window (20:00–06:00, as required for PIX) and of
+// the waiting period before a limit increase takes effect
+const (
+	DefaultNightStart    = 20
+	DefaultNightEnd      = 6
+	DefaultIncreaseDelay = 24 * time.Hour
+)
+
+// Currency of limits and reservations, which are kept in reais
+const Currency = "BRL"
+
+// ═══════════════════════════════════════════════════════════════════════════
+// POLICY
+// ═══════════════════════════════════════════════════════════════════════════
+
+// Kind identifies one of the limits of a policy
+type Kind string
+
+const (
+	KindPerTransaction Kind = "per_transaction"
+	KindDaily          Kind = "daily"
+	KindMonthly        Kind = "monthly"
+	KindNight          Kind = "night"
+)
+
+// Policy holds the limits of one method. Zero means unlimited.
+type Policy struct {
+	PerTransaction float64 `json:"per_transaction"`
+	Daily          float64 `json:"daily"`
+	Monthly        float64 `json:"monthly"`
+	Night          float64 `json:"night"`
+}
+
+// DefaultPolicies returns the policies applied to accounts without overrides
+func DefaultPolicies() map[string]Policy {
+	return map[string]Policy{
+		MethodPIX:      {PerTransaction: 5000, Daily: 20000, Monthly: 100000, Night: 1000},
+		MethodTED:      {PerTransaction: 20000, Daily: 50000, Monthly: 200000, Night: 1000},
+		MethodBoleto:   {PerTransaction: 10000, Daily: 30000, Monthly: 150000},
+		MethodTransfer: {PerTransaction: 20000, Daily: 50000, Monthly: 200000},
+		MethodAny:      {PerTransaction: 5000, Daily: 20000, Monthly: 100000, Night: 1000},
+	}
+}
+
+// Validate rejects negative limits
+func (p Policy) Validate() error {
+	for kind, limit := range p.limits() {
+		if limit < 0 {
+			return errors.BadRequest(errors.ErrInvalidField.Code, fmt.Sprintf("%s limit must not be negative", kind))
+		}
+	}
+	return nil
+}
+
+// Lowers reports whether every limit of p is at most the same limit of
+// current, i.e. applying p never lets the account move more money
+func (p Policy) Lowers(current Policy) bool {
+	requested := p.limits()
+	for kind, limit := range current.limits() {
+		if limit == 0 {
+			continue
+		}
+		if requested[kind] == 0 || ledger.ToMinor(requested[kind], Currency) > ledger.ToMinor(limit, Currency) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p Policy) limits() map[Kind]float64 {
+	return map[Kind]float64{
+		KindPerTransaction: p.PerTransaction,
+		KindDaily:          p.Daily,
+		KindMonthly:        p.Monthly,
+		KindNight:          p.Night,
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// RESERVATIONS & USAGE
+// ═══════════════════════════════════════════════════════════════════════════
+
+// ReservationStatus is the state of a reservation
+type ReservationStatus string
+
+const (
+	StatusReserved ReservationStatus = "reserved"
+	StatusConsumed ReservationStatus = "consumed"
+)
+
+// Reservation holds an amount against the account limits while the
+// operation runs. Both reserved and consumed amounts count as used.
+type Reservation struct {
+	ID        string            `json:"id"`
+	AccountID string            `json:"account_id"`
+	Method    string            `json:"method"`
+	Amount    float64           `json:"amount"`
+	Status    ReservationStatus `json:"status"`
+	At        time.Time         `json:"at"`
+}
+
+// Usage is the amount used in each window of a method
+type Usage struct {
+	Daily   float64 `json:"daily"`
+	Monthly float64 `json:"monthly"`
+	Night   float64 `json:"night"`
+}
+
+// Limits is the effective policy and usage of one method
+type Limits struct {
+	Method string `json:"method"`
+	Policy Policy `json:"policy"`
+	Usage  Usage  `json:"usage"`
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// CHANGE REQUESTS
+// ═══════════════════════════════════════════════════════════════════════════
+
+// ChangeStatus is the state of a limit change request
+type ChangeStatus string
+
+const (
+	ChangePending   ChangeStatus = "pending"
+	ChangeApplied   ChangeStatus = "applied"
+	ChangeCancelled ChangeStatus = "cancelled"
+)
+
+// ChangeRequest asks for new limits on a method. Decreases apply at once;
+// increases wait for EffectiveAt.
+type ChangeRequest struct {
+	ID          string       `json:"id"`
+	AccountID   string       `json:"account_id"`
+	Method      string       `json:"method"`
+	Policy      Policy       `json:"policy"`
+	Status      ChangeStatus `json:"status"`
+	RequestedAt time.Time    `json:"requested_at"`
+	EffectiveAt time.Time    `json:"effective_at"`
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// MANAGER
+// ═══════════════════════════════════════════════════════════════════════════
+
+// Manager checks, reserves and releases amounts against account limits
+type Manager struct {
+	store         Store
+	policies      PolicyStore
+	defaults      map[string]Policy
+	location      *time.Location
+	nightStart    int
+	nightEnd      int
+	increaseDelay time.Duration
+	now           func() time.Time
+}
+
+// Option configures a Manager
+type Option func(*Manager)
+
+// WithDefaults replaces the default policies
+func WithDefaults(defaults map[string]Policy) Option {
+	return func(m *Manager) {
+		m.defaults = defaults
+	}
+}
+
+// WithNightWindow sets the night window hours; it wraps past midnight when
+// start is after end
+func WithNightWindow(start, end int) Option {
+	return func(m *Manager) {
+		m.nightStart = start
+		m.nightEnd = end
+	}
+}
+
+// WithLocation sets the time zone of the daily, monthly and night windows
+func WithLocation(loc *time.Location) Option {
+	return func(m *Manager) {
+		m.location = loc
+	}
+}
+
+// WithIncreaseDelay sets how long limit increases wait before applying
+func WithIncreaseDelay(delay time.Duration) Option {
+	return func(m *Manager) {
+		m.increaseDelay = delay
+	}
+}
+
+// WithClock sets the clock used for change requests and reservations
+// without a timestamp
+func WithClock(now func() time.Time) Option {
+	return func(m *Manager) {
+		m.now = now
+	}
+}
+
+// NewManager creates a manager with the default policies in the banking
+// calendar time zone
+func NewManager(store Store, policies PolicyStore, opts ...Option) *Manager {
+	m := &Manager{
+		store:         store,
+		policies:      policies,
+		defaults:      DefaultPolicies(),
+		location:      calendar.Default().Location(),
+		nightStart:    DefaultNightStart,
+		nightEnd:      DefaultNightEnd,
+		increaseDelay: DefaultIncreaseDelay,
+		now:           time.Now,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Policy returns the effective policy of an account for a method, applying
+// change requests that became effective
+func (m *Manager) Policy(ctx context.Context, accountID, method string) (Policy, error) {
+	if _, err := m.applyDueChanges(ctx, accountID); err != nil {
+		return Policy{}, err
+	}
+	return m.resolve(ctx, accountID, method)
+}
+
+func (m *Manager) resolve(ctx context.Context, accountID, method string) (Policy, error) {
+	override, err := m.policies.Policy(ctx, accountID, method)
+	if err != nil {
+		return Policy{}, err
+	}
+	if override != nil {
+		return *override, nil
+	}
+	if policy, ok := m.defaults[method]; ok {
+		return policy, nil
+	}
+	return m.defaults[MethodAny], nil
+}
+
+// Limits returns the policy and usage of each method with a default policy,
+// sorted by method
+func (m *Manager) Limits(ctx context.Context, accountID string) ([]Limits, error) {
+	reservations, err := m.store.Reservations(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	methods := make([]string, 0, len(m.defaults))
+	for method := range m.defaults {
+		if method != MethodAny {
+			methods = append(methods, method)
+		}
+	}
+	sort.Strings(methods)
+
+	now := m.now()
+	result := make([]Limits, 0, len(methods))
+	for _, method := range methods {
+		policy, err := m.Policy(ctx, accountID, method)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, Limits{
+			Method: method,
+			Policy: policy,
+			Usage:  m.usage(reservations, method, now),
+		})
+	}
+	return result, nil
+}
+
+// Reserve atomically checks the amount against the account limits and holds
+// it under r.ID. Reserving an ID that is already held is a no-op. Breaches
+// return an errors.ErrLimitExceeded copy detailing the limit.
+func (m *Manager) Reserve(ctx context.Context, r Reservation) error {
+	if ledger.ToMinor(r.Amount, Currency) <= 0 {
+		return errors.ErrInvalidAmount
+	}
+	if r.At.IsZero() {
+		r.At = m.now()
+	}
+	r.Status = StatusReserved
+
+	if _, err := m.applyDueChanges(ctx, r.AccountID); err != nil {
+		return err
+	}
+
+	return m.store.Update(ctx, r.AccountID, func(reservations []Reservation) ([]Reservation, error) {
+		for _, existing := range reservations {
+			if existing.ID == r.ID {
+				return reservations, nil
+			}
+		}
+
+		// Resolved here, so a limit change that lands before the update is
+		// checked (and an update retried by the store sees the latest one)
+		policy, err := m.resolve(ctx, r.AccountID, r.Method)
+		if err != nil {
+			return nil, err
+		}
+		if err := m.check(policy, m.usage(reservations, r.Method, r.At), r); err != nil {
+			return nil, err
+		}
+		return append(m.prune(reservations, r.At), r), nil
+	})
+}
+
+// Consume marks a reservation as used by a completed operation. Unknown
+// IDs are ignored.
+func (m *Manager) Consume(ctx context.Context, accountID, id string) error {
+	return m.store.Update(ctx, accountID, func(reservations []Reservation) ([]Reservation, error) {
+		for i := range reservations {
+			if reservations[i].ID == id {
+				reservations[i].Status = StatusConsumed
+			}
+		}
+		return reservations, nil
+	})
+}
+
+// Release frees the amount of a failed or reversed operation. Unknown IDs
+// are ignored.
+func (m *Manager) Release(ctx context.Context, accountID, id string) error {
+	return m.store.Update(ctx, accountID, func(reservations []Reservation) ([]Reservation, error) {
+		kept := reservations[:0]
+		for _, r := range reservations {
+			if r.ID != id {
+				kept = append(kept, r)
+			}
+		}
+		return kept, nil
+	})
+}
+
+// RequestChange asks for new limits on a method. Decreases apply at once;
+// increases become pending for the increase delay, replacing older pending
+// requests of the same method.
+func (m *Manager) RequestChange(ctx context.Context, accountID, method string, policy Policy) (*ChangeRequest, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	changes, err := m.applyDueChanges(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	current, err := m.resolve(ctx, accountID, method)
+	if err != nil {
+		return nil, err
+	}
+
+	now := m.now().UTC()
+	change := &ChangeRequest{
+		ID:          uuid.NewString(),
+		AccountID:   accountID,
+		Method:      method,
+		Policy:      policy,
+		Status:      ChangePending,
+		RequestedAt: now,
+		EffectiveAt: now.Add(m.increaseDelay),
+	}
+	if policy.Lowers(current) {
+		change.Status = ChangeApplied
+		change.EffectiveAt = now
+		if err := m.policies.SetPolicy(ctx, accountID, method, policy); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, previous := range changes {
+		if previous.Method == method && previous.Status == ChangePending {
+			previous.Status = ChangeCancelled
+			if err := m.policies.SaveChange(ctx, previous); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := m.policies.SaveChange(ctx, change); err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+// Changes returns the account limit change requests, oldest first
+func (m *Manager) Changes(ctx context.Context, accountID string) ([]*ChangeRequest, error) {
+	return m.applyDueChanges(ctx, accountID)
+}
+
+// applyDueChanges applies the pending changes that became effective and
+// returns every change request of the account
+func (m *Manager) applyDueChanges(ctx context.Context, accountID string) ([]*ChangeRequest, error) {
+	changes, err := m.policies.Changes(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := m.now()
+	for _, change := range changes {
+		if change.Status != ChangePending || change.EffectiveAt.After(now) {
+			continue
+		}
+		if err := m.policies.SetPolicy(ctx, accountID, change.Method, change.Policy); err != nil {
+			return nil, err
+		}
+		change.Status = ChangeApplied
+		if err := m.policies.SaveChange(ctx, change); err != nil {
+			return nil, err
+		}
+	}
+	return changes, nil
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// WINDOWS
+// ═══════════════════════════════════════════════════════════════════════════
+
+func (m *Manager) check(policy Policy, usage Usage, r Reservation) error {
+	amount := ledger.ToMinor(r.Amount, Currency)
+
+	if err := exceeds(KindPerTransaction, policy.PerTransaction, 0, amount); err != nil {
+		return err
+	}
+	if err := exceeds(KindDaily, policy.Daily, usage.Daily, amount); err != nil {
+		return err
+	}
+	if err := exceeds(KindMonthly, policy.Monthly, usage.Monthly, amount); err != nil {
+		return err
+	}
+	if _, night := m.nightSince(r.At); night {
+		return exceeds(KindNight, policy.Night, usage.Night, amount)
+	}
+	return nil
+}
+
+func exceeds(kind Kind, limit, used float64, amount int64) error {
+	if limit == 0 || ledger.ToMinor(used, Currency)+amount <= ledger.ToMinor(limit, Currency) {
+		return nil
+	}
+
+	available := ledger.FromMinor(max(ledger.ToMinor(limit, Currency)-ledger.ToMinor(used, Currency), 0), Currency)
+	return errors.UnprocessableEntity(errors.ErrLimitExceeded.Code, fmt.Sprintf("%s limit exceeded", kind)).
+		WithDetails(map[string]string{
+			"limit":     string(kind),
+			"max":       fmt.Sprintf("%.2f", limit),
+			"available": fmt.Sprintf("%.2f", available),
+		})
+}
+
+func (m *Manager) usage(reservations []Reservation, method string, at time.Time) Usage {
+	day := m.startOfDay(at)
+	month := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, m.location)
+	night, inNight := m.nightSince(at)
+
+	var daily, monthly, nightly int64
+	for _, r := range reservations {
+		if r.Method != method || r.At.After(at) {
+			continue
+		}
+		amount := ledger.ToMinor(r.Amount, Currency)
+		if !r.At.Before(day) {
+			daily += amount
+		}
+		if !r.At.Before(month) {
+			monthly += amount
+		}
+		if inNight && !r.At.Before(night) {
+			nightly += amount
+		}
+	}
+	return Usage{
+		Daily:   ledger.FromMinor(daily, Currency),
+		Monthly: ledger.FromMinor(monthly, Currency),
+		Night:   ledger.FromMinor(nightly, Currency),
+	}
+}
+
+// nightSince returns when the night window containing at started
+func (m *Manager) nightSince(at time.Time) (time.Time, bool) {
+	day := m.startOfDay(at)
+	hour := at.In(m.location).Hour()
+	start := time.Date(day.Year(), day.Month(), day.Day(), m.nightStart, 0, 0, 0, m.location)
+
+	switch {
+	case m.nightStart < m.nightEnd:
+		return start, hour >= m.nightStart && hour < m.nightEnd
+	case hour >= m.nightStart:
+		return start, true
+	case hour < m.nightEnd:
+		return start.AddDate(0, 0, -1), true
+	}
+	return time.Time{}, false
+}
+
+func (m *Manager) startOfDay(t time.Time) time.Time {
+	local := t.In(m.location)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, m.location)
+}
+
+// prune drops reservations older than every window containing at
+func (m *Manager) prune(reservations []Reservation, at time.Time) []Reservation {
+	day := m.startOfDay(at)
+	cutoff := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, m.location).AddDate(0, 0, -1)
+
+	kept := reservations[:0]
+	for _, r := range reservations {
+		if !r.At.Before(cutoff) {
+			kept = append(kept, r)
+		}
+	}
+	return kept
+}
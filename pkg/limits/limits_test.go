@@ -0,0 +1,421 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package limits - Manager Tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package limits
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	apperrors "github.com/fintech-bank-platform/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	brt    = time.FixedZone("BRT", -3*60*60)
+	noon   = time.Date(2026, 3, 10, 12, 0, 0, 0, brt)
+	errBad = errors.New("store unavailable")
+)
+
+// failingStore fails every operation of both store interfaces
+type failingStore struct{}
+
+func (failingStore) Update(context.Context, string, func([]Reservation) ([]Reservation, error)) error {
+	return errBad
+}
+
+func (failingStore) Reservations(context.Context, string) ([]Reservation, error) { return nil, errBad }
+
+func (failingStore) Policy(context.Context, string, string) (*Policy, error) { return nil, errBad }
+
+func (failingStore) SetPolicy(context.Context, string, string, Policy) error { return errBad }
+
+func (failingStore) SaveChange(context.Context, *ChangeRequest) error { return errBad }
+
+func (failingStore) Changes(context.Context, string) ([]*ChangeRequest, error) { return nil, errBad }
+
+// flakyPolicies wraps a MemoryStore and fails the configured operations
+type flakyPolicies struct {
+	*MemoryStore
+	policy, setPolicy, saveChange, changes bool
+	saves                                  int
+}
+
+func (s *flakyPolicies) Policy(ctx context.Context, accountID, method string) (*Policy, error) {
+	if s.policy {
+		return nil, errBad
+	}
+	return s.MemoryStore.Policy(ctx, accountID, method)
+}
+
+func (s *flakyPolicies) SetPolicy(ctx context.Context, accountID, method string, policy Policy) error {
+	if s.setPolicy {
+		return errBad
+	}
+	return s.MemoryStore.SetPolicy(ctx, accountID, method, policy)
+}
+
+func (s *flakyPolicies) SaveChange(ctx context.Context, change *ChangeRequest) error {
+	s.saves++
+	if s.saveChange {
+		return errBad
+	}
+	return s.MemoryStore.SaveChange(ctx, change)
+}
+
+func (s *flakyPolicies) Changes(ctx context.Context, accountID string) ([]*ChangeRequest, error) {
+	if s.changes {
+		return nil, errBad
+	}
+	return s.MemoryStore.Changes(ctx, accountID)
+}
+
+func newManager(now *time.Time, opts ...Option) (*Manager, *MemoryStore) {
+	store := NewMemoryStore()
+	opts = append([]Option{WithLocation(brt), WithClock(func() time.Time { return *now })}, opts...)
+	return NewManager(store, store, opts...), store
+}
+
+func reservation(id string, amount float64, at time.Time) Reservation {
+	return Reservation{ID: id, AccountID: "acc-1", Method: MethodPIX, Amount: amount, At: at}
+}
+
+func limitError(t *testing.T, err error) *apperrors.AppError {
+	t.Helper()
+	require.ErrorIs(t, err, apperrors.ErrLimitExceeded)
+	appErr, ok := apperrors.AsAppError(err)
+	require.True(t, ok)
+	return appErr
+}
+
+func TestPolicyValidate(t *testing.T) {
+	assert.NoError(t, Policy{Daily: 100}.Validate())
+
+	err := Policy{Night: -1}.Validate()
+	require.ErrorIs(t, err, apperrors.ErrInvalidField)
+	assert.Contains(t, err.Error(), "night limit")
+}
+
+func TestPolicyLowers(t *testing.T) {
+	current := Policy{PerTransaction: 100, Daily: 500}
+
+	assert.True(t, Policy{PerTransaction: 100, Daily: 400}.Lowers(current))
+	assert.True(t, Policy{PerTransaction: 50, Daily: 500, Night: 10}.Lowers(current))
+	assert.False(t, Policy{PerTransaction: 100, Daily: 500.01}.Lowers(current))
+	assert.False(t, Policy{PerTransaction: 100}.Lowers(current), "dropping a limit is an increase")
+}
+
+func TestReservePerTransactionLimit(t *testing.T) {
+	now := noon
+	manager, _ := newManager(&now)
+	ctx := context.Background()
+
+	assert.NoError(t, manager.Reserve(ctx, reservation("r-1", 5000, noon)))
+
+	appErr := limitError(t, manager.Reserve(ctx, reservation("r-2", 5000.01, noon)))
+	assert.Equal(t, "per_transaction limit exceeded", appErr.Message)
+	assert.Equal(t, "per_transaction", appErr.Details["limit"])
+	assert.Equal(t, "5000.00", appErr.Details["max"])
+}
+
+func TestReserveDailyAndMonthlyLimits(t *testing.T) {
+	now := noon
+	manager, _ := newManager(&now, WithDefaults(map[string]Policy{MethodAny: {Daily: 100, Monthly: 250}}))
+	ctx := context.Background()
+
+	require.NoError(t, manager.Reserve(ctx, reservation("r-1", 60, noon)))
+	appErr := limitError(t, manager.Reserve(ctx, reservation("r-2", 50, noon)))
+	assert.Equal(t, "daily", appErr.Details["limit"])
+	assert.Equal(t, "40.00", appErr.Details["available"])
+
+	require.NoError(t, manager.Reserve(ctx, reservation("r-3", 40, noon)))
+
+	nextDay := noon.AddDate(0, 0, 1)
+	require.NoError(t, manager.Reserve(ctx, reservation("r-4", 100, nextDay)))
+	appErr = limitError(t, manager.Reserve(ctx, reservation("r-5", 60, nextDay.AddDate(0, 0, 1))))
+	assert.Equal(t, "monthly", appErr.Details["limit"])
+
+	nextMonth := time.Date(2026, 4, 1, 9, 0, 0, 0, brt)
+	assert.NoError(t, manager.Reserve(ctx, reservation("r-6", 100, nextMonth)))
+}
+
+func TestReserveNightLimit(t *testing.T) {
+	now := noon
+	manager, _ := newManager(&now)
+	ctx := context.Background()
+	evening := time.Date(2026, 3, 10, 21, 0, 0, 0, brt)
+
+	require.NoError(t, manager.Reserve(ctx, reservation("day", 3000, time.Date(2026, 3, 10, 19, 59, 0, 0, brt))))
+	require.NoError(t, manager.Reserve(ctx, reservation("n-1", 600, evening)))
+
+	appErr := limitError(t, manager.Reserve(ctx, reservation("n-2", 500, evening.Add(5*time.Hour))))
+	assert.Equal(t, "night", appErr.Details["limit"])
+	assert.Equal(t, "400.00", appErr.Details["available"])
+
+	// 06:00 starts a new day outside the night window
+	assert.NoError(t, manager.Reserve(ctx, reservation("n-3", 500, time.Date(2026, 3, 11, 6, 0, 0, 0, brt))))
+}
+
+func TestReserveSameDayNightWindow(t *testing.T) {
+	now := noon
+	manager, _ := newManager(&now,
+		WithNightWindow(1, 5),
+		WithDefaults(map[string]Policy{MethodAny: {Night: 100}}),
+	)
+	ctx := context.Background()
+
+	assert.NoError(t, manager.Reserve(ctx, reservation("day", 500, noon)))
+	limitError(t, manager.Reserve(ctx, reservation("night", 500, time.Date(2026, 3, 10, 2, 0, 0, 0, brt))))
+}
+
+func TestReserveIsIdempotentAndUsesClock(t *testing.T) {
+	now := noon
+	manager, store := newManager(&now)
+	ctx := context.Background()
+
+	require.NoError(t, manager.Reserve(ctx, Reservation{ID: "r-1", AccountID: "acc-1", Method: MethodPIX, Amount: 100}))
+	require.NoError(t, manager.Reserve(ctx, Reservation{ID: "r-1", AccountID: "acc-1", Method: MethodPIX, Amount: 100}))
+
+	reservations, _ := store.Reservations(ctx, "acc-1")
+	require.Len(t, reservations, 1)
+	assert.Equal(t, noon, reservations[0].At)
+	assert.Equal(t, StatusReserved, reservations[0].Status)
+}
+
+func TestReservePrunesPastMonths(t *testing.T) {
+	now := noon
+	manager, store := newManager(&now)
+	ctx := context.Background()
+
+	require.NoError(t, manager.Reserve(ctx, reservation("old", 10, noon.AddDate(0, -2, 0))))
+	require.NoError(t, manager.Reserve(ctx, reservation("eve", 10, time.Date(2026, 2, 28, 23, 0, 0, 0, brt))))
+	require.NoError(t, manager.Reserve(ctx, reservation("new", 10, time.Date(2026, 3, 1, 1, 0, 0, 0, brt))))
+
+	reservations, _ := store.Reservations(ctx, "acc-1")
+	require.Len(t, reservations, 2, "the night before the month start is kept")
+	assert.Equal(t, "eve", reservations[0].ID)
+}
+
+func TestReserveErrors(t *testing.T) {
+	now := noon
+	manager, _ := newManager(&now)
+	ctx := context.Background()
+
+	assert.ErrorIs(t, manager.Reserve(ctx, reservation("r-1", 0, noon)), apperrors.ErrInvalidAmount)
+	assert.ErrorIs(t, manager.Reserve(ctx, reservation("r-1", 0.001, noon)), apperrors.ErrInvalidAmount)
+
+	broken := NewManager(failingStore{}, failingStore{})
+	assert.ErrorIs(t, broken.Reserve(ctx, reservation("r-1", 10, noon)), errBad)
+
+	store := NewMemoryStore()
+	policies := &flakyPolicies{MemoryStore: store, policy: true}
+	assert.ErrorIs(t, NewManager(store, policies).Reserve(ctx, reservation("r-1", 10, noon)), errBad)
+}
+
+// racingStore lowers the limit right before each update, like a change
+// that lands between a reservation's policy lookup and its update
+type racingStore struct {
+	*MemoryStore
+}
+
+func (s racingStore) Update(ctx context.Context, accountID string, fn func([]Reservation) ([]Reservation, error)) error {
+	policy := DefaultPolicies()[MethodPIX]
+	policy.PerTransaction = 100
+	if err := s.SetPolicy(ctx, accountID, MethodPIX, policy); err != nil {
+		return err
+	}
+	return s.MemoryStore.Update(ctx, accountID, fn)
+}
+
+func TestReserveUsesPolicyAtUpdateTime(t *testing.T) {
+	store := racingStore{NewMemoryStore()}
+	manager := NewManager(store, store, WithLocation(brt))
+
+	appErr := limitError(t, manager.Reserve(context.Background(), reservation("r-1", 500, noon)))
+
+	assert.Equal(t, "100.00", appErr.Details["max"])
+}
+
+func TestConsumeAndRelease(t *testing.T) {
+	now := noon
+	manager, store := newManager(&now, WithDefaults(map[string]Policy{MethodAny: {Daily: 100}}))
+	ctx := context.Background()
+
+	require.NoError(t, manager.Reserve(ctx, reservation("r-1", 60, noon)))
+	require.NoError(t, manager.Reserve(ctx, reservation("r-2", 40, noon)))
+	limitError(t, manager.Reserve(ctx, reservation("r-3", 10, noon)))
+
+	require.NoError(t, manager.Consume(ctx, "acc-1", "r-1"))
+	require.NoError(t, manager.Release(ctx, "acc-1", "r-2"))
+	require.NoError(t, manager.Release(ctx, "acc-1", "unknown"))
+
+	reservations, _ := store.Reservations(ctx, "acc-1")
+	require.Len(t, reservations, 1)
+	assert.Equal(t, StatusConsumed, reservations[0].Status)
+
+	assert.NoError(t, manager.Reserve(ctx, reservation("r-3", 40, noon)))
+}
+
+func TestPolicyResolution(t *testing.T) {
+	now := noon
+	manager, store := newManager(&now)
+	ctx := context.Background()
+
+	policy, err := manager.Policy(ctx, "acc-1", MethodBoleto)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultPolicies()[MethodBoleto], policy)
+
+	policy, _ = manager.Policy(ctx, "acc-1", "card")
+	assert.Equal(t, DefaultPolicies()[MethodAny], policy)
+
+	_ = store.SetPolicy(ctx, "acc-1", MethodBoleto, Policy{Daily: 1})
+	policy, _ = manager.Policy(ctx, "acc-1", MethodBoleto)
+	assert.Equal(t, Policy{Daily: 1}, policy)
+}
+
+func TestLimits(t *testing.T) {
+	now := time.Date(2026, 3, 10, 22, 0, 0, 0, brt)
+	manager, _ := newManager(&now)
+	ctx := context.Background()
+
+	require.NoError(t, manager.Reserve(ctx, reservation("r-1", 300, noon)))
+	require.NoError(t, manager.Reserve(ctx, reservation("r-2", 200, now.Add(-time.Hour))))
+	require.NoError(t, manager.Reserve(ctx, reservation("later", 200, now.Add(time.Hour))))
+
+	limits, err := manager.Limits(ctx, "acc-1")
+
+	require.NoError(t, err)
+	require.Len(t, limits, 4)
+	assert.Equal(t, []string{"boleto", "pix", "ted", "transfer"},
+		[]string{limits[0].Method, limits[1].Method, limits[2].Method, limits[3].Method})
+	assert.Equal(t, Usage{Daily: 500, Monthly: 500, Night: 200}, limits[1].Usage)
+	assert.Equal(t, Usage{}, limits[0].Usage)
+
+	now = noon
+	limits, _ = manager.Limits(ctx, "acc-1")
+	assert.Equal(t, Usage{Daily: 300, Monthly: 300}, limits[1].Usage)
+}
+
+func TestLimitsErrors(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewManager(failingStore{}, failingStore{}).Limits(ctx, "acc-1")
+	assert.ErrorIs(t, err, errBad)
+
+	_, err = NewManager(NewMemoryStore(), failingStore{}).Limits(ctx, "acc-1")
+	assert.ErrorIs(t, err, errBad)
+}
+
+func TestRequestChangeDecreaseAppliesAtOnce(t *testing.T) {
+	now := noon
+	manager, _ := newManager(&now)
+	ctx := context.Background()
+	lower := Policy{PerTransaction: 1000, Daily: 2000, Monthly: 10000, Night: 500}
+
+	change, err := manager.RequestChange(ctx, "acc-1", MethodPIX, lower)
+
+	require.NoError(t, err)
+	assert.Equal(t, ChangeApplied, change.Status)
+	assert.Equal(t, noon.UTC(), change.EffectiveAt)
+	policy, _ := manager.Policy(ctx, "acc-1", MethodPIX)
+	assert.Equal(t, lower, policy)
+	limitError(t, manager.Reserve(ctx, reservation("r-1", 1500, noon)))
+}
+
+func TestRequestChangeIncreaseWaits(t *testing.T) {
+	now := noon
+	manager, _ := newManager(&now, WithIncreaseDelay(48*time.Hour))
+	ctx := context.Background()
+	higher := DefaultPolicies()[MethodPIX]
+	higher.PerTransaction = 8000
+
+	first, err := manager.RequestChange(ctx, "acc-1", MethodPIX, higher)
+	require.NoError(t, err)
+	assert.Equal(t, ChangePending, first.Status)
+	assert.Equal(t, noon.UTC().Add(48*time.Hour), first.EffectiveAt)
+
+	higher.PerTransaction = 9000
+	second, err := manager.RequestChange(ctx, "acc-1", MethodPIX, higher)
+	require.NoError(t, err)
+
+	limitError(t, manager.Reserve(ctx, reservation("r-1", 8500, noon)))
+
+	now = noon.Add(48 * time.Hour)
+	changes, err := manager.Changes(ctx, "acc-1")
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	assert.Equal(t, ChangeCancelled, changes[0].Status)
+	assert.Equal(t, second.ID, changes[1].ID)
+	assert.Equal(t, ChangeApplied, changes[1].Status)
+
+	assert.NoError(t, manager.Reserve(ctx, reservation("r-1", 8500, now)))
+}
+
+func TestRequestChangeErrors(t *testing.T) {
+	ctx := context.Background()
+	now := noon
+	higher := Policy{Daily: 1_000_000}
+	lower := Policy{PerTransaction: 1, Daily: 1, Monthly: 1, Night: 1}
+
+	manager, _ := newManager(&now)
+	_, err := manager.RequestChange(ctx, "acc-1", MethodPIX, Policy{Daily: -1})
+	assert.ErrorIs(t, err, apperrors.ErrInvalidField)
+
+	cases := []struct {
+		name   string
+		store  *flakyPolicies
+		policy Policy
+	}{
+		{"policy", &flakyPolicies{policy: true}, higher},
+		{"set policy", &flakyPolicies{setPolicy: true}, lower},
+		{"changes", &flakyPolicies{changes: true}, higher},
+		{"save change", &flakyPolicies{saveChange: true}, higher},
+	}
+	for _, tc := range cases {
+		tc.store.MemoryStore = NewMemoryStore()
+		manager := NewManager(tc.store, tc.store, WithClock(func() time.Time { return noon }))
+		_, err := manager.RequestChange(ctx, "acc-1", MethodPIX, tc.policy)
+		assert.ErrorIs(t, err, errBad, tc.name)
+	}
+}
+
+func TestRequestChangeCancelFailure(t *testing.T) {
+	ctx := context.Background()
+	store := &flakyPolicies{MemoryStore: NewMemoryStore()}
+	manager := NewManager(store, store, WithClock(func() time.Time { return noon }))
+
+	_, err := manager.RequestChange(ctx, "acc-1", MethodPIX, Policy{Daily: 1_000_000})
+	require.NoError(t, err)
+
+	store.saveChange = true
+	_, err = manager.RequestChange(ctx, "acc-1", MethodPIX, Policy{Daily: 2_000_000})
+	assert.ErrorIs(t, err, errBad)
+	assert.Equal(t, 2, store.saves, "fails while cancelling the previous request")
+}
+
+func TestApplyDueChangesErrors(t *testing.T) {
+	ctx := context.Background()
+	now := noon
+
+	for _, failing := range []string{"set policy", "save change"} {
+		store := &flakyPolicies{MemoryStore: NewMemoryStore()}
+		manager := NewManager(store, store, WithClock(func() time.Time { return now }))
+		_, err := manager.RequestChange(ctx, "acc-1", MethodPIX, Policy{Daily: 1_000_000})
+		require.NoError(t, err)
+
+		store.setPolicy = failing == "set policy"
+		store.saveChange = failing == "save change"
+		now = noon.Add(DefaultIncreaseDelay)
+
+		_, err = manager.Changes(ctx, "acc-1")
+		assert.ErrorIs(t, err, errBad, failing)
+		now = noon
+	}
+
+	_, err := NewManager(NewMemoryStore(), failingStore{}).Changes(ctx, "acc-1")
+	assert.ErrorIs(t, err, errBad)
+}
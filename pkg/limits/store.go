@@ -0,0 +1,127 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package limits - Reservation and policy stores
+// ═══════════════════════════════════════════════════════════════════════════
+
+package limits
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// Store keeps the amount reservations of each account (Redis or Cassandra
+// lightweight transactions in production)
+type Store interface {
+	// Update loads the account reservations, applies fn and saves its result
+	// atomically with respect to other updates of the same account. Nothing
+	// is saved when fn returns an error. fn may read the PolicyStore.
+	Update(ctx context.Context, accountID string, fn func([]Reservation) ([]Reservation, error)) error
+	// Reservations returns the account reservations, oldest first
+	Reservations(ctx context.Context, accountID string) ([]Reservation, error)
+}
+
+// PolicyStore keeps per-account policy overrides and limit change requests
+type PolicyStore interface {
+	// Policy returns the account override for method, or nil when the
+	// account uses the defaults
+	Policy(ctx context.Context, accountID, method string) (*Policy, error)
+	SetPolicy(ctx context.Context, accountID, method string, policy Policy) error
+	SaveChange(ctx context.Context, change *ChangeRequest) error
+	// Changes returns the account change requests, oldest first
+	Changes(ctx context.Context, accountID string) ([]*ChangeRequest, error)
+}
+
+// MemoryStore is an in-memory Store and PolicyStore used in tests and
+// local development. Reservations and policies have separate locks, so an
+// Update can read policies.
+type MemoryStore struct {
+	mu           sync.Mutex
+	reservations map[string][]Reservation
+	policyMu     sync.Mutex
+	policies     map[string]map[string]Policy
+	changes      map[string][]*ChangeRequest
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		reservations: make(map[string][]Reservation),
+		policies:     make(map[string]map[string]Policy),
+		changes:      make(map[string][]*ChangeRequest),
+	}
+}
+
+// Update implements Store
+func (s *MemoryStore) Update(_ context.Context, accountID string, fn func([]Reservation) ([]Reservation, error)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	updated, err := fn(append([]Reservation(nil), s.reservations[accountID]...))
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(updated, func(i, j int) bool { return updated[i].At.Before(updated[j].At) })
+	s.reservations[accountID] = updated
+	return nil
+}
+
+// Reservations implements Store
+func (s *MemoryStore) Reservations(_ context.Context, accountID string) ([]Reservation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Reservation(nil), s.reservations[accountID]...), nil
+}
+
+// Policy implements PolicyStore
+func (s *MemoryStore) Policy(_ context.Context, accountID, method string) (*Policy, error) {
+	s.policyMu.Lock()
+	defer s.policyMu.Unlock()
+
+	policy, ok := s.policies[accountID][method]
+	if !ok {
+		return nil, nil
+	}
+	return &policy, nil
+}
+
+// SetPolicy implements PolicyStore
+func (s *MemoryStore) SetPolicy(_ context.Context, accountID, method string, policy Policy) error {
+	s.policyMu.Lock()
+	defer s.policyMu.Unlock()
+
+	if s.policies[accountID] == nil {
+		s.policies[accountID] = make(map[string]Policy)
+	}
+	s.policies[accountID][method] = policy
+	return nil
+}
+
+// SaveChange implements PolicyStore
+func (s *MemoryStore) SaveChange(_ context.Context, change *ChangeRequest) error {
+	s.policyMu.Lock()
+	defer s.policyMu.Unlock()
+
+	saved := *change
+	for i, existing := range s.changes[change.AccountID] {
+		if existing.ID == change.ID {
+			s.changes[change.AccountID][i] = &saved
+			return nil
+		}
+	}
+	s.changes[change.AccountID] = append(s.changes[change.AccountID], &saved)
+	return nil
+}
+
+// Changes implements PolicyStore
+func (s *MemoryStore) Changes(_ context.Context, accountID string) ([]*ChangeRequest, error) {
+	s.policyMu.Lock()
+	defer s.policyMu.Unlock()
+
+	changes := make([]*ChangeRequest, 0, len(s.changes[accountID]))
+	for _, change := range s.changes[accountID] {
+		copied := *change
+		changes = append(changes, &copied)
+	}
+	return changes, nil
+}
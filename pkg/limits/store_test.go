@@ -0,0 +1,71 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package limits - Store Tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package limits
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStoreUpdateSortsAndRollsBack(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	base := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	err := store.Update(ctx, "acc-1", func(rs []Reservation) ([]Reservation, error) {
+		return append(rs, Reservation{ID: "b", At: base.Add(time.Minute)}, Reservation{ID: "a", At: base}), nil
+	})
+	require.NoError(t, err)
+
+	err = store.Update(ctx, "acc-1", func(rs []Reservation) ([]Reservation, error) {
+		return nil, errors.New("boom")
+	})
+	assert.EqualError(t, err, "boom")
+
+	reservations, err := store.Reservations(ctx, "acc-1")
+	require.NoError(t, err)
+	require.Len(t, reservations, 2)
+	assert.Equal(t, "a", reservations[0].ID)
+	assert.Equal(t, "b", reservations[1].ID)
+}
+
+func TestMemoryStorePolicies(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	policy, err := store.Policy(ctx, "acc-1", MethodPIX)
+	require.NoError(t, err)
+	assert.Nil(t, policy)
+
+	require.NoError(t, store.SetPolicy(ctx, "acc-1", MethodPIX, Policy{Daily: 100}))
+	require.NoError(t, store.SetPolicy(ctx, "acc-1", MethodTED, Policy{Daily: 200}))
+
+	policy, _ = store.Policy(ctx, "acc-1", MethodPIX)
+	assert.Equal(t, &Policy{Daily: 100}, policy)
+}
+
+func TestMemoryStoreChanges(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	change := &ChangeRequest{ID: "c-1", AccountID: "acc-1", Status: ChangePending}
+
+	require.NoError(t, store.SaveChange(ctx, change))
+	require.NoError(t, store.SaveChange(ctx, &ChangeRequest{ID: "c-2", AccountID: "acc-1"}))
+
+	change.Status = ChangeApplied
+	changes, _ := store.Changes(ctx, "acc-1")
+	assert.Equal(t, ChangePending, changes[0].Status, "saved copies are not aliased")
+
+	require.NoError(t, store.SaveChange(ctx, change))
+	changes, _ = store.Changes(ctx, "acc-1")
+	require.Len(t, changes, 2)
+	assert.Equal(t, ChangeApplied, changes[0].Status)
+	assert.Equal(t, "c-2", changes[1].ID)
+}
@@ -9,6 +9,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/fintech-bank-platform/pkg/pii"
 	"github.com/rs/zerolog"
 )
 
@@ -82,16 +83,17 @@ func NewProduction() *Logger {
 	return New(cfg)
 }
 
-// WithField adds a field to the logger
+// WithField adds a field to the logger with personal data masked (see
+// pii.Field)
 func (l *Logger) WithField(key string, value interface{}) *Logger {
-	return &Logger{Logger: l.Logger.With().Interface(key, value).Logger()}
+	return &Logger{Logger: l.Logger.With().Interface(key, pii.Field(key, value)).Logger()}
 }
 
-// WithFields adds multiple fields to the logger
+// WithFields adds multiple fields to the logger with personal data masked
 func (l *Logger) WithFields(fields map[string]interface{}) *Logger {
 	ctx := l.Logger.With()
 	for k, v := range fields {
-		ctx = ctx.Interface(k, v)
+		ctx = ctx.Interface(k, pii.Field(k, v))
 	}
 	return &Logger{Logger: ctx.Logger()}
 }
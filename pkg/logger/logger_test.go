@@ -73,6 +73,26 @@ func TestWithFields(t *testing.T) {
 	assert.Contains(t, output, "value1")
 }
 
+func TestWithFieldsMasksPersonalData(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(Config{Output: &buf})
+
+	log.WithField("email", "joao@example.com").
+		WithFields(map[string]interface{}{
+			"document": "529.982.247-25",
+			"account": struct {
+				Phone string `json:"phone" pii:"phone"`
+			}{Phone: "11987654321"},
+		}).
+		Info().Msg("account created")
+
+	output := buf.String()
+	assert.Contains(t, output, `"email":"j***@example.com"`)
+	assert.Contains(t, output, `"document":"***.982.247-**"`)
+	assert.Contains(t, output, `"phone":"(11) *****-4321"`)
+	assert.NotContains(t, output, "529.982.247-25")
+}
+
 func TestWithError(t *testing.T) {
 	var buf bytes.Buffer
 	log := New(Config{Output: &buf})
@@ -0,0 +1,295 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package payment - Payment lifecycle, refunds and cancellations
+// ═══════════════════════════════════════════════════════════════════════════
+
+package payment
+
+import (
+	"time"
+
+	"github.com/fintech-bank-platform/pkg/errors"
+	"github.com/fintech-bank-platform/pkg/events"
+	"github.com/fintech-bank-platform/pkg/ledger"
+	"github.com/google/uuid"
+)
+
+// ClearingAccountID is the internal account holding funds of outgoing payments
+const ClearingAccountID = "payment-clearing"
+
+// now returns the current time (replaceable in tests)
+var now = func() time.Time {
+	return time.Now().UTC()
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// STATE MACHINE
+// ═══════════════════════════════════════════════════════════════════════════
+
+// Status represents the state of a payment
+type Status string
+
+const (
+	StatusCreated           Status = "created"
+	StatusProcessing        Status = "processing"
+	StatusCompleted         Status = "completed"
+	StatusPartiallyRefunded Status = "partially_refunded"
+	StatusRefunded          Status = "refunded"
+	StatusCancelled         Status = "cancelled"
+	StatusFailed            Status = "failed"
+)
+
+// transitions lists the allowed target states for each state
+var transitions = map[Status][]Status{
+	StatusCreated:           {StatusProcessing, StatusCancelled},
+	StatusProcessing:        {StatusCompleted, StatusFailed, StatusCancelled},
+	StatusCompleted:         {StatusPartiallyRefunded, StatusRefunded},
+	StatusPartiallyRefunded: {StatusPartiallyRefunded, StatusRefunded},
+}
+
+// CanTransition checks if a payment may move from one state to another
+func CanTransition(from, to Status) bool {
+	for _, allowed := range transitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFinal checks if no further transitions are possible from the state
+func (s Status) IsFinal() bool {
+	return len(transitions[s]) == 0
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// PAYMENT
+// ═══════════════════════════════════════════════════════════════════════════
+
+// Refund represents a (partial) refund applied to a payment
+type Refund struct {
+	ID             string    `json:"id"`
+	Amount         float64   `json:"amount"`
+	Reason         string    `json:"reason,omitempty"`
+	IdempotencyKey string    `json:"idempotency_key"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// Payment represents a payment and its refund history
+type Payment struct {
+	ID             string    `json:"id"`
+	AccountID      string    `json:"account_id"`
+	PaymentMethod  string    `json:"payment_method"`
+	Amount         float64   `json:"amount"`
+	Currency       string    `json:"currency"`
+	Recipient      string    `json:"recipient"`
+	ExternalID     string    `json:"external_id,omitempty"`
+	Status         Status    `json:"status"`
+	RefundedAmount float64   `json:"refunded_amount"`
+	Refunds        []Refund  `json:"refunds,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// Transition is the outcome of a state change: the events to publish and
+// the ledger movements to post
+type Transition struct {
+	From    Status
+	To      Status
+	Events  []*events.Event
+	Entries []ledger.Entry
+}
+
+// New creates a payment in the created state from a process command
+func New(id string, cmd events.ProcessPaymentPayload) (*Payment, error) {
+	if ledger.ToMinor(cmd.Amount, cmd.Currency) <= 0 {
+		return nil, errors.ErrInvalidAmount
+	}
+
+	createdAt := now()
+	return &Payment{
+		ID:            id,
+		AccountID:     cmd.AccountID,
+		PaymentMethod: cmd.PaymentMethod,
+		Amount:        cmd.Amount,
+		Currency:      cmd.Currency,
+		Recipient:     cmd.Recipient,
+		Status:        StatusCreated,
+		CreatedAt:     createdAt,
+		UpdatedAt:     createdAt,
+	}, nil
+}
+
+// RemainingAmount returns the amount that can still be refunded
+func (p *Payment) RemainingAmount() float64 {
+	return ledger.FromMinor(ledger.ToMinor(p.Amount, p.Currency)-ledger.ToMinor(p.RefundedAmount, p.Currency), p.Currency)
+}
+
+// Process moves the payment to processing
+func (p *Payment) Process() (*Transition, error) {
+	transition, err := p.transition(StatusProcessing)
+	if err != nil {
+		return nil, err
+	}
+
+	transition.Events = append(transition.Events, p.event(events.EventTypes.PaymentProcessed, events.PaymentProcessedPayload{
+		PaymentID:     p.ID,
+		AccountID:     p.AccountID,
+		PaymentMethod: p.PaymentMethod,
+		Amount:        p.Amount,
+		Currency:      p.Currency,
+		Status:        string(p.Status),
+		ProcessedAt:   p.UpdatedAt,
+	}))
+
+	return transition, nil
+}
+
+// Complete settles the payment, moving funds from the account to the clearing account
+func (p *Payment) Complete(externalID string) (*Transition, error) {
+	transition, err := p.transition(StatusCompleted)
+	if err != nil {
+		return nil, err
+	}
+
+	p.ExternalID = externalID
+	transition.Entries = ledger.NewTransfer(p.AccountID, ClearingAccountID, p.Amount, p.Currency, p.ID)
+	transition.Events = append(transition.Events, p.event(events.EventTypes.PaymentCompleted, events.PaymentCompletedPayload{
+		PaymentID:     p.ID,
+		AccountID:     p.AccountID,
+		PaymentMethod: p.PaymentMethod,
+		Amount:        p.Amount,
+		Currency:      p.Currency,
+		Status:        string(p.Status),
+		ExternalID:    externalID,
+		CompletedAt:   p.UpdatedAt,
+	}))
+
+	return transition, nil
+}
+
+// Fail marks the payment as failed while processing
+func (p *Payment) Fail(code, message string) (*Transition, error) {
+	transition, err := p.transition(StatusFailed)
+	if err != nil {
+		return nil, err
+	}
+
+	transition.Events = append(transition.Events, p.event(events.EventTypes.PaymentFailed, events.PaymentFailedPayload{
+		PaymentID:    p.ID,
+		AccountID:    p.AccountID,
+		Status:       string(p.Status),
+		ErrorCode:    code,
+		ErrorMessage: message,
+		FailedAt:     p.UpdatedAt,
+	}))
+
+	return transition, nil
+}
+
+// Cancel cancels a payment that has not been completed yet.
+// No funds have moved before completion, so no ledger entries are produced.
+func (p *Payment) Cancel(cmd events.CancelPaymentPayload) (*Transition, error) {
+	if cmd.PaymentID != p.ID {
+		return nil, errors.ErrPaymentNotFound
+	}
+
+	transition, err := p.transition(StatusCancelled)
+	if err != nil {
+		return nil, err
+	}
+
+	transition.Events = append(transition.Events, p.event(events.EventTypes.PaymentCancelled, events.PaymentCancelledPayload{
+		PaymentID:   p.ID,
+		AccountID:   p.AccountID,
+		Status:      string(p.Status),
+		Reason:      cmd.Reason,
+		CancelledAt: p.UpdatedAt,
+	}))
+
+	return transition, nil
+}
+
+// Refund returns (part of) a completed payment to the account.
+// The sum of all refunds can never exceed the original amount.
+func (p *Payment) Refund(cmd events.RefundPaymentPayload) (*Transition, error) {
+	if cmd.PaymentID != p.ID {
+		return nil, errors.ErrPaymentNotFound
+	}
+
+	amount := ledger.ToMinor(cmd.Amount, p.Currency)
+	if amount <= 0 {
+		return nil, errors.ErrInvalidAmount
+	}
+
+	for _, refund := range p.Refunds {
+		if cmd.IdempotencyKey != "" && refund.IdempotencyKey == cmd.IdempotencyKey {
+			return nil, errors.ErrDuplicateRefund
+		}
+	}
+
+	if !CanTransition(p.Status, StatusRefunded) {
+		return nil, errors.ErrInvalidPaymentState
+	}
+
+	remaining := ledger.ToMinor(p.Amount, p.Currency) - ledger.ToMinor(p.RefundedAmount, p.Currency)
+	if amount > remaining {
+		return nil, errors.ErrRefundExceedsAmount
+	}
+
+	target := StatusPartiallyRefunded
+	if amount == remaining {
+		target = StatusRefunded
+	}
+
+	transition := p.apply(target)
+
+	refund := Refund{
+		ID:             uuid.NewString(),
+		Amount:         ledger.FromMinor(amount, p.Currency),
+		Reason:         cmd.Reason,
+		IdempotencyKey: cmd.IdempotencyKey,
+		CreatedAt:      p.UpdatedAt,
+	}
+	p.Refunds = append(p.Refunds, refund)
+	p.RefundedAmount = ledger.FromMinor(ledger.ToMinor(p.RefundedAmount, p.Currency)+amount, p.Currency)
+
+	// Compensating movement: the refunded share of the original settlement
+	settlement := ledger.NewTransfer(p.AccountID, ClearingAccountID, refund.Amount, p.Currency, p.ID)
+	transition.Entries = ledger.Compensate(settlement, refund.ID)
+	transition.Events = append(transition.Events, p.event(events.EventTypes.PaymentRefunded, events.PaymentRefundedPayload{
+		RefundID:        refund.ID,
+		PaymentID:       p.ID,
+		AccountID:       p.AccountID,
+		Amount:          refund.Amount,
+		Currency:        p.Currency,
+		RefundedTotal:   p.RefundedAmount,
+		RemainingAmount: p.RemainingAmount(),
+		Status:          string(p.Status),
+		Reason:          cmd.Reason,
+		RefundedAt:      p.UpdatedAt,
+	}))
+
+	return transition, nil
+}
+
+// transition validates and applies a state change
+func (p *Payment) transition(to Status) (*Transition, error) {
+	if !CanTransition(p.Status, to) {
+		return nil, errors.ErrInvalidPaymentState
+	}
+	return p.apply(to), nil
+}
+
+// apply changes the state without validation
+func (p *Payment) apply(to Status) *Transition {
+	from := p.Status
+	p.Status = to
+	p.UpdatedAt = now()
+
+	return &Transition{From: from, To: to}
+}
+
+// event builds a payment event referencing the original payment ID
+func (p *Payment) event(eventType string, payload interface{}) *events.Event {
+	return events.NewPaymentEvent(eventType, payload).WithMetadata("payment_id", p.ID)
+}
@@ -0,0 +1,313 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package payment - Tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package payment
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fintech-bank-platform/pkg/errors"
+	"github.com/fintech-bank-platform/pkg/events"
+	"github.com/fintech-bank-platform/pkg/ledger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPayment(t *testing.T, amount float64) *Payment {
+	p, err := New("pay-123", events.ProcessPaymentPayload{
+		AccountID:      "acc-123",
+		PaymentMethod:  "pix",
+		Amount:         amount,
+		Currency:       "BRL",
+		Recipient:      "Merchant XYZ",
+		IdempotencyKey: "idem-1",
+	})
+	require.NoError(t, err)
+	return p
+}
+
+func completedPayment(t *testing.T, amount float64) *Payment {
+	p := newPayment(t, amount)
+	_, err := p.Process()
+	require.NoError(t, err)
+	_, err = p.Complete("ext-1")
+	require.NoError(t, err)
+	return p
+}
+
+func refund(amount float64, key string) events.RefundPaymentPayload {
+	return events.RefundPaymentPayload{PaymentID: "pay-123", Amount: amount, Reason: "customer_request", IdempotencyKey: key}
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// STATE MACHINE TESTS
+// ═══════════════════════════════════════════════════════════════════════════
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from     Status
+		to       Status
+		expected bool
+	}{
+		{StatusCreated, StatusProcessing, true},
+		{StatusCreated, StatusCancelled, true},
+		{StatusCreated, StatusCompleted, false},
+		{StatusProcessing, StatusCompleted, true},
+		{StatusProcessing, StatusFailed, true},
+		{StatusProcessing, StatusCancelled, true},
+		{StatusCompleted, StatusPartiallyRefunded, true},
+		{StatusCompleted, StatusRefunded, true},
+		{StatusCompleted, StatusCancelled, false},
+		{StatusPartiallyRefunded, StatusPartiallyRefunded, true},
+		{StatusPartiallyRefunded, StatusRefunded, true},
+		{StatusRefunded, StatusPartiallyRefunded, false},
+		{StatusCancelled, StatusProcessing, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			assert.Equal(t, tt.expected, CanTransition(tt.from, tt.to))
+		})
+	}
+}
+
+func TestStatusIsFinal(t *testing.T) {
+	assert.False(t, StatusCreated.IsFinal())
+	assert.False(t, StatusPartiallyRefunded.IsFinal())
+	assert.True(t, StatusRefunded.IsFinal())
+	assert.True(t, StatusCancelled.IsFinal())
+	assert.True(t, StatusFailed.IsFinal())
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// LIFECYCLE TESTS
+// ═══════════════════════════════════════════════════════════════════════════
+
+func TestNew(t *testing.T) {
+	p := newPayment(t, 250)
+
+	assert.Equal(t, StatusCreated, p.Status)
+	assert.Equal(t, "acc-123", p.AccountID)
+	assert.Equal(t, 250.0, p.RemainingAmount())
+	assert.False(t, p.CreatedAt.IsZero())
+}
+
+func TestNewInvalidAmount(t *testing.T) {
+	_, err := New("pay-1", events.ProcessPaymentPayload{Amount: 0})
+	assert.Equal(t, errors.ErrInvalidAmount, err)
+
+	_, err = New("pay-1", events.ProcessPaymentPayload{Amount: -10})
+	assert.Equal(t, errors.ErrInvalidAmount, err)
+}
+
+func TestProcess(t *testing.T) {
+	p := newPayment(t, 250)
+
+	transition, err := p.Process()
+
+	require.NoError(t, err)
+	assert.Equal(t, StatusCreated, transition.From)
+	assert.Equal(t, StatusProcessing, transition.To)
+	require.Len(t, transition.Events, 1)
+	assert.Equal(t, events.EventTypes.PaymentProcessed, transition.Events[0].Type)
+	assert.Equal(t, "pay-123", transition.Events[0].Metadata["payment_id"])
+	assert.Empty(t, transition.Entries)
+
+	_, err = p.Process()
+	assert.Equal(t, errors.ErrInvalidPaymentState, err)
+}
+
+func TestComplete(t *testing.T) {
+	p := newPayment(t, 250)
+	_, err := p.Complete("ext-1")
+	assert.Equal(t, errors.ErrInvalidPaymentState, err)
+
+	_, _ = p.Process()
+	transition, err := p.Complete("ext-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, p.Status)
+	assert.Equal(t, "ext-1", p.ExternalID)
+	require.Len(t, transition.Events, 1)
+	assert.Equal(t, events.EventTypes.PaymentCompleted, transition.Events[0].Type)
+
+	payload := transition.Events[0].Payload.(events.PaymentCompletedPayload)
+	assert.Equal(t, "pay-123", payload.PaymentID)
+	assert.Equal(t, "ext-1", payload.ExternalID)
+
+	assert.Equal(t, ledger.NewTransfer("acc-123", ClearingAccountID, 250, "BRL", "pay-123"), transition.Entries)
+}
+
+func TestFail(t *testing.T) {
+	p := newPayment(t, 250)
+	_, err := p.Fail("PROVIDER_ERROR", "timeout")
+	assert.Equal(t, errors.ErrInvalidPaymentState, err)
+
+	_, _ = p.Process()
+	transition, err := p.Fail("PROVIDER_ERROR", "timeout")
+
+	require.NoError(t, err)
+	assert.Equal(t, StatusFailed, p.Status)
+	payload := transition.Events[0].Payload.(events.PaymentFailedPayload)
+	assert.Equal(t, "PROVIDER_ERROR", payload.ErrorCode)
+	assert.Equal(t, "timeout", payload.ErrorMessage)
+}
+
+func TestCancel(t *testing.T) {
+	p := newPayment(t, 250)
+
+	transition, err := p.Cancel(events.CancelPaymentPayload{PaymentID: "pay-123", Reason: "duplicate"})
+
+	require.NoError(t, err)
+	assert.Equal(t, StatusCancelled, p.Status)
+	assert.Empty(t, transition.Entries)
+	require.Len(t, transition.Events, 1)
+	assert.Equal(t, events.EventTypes.PaymentCancelled, transition.Events[0].Type)
+	assert.Equal(t, "duplicate", transition.Events[0].Payload.(events.PaymentCancelledPayload).Reason)
+}
+
+func TestCancelWhileProcessing(t *testing.T) {
+	p := newPayment(t, 250)
+	_, _ = p.Process()
+
+	_, err := p.Cancel(events.CancelPaymentPayload{PaymentID: "pay-123"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, StatusCancelled, p.Status)
+}
+
+func TestCancelCompletedPayment(t *testing.T) {
+	p := completedPayment(t, 250)
+
+	_, err := p.Cancel(events.CancelPaymentPayload{PaymentID: "pay-123"})
+
+	assert.Equal(t, errors.ErrInvalidPaymentState, err)
+	assert.Equal(t, StatusCompleted, p.Status)
+}
+
+func TestCancelWrongPayment(t *testing.T) {
+	p := newPayment(t, 250)
+
+	_, err := p.Cancel(events.CancelPaymentPayload{PaymentID: "other"})
+
+	assert.Equal(t, errors.ErrPaymentNotFound, err)
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// REFUND TESTS
+// ═══════════════════════════════════════════════════════════════════════════
+
+func TestPartialRefunds(t *testing.T) {
+	p := completedPayment(t, 100)
+
+	first, err := p.Refund(refund(30.10, "r-1"))
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, first.From)
+	assert.Equal(t, StatusPartiallyRefunded, first.To)
+	assert.Equal(t, 69.90, p.RemainingAmount())
+
+	second, err := p.Refund(refund(69.90, "r-2"))
+	require.NoError(t, err)
+	assert.Equal(t, StatusPartiallyRefunded, second.From)
+	assert.Equal(t, StatusRefunded, second.To)
+	assert.Equal(t, 100.0, p.RefundedAmount)
+	assert.Equal(t, 0.0, p.RemainingAmount())
+	assert.Len(t, p.Refunds, 2)
+
+	payload := second.Events[0].Payload.(events.PaymentRefundedPayload)
+	assert.Equal(t, "pay-123", payload.PaymentID)
+	assert.Equal(t, p.Refunds[1].ID, payload.RefundID)
+	assert.Equal(t, 100.0, payload.RefundedTotal)
+	assert.Equal(t, string(StatusRefunded), payload.Status)
+	assert.Equal(t, "pay-123", second.Events[0].Metadata["payment_id"])
+}
+
+func TestRefundProducesCompensatingEntries(t *testing.T) {
+	p := completedPayment(t, 100)
+
+	transition, err := p.Refund(refund(40, "r-1"))
+
+	require.NoError(t, err)
+	require.Len(t, transition.Entries, 2)
+	assert.Equal(t, ClearingAccountID, transition.Entries[0].AccountID)
+	assert.Equal(t, ledger.Debit, transition.Entries[0].Direction)
+	assert.Equal(t, "acc-123", transition.Entries[1].AccountID)
+	assert.Equal(t, ledger.Credit, transition.Entries[1].Direction)
+	assert.Equal(t, 40.0, transition.Entries[1].Amount)
+	assert.Equal(t, p.Refunds[0].ID, transition.Entries[0].Reference)
+	assert.True(t, ledger.IsBalanced(transition.Entries))
+}
+
+func TestRefundCannotExceedOriginalAmount(t *testing.T) {
+	p := completedPayment(t, 100)
+
+	_, err := p.Refund(refund(100.01, "r-1"))
+	assert.Equal(t, errors.ErrRefundExceedsAmount, err)
+
+	_, err = p.Refund(refund(60, "r-2"))
+	require.NoError(t, err)
+
+	_, err = p.Refund(refund(40.01, "r-3"))
+	assert.Equal(t, errors.ErrRefundExceedsAmount, err)
+	assert.Equal(t, StatusPartiallyRefunded, p.Status)
+	assert.Equal(t, 60.0, p.RefundedAmount)
+}
+
+func TestRefundFullyRefundedPayment(t *testing.T) {
+	p := completedPayment(t, 100)
+	_, err := p.Refund(refund(100, "r-1"))
+	require.NoError(t, err)
+
+	_, err = p.Refund(refund(1, "r-2"))
+
+	assert.Equal(t, errors.ErrInvalidPaymentState, err)
+}
+
+func TestRefundBeforeCompletion(t *testing.T) {
+	p := newPayment(t, 100)
+
+	_, err := p.Refund(refund(10, "r-1"))
+
+	assert.Equal(t, errors.ErrInvalidPaymentState, err)
+}
+
+func TestRefundInvalidAmount(t *testing.T) {
+	p := completedPayment(t, 100)
+
+	_, err := p.Refund(refund(0, "r-1"))
+
+	assert.Equal(t, errors.ErrInvalidAmount, err)
+}
+
+func TestRefundDuplicateIdempotencyKey(t *testing.T) {
+	p := completedPayment(t, 100)
+	_, err := p.Refund(refund(10, "r-1"))
+	require.NoError(t, err)
+
+	_, err = p.Refund(refund(10, "r-1"))
+
+	assert.Equal(t, errors.ErrDuplicateRefund, err)
+	assert.Len(t, p.Refunds, 1)
+}
+
+func TestRefundWrongPayment(t *testing.T) {
+	p := completedPayment(t, 100)
+
+	_, err := p.Refund(events.RefundPaymentPayload{PaymentID: "other", Amount: 10})
+
+	assert.Equal(t, errors.ErrPaymentNotFound, err)
+}
+
+func TestTransitionsUpdateTimestamp(t *testing.T) {
+	fixed := time.Date(2025, time.June, 10, 12, 0, 0, 0, time.UTC)
+	original := now
+	now = func() time.Time { return fixed }
+	defer func() { now = original }()
+
+	p := newPayment(t, 10)
+	_, _ = p.Process()
+
+	assert.Equal(t, fixed, p.UpdatedAt)
+}
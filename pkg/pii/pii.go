@@ -0,0 +1,182 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package pii - Masking of personal data (LGPD) in logs and dumps
+// ═══════════════════════════════════════════════════════════════════════════
+
+package pii
+
+import (
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// Kind is a type of personal data. Struct fields declare it with a pii tag,
+// such as `pii:"cpf"`.
+type Kind string
+
+const (
+	KindCPF  Kind = "cpf"
+	KindCNPJ Kind = "cnpj"
+	// KindDocument is a CPF or a CNPJ, told apart by the number of digits
+	KindDocument Kind = "document"
+	KindEmail    Kind = "email"
+	KindPhone    Kind = "phone"
+	// KindPixKey is a Pix key, which may be any of the kinds above
+	KindPixKey Kind = "pix_key"
+)
+
+// Redacted replaces values that cannot be masked keeping their shape
+const Redacted = "[REDACTED]"
+
+// AllowListEnv names the variable read by AllowFromEnv
+const AllowListEnv = "PII_ALLOW_LIST"
+
+var kinds = map[string]Kind{
+	"cpf":      KindCPF,
+	"cnpj":     KindCNPJ,
+	"document": KindDocument,
+	"email":    KindEmail,
+	"phone":    KindPhone,
+	"pix_key":  KindPixKey,
+}
+
+// KindOf returns the kind of a map key or field name such as "email" or
+// "Document", or "" when the name is not known to hold personal data
+func KindOf(name string) Kind {
+	return kinds[strings.ToLower(name)]
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// ALLOW-LIST
+// ═══════════════════════════════════════════════════════════════════════════
+
+var allowed atomic.Pointer[map[Kind]bool]
+
+// Allow turns masking off for kinds, for debug environments that need the
+// raw values. It replaces the previous allow-list; Allow() masks every kind
+// again. Call it once at startup.
+func Allow(kinds ...Kind) {
+	set := make(map[Kind]bool, len(kinds))
+	for _, kind := range kinds {
+		set[kind] = true
+	}
+	allowed.Store(&set)
+}
+
+// AllowFromEnv calls Allow with the comma-separated kinds in PII_ALLOW_LIST,
+// such as "email,phone"
+func AllowFromEnv() {
+	var list []Kind
+	for _, kind := range strings.Split(os.Getenv(AllowListEnv), ",") {
+		if kind = strings.TrimSpace(kind); kind != "" {
+			list = append(list, Kind(kind))
+		}
+	}
+	Allow(list...)
+}
+
+func isAllowed(kind Kind) bool {
+	set := allowed.Load()
+	return set != nil && (*set)[kind]
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// MASKING
+// ═══════════════════════════════════════════════════════════════════════════
+
+// Mask masks value as kind unless kind is allowed. Empty values stay empty
+// and values that do not look like kind become Redacted.
+func Mask(kind Kind, value string) string {
+	if value == "" || isAllowed(kind) {
+		return value
+	}
+
+	switch kind {
+	case KindCPF:
+		return MaskCPF(value)
+	case KindCNPJ:
+		return MaskCNPJ(value)
+	case KindDocument:
+		return MaskDocument(value)
+	case KindEmail:
+		return MaskEmail(value)
+	case KindPhone:
+		return MaskPhone(value)
+	case KindPixKey:
+		return maskPixKey(value)
+	}
+	return Redacted
+}
+
+// MaskCPF keeps the middle digits in the validation.FormatCPF shape:
+// ***.456.789-**
+func MaskCPF(cpf string) string {
+	digits := onlyDigits(cpf)
+	if len(digits) != 11 {
+		return Redacted
+	}
+	return "***." + digits[3:6] + "." + digits[6:9] + "-**"
+}
+
+// MaskCNPJ keeps the middle digits in the validation.FormatCNPJ shape:
+// **.345.678/0001-**
+func MaskCNPJ(cnpj string) string {
+	digits := onlyDigits(cnpj)
+	if len(digits) != 14 {
+		return Redacted
+	}
+	return "**." + digits[2:5] + "." + digits[5:8] + "/" + digits[8:12] + "-**"
+}
+
+// MaskDocument masks a CPF or a CNPJ
+func MaskDocument(document string) string {
+	if len(onlyDigits(document)) == 14 {
+		return MaskCNPJ(document)
+	}
+	return MaskCPF(document)
+}
+
+// MaskEmail keeps the first letter and the domain: j***@example.com
+func MaskEmail(email string) string {
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok || local == "" || domain == "" {
+		return Redacted
+	}
+	return local[:1] + "***@" + domain
+}
+
+// MaskPhone keeps the area code and the last four digits in the
+// validation.FormatPhone shape: (11) *****-4321
+func MaskPhone(phone string) string {
+	digits := onlyDigits(phone)
+	if strings.HasPrefix(digits, "55") && len(digits) > 11 {
+		digits = digits[2:]
+	}
+	if len(digits) != 10 && len(digits) != 11 {
+		return Redacted
+	}
+	return "(" + digits[:2] + ") " + strings.Repeat("*", len(digits)-6) + "-" + digits[len(digits)-4:]
+}
+
+// maskPixKey masks email, phone (+55...) and CPF/CNPJ keys. Random keys
+// carry no personal data and are kept.
+func maskPixKey(key string) string {
+	switch {
+	case strings.Contains(key, "@"):
+		return MaskEmail(key)
+	case strings.HasPrefix(key, "+"):
+		return MaskPhone(key)
+	case len(onlyDigits(key)) == len(key):
+		return MaskDocument(key)
+	}
+	return key
+}
+
+func onlyDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < '0' || r > '9' {
+			return -1
+		}
+		return r
+	}, s)
+}
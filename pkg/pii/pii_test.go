@@ -0,0 +1,71 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package pii - Masking tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package pii
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMask(t *testing.T) {
+	tests := []struct {
+		kind     Kind
+		value    string
+		expected string
+	}{
+		{KindCPF, "529.982.247-25", "***.982.247-**"},
+		{KindCPF, "52998224725", "***.982.247-**"},
+		{KindCPF, "5299822", Redacted},
+		{KindCNPJ, "11.222.333/0001-81", "**.222.333/0001-**"},
+		{KindCNPJ, "112223330001", Redacted},
+		{KindDocument, "52998224725", "***.982.247-**"},
+		{KindDocument, "11222333000181", "**.222.333/0001-**"},
+		{KindEmail, "joao.silva@example.com", "j***@example.com"},
+		{KindEmail, "joao.silva", Redacted},
+		{KindEmail, "@example.com", Redacted},
+		{KindPhone, "+55 (11) 98765-4321", "(11) *****-4321"},
+		{KindPhone, "1133334444", "(11) ****-4444"},
+		{KindPhone, "98765", Redacted},
+		{KindPixKey, "joao@example.com", "j***@example.com"},
+		{KindPixKey, "+5511987654321", "(11) *****-4321"},
+		{KindPixKey, "52998224725", "***.982.247-**"},
+		{KindPixKey, "123e4567-e89b-12d3-a456-426614174000", "123e4567-e89b-12d3-a456-426614174000"},
+		{Kind("name"), "João da Silva", Redacted},
+		{KindEmail, "", ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, Mask(tt.kind, tt.value), "%s %q", tt.kind, tt.value)
+	}
+}
+
+func TestKindOf(t *testing.T) {
+	assert.Equal(t, KindEmail, KindOf("Email"))
+	assert.Equal(t, KindPixKey, KindOf("pix_key"))
+	assert.Equal(t, Kind(""), KindOf("account_id"))
+}
+
+func TestAllow(t *testing.T) {
+	t.Cleanup(func() { Allow() })
+
+	Allow(KindEmail)
+	assert.Equal(t, "joao@example.com", Mask(KindEmail, "joao@example.com"))
+	assert.Equal(t, "***.982.247-**", Mask(KindCPF, "52998224725"))
+
+	Allow()
+	assert.Equal(t, "j***@example.com", Mask(KindEmail, "joao@example.com"))
+}
+
+func TestAllowFromEnv(t *testing.T) {
+	t.Cleanup(func() { Allow() })
+	t.Setenv(AllowListEnv, "cpf, phone,")
+
+	AllowFromEnv()
+
+	assert.Equal(t, "52998224725", Mask(KindCPF, "52998224725"))
+	assert.Equal(t, "11987654321", Mask(KindPhone, "11987654321"))
+	assert.Equal(t, "j***@example.com", Mask(KindEmail, "joao@example.com"))
+}
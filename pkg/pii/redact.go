@@ -0,0 +1,213 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package pii - Redaction of structs, maps and slices
+// ═══════════════════════════════════════════════════════════════════════════
+
+package pii
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// Redact returns a copy of v with personal data masked: string fields tagged
+// `pii:"<kind>"` (also behind pointers, interfaces and in slices) and map
+// entries whose key is a known kind, such as "email". Nested structs, maps
+// and slices are walked; v itself is never modified. Each pointer, map and
+// slice is copied once, so values shared in v are shared in the copy and
+// cyclic values are copied with the same cycles.
+func Redact(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	return newRedactor().redact(reflect.ValueOf(v)).Interface()
+}
+
+// RedactMap returns a copy of m with the values of known keys masked
+func RedactMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	return Redact(m).(map[string]string)
+}
+
+// Field redacts value when logged under key: strings are masked when key is
+// a known kind, anything else goes through Redact
+func Field(key string, value interface{}) interface{} {
+	if s, ok := value.(string); ok {
+		if kind := KindOf(key); kind != "" {
+			return Mask(kind, s)
+		}
+	}
+	return Redact(value)
+}
+
+// Safe wraps v so that it marshals to JSON redacted, for lazy use in log
+// fields: logger.Info().Interface("payload", pii.Safe(payload))
+func Safe(v interface{}) json.Marshaler {
+	return safe{v}
+}
+
+type safe struct {
+	v interface{}
+}
+
+func (s safe) MarshalJSON() ([]byte, error) {
+	return json.Marshal(Redact(s.v))
+}
+
+// redactor remembers the copies it made of pointers, maps and slices
+type redactor struct {
+	copies map[visit]reflect.Value
+}
+
+// visit identifies a pointer, map or slice and the kind it is masked as,
+// empty when it is only redacted. Slices sharing an array differ in length.
+type visit struct {
+	typ  reflect.Type
+	ptr  uintptr
+	len  int
+	kind Kind
+}
+
+func newRedactor() *redactor {
+	return &redactor{copies: make(map[visit]reflect.Value)}
+}
+
+// copied returns the copy already made of v and the key to remember a new one
+func (r *redactor) copied(kind Kind, v reflect.Value) (reflect.Value, visit, bool) {
+	key := visit{typ: v.Type(), ptr: v.Pointer(), kind: kind}
+	if v.Kind() == reflect.Slice {
+		key.len = v.Len()
+	}
+	out, ok := r.copies[key]
+	return out, key, ok
+}
+
+func (r *redactor) redact(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		out, key, ok := r.copied("", v)
+		if ok {
+			return out
+		}
+		out = reflect.New(v.Type().Elem())
+		r.copies[key] = out
+		out.Elem().Set(r.redact(v.Elem()))
+		return out
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type()).Elem()
+		out.Set(r.redact(v.Elem()))
+		return out
+
+	case reflect.Struct:
+		out := reflect.New(v.Type()).Elem()
+		out.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if kind := Kind(field.Tag.Get("pii")); kind != "" {
+				out.Field(i).Set(r.mask(kind, v.Field(i)))
+			} else {
+				out.Field(i).Set(r.redact(v.Field(i)))
+			}
+		}
+		return out
+
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		out, key, ok := r.copied("", v)
+		if ok {
+			return out
+		}
+		out = reflect.MakeMapWithSize(v.Type(), v.Len())
+		r.copies[key] = out
+		for iter := v.MapRange(); iter.Next(); {
+			key, value := iter.Key(), iter.Value()
+			var kind Kind
+			if key.Kind() == reflect.String {
+				kind = KindOf(key.String())
+			}
+			if kind != "" {
+				out.SetMapIndex(key, r.mask(kind, value))
+			} else {
+				out.SetMapIndex(key, r.redact(value))
+			}
+		}
+		return out
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		out, key, ok := r.copied("", v)
+		if ok {
+			return out
+		}
+		out = reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		r.copies[key] = out
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(r.redact(v.Index(i)))
+		}
+		return out
+	}
+	return v
+}
+
+// mask masks the strings in v, which may be a string or a pointer,
+// interface or slice holding strings
+func (r *redactor) mask(kind Kind, v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.String:
+		out := reflect.New(v.Type()).Elem()
+		out.SetString(Mask(kind, v.String()))
+		return out
+
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		out, key, ok := r.copied(kind, v)
+		if ok {
+			return out
+		}
+		out = reflect.New(v.Type().Elem())
+		r.copies[key] = out
+		out.Elem().Set(r.mask(kind, v.Elem()))
+		return out
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type()).Elem()
+		out.Set(r.mask(kind, v.Elem()))
+		return out
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		out, key, ok := r.copied(kind, v)
+		if ok {
+			return out
+		}
+		out = reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		r.copies[key] = out
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(r.mask(kind, v.Index(i)))
+		}
+		return out
+	}
+	return r.redact(v)
+}
@@ -0,0 +1,154 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package pii - Redaction tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package pii
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type contact struct {
+	Email  *string  `json:"email,omitempty" pii:"email"`
+	Phones []string `json:"phones,omitempty" pii:"phone"`
+}
+
+type customer struct {
+	Name      string                 `json:"name"`
+	Document  string                 `json:"document" pii:"document"`
+	Contact   contact                `json:"contact"`
+	Backup    *contact               `json:"backup,omitempty"`
+	Extra     map[string]interface{} `json:"extra,omitempty"`
+	Tags      []string               `json:"tags,omitempty"`
+	Legacy    interface{}            `json:"legacy,omitempty" pii:"cpf"`
+	CreatedAt time.Time              `json:"created_at"`
+	internal  string
+}
+
+func TestRedactStruct(t *testing.T) {
+	email := "joao@example.com"
+	original := customer{
+		Name:     "João da Silva",
+		Document: "529.982.247-25",
+		Contact:  contact{Email: &email, Phones: []string{"11987654321"}},
+		Extra: map[string]interface{}{
+			"email":    "joao.work@example.com",
+			"phone":    nil,
+			"cpf":      52998224725,
+			"channel":  "app",
+			"referrer": nil,
+			"nested":   map[string]string{"cpf": "52998224725"},
+		},
+		Tags:      []string{"vip"},
+		Legacy:    "52998224725",
+		CreatedAt: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+		internal:  "kept",
+	}
+
+	redacted := Redact(&original).(*customer)
+
+	assert.Equal(t, "João da Silva", redacted.Name)
+	assert.Equal(t, "***.982.247-**", redacted.Document)
+	assert.Equal(t, "j***@example.com", *redacted.Contact.Email)
+	assert.Equal(t, []string{"(11) *****-4321"}, redacted.Contact.Phones)
+	assert.Nil(t, redacted.Backup)
+	assert.Equal(t, "j***@example.com", redacted.Extra["email"])
+	assert.Nil(t, redacted.Extra["phone"])
+	assert.Equal(t, 52998224725, redacted.Extra["cpf"], "only strings are masked")
+	assert.Nil(t, redacted.Extra["referrer"])
+	assert.Equal(t, "app", redacted.Extra["channel"])
+	assert.Equal(t, map[string]string{"cpf": "***.982.247-**"}, redacted.Extra["nested"])
+	assert.Equal(t, []string{"vip"}, redacted.Tags)
+	assert.Equal(t, "***.982.247-**", redacted.Legacy)
+	assert.Equal(t, original.CreatedAt, redacted.CreatedAt)
+	assert.Equal(t, "kept", redacted.internal)
+
+	assert.Equal(t, "529.982.247-25", original.Document, "the original is not modified")
+	assert.Equal(t, "joao@example.com", email)
+	assert.Equal(t, "joao.work@example.com", original.Extra["email"])
+}
+
+type node struct {
+	Email string      `pii:"email"`
+	Next  *node       `json:"-"`
+	Tags  interface{} `pii:"email"`
+}
+
+func TestRedactCycles(t *testing.T) {
+	tags := make([]interface{}, 2)
+	tags[0], tags[1] = "joao@example.com", tags
+	original := &node{Email: "joao@example.com", Tags: tags}
+	original.Next = original
+
+	redacted := Redact(original).(*node)
+
+	assert.Same(t, redacted, redacted.Next)
+	assert.Equal(t, "j***@example.com", redacted.Email)
+	masked := redacted.Tags.([]interface{})
+	assert.Equal(t, "j***@example.com", masked[0])
+	assert.Equal(t, reflect.ValueOf(masked).Pointer(), reflect.ValueOf(masked[1]).Pointer())
+	assert.Equal(t, "joao@example.com", original.Email)
+
+	extra := map[string]interface{}{"email": "joao@example.com"}
+	extra["self"] = extra
+	list := []interface{}{nil}
+	list[0] = list
+	redactedMap := Redact(extra).(map[string]interface{})
+	assert.Equal(t, "j***@example.com", redactedMap["email"])
+	assert.Equal(t, reflect.ValueOf(redactedMap).Pointer(), reflect.ValueOf(redactedMap["self"]).Pointer())
+	assert.NotPanics(t, func() { Redact(list) })
+}
+
+func TestRedactSharedPointers(t *testing.T) {
+	email := "joao@example.com"
+	type pair struct {
+		Masked *string `pii:"email"`
+		Plain  *string
+		Again  *string `pii:"email"`
+	}
+
+	redacted := Redact(pair{Masked: &email, Plain: &email, Again: &email}).(pair)
+
+	assert.Equal(t, "j***@example.com", *redacted.Masked)
+	assert.Equal(t, "joao@example.com", *redacted.Plain, "a pointer masked elsewhere is copied apart")
+	assert.Same(t, redacted.Masked, redacted.Again)
+}
+
+func TestRedactNilAndEmptyValues(t *testing.T) {
+	assert.Nil(t, Redact(nil))
+	assert.Nil(t, RedactMap(nil))
+	assert.Equal(t, 42, Redact(42))
+	assert.Equal(t, map[int]string{1: "email"}, Redact(map[int]string{1: "email"}))
+
+	redacted := Redact(customer{Contact: contact{}}).(customer)
+	assert.Nil(t, redacted.Contact.Email)
+	assert.Nil(t, redacted.Contact.Phones)
+	assert.Nil(t, redacted.Extra)
+	assert.Nil(t, redacted.Tags)
+	assert.Nil(t, redacted.Legacy)
+}
+
+func TestRedactMap(t *testing.T) {
+	details := map[string]string{"email": "joao@example.com", "reason": "duplicate"}
+
+	assert.Equal(t, map[string]string{"email": "j***@example.com", "reason": "duplicate"}, RedactMap(details))
+}
+
+func TestField(t *testing.T) {
+	assert.Equal(t, "j***@example.com", Field("email", "joao@example.com"))
+	assert.Equal(t, "app", Field("channel", "app"))
+	assert.Equal(t, map[string]string{"phone": "(11) *****-4321"}, Field("contact", map[string]string{"phone": "11987654321"}))
+}
+
+func TestSafe(t *testing.T) {
+	data, err := json.Marshal(map[string]interface{}{"customer": Safe(customer{Document: "11222333000181"})})
+
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"document":"**.222.333/0001-**"`)
+}
@@ -0,0 +1,229 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package request - JSON request binding
+// ═══════════════════════════════════════════════════════════════════════════
+
+package request
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/fintech-bank-platform/pkg/errors"
+	"github.com/fintech-bank-platform/pkg/validation"
+)
+
+// DefaultMaxBodySize is the largest body Bind reads unless WithMaxBodySize
+// says otherwise
+const DefaultMaxBodySize int64 = 1 << 20
+
+var errTrailingData = stderrors.New("unexpected data after the JSON value")
+
+// Option configures Bind
+type Option func(*binder)
+
+type binder struct {
+	maxBodySize int64
+}
+
+// WithMaxBodySize limits the request body to n bytes
+func WithMaxBodySize(n int64) Option {
+	return func(b *binder) {
+		b.maxBodySize = n
+	}
+}
+
+// Bind decodes the JSON body of r into a T, normalizes its documents and
+// validates it. Every failure is returned as an AppError ready to be
+// written with response.AppErrorFor:
+//
+//   - UNSUPPORTED_MEDIA_TYPE when Content-Type is not JSON
+//   - PAYLOAD_TOO_LARGE when the body exceeds the max size
+//   - INVALID_JSON for malformed, empty or trailing data, with the offset
+//   - INVALID_FIELD for unknown fields or values of the wrong type
+//   - VALIDATION_ERROR with one message per field, in the Accept-Language
+//     locale
+//
+// Fields validated as cpf, cnpj or phone_br are stored without formatting.
+// Only structs, or pointers to them, are validated; other types such as maps
+// and slices are decoded as they are.
+func Bind[T any](r *http.Request, opts ...Option) (T, *errors.AppError) {
+	b := &binder{maxBodySize: DefaultMaxBodySize}
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	var target T
+	if !isJSON(r.Header.Get("Content-Type")) {
+		return target, errors.ErrUnsupportedMediaType
+	}
+
+	decoder := json.NewDecoder(http.MaxBytesReader(nil, r.Body, b.maxBodySize))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&target); err != nil {
+		return target, b.decodeError(err, decoder)
+	}
+
+	end := decoder.InputOffset()
+	var trailing json.RawMessage
+	if err := decoder.Decode(&trailing); !stderrors.Is(err, io.EOF) {
+		if appErr := b.tooLarge(err); appErr != nil {
+			return target, appErr
+		}
+		if err == nil {
+			err = errTrailingData
+		}
+		return target, invalidJSON("trailing_data", end, err)
+	}
+
+	Normalize(&target)
+
+	locale, ok := errors.MatchLocale(r.Header.Get("Accept-Language"))
+	if !ok {
+		locale = errors.LocaleEN
+	}
+	if value, ok := structOf(&target); ok {
+		if appErr := validation.ToAppErrorIn(validation.Validate(value), locale); appErr != nil {
+			return target, appErr
+		}
+	}
+	return target, nil
+}
+
+// structOf returns a pointer to the struct that target points to, through
+// any number of pointers. The validator rejects anything else, and nil
+// pointers, as invalid input rather than as field errors.
+func structOf(target interface{}) (interface{}, bool) {
+	value := reflect.ValueOf(target)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil, false
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, false
+	}
+	return value.Addr().Interface(), true
+}
+
+// isJSON accepts application/json and structured +json media types
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" ||
+		strings.HasPrefix(mediaType, "application/") && strings.HasSuffix(mediaType, "+json")
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// Decode Errors
+// ═══════════════════════════════════════════════════════════════════════════
+
+func (b *binder) decodeError(err error, decoder *json.Decoder) *errors.AppError {
+	if appErr := b.tooLarge(err); appErr != nil {
+		return appErr
+	}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case stderrors.As(err, &syntaxErr):
+		return invalidJSON("syntax", syntaxErr.Offset, err)
+	case stderrors.As(err, &typeErr) && typeErr.Field != "":
+		return errors.BadRequest(errors.ErrInvalidField.Code, errors.ErrInvalidField.Message).
+			WithDetails(map[string]string{
+				"field":    typeErr.Field,
+				"expected": typeErr.Type.String(),
+				"offset":   strconv.FormatInt(typeErr.Offset, 10),
+			}).
+			Wrap(err)
+	case stderrors.As(err, &typeErr):
+		return invalidJSON("type", typeErr.Offset, err)
+	case stderrors.Is(err, io.EOF):
+		return invalidJSON("empty_body", 0, err)
+	case stderrors.Is(err, io.ErrUnexpectedEOF):
+		return invalidJSON("unexpected_eof", decoder.InputOffset(), err)
+	}
+
+	// encoding/json has no typed error for DisallowUnknownFields
+	if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		return errors.BadRequest(errors.ErrInvalidField.Code, errors.ErrInvalidField.Message).
+			WithDetails(map[string]string{"field": strings.Trim(field, `"`), "reason": "unknown_field"}).
+			Wrap(err)
+	}
+	return invalidJSON("unreadable", decoder.InputOffset(), err)
+}
+
+func (b *binder) tooLarge(err error) *errors.AppError {
+	var maxErr *http.MaxBytesError
+	if !stderrors.As(err, &maxErr) {
+		return nil
+	}
+	return errors.New(errors.ErrPayloadTooLarge.Code, errors.ErrPayloadTooLarge.Message, errors.ErrPayloadTooLarge.HTTPStatus).
+		WithDetail("max_bytes", strconv.FormatInt(b.maxBodySize, 10)).
+		Wrap(err)
+}
+
+func invalidJSON(reason string, offset int64, err error) *errors.AppError {
+	return errors.BadRequest(errors.ErrInvalidJSON.Code, errors.ErrInvalidJSON.Message).
+		WithDetails(map[string]string{"reason": reason, "offset": strconv.FormatInt(offset, 10)}).
+		Wrap(err)
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// Normalization
+// ═══════════════════════════════════════════════════════════════════════════
+
+var sanitizers = map[string]func(string) string{
+	"cpf":      validation.SanitizeCPF,
+	"cnpj":     validation.SanitizeCNPJ,
+	"phone_br": validation.SanitizePhone,
+}
+
+// Normalize strips the formatting of string fields validated as cpf, cnpj
+// or phone_br, walking nested structs, pointers and slices. v must be a
+// pointer.
+func Normalize(v interface{}) {
+	normalize(reflect.ValueOf(v))
+}
+
+func normalize(value reflect.Value) {
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !value.IsNil() {
+			normalize(value.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			normalize(value.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Field(i)
+			if !field.CanSet() {
+				continue
+			}
+			if sanitize := sanitizerFor(value.Type().Field(i).Tag.Get("validate")); sanitize != nil && field.Kind() == reflect.String {
+				field.SetString(sanitize(field.String()))
+				continue
+			}
+			normalize(field)
+		}
+	}
+}
+
+func sanitizerFor(tag string) func(string) string {
+	for _, rule := range strings.FieldsFunc(tag, func(r rune) bool { return r == ',' || r == '|' }) {
+		if sanitize, ok := sanitizers[rule]; ok {
+			return sanitize
+		}
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package request - Tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package request
+
+import (
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fintech-bank-platform/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testContact struct {
+	Phone string `json:"phone" validate:"required,phone_br"`
+}
+
+type testTransfer struct {
+	Document  string        `json:"document" validate:"required,cpf|cnpj"`
+	Amount    float64       `json:"amount" validate:"gt=0"`
+	Contact   *testContact  `json:"contact"`
+	Contacts  []testContact `json:"contacts" validate:"dive"`
+	ExpiresAt *time.Time    `json:"expires_at"`
+}
+
+func newRequest(body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/transfers", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+func bindError(t *testing.T, req *http.Request, opts ...Option) *errors.AppError {
+	t.Helper()
+	_, appErr := Bind[testTransfer](req, opts...)
+	require.NotNil(t, appErr)
+	return appErr
+}
+
+func TestBindDecodesAndNormalizes(t *testing.T) {
+	req := newRequest(`{
+		"document": "529.982.247-25",
+		"amount": 10.5,
+		"contact": {"phone": "(11) 98765-4321"},
+		"contacts": [{"phone": "+55 11 98765-4321"}]
+	}`)
+
+	transfer, appErr := Bind[testTransfer](req)
+
+	require.Nil(t, appErr)
+	assert.Equal(t, "52998224725", transfer.Document)
+	assert.Equal(t, 10.5, transfer.Amount)
+	assert.Equal(t, "11987654321", transfer.Contact.Phone)
+	assert.Equal(t, "5511987654321", transfer.Contacts[0].Phone)
+}
+
+func TestBindAcceptsJSONMediaTypes(t *testing.T) {
+	for _, contentType := range []string{"application/json; charset=utf-8", "application/merge-patch+json"} {
+		req := newRequest(`{"document": "52998224725", "amount": 1}`)
+		req.Header.Set("Content-Type", contentType)
+
+		_, appErr := Bind[testTransfer](req)
+		assert.Nil(t, appErr, contentType)
+	}
+}
+
+func TestBindRejectsUnsupportedMediaType(t *testing.T) {
+	for _, contentType := range []string{"", "text/plain", "application/xml+jsonx", "application/json; =bad"} {
+		req := newRequest(`{}`)
+		req.Header.Set("Content-Type", contentType)
+
+		appErr := bindError(t, req)
+		assert.Equal(t, errors.ErrUnsupportedMediaType, appErr, contentType)
+	}
+}
+
+func TestBindRejectsLargeBodies(t *testing.T) {
+	appErr := bindError(t, newRequest(`{"document": "52998224725", "amount": 1}`), WithMaxBodySize(10))
+
+	assert.True(t, stderrors.Is(appErr, errors.ErrPayloadTooLarge))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, appErr.HTTPStatus)
+	assert.Equal(t, "10", appErr.Details["max_bytes"])
+	assert.Nil(t, errors.ErrPayloadTooLarge.Details)
+}
+
+func TestBindRejectsLargeTrailingData(t *testing.T) {
+	appErr := bindError(t, newRequest(`{}`+strings.Repeat(" ", 64)+`{}`), WithMaxBodySize(16))
+
+	assert.Equal(t, errors.ErrPayloadTooLarge.Code, appErr.Code)
+}
+
+func TestBindMapsDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		code    string
+		details map[string]string
+	}{
+		{"syntax", `{"amount": 1,}`, "INVALID_JSON", map[string]string{"reason": "syntax", "offset": "14"}},
+		{"empty", ``, "INVALID_JSON", map[string]string{"reason": "empty_body", "offset": "0"}},
+		{"truncated", `{"amount": 1`, "INVALID_JSON", map[string]string{"reason": "unexpected_eof", "offset": "0"}},
+		{"trailing", `{} {}`, "INVALID_JSON", map[string]string{"reason": "trailing_data", "offset": "2"}},
+		{"not an object", `[1]`, "INVALID_JSON", map[string]string{"reason": "type", "offset": "1"}},
+		{"unreadable", `{"expires_at": "tomorrow"}`, "INVALID_JSON", map[string]string{"reason": "unreadable", "offset": "26"}},
+		{"wrong type", `{"amount": "ten"}`, "INVALID_FIELD", map[string]string{"field": "amount", "expected": "float64", "offset": "16"}},
+		{"unknown field", `{"amount": 1, "fee": 2}`, "INVALID_FIELD", map[string]string{"field": "fee", "reason": "unknown_field"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appErr := bindError(t, newRequest(tt.body))
+
+			assert.Equal(t, tt.code, appErr.Code)
+			assert.Equal(t, http.StatusBadRequest, appErr.HTTPStatus)
+			assert.Equal(t, tt.details, appErr.Details)
+			assert.NotNil(t, appErr.Unwrap())
+		})
+	}
+
+	assert.Nil(t, errors.ErrInvalidJSON.Details)
+	assert.Nil(t, errors.ErrInvalidField.Details)
+}
+
+func TestBindValidates(t *testing.T) {
+	appErr := bindError(t, newRequest(`{"document": "111.111.111-11", "amount": 0, "contacts": [{"phone": "123"}]}`))
+
+	assert.True(t, stderrors.Is(appErr, errors.ErrValidation))
+	assert.Contains(t, appErr.Details, "document")
+	assert.Equal(t, "must be greater than 0", appErr.Details["amount"])
+	assert.Contains(t, appErr.Details, "contacts.0.phone")
+}
+
+func TestBindValidatesInRequestLocale(t *testing.T) {
+	req := newRequest(`{"amount": 1}`)
+	req.Header.Set("Accept-Language", "pt-BR")
+
+	appErr := bindError(t, req)
+
+	assert.Equal(t, "é obrigatório", appErr.Details["document"])
+}
+
+func TestBindNonStructTypes(t *testing.T) {
+	fields, appErr := Bind[map[string]interface{}](newRequest(`{"amount": 1}`))
+	require.Nil(t, appErr)
+	assert.Equal(t, 1.0, fields["amount"])
+
+	ids, appErr := Bind[[]int](newRequest(`[1, 2]`))
+	require.Nil(t, appErr)
+	assert.Equal(t, []int{1, 2}, ids)
+
+	contact, appErr := Bind[*testContact](newRequest(`null`))
+	require.Nil(t, appErr)
+	assert.Nil(t, contact)
+
+	_, appErr = Bind[**testContact](newRequest(`{"phone": "123"}`))
+	require.NotNil(t, appErr)
+	assert.Contains(t, appErr.Details, "phone", "pointers to structs are validated")
+}
+
+func TestNormalizeSkipsWhatItCannotSet(t *testing.T) {
+	type record struct {
+		document string `validate:"cpf"`
+		Count    int    `validate:"cpf"`
+		Any      interface{}
+		Nil      *testContact
+		Fixed    [1]testContact
+	}
+	value := record{document: "529.982.247-25", Count: 1, Any: &testContact{Phone: "(11) 98765-4321"}, Fixed: [1]testContact{{Phone: "(11) 2345-6789"}}}
+
+	Normalize(&value)
+
+	assert.Equal(t, "529.982.247-25", value.document)
+	assert.Equal(t, 1, value.Count)
+	assert.Equal(t, "11987654321", value.Any.(*testContact).Phone)
+	assert.Equal(t, "1123456789", value.Fixed[0].Phone)
+}
@@ -0,0 +1,186 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package response - Cursor pagination
+// ═══════════════════════════════════════════════════════════════════════════
+
+package response
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/fintech-bank-platform/pkg/errors"
+)
+
+const (
+	// DefaultPageLimit is the page size when the request has no limit
+	DefaultPageLimit = 50
+	// DefaultMaxPageLimit is the largest limit a request may ask for
+	DefaultMaxPageLimit = 200
+
+	// maxCursorLength bounds the tokens accepted from clients before any
+	// decoding work is done
+	maxCursorLength = 2048
+)
+
+// ═══════════════════════════════════════════════════════════════════════════
+// Cursor Codec
+// ═══════════════════════════════════════════════════════════════════════════
+
+// CursorCodec turns any JSON-serializable position - a Cassandra paging
+// state, the sort key of the last row - into an opaque token signed with
+// HMAC-SHA256, so clients can neither read nor forge positions.
+//
+// Tokens have the form base64url(json) + "." + base64url(mac).
+type CursorCodec struct {
+	secret []byte
+}
+
+// NewCursorCodec creates a codec signing with secret. Every instance of a
+// service must share the secret for cursors to survive load balancing.
+func NewCursorCodec(secret []byte) *CursorCodec {
+	return &CursorCodec{secret: secret}
+}
+
+// Encode signs position as a cursor token
+func (c *CursorCodec) Encode(position interface{}) (string, error) {
+	payload, err := json.Marshal(position)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(c.sign(payload)), nil
+}
+
+// Decode verifies token and unmarshals its position into target. Malformed,
+// tampered or foreign tokens return errors.ErrInvalidCursor.
+func (c *CursorCodec) Decode(token string, target interface{}) error {
+	encodedPayload, encodedMAC, found := strings.Cut(token, ".")
+	if !found || len(token) > maxCursorLength {
+		return errors.ErrInvalidCursor
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return errors.ErrInvalidCursor
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(encodedMAC)
+	if err != nil || !hmac.Equal(mac, c.sign(payload)) {
+		return errors.ErrInvalidCursor
+	}
+
+	if err := json.Unmarshal(payload, target); err != nil {
+		return errors.ErrInvalidCursor
+	}
+	return nil
+}
+
+func (c *CursorCodec) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// Page Requests
+// ═══════════════════════════════════════════════════════════════════════════
+
+// PageLimits bounds the limit query parameter. Zero values fall back to
+// DefaultPageLimit and DefaultMaxPageLimit.
+type PageLimits struct {
+	Default int
+	Max     int
+}
+
+// PageRequest is the validated limit and cursor of a list request
+type PageRequest struct {
+	Limit  int
+	Cursor string
+}
+
+// HasCursor reports whether the request continues from a previous page
+func (p PageRequest) HasCursor() bool {
+	return p.Cursor != ""
+}
+
+// ParsePageRequest validates the limit and cursor query parameters. limit
+// must be an integer between 1 and limits.Max. When the request carries a
+// cursor it is verified with codec and decoded into position.
+func ParsePageRequest(r *http.Request, limits PageLimits, codec *CursorCodec, position interface{}) (PageRequest, error) {
+	if limits.Default <= 0 {
+		limits.Default = DefaultPageLimit
+	}
+	if limits.Max <= 0 {
+		limits.Max = DefaultMaxPageLimit
+	}
+
+	params := r.URL.Query()
+	page := PageRequest{Limit: min(limits.Default, limits.Max), Cursor: params.Get("cursor")}
+
+	if value := params.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > limits.Max {
+			return PageRequest{}, errors.BadRequest(errors.ErrInvalidField.Code, errors.ErrInvalidField.Message).
+				WithDetail("field", "limit").
+				WithDetail("max", strconv.Itoa(limits.Max))
+		}
+		page.Limit = limit
+	}
+
+	if page.HasCursor() {
+		if err := codec.Decode(page.Cursor, position); err != nil {
+			return PageRequest{}, err
+		}
+	}
+	return page, nil
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// Paginated Responses
+// ═══════════════════════════════════════════════════════════════════════════
+
+// SetPaginationLinks sets the RFC 8288 Link header for the cursors in meta.
+// Links keep the request path and query, replacing cursor and limit, and
+// include rel="first" when the request was not on the first page.
+func SetPaginationLinks(w http.ResponseWriter, r *http.Request, meta *Meta) {
+	var links []string
+	link := func(cursor, rel string) {
+		query := r.URL.Query()
+		query.Del("cursor")
+		if cursor != "" {
+			query.Set("cursor", cursor)
+		}
+		if meta.PerPage > 0 {
+			query.Set("limit", strconv.Itoa(meta.PerPage))
+		}
+
+		target := r.URL.Path
+		if encoded := query.Encode(); encoded != "" {
+			target += "?" + encoded
+		}
+		links = append(links, "<"+target+`>; rel="`+rel+`"`)
+	}
+
+	if meta.NextCursor != "" {
+		link(meta.NextCursor, "next")
+	}
+	if meta.PrevCursor != "" {
+		link(meta.PrevCursor, "prev")
+	}
+	if r.URL.Query().Get("cursor") != "" {
+		link("", "first")
+	}
+
+	if len(links) > 0 {
+		w.Header().Set("Link", strings.Join(links, ", "))
+	}
+}
+
+// Paginated writes a 200 OK response with the cursor meta and Link header
+func Paginated(w http.ResponseWriter, r *http.Request, data interface{}, meta *Meta) {
+	SetPaginationLinks(w, r, meta)
+	SuccessWithMeta(w, http.StatusOK, data, meta)
+}
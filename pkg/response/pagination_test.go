@@ -0,0 +1,177 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package response - Cursor pagination tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package response
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	pkgErrors "github.com/fintech-bank-platform/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testPosition struct {
+	PagingState []byte `json:"ps,omitempty"`
+	ID          string `json:"id,omitempty"`
+}
+
+func TestCursorCodecRoundTrip(t *testing.T) {
+	codec := NewCursorCodec([]byte("secret"))
+
+	token, err := codec.Encode(testPosition{PagingState: []byte{0x00, 0xff, 0x10}, ID: "t-1"})
+	require.NoError(t, err)
+
+	var position testPosition
+	require.NoError(t, codec.Decode(token, &position))
+	assert.Equal(t, []byte{0x00, 0xff, 0x10}, position.PagingState)
+	assert.Equal(t, "t-1", position.ID)
+	assert.NotContains(t, token, "t-1", "cursor is opaque")
+	assert.Equal(t, token, strings.NewReplacer("+", "", "/", "", "=", "").Replace(token), "cursor is URL safe")
+}
+
+func TestCursorCodecEncodeError(t *testing.T) {
+	_, err := NewCursorCodec([]byte("secret")).Encode(make(chan int))
+
+	assert.Error(t, err)
+}
+
+func TestCursorCodecRejectsInvalidTokens(t *testing.T) {
+	codec := NewCursorCodec([]byte("secret"))
+	valid, _ := codec.Encode(testPosition{ID: "t-1"})
+	foreign, _ := NewCursorCodec([]byte("other")).Encode(testPosition{ID: "t-1"})
+	payload, mac, _ := strings.Cut(valid, ".")
+	tampered := base64.RawURLEncoding.EncodeToString([]byte(`{"id":"t-2"}`)) + "." + mac
+	notJSON := base64.RawURLEncoding.EncodeToString([]byte("plain"))
+	notJSONToken := notJSON + "." + base64.RawURLEncoding.EncodeToString(codec.sign([]byte("plain")))
+
+	for name, token := range map[string]string{
+		"no separator":   payload,
+		"bad payload":    "%%%." + mac,
+		"bad mac":        payload + ".%%%",
+		"tampered":       tampered,
+		"foreign secret": foreign,
+		"not json":       notJSONToken,
+		"too long":       valid + strings.Repeat("A", maxCursorLength),
+	} {
+		var position testPosition
+		err := codec.Decode(token, &position)
+
+		assert.ErrorIs(t, err, pkgErrors.ErrInvalidCursor, name)
+	}
+}
+
+func TestParsePageRequestDefaults(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/items", nil)
+
+	page, err := ParsePageRequest(r, PageLimits{}, NewCursorCodec(nil), nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, DefaultPageLimit, page.Limit)
+	assert.False(t, page.HasCursor())
+}
+
+func TestParsePageRequestDefaultNeverExceedsMax(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/items", nil)
+
+	page, err := ParsePageRequest(r, PageLimits{Max: 10}, NewCursorCodec(nil), nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, 10, page.Limit)
+}
+
+func TestParsePageRequestLimit(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/items?limit=25", nil)
+
+	page, err := ParsePageRequest(r, PageLimits{Default: 10, Max: 25}, NewCursorCodec(nil), nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, 25, page.Limit)
+}
+
+func TestParsePageRequestInvalidLimit(t *testing.T) {
+	for _, limit := range []string{"0", "-1", "26", "ten"} {
+		r := httptest.NewRequest(http.MethodGet, "/items?limit="+limit, nil)
+
+		_, err := ParsePageRequest(r, PageLimits{Default: 10, Max: 25}, NewCursorCodec(nil), nil)
+
+		appErr, ok := pkgErrors.AsAppError(err)
+		require.True(t, ok, limit)
+		assert.Equal(t, "INVALID_FIELD", appErr.Code)
+		assert.Equal(t, map[string]string{"field": "limit", "max": "25"}, appErr.Details)
+	}
+}
+
+func TestParsePageRequestCursor(t *testing.T) {
+	codec := NewCursorCodec([]byte("secret"))
+	token, _ := codec.Encode(testPosition{ID: "t-9"})
+	r := httptest.NewRequest(http.MethodGet, "/items?cursor="+token, nil)
+
+	var position testPosition
+	page, err := ParsePageRequest(r, PageLimits{}, codec, &position)
+
+	require.NoError(t, err)
+	assert.True(t, page.HasCursor())
+	assert.Equal(t, token, page.Cursor)
+	assert.Equal(t, "t-9", position.ID)
+}
+
+func TestParsePageRequestInvalidCursor(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/items?cursor=forged", nil)
+
+	var position testPosition
+	_, err := ParsePageRequest(r, PageLimits{}, NewCursorCodec([]byte("secret")), &position)
+
+	assert.ErrorIs(t, err, pkgErrors.ErrInvalidCursor)
+}
+
+func TestSetPaginationLinks(t *testing.T) {
+	rec := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/items?status=active&cursor=c1&limit=5", nil)
+
+	SetPaginationLinks(rec, r, &Meta{PerPage: 5, NextCursor: "c2", PrevCursor: "c0"})
+
+	assert.Equal(t, `</items?cursor=c2&limit=5&status=active>; rel="next", `+
+		`</items?cursor=c0&limit=5&status=active>; rel="prev", `+
+		`</items?limit=5&status=active>; rel="first"`, rec.Header().Get("Link"))
+}
+
+func TestSetPaginationLinksWithoutQuery(t *testing.T) {
+	rec := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/items?cursor=c1", nil)
+
+	SetPaginationLinks(rec, r, &Meta{})
+
+	assert.Equal(t, `</items>; rel="first"`, rec.Header().Get("Link"))
+}
+
+func TestSetPaginationLinksSinglePage(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	SetPaginationLinks(rec, httptest.NewRequest(http.MethodGet, "/items", nil), &Meta{PerPage: 5})
+
+	assert.Empty(t, rec.Header().Values("Link"))
+}
+
+func TestPaginated(t *testing.T) {
+	rec := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/items", nil)
+
+	Paginated(rec, r, []string{"a"}, &Meta{PerPage: 1, NextCursor: "c2", HasMore: true})
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `</items?cursor=c2&limit=1>; rel="next"`, rec.Header().Get("Link"))
+
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	meta := result["meta"].(map[string]interface{})
+	assert.Equal(t, "c2", meta["next_cursor"])
+	assert.Equal(t, true, meta["has_more"])
+	assert.NotContains(t, meta, "prev_cursor")
+}
@@ -0,0 +1,148 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package response - RFC 7807 problem details
+// ═══════════════════════════════════════════════════════════════════════════
+
+package response
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/fintech-bank-platform/pkg/errors"
+)
+
+const (
+	// ProblemContentType is the media type of RFC 7807 responses
+	ProblemContentType = "application/problem+json"
+
+	// RequestIDHeader carries the request ID used as the problem instance
+	RequestIDHeader = "X-Request-ID"
+)
+
+// ProblemTypeBase prefixes the type URI of every problem; the error code is
+// appended in kebab case, e.g. LIMIT_EXCEEDED becomes .../limit-exceeded
+var ProblemTypeBase = "https://docs.fintech-bank-platform.com/errors/"
+
+// Problem is an RFC 7807 problem details object. Extensions are written as
+// top-level members next to the standard ones, which take precedence.
+type Problem struct {
+	Type       string
+	Title      string
+	Status     int
+	Detail     string
+	Instance   string
+	Extensions map[string]string
+}
+
+// NewProblem renders an AppError as a problem. The code is kept as the
+// "code" extension member and every detail becomes an extension member.
+func NewProblem(err *errors.AppError, instance string) *Problem {
+	extensions := map[string]string{"code": err.Code}
+	for key, value := range err.Details {
+		extensions[key] = value
+	}
+
+	return &Problem{
+		Type:       ProblemTypeBase + strings.ReplaceAll(strings.ToLower(err.Code), "_", "-"),
+		Title:      http.StatusText(err.HTTPStatus),
+		Status:     err.HTTPStatus,
+		Detail:     err.Message,
+		Instance:   instance,
+		Extensions: extensions,
+	}
+}
+
+// MarshalJSON flattens the extensions into the problem object
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	members := make(map[string]interface{}, len(p.Extensions)+5)
+	for key, value := range p.Extensions {
+		members[key] = value
+	}
+
+	members["type"] = p.Type
+	members["title"] = p.Title
+	members["status"] = p.Status
+	if p.Detail != "" {
+		members["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		members["instance"] = p.Instance
+	} else {
+		delete(members, "instance")
+	}
+	return json.Marshal(members)
+}
+
+// WriteProblem writes a problem with the application/problem+json type
+func WriteProblem(w http.ResponseWriter, problem *Problem) {
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(problem.Status)
+	json.NewEncoder(w).Encode(problem)
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// Content Negotiation
+// ═══════════════════════════════════════════════════════════════════════════
+
+// WantsProblem reports whether the Accept header prefers
+// application/problem+json over application/json. Wildcards do not count,
+// so clients that do not ask for it keep the legacy envelope.
+func WantsProblem(r *http.Request) bool {
+	var problem, plain float64
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err != nil {
+			continue
+		}
+
+		quality := 1.0
+		if value, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(value, 64); err != nil {
+				continue
+			}
+		}
+
+		switch mediaType {
+		case ProblemContentType:
+			problem = max(problem, quality)
+		case "application/json":
+			plain = max(plain, quality)
+		}
+	}
+	return problem > 0 && problem >= plain
+}
+
+// AppErrorFor writes an AppError as a problem when the client asks for
+// application/problem+json, and as the legacy envelope otherwise. The
+// message is translated to the Accept-Language locale when the catalog
+// has it. The instance is the request ID set on the response or the request.
+func AppErrorFor(w http.ResponseWriter, r *http.Request, err *errors.AppError) {
+	w.Header().Add("Vary", "Accept, Accept-Language")
+	if locale, ok := errors.MatchLocale(r.Header.Get("Accept-Language")); ok {
+		err = err.In(locale)
+	}
+
+	if !WantsProblem(r) {
+		AppError(w, err)
+		return
+	}
+
+	instance := w.Header().Get(RequestIDHeader)
+	if instance == "" {
+		instance = r.Header.Get(RequestIDHeader)
+	}
+	WriteProblem(w, NewProblem(err, instance))
+}
+
+// FromErrorFor writes any error with content negotiation, hiding errors
+// that are not AppErrors behind errors.ErrInternalServer
+func FromErrorFor(w http.ResponseWriter, r *http.Request, err error) {
+	if appErr, ok := errors.AsAppError(err); ok {
+		AppErrorFor(w, r, appErr)
+		return
+	}
+	AppErrorFor(w, r, errors.ErrInternalServer)
+}
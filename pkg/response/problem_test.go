@@ -0,0 +1,181 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package response - Problem details tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pkgErrors "github.com/fintech-bank-platform/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func problemRequest(accept string) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, "/accounts/1", nil)
+	if accept != "" {
+		r.Header.Set("Accept", accept)
+	}
+	return r
+}
+
+func decodeProblem(t *testing.T, rec *httptest.ResponseRecorder) map[string]interface{} {
+	t.Helper()
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	return result
+}
+
+func TestNewProblem(t *testing.T) {
+	err := pkgErrors.UnprocessableEntity("LIMIT_EXCEEDED", "Daily limit exceeded").WithDetail("limit", "daily")
+
+	problem := NewProblem(err, "req-1")
+
+	assert.Equal(t, "https://docs.fintech-bank-platform.com/errors/limit-exceeded", problem.Type)
+	assert.Equal(t, "Unprocessable Entity", problem.Title)
+	assert.Equal(t, http.StatusUnprocessableEntity, problem.Status)
+	assert.Equal(t, "Daily limit exceeded", problem.Detail)
+	assert.Equal(t, "req-1", problem.Instance)
+	assert.Equal(t, map[string]string{"code": "LIMIT_EXCEEDED", "limit": "daily"}, problem.Extensions)
+}
+
+func TestProblemMarshalJSON(t *testing.T) {
+	problem := &Problem{
+		Type: "about:blank", Title: "Not Found", Status: http.StatusNotFound, Detail: "Account not found",
+		Instance:   "req-1",
+		Extensions: map[string]string{"code": "ACCOUNT_NOT_FOUND", "status": "shadowed"},
+	}
+
+	data, err := json.Marshal(problem)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"type":"about:blank","title":"Not Found","status":404,"detail":"Account not found",
+		"instance":"req-1","code":"ACCOUNT_NOT_FOUND"}`, string(data))
+}
+
+func TestProblemMarshalJSONOmitsEmptyMembers(t *testing.T) {
+	problem := &Problem{Type: "about:blank", Title: "Bad Request", Status: http.StatusBadRequest,
+		Extensions: map[string]string{"instance": "spoofed"}}
+
+	data, err := json.Marshal(problem)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"type":"about:blank","title":"Bad Request","status":400}`, string(data))
+}
+
+func TestWantsProblem(t *testing.T) {
+	cases := map[string]bool{
+		"":                         false,
+		"*/*":                      false,
+		"application/json":         false,
+		"application/problem+json": true,
+		"application/json, application/problem+json":             true,
+		"application/problem+json;q=0.5, application/json":       false,
+		"application/problem+json, application/json;q=0.9":       true,
+		"application/problem+json;q=0":                           false,
+		"application/problem+json;q=high":                        false,
+		"text/html, ;;, application/problem+json; charset=utf-8": true,
+	}
+
+	for accept, expected := range cases {
+		assert.Equal(t, expected, WantsProblem(problemRequest(accept)), accept)
+	}
+}
+
+func TestAppErrorFor_Problem(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rec.Header().Set(RequestIDHeader, "req-from-middleware")
+	r := problemRequest(ProblemContentType)
+
+	AppErrorFor(rec, r, pkgErrors.ErrAccountNotFound)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, ProblemContentType, rec.Header().Get("Content-Type"))
+	result := decodeProblem(t, rec)
+	assert.Equal(t, "https://docs.fintech-bank-platform.com/errors/account-not-found", result["type"])
+	assert.Equal(t, "Account not found", result["detail"])
+	assert.Equal(t, "req-from-middleware", result["instance"])
+	assert.Equal(t, "ACCOUNT_NOT_FOUND", result["code"])
+}
+
+func TestAppErrorFor_RequestIDFromRequest(t *testing.T) {
+	rec := httptest.NewRecorder()
+	r := problemRequest(ProblemContentType)
+	r.Header.Set(RequestIDHeader, "req-from-client")
+
+	AppErrorFor(rec, r, pkgErrors.ErrForbidden)
+
+	assert.Equal(t, "req-from-client", decodeProblem(t, rec)["instance"])
+}
+
+func TestAppErrorFor_LegacyEnvelope(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	AppErrorFor(rec, problemRequest("application/json"), pkgErrors.ErrAccountNotFound)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var result Response
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	assert.False(t, result.Success)
+	assert.Equal(t, "ACCOUNT_NOT_FOUND", result.Error.Code)
+}
+
+func TestFromErrorFor_AppError(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	FromErrorFor(rec, problemRequest(ProblemContentType), pkgErrors.ErrInvalidCursor)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "INVALID_CURSOR", decodeProblem(t, rec)["code"])
+}
+
+func TestFromErrorFor_StandardError(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	FromErrorFor(rec, problemRequest(ProblemContentType), errors.New("connection refused"))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	result := decodeProblem(t, rec)
+	assert.Equal(t, "Internal server error", result["detail"])
+	assert.NotContains(t, rec.Body.String(), "connection refused")
+}
+
+func TestAppErrorFor_Localized(t *testing.T) {
+	rec := httptest.NewRecorder()
+	r := problemRequest("")
+	r.Header.Set("Accept-Language", "pt-BR,pt;q=0.9,en;q=0.8")
+
+	AppErrorFor(rec, r, pkgErrors.BadRequest("MISSING_FIELD", "Required field is missing").WithDetail("field", "method"))
+
+	var result Response
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	assert.Equal(t, "O campo method é obrigatório", result.Error.Message)
+	assert.Equal(t, "Accept, Accept-Language", rec.Header().Get("Vary"))
+}
+
+func TestAppErrorFor_LocalizedProblem(t *testing.T) {
+	rec := httptest.NewRecorder()
+	r := problemRequest(ProblemContentType)
+	r.Header.Set("Accept-Language", "pt")
+
+	FromErrorFor(rec, r, errors.New("boom"))
+
+	assert.Equal(t, "Erro interno do servidor", decodeProblem(t, rec)["detail"])
+}
+
+func TestAppErrorFor_UnsupportedLanguageKeepsMessage(t *testing.T) {
+	rec := httptest.NewRecorder()
+	r := problemRequest("")
+	r.Header.Set("Accept-Language", "fr")
+
+	AppErrorFor(rec, r, pkgErrors.ErrAccountNotFound)
+
+	assert.Contains(t, rec.Body.String(), "Account not found")
+}
@@ -33,6 +33,9 @@ type Meta struct {
 	PerPage    int    `json:"per_page,omitempty"`
 	Total      int64  `json:"total,omitempty"`
 	TotalPages int    `json:"total_pages,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+	HasMore    bool   `json:"has_more,omitempty"`
 }
 
 // ═══════════════════════════════════════════════════════════════════════════
@@ -64,6 +64,19 @@ func TestSuccessWithMeta(t *testing.T) {
 	assert.Equal(t, int64(100), result.Meta.Total)
 }
 
+func TestSuccessWithCursorMeta(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	SuccessWithMeta(rec, http.StatusOK, []string{"item1"}, &Meta{PerPage: 1, NextCursor: "abc", HasMore: true})
+
+	var result map[string]map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &result)
+
+	assert.Equal(t, "abc", result["meta"]["next_cursor"])
+	assert.Equal(t, true, result["meta"]["has_more"])
+	assert.NotContains(t, result["meta"], "page")
+}
+
 func TestError(t *testing.T) {
 	rec := httptest.NewRecorder()
 
@@ -0,0 +1,320 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package risk - Fraud and risk scoring for transfers and payments
+// ═══════════════════════════════════════════════════════════════════════════
+
+package risk
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/fintech-bank-platform/pkg/errors"
+	"github.com/fintech-bank-platform/pkg/events"
+	"github.com/google/uuid"
+)
+
+// Event metadata keys the gateway sets with the client's device and IP
+const (
+	MetadataDeviceID  = "device_id"
+	MetadataIPAddress = "ip_address"
+	MetadataDecision  = "risk_decision"
+)
+
+// Default score thresholds
+const (
+	DefaultReviewScore = 40
+	DefaultDenyScore   = 80
+)
+
+// ═══════════════════════════════════════════════════════════════════════════
+// DECISION
+// ═══════════════════════════════════════════════════════════════════════════
+
+// Decision is the outcome of an assessment
+type Decision string
+
+const (
+	DecisionAllow  Decision = "allow"
+	DecisionReview Decision = "review"
+	DecisionDeny   Decision = "deny"
+)
+
+func (d Decision) severity() int {
+	switch d {
+	case DecisionDeny:
+		return 2
+	case DecisionReview:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// REQUEST & ASSESSMENT
+// ═══════════════════════════════════════════════════════════════════════════
+
+// Request is a transfer or payment command normalized for scoring
+type Request struct {
+	CommandID     string
+	CommandType   string
+	AccountID     string
+	Recipient     string
+	PaymentMethod string
+	PixKey        string
+	Amount        float64
+	Currency      string
+	DeviceID      string
+	IPAddress     string
+	At            time.Time
+}
+
+// RequestFromEvent builds a Request from a ProcessTransfer or ProcessPayment
+// command. Device and IP come from the event metadata.
+func RequestFromEvent(event *events.Event) (*Request, error) {
+	req := &Request{
+		CommandID:   event.ID,
+		CommandType: event.Type,
+		DeviceID:    event.Metadata[MetadataDeviceID],
+		IPAddress:   event.Metadata[MetadataIPAddress],
+		At:          event.Timestamp,
+	}
+
+	switch event.Type {
+	case events.EventTypes.ProcessTransfer:
+		var cmd events.ProcessTransferPayload
+		if err := event.DecodePayload(&cmd); err != nil {
+			return nil, err
+		}
+		req.AccountID, req.Recipient = cmd.FromAccountID, cmd.ToAccountID
+		req.Amount, req.Currency = cmd.Amount, cmd.Currency
+	case events.EventTypes.ProcessPayment:
+		var cmd events.ProcessPaymentPayload
+		if err := event.DecodePayload(&cmd); err != nil {
+			return nil, err
+		}
+		req.AccountID, req.Recipient = cmd.AccountID, cmd.Recipient
+		req.PaymentMethod, req.PixKey = cmd.PaymentMethod, cmd.PixKey
+		req.Amount, req.Currency = cmd.Amount, cmd.Currency
+		if cmd.PixKey != "" {
+			req.Recipient = cmd.PixKey
+		}
+	default:
+		return nil, fmt.Errorf("risk: unsupported command %q", event.Type)
+	}
+	return req, nil
+}
+
+// Reason is one rule's contribution to an assessment. A rule may force a
+// minimum decision regardless of the total score.
+type Reason struct {
+	Rule     string
+	Code     string
+	Message  string
+	Score    int
+	Decision Decision
+}
+
+// Assessment is the result of scoring a request
+type Assessment struct {
+	ID         string
+	Request    *Request
+	Decision   Decision
+	Score      int
+	Reasons    []Reason
+	AssessedAt time.Time
+}
+
+// Event builds the RiskAssessed event for the assessment
+func (a *Assessment) Event() *events.Event {
+	reasons := make([]events.RiskReason, 0, len(a.Reasons))
+	for _, r := range a.Reasons {
+		reasons = append(reasons, events.RiskReason{Rule: r.Rule, Code: r.Code, Message: r.Message, Score: r.Score})
+	}
+
+	return events.NewRiskEvent(events.EventTypes.RiskAssessed, events.RiskAssessedPayload{
+		AssessmentID: a.ID,
+		CommandID:    a.Request.CommandID,
+		CommandType:  a.Request.CommandType,
+		AccountID:    a.Request.AccountID,
+		Amount:       a.Request.Amount,
+		Currency:     a.Request.Currency,
+		Decision:     string(a.Decision),
+		Score:        a.Score,
+		Reasons:      reasons,
+		AssessedAt:   a.AssessedAt,
+	}).WithMetadata("command_id", a.Request.CommandID)
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// ENGINE
+// ═══════════════════════════════════════════════════════════════════════════
+
+// Rule scores one aspect of a request. It returns nil when it sees no risk.
+type Rule interface {
+	Name() string
+	Evaluate(ctx context.Context, req *Request, store Store) (*Reason, error)
+}
+
+// Engine runs the rules and aggregates their reasons into a decision
+type Engine struct {
+	store       Store
+	rules       []Rule
+	reviewScore int
+	denyScore   int
+	now         func() time.Time
+}
+
+// Option configures an Engine
+type Option func(*Engine)
+
+// WithRules replaces the default rules
+func WithRules(rules ...Rule) Option {
+	return func(e *Engine) {
+		e.rules = rules
+	}
+}
+
+// WithThresholds sets the total scores that trigger review and deny
+func WithThresholds(review, deny int) Option {
+	return func(e *Engine) {
+		e.reviewScore = review
+		e.denyScore = deny
+	}
+}
+
+// WithClock sets the clock used for requests without a timestamp
+func WithClock(now func() time.Time) Option {
+	return func(e *Engine) {
+		e.now = now
+	}
+}
+
+// NewEngine creates an engine with the default rules and thresholds
+func NewEngine(store Store, opts ...Option) *Engine {
+	e := &Engine{
+		store:       store,
+		rules:       DefaultRules(),
+		reviewScore: DefaultReviewScore,
+		denyScore:   DefaultDenyScore,
+		now:         time.Now,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Assess scores the request. Operations that are not denied are recorded
+// in the store so later requests see them in their windows.
+func (e *Engine) Assess(ctx context.Context, req *Request) (*Assessment, error) {
+	assessment, err := e.score(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if assessment.Decision != DecisionDeny {
+		if err := e.record(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	return assessment, nil
+}
+
+// score runs the rules without recording the operation
+func (e *Engine) score(ctx context.Context, req *Request) (*Assessment, error) {
+	if req.At.IsZero() {
+		req.At = e.now()
+	}
+
+	assessment := &Assessment{
+		ID:         uuid.NewString(),
+		Request:    req,
+		Decision:   DecisionAllow,
+		AssessedAt: e.now().UTC(),
+	}
+
+	forced := DecisionAllow
+	for _, rule := range e.rules {
+		reason, err := rule.Evaluate(ctx, req, e.store)
+		if err != nil {
+			return nil, fmt.Errorf("risk rule %s: %w", rule.Name(), err)
+		}
+		if reason == nil {
+			continue
+		}
+		reason.Rule = rule.Name()
+		assessment.Reasons = append(assessment.Reasons, *reason)
+		assessment.Score += reason.Score
+		if reason.Decision.severity() > forced.severity() {
+			forced = reason.Decision
+		}
+	}
+
+	switch {
+	case assessment.Score >= e.denyScore:
+		assessment.Decision = DecisionDeny
+	case assessment.Score >= e.reviewScore:
+		assessment.Decision = DecisionReview
+	}
+	if forced.severity() > assessment.Decision.severity() {
+		assessment.Decision = forced
+	}
+
+	return assessment, nil
+}
+
+// record adds the operation to the account's windows
+func (e *Engine) record(ctx context.Context, req *Request) error {
+	return e.store.Record(ctx, req.AccountID, Operation{
+		Amount:    req.Amount,
+		Currency:  req.Currency,
+		Recipient: req.Recipient,
+		DeviceID:  req.DeviceID,
+		IPAddress: req.IPAddress,
+		At:        req.At,
+	})
+}
+
+// Guard screens transfer and payment commands before next handles them.
+// Every decision is published to Topics.RiskEvents; denied commands stop
+// with errors.ErrRiskDenied and the others reach next as a copy flagged with
+// the risk_decision metadata, leaving the caller's event untouched. An
+// operation is recorded for later windows only once next handled it, so
+// failed commands do not count. Other event types pass through untouched.
+func (e *Engine) Guard(publisher events.Publisher, next events.Handler) events.Handler {
+	return func(ctx context.Context, event *events.Event) error {
+		if event.Type != events.EventTypes.ProcessTransfer && event.Type != events.EventTypes.ProcessPayment {
+			return next(ctx, event)
+		}
+
+		req, err := RequestFromEvent(event)
+		if err != nil {
+			return err
+		}
+		assessment, err := e.score(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		decision := assessment.Event()
+		if event.TraceID != "" {
+			decision.WithTraceID(event.TraceID)
+		}
+		if err := publisher.Publish(ctx, events.Topics.RiskEvents, decision); err != nil {
+			return err
+		}
+
+		if assessment.Decision == DecisionDeny {
+			return errors.ErrRiskDenied
+		}
+
+		flagged := *event
+		flagged.Metadata = maps.Clone(event.Metadata)
+		if err := next(ctx, flagged.WithMetadata(MetadataDecision, string(assessment.Decision))); err != nil {
+			return err
+		}
+		return e.record(ctx, req)
+	}
+}
@@ -0,0 +1,334 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package risk - Engine Tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package risk
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	apperrors "github.com/fintech-bank-platform/pkg/errors"
+	"github.com/fintech-bank-platform/pkg/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixedRule returns the same reason for every request
+type fixedRule struct {
+	name   string
+	reason *Reason
+	err    error
+}
+
+func (r fixedRule) Name() string { return r.name }
+
+func (r fixedRule) Evaluate(context.Context, *Request, Store) (*Reason, error) {
+	if r.reason == nil {
+		return nil, r.err
+	}
+	reason := *r.reason
+	return &reason, r.err
+}
+
+func score(name string, points int) Rule {
+	return fixedRule{name: name, reason: &Reason{Code: name, Score: points}}
+}
+
+func transferCommand(amount float64) *events.Event {
+	return events.NewTransactionCommand(events.EventTypes.ProcessTransfer, events.ProcessTransferPayload{
+		FromAccountID: "acc-1",
+		ToAccountID:   "acc-2",
+		Amount:        amount,
+		Currency:      "BRL",
+	})
+}
+
+func TestRequestFromTransfer(t *testing.T) {
+	event := transferCommand(150).
+		WithMetadata(MetadataDeviceID, "phone-1").
+		WithMetadata(MetadataIPAddress, "10.0.0.1")
+
+	req, err := RequestFromEvent(event)
+
+	require.NoError(t, err)
+	assert.Equal(t, event.ID, req.CommandID)
+	assert.Equal(t, events.EventTypes.ProcessTransfer, req.CommandType)
+	assert.Equal(t, "acc-1", req.AccountID)
+	assert.Equal(t, "acc-2", req.Recipient)
+	assert.Equal(t, 150.0, req.Amount)
+	assert.Equal(t, "BRL", req.Currency)
+	assert.Equal(t, "phone-1", req.DeviceID)
+	assert.Equal(t, "10.0.0.1", req.IPAddress)
+	assert.Equal(t, event.Timestamp, req.At)
+}
+
+func TestRequestFromPayment(t *testing.T) {
+	pix := events.NewPaymentCommand(events.EventTypes.ProcessPayment, events.ProcessPaymentPayload{
+		AccountID:     "acc-1",
+		PaymentMethod: "pix",
+		Amount:        80,
+		Currency:      "BRL",
+		Recipient:     "Loja",
+		PixKey:        "loja@pix",
+	})
+	boleto := events.NewPaymentCommand(events.EventTypes.ProcessPayment, events.ProcessPaymentPayload{
+		AccountID:     "acc-1",
+		PaymentMethod: "boleto",
+		Amount:        80,
+		Recipient:     "Energia",
+	})
+
+	req, err := RequestFromEvent(pix)
+	require.NoError(t, err)
+	assert.Equal(t, "loja@pix", req.Recipient)
+	assert.Equal(t, "loja@pix", req.PixKey)
+	assert.Equal(t, "pix", req.PaymentMethod)
+
+	req, err = RequestFromEvent(boleto)
+	require.NoError(t, err)
+	assert.Equal(t, "Energia", req.Recipient)
+	assert.Empty(t, req.PixKey)
+}
+
+func TestRequestFromEventErrors(t *testing.T) {
+	_, err := RequestFromEvent(events.NewAccountEvent(events.EventTypes.AccountCreated, nil))
+	assert.ErrorContains(t, err, "unsupported command")
+
+	_, err = RequestFromEvent(events.NewTransactionCommand(events.EventTypes.ProcessTransfer, "text"))
+	assert.Error(t, err)
+
+	_, err = RequestFromEvent(events.NewPaymentCommand(events.EventTypes.ProcessPayment, "text"))
+	assert.Error(t, err)
+}
+
+func TestAssessThresholds(t *testing.T) {
+	cases := []struct {
+		rules    []Rule
+		decision Decision
+		score    int
+	}{
+		{nil, DecisionAllow, 0},
+		{[]Rule{score("a", 20), fixedRule{name: "quiet"}}, DecisionAllow, 20},
+		{[]Rule{score("a", 20), score("b", 20)}, DecisionReview, 40},
+		{[]Rule{score("a", 50), score("b", 30)}, DecisionDeny, 80},
+	}
+
+	for _, tc := range cases {
+		engine := NewEngine(NewMemoryStore(time.Hour), WithRules(tc.rules...))
+		assessment, err := engine.Assess(context.Background(), request(10))
+
+		require.NoError(t, err)
+		assert.Equal(t, tc.decision, assessment.Decision)
+		assert.Equal(t, tc.score, assessment.Score)
+		assert.NotEmpty(t, assessment.ID)
+	}
+}
+
+func TestAssessReasonsForceDecision(t *testing.T) {
+	forced := fixedRule{name: "forced", reason: &Reason{Code: "X", Decision: DecisionReview}}
+	engine := NewEngine(NewMemoryStore(time.Hour),
+		WithRules(forced, score("a", 10)),
+		WithThresholds(30, 60),
+	)
+
+	assessment, err := engine.Assess(context.Background(), request(10))
+
+	require.NoError(t, err)
+	assert.Equal(t, DecisionReview, assessment.Decision)
+	assert.Equal(t, 10, assessment.Score)
+	require.Len(t, assessment.Reasons, 2)
+	assert.Equal(t, "forced", assessment.Reasons[0].Rule)
+	assert.Equal(t, "a", assessment.Reasons[1].Rule)
+
+	// a lower forced decision never downgrades the score-based one
+	engine = NewEngine(NewMemoryStore(time.Hour), WithRules(forced, score("a", 90)))
+	assessment, _ = engine.Assess(context.Background(), request(10))
+	assert.Equal(t, DecisionDeny, assessment.Decision)
+}
+
+func TestAssessRecordsOnlyAcceptedOperations(t *testing.T) {
+	store := NewMemoryStore(time.Hour)
+	night := NightRule{StartHour: 0, EndHour: 24, MaxAmount: 100, Location: time.UTC}
+	engine := NewEngine(store, WithRules(night))
+
+	allowed, err := engine.Assess(context.Background(), request(50))
+	require.NoError(t, err)
+	assert.Equal(t, DecisionAllow, allowed.Decision)
+
+	denied, err := engine.Assess(context.Background(), request(500))
+	require.NoError(t, err)
+	assert.Equal(t, DecisionDeny, denied.Decision)
+
+	ops, _ := store.History(context.Background(), "acc-1", time.Time{})
+	require.Len(t, ops, 1)
+	assert.Equal(t, 50.0, ops[0].Amount)
+}
+
+func TestAssessUsesClockWithoutTimestamp(t *testing.T) {
+	engine := NewEngine(NewMemoryStore(time.Hour), WithRules(), WithClock(func() time.Time { return noon }))
+	req := &Request{AccountID: "acc-1", Amount: 10}
+
+	assessment, err := engine.Assess(context.Background(), req)
+
+	require.NoError(t, err)
+	assert.Equal(t, noon, req.At)
+	assert.Equal(t, noon.UTC(), assessment.AssessedAt)
+}
+
+func TestAssessErrors(t *testing.T) {
+	broken := fixedRule{name: "broken", err: errBad}
+	_, err := NewEngine(NewMemoryStore(time.Hour), WithRules(broken)).Assess(context.Background(), request(10))
+	assert.ErrorIs(t, err, errBad)
+	assert.ErrorContains(t, err, "risk rule broken")
+
+	_, err = NewEngine(failingStore{}, WithRules()).Assess(context.Background(), request(10))
+	assert.ErrorIs(t, err, errBad)
+}
+
+func TestDefaultEngineScoresVelocityAndDevice(t *testing.T) {
+	store := NewMemoryStore(90 * 24 * time.Hour)
+	engine := NewEngine(store)
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		req := request(100)
+		req.DeviceID = "phone-1"
+		req.At = noon.Add(time.Duration(i) * time.Minute)
+		assessment, err := engine.Assess(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, DecisionAllow, assessment.Decision)
+	}
+
+	req := request(100)
+	req.DeviceID = "phone-2"
+	req.At = noon.Add(5 * time.Minute)
+	assessment, err := engine.Assess(ctx, req)
+
+	require.NoError(t, err)
+	assert.Equal(t, DecisionReview, assessment.Decision)
+	assert.Equal(t, 70, assessment.Score)
+}
+
+func TestAssessmentEvent(t *testing.T) {
+	engine := NewEngine(NewMemoryStore(time.Hour), WithRules(score("a", 45)))
+	req, _ := RequestFromEvent(transferCommand(100))
+	assessment, _ := engine.Assess(context.Background(), req)
+
+	event := assessment.Event()
+
+	assert.Equal(t, events.EventTypes.RiskAssessed, event.Type)
+	assert.Equal(t, "risk-engine", event.Source)
+	assert.Equal(t, req.CommandID, event.Metadata["command_id"])
+
+	var payload events.RiskAssessedPayload
+	require.NoError(t, event.DecodePayload(&payload))
+	assert.Equal(t, assessment.ID, payload.AssessmentID)
+	assert.Equal(t, "acc-1", payload.AccountID)
+	assert.Equal(t, "review", payload.Decision)
+	assert.Equal(t, 45, payload.Score)
+	assert.Equal(t, []events.RiskReason{{Rule: "a", Code: "a", Score: 45}}, payload.Reasons)
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// GUARD
+// ═══════════════════════════════════════════════════════════════════════════
+
+type recorder struct {
+	events []*events.Event
+}
+
+func (r *recorder) handle(_ context.Context, event *events.Event) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+func TestGuardPassesThroughOtherEvents(t *testing.T) {
+	bus := events.NewMemoryBus()
+	next := &recorder{}
+	guard := NewEngine(NewMemoryStore(time.Hour)).Guard(bus, next.handle)
+
+	err := guard(context.Background(), events.NewAccountCommand(events.EventTypes.CreateAccount, nil))
+
+	require.NoError(t, err)
+	assert.Len(t, next.events, 1)
+	assert.Empty(t, bus.Published(events.Topics.RiskEvents))
+}
+
+func TestGuardAllowsAndFlagsCommands(t *testing.T) {
+	bus := events.NewMemoryBus()
+	next := &recorder{}
+	guard := NewEngine(NewMemoryStore(time.Hour), WithRules(score("a", 50))).Guard(bus, next.handle)
+
+	command := transferCommand(100).WithTraceID("trace-1")
+	err := guard(context.Background(), command)
+
+	require.NoError(t, err)
+	require.Len(t, next.events, 1)
+	assert.Equal(t, "review", next.events[0].Metadata[MetadataDecision])
+	assert.Nil(t, command.Metadata, "the caller's event is not modified")
+
+	published := bus.Published(events.Topics.RiskEvents)
+	require.Len(t, published, 1)
+	assert.Equal(t, "trace-1", published[0].TraceID)
+}
+
+func TestGuardDeniesCommands(t *testing.T) {
+	bus := events.NewMemoryBus()
+	next := &recorder{}
+	guard := NewEngine(NewMemoryStore(time.Hour), WithRules(score("a", 100))).Guard(bus, next.handle)
+
+	err := guard(context.Background(), transferCommand(100))
+
+	assert.ErrorIs(t, err, apperrors.ErrRiskDenied)
+	assert.Empty(t, next.events)
+	require.Len(t, bus.Published(events.Topics.RiskEvents), 1)
+	assert.Empty(t, bus.Published(events.Topics.RiskEvents)[0].TraceID)
+}
+
+func TestGuardErrors(t *testing.T) {
+	next := &recorder{}
+	bus := events.NewMemoryBus()
+
+	guard := NewEngine(NewMemoryStore(time.Hour)).Guard(bus, next.handle)
+	err := guard(context.Background(), events.NewTransactionCommand(events.EventTypes.ProcessTransfer, "text"))
+	assert.Error(t, err)
+
+	guard = NewEngine(failingStore{}).Guard(bus, next.handle)
+	err = guard(context.Background(), transferCommand(100))
+	assert.ErrorIs(t, err, errBad)
+
+	failing := events.NewMemoryBus()
+	failing.Subscribe(events.Topics.RiskEvents, func(context.Context, *events.Event) error {
+		return errors.New("broker down")
+	})
+	guard = NewEngine(NewMemoryStore(time.Hour), WithRules()).Guard(failing, next.handle)
+	err = guard(context.Background(), transferCommand(100))
+	assert.ErrorContains(t, err, "broker down")
+
+	assert.Empty(t, next.events)
+}
+
+func TestGuardRecordsOnlyHandledCommands(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(time.Hour)
+	engine := NewEngine(store, WithRules())
+	failed := engine.Guard(events.NewMemoryBus(), func(context.Context, *events.Event) error {
+		return errBad
+	})
+
+	assert.ErrorIs(t, failed(ctx, transferCommand(100)), errBad)
+	history, _ := store.History(ctx, "acc-1", time.Time{})
+	assert.Empty(t, history, "a command next failed is not recorded")
+
+	next := &recorder{}
+	require.NoError(t, engine.Guard(events.NewMemoryBus(), next.handle)(ctx, transferCommand(100)))
+	history, _ = store.History(ctx, "acc-1", time.Time{})
+	assert.Len(t, history, 1)
+
+	guard := NewEngine(failingStore{}, WithRules()).Guard(events.NewMemoryBus(), next.handle)
+	assert.ErrorIs(t, guard(ctx, transferCommand(100)), errBad)
+	assert.Len(t, next.events, 2, "recording happens after next")
+}
@@ -0,0 +1,191 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package risk - Built-in rules
+// ═══════════════════════════════════════════════════════════════════════════
+
+package risk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fintech-bank-platform/pkg/calendar"
+	"github.com/fintech-bank-platform/pkg/ledger"
+)
+
+// Reason codes of the built-in rules
+const (
+	CodeVelocity       = "VELOCITY"
+	CodeAmountDeviance = "AMOUNT_ABOVE_AVERAGE"
+	CodeNewPixKey      = "NEW_PIX_KEY"
+	CodeNightLimit     = "NIGHT_LIMIT"
+	CodeDeviceChange   = "DEVICE_CHANGE"
+)
+
+// DefaultRules returns the built-in rules with their default settings
+func DefaultRules() []Rule {
+	return []Rule{
+		VelocityRule{Window: 10 * time.Minute, MaxOperations: 5, Score: 40},
+		AmountRule{Lookback: 90 * 24 * time.Hour, MinSamples: 5, Multiplier: 5, Score: 40},
+		NewPixKeyRule{Lookback: 90 * 24 * time.Hour, Score: 20},
+		NightRule{StartHour: 20, EndHour: 6, MaxAmount: 1000, Location: calendar.Default().Location()},
+		DeviceChangeRule{Lookback: 30 * 24 * time.Hour, Score: 30},
+	}
+}
+
+// VelocityRule flags accounts with too many operations in a sliding window
+type VelocityRule struct {
+	Window        time.Duration
+	MaxOperations int
+	Score         int
+}
+
+// Name implements Rule
+func (VelocityRule) Name() string { return "velocity" }
+
+// Evaluate implements Rule
+func (r VelocityRule) Evaluate(ctx context.Context, req *Request, store Store) (*Reason, error) {
+	ops, err := store.History(ctx, req.AccountID, req.At.Add(-r.Window))
+	if err != nil {
+		return nil, err
+	}
+	if len(ops)+1 <= r.MaxOperations {
+		return nil, nil
+	}
+	return &Reason{
+		Code:    CodeVelocity,
+		Message: fmt.Sprintf("%d operations in %s", len(ops)+1, r.Window),
+		Score:   r.Score,
+	}, nil
+}
+
+// AmountRule flags amounts far above the account's historical average in
+// the currency of the request; operations in other currencies are ignored
+type AmountRule struct {
+	Lookback   time.Duration
+	MinSamples int
+	Multiplier float64
+	Score      int
+}
+
+// Name implements Rule
+func (AmountRule) Name() string { return "amount_average" }
+
+// Evaluate implements Rule
+func (r AmountRule) Evaluate(ctx context.Context, req *Request, store Store) (*Reason, error) {
+	history, err := store.History(ctx, req.AccountID, req.At.Add(-r.Lookback))
+	if err != nil {
+		return nil, err
+	}
+
+	var total, samples int64
+	for _, op := range history {
+		if strings.EqualFold(op.Currency, req.Currency) {
+			total += ledger.ToMinor(op.Amount, req.Currency)
+			samples++
+		}
+	}
+	if samples < int64(r.MinSamples) || samples == 0 {
+		return nil, nil
+	}
+	average := ledger.FromMinor(total/samples, req.Currency)
+	if req.Amount <= average*r.Multiplier {
+		return nil, nil
+	}
+	return &Reason{
+		Code:    CodeAmountDeviance,
+		Message: fmt.Sprintf("amount %.2f is above %.0fx the average %.2f", req.Amount, r.Multiplier, average),
+		Score:   r.Score,
+	}, nil
+}
+
+// NewPixKeyRule flags PIX payments to keys the account never paid before
+type NewPixKeyRule struct {
+	Lookback time.Duration
+	Score    int
+}
+
+// Name implements Rule
+func (NewPixKeyRule) Name() string { return "new_pix_key" }
+
+// Evaluate implements Rule
+func (r NewPixKeyRule) Evaluate(ctx context.Context, req *Request, store Store) (*Reason, error) {
+	if req.PixKey == "" {
+		return nil, nil
+	}
+
+	ops, err := store.History(ctx, req.AccountID, req.At.Add(-r.Lookback))
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range ops {
+		if op.Recipient == req.PixKey {
+			return nil, nil
+		}
+	}
+	return &Reason{
+		Code:    CodeNewPixKey,
+		Message: "first payment to this PIX key",
+		Score:   r.Score,
+	}, nil
+}
+
+// NightRule denies operations above MaxAmount during the night window
+// (StartHour until EndHour in Location, wrapping past midnight)
+type NightRule struct {
+	StartHour int
+	EndHour   int
+	MaxAmount float64
+	Location  *time.Location
+}
+
+// Name implements Rule
+func (NightRule) Name() string { return "night_limit" }
+
+// Evaluate implements Rule
+func (r NightRule) Evaluate(_ context.Context, req *Request, _ Store) (*Reason, error) {
+	hour := req.At.In(r.Location).Hour()
+	night := hour >= r.StartHour || hour < r.EndHour
+	if r.StartHour < r.EndHour {
+		night = hour >= r.StartHour && hour < r.EndHour
+	}
+	if !night || ledger.ToMinor(req.Amount, req.Currency) <= ledger.ToMinor(r.MaxAmount, req.Currency) {
+		return nil, nil
+	}
+	return &Reason{
+		Code:     CodeNightLimit,
+		Message:  fmt.Sprintf("amount %.2f exceeds the night limit of %.2f", req.Amount, r.MaxAmount),
+		Decision: DecisionDeny,
+	}, nil
+}
+
+// DeviceChangeRule flags requests from a device or IP that differs from
+// the account's last operation
+type DeviceChangeRule struct {
+	Lookback time.Duration
+	Score    int
+}
+
+// Name implements Rule
+func (DeviceChangeRule) Name() string { return "device_change" }
+
+// Evaluate implements Rule
+func (r DeviceChangeRule) Evaluate(ctx context.Context, req *Request, store Store) (*Reason, error) {
+	ops, err := store.History(ctx, req.AccountID, req.At.Add(-r.Lookback))
+	if err != nil {
+		return nil, err
+	}
+	if len(ops) == 0 {
+		return nil, nil
+	}
+
+	last := ops[len(ops)-1]
+	switch {
+	case req.DeviceID != "" && last.DeviceID != "" && req.DeviceID != last.DeviceID:
+		return &Reason{Code: CodeDeviceChange, Message: "new device", Score: r.Score}, nil
+	case req.IPAddress != "" && last.IPAddress != "" && req.IPAddress != last.IPAddress:
+		return &Reason{Code: CodeDeviceChange, Message: "new IP address", Score: r.Score}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,196 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package risk - Rule Tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package risk
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	brt    = time.FixedZone("BRT", -3*60*60)
+	noon   = time.Date(2026, 3, 10, 12, 0, 0, 0, brt)
+	errBad = errors.New("store unavailable")
+)
+
+type failingStore struct{}
+
+func (failingStore) Record(context.Context, string, Operation) error { return errBad }
+
+func (failingStore) History(context.Context, string, time.Time) ([]Operation, error) {
+	return nil, errBad
+}
+
+func storeWith(ops ...Operation) *MemoryStore {
+	store := NewMemoryStore(365 * 24 * time.Hour)
+	for _, op := range ops {
+		_ = store.Record(context.Background(), "acc-1", op)
+	}
+	return store
+}
+
+func request(amount float64) *Request {
+	return &Request{AccountID: "acc-1", Amount: amount, Currency: "BRL", At: noon}
+}
+
+func TestVelocityRule(t *testing.T) {
+	rule := VelocityRule{Window: 10 * time.Minute, MaxOperations: 2, Score: 40}
+	store := storeWith(
+		Operation{Amount: 10, At: noon.Add(-time.Hour)},
+		Operation{Amount: 10, At: noon.Add(-5 * time.Minute)},
+	)
+
+	reason, err := rule.Evaluate(context.Background(), request(10), store)
+	require.NoError(t, err)
+	assert.Nil(t, reason)
+
+	_ = store.Record(context.Background(), "acc-1", Operation{Amount: 10, At: noon.Add(-time.Minute)})
+	reason, err = rule.Evaluate(context.Background(), request(10), store)
+	require.NoError(t, err)
+	require.NotNil(t, reason)
+	assert.Equal(t, CodeVelocity, reason.Code)
+	assert.Equal(t, 40, reason.Score)
+	assert.Equal(t, "velocity", rule.Name())
+}
+
+func TestAmountRule(t *testing.T) {
+	rule := AmountRule{Lookback: 24 * time.Hour, MinSamples: 3, Multiplier: 5, Score: 40}
+	few := storeWith(
+		Operation{Amount: 100, Currency: "BRL", At: noon.Add(-time.Hour)},
+		Operation{Amount: 1, Currency: "USD", At: noon.Add(-time.Hour)},
+		Operation{Amount: 1, Currency: "USD", At: noon.Add(-time.Hour)},
+	)
+	history := storeWith(
+		Operation{Amount: 100, Currency: "BRL", At: noon.Add(-3 * time.Hour)},
+		Operation{Amount: 200, Currency: "BRL", At: noon.Add(-2 * time.Hour)},
+		Operation{Amount: 100000, Currency: "JPY", At: noon.Add(-2 * time.Hour)},
+		Operation{Amount: 300, Currency: "BRL", At: noon.Add(-time.Hour)},
+	)
+
+	reason, _ := rule.Evaluate(context.Background(), request(5000), few)
+	assert.Nil(t, reason, "not enough history in reais")
+
+	reason, _ = rule.Evaluate(context.Background(), request(1000), history)
+	assert.Nil(t, reason, "exactly 5x the average")
+
+	reason, err := rule.Evaluate(context.Background(), request(1000.01), history)
+	require.NoError(t, err)
+	require.NotNil(t, reason)
+	assert.Equal(t, CodeAmountDeviance, reason.Code)
+	assert.Contains(t, reason.Message, "200.00")
+	assert.Equal(t, "amount_average", rule.Name())
+
+	reason, err = AmountRule{Multiplier: 5}.Evaluate(context.Background(), request(1), storeWith())
+	require.NoError(t, err)
+	assert.Nil(t, reason, "no history without a minimum")
+}
+
+func TestNewPixKeyRule(t *testing.T) {
+	rule := NewPixKeyRule{Lookback: 24 * time.Hour, Score: 20}
+	store := storeWith(Operation{Amount: 10, Recipient: "known@pix", At: noon.Add(-time.Hour)})
+
+	req := request(10)
+	reason, _ := rule.Evaluate(context.Background(), req, store)
+	assert.Nil(t, reason, "not a PIX payment")
+
+	req.PixKey = "known@pix"
+	reason, _ = rule.Evaluate(context.Background(), req, store)
+	assert.Nil(t, reason)
+
+	req.PixKey = "new@pix"
+	reason, err := rule.Evaluate(context.Background(), req, store)
+	require.NoError(t, err)
+	require.NotNil(t, reason)
+	assert.Equal(t, CodeNewPixKey, reason.Code)
+	assert.Equal(t, "new_pix_key", rule.Name())
+}
+
+func TestNightRule(t *testing.T) {
+	rule := NightRule{StartHour: 20, EndHour: 6, MaxAmount: 1000, Location: brt}
+
+	cases := []struct {
+		at     time.Time
+		amount float64
+		denied bool
+	}{
+		{time.Date(2026, 3, 10, 21, 0, 0, 0, brt), 1000, false},
+		{time.Date(2026, 3, 10, 21, 0, 0, 0, brt), 1000.01, true},
+		{time.Date(2026, 3, 10, 5, 59, 0, 0, brt), 5000, true},
+		{time.Date(2026, 3, 10, 6, 0, 0, 0, brt), 5000, false},
+		{time.Date(2026, 3, 10, 23, 30, 0, 0, time.UTC), 5000, true}, // 20:30 in BRT
+	}
+
+	for _, tc := range cases {
+		req := request(tc.amount)
+		req.At = tc.at
+		reason, err := rule.Evaluate(context.Background(), req, nil)
+		require.NoError(t, err)
+		if !tc.denied {
+			assert.Nil(t, reason, "%s %.2f", tc.at, tc.amount)
+			continue
+		}
+		require.NotNil(t, reason, "%s %.2f", tc.at, tc.amount)
+		assert.Equal(t, CodeNightLimit, reason.Code)
+		assert.Equal(t, DecisionDeny, reason.Decision)
+	}
+	assert.Equal(t, "night_limit", rule.Name())
+}
+
+func TestNightRuleSameDayWindow(t *testing.T) {
+	rule := NightRule{StartHour: 1, EndHour: 5, MaxAmount: 100, Location: brt}
+
+	req := request(500)
+	req.At = time.Date(2026, 3, 10, 3, 0, 0, 0, brt)
+	reason, _ := rule.Evaluate(context.Background(), req, nil)
+	assert.NotNil(t, reason)
+
+	req.At = time.Date(2026, 3, 10, 22, 0, 0, 0, brt)
+	reason, _ = rule.Evaluate(context.Background(), req, nil)
+	assert.Nil(t, reason)
+}
+
+func TestDeviceChangeRule(t *testing.T) {
+	rule := DeviceChangeRule{Lookback: 24 * time.Hour, Score: 30}
+	store := storeWith(Operation{Amount: 10, DeviceID: "phone-1", IPAddress: "10.0.0.1", At: noon.Add(-time.Hour)})
+
+	reason, _ := rule.Evaluate(context.Background(), request(10), NewMemoryStore(time.Hour))
+	assert.Nil(t, reason, "no history")
+
+	req := request(10)
+	req.DeviceID, req.IPAddress = "phone-1", "10.0.0.1"
+	reason, _ = rule.Evaluate(context.Background(), req, store)
+	assert.Nil(t, reason)
+
+	req.DeviceID = "phone-2"
+	reason, _ = rule.Evaluate(context.Background(), req, store)
+	require.NotNil(t, reason)
+	assert.Equal(t, "new device", reason.Message)
+
+	req.DeviceID, req.IPAddress = "phone-1", "200.1.1.1"
+	reason, err := rule.Evaluate(context.Background(), req, store)
+	require.NoError(t, err)
+	require.NotNil(t, reason)
+	assert.Equal(t, "new IP address", reason.Message)
+	assert.Equal(t, CodeDeviceChange, reason.Code)
+	assert.Equal(t, "device_change", rule.Name())
+}
+
+func TestRulesReturnStoreErrors(t *testing.T) {
+	req := request(10)
+	req.PixKey = "key@pix"
+
+	for _, rule := range DefaultRules() {
+		if _, ok := rule.(NightRule); ok {
+			continue
+		}
+		_, err := rule.Evaluate(context.Background(), req, failingStore{})
+		assert.ErrorIs(t, err, errBad, rule.Name())
+	}
+}
@@ -0,0 +1,75 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package risk - Sliding-window history store
+// ═══════════════════════════════════════════════════════════════════════════
+
+package risk
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Operation is an accepted command kept in the account history
+type Operation struct {
+	Amount    float64   `json:"amount"`
+	Currency  string    `json:"currency,omitempty"`
+	Recipient string    `json:"recipient,omitempty"`
+	DeviceID  string    `json:"device_id,omitempty"`
+	IPAddress string    `json:"ip_address,omitempty"`
+	At        time.Time `json:"at"`
+}
+
+// Store keeps the per-account operation history that rules read from
+// (Redis sorted sets or Cassandra in production)
+type Store interface {
+	// Record appends an operation to the account history
+	Record(ctx context.Context, accountID string, op Operation) error
+	// History returns the operations at or after since, oldest first
+	History(ctx context.Context, accountID string, since time.Time) ([]Operation, error)
+}
+
+// MemoryStore is an in-memory Store that forgets operations older than
+// its retention. Used in tests and local development.
+type MemoryStore struct {
+	mu        sync.Mutex
+	retention time.Duration
+	history   map[string][]Operation
+}
+
+// NewMemoryStore creates an in-memory store keeping retention of history
+func NewMemoryStore(retention time.Duration) *MemoryStore {
+	return &MemoryStore{
+		retention: retention,
+		history:   make(map[string][]Operation),
+	}
+}
+
+// Record appends an operation and drops the ones past retention
+func (s *MemoryStore) Record(_ context.Context, accountID string, op Operation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ops := append(s.history[accountID], op)
+	cutoff := op.At.Add(-s.retention)
+	start := 0
+	for start < len(ops) && ops[start].At.Before(cutoff) {
+		start++
+	}
+	s.history[accountID] = append([]Operation(nil), ops[start:]...)
+	return nil
+}
+
+// History returns the operations at or after since
+func (s *MemoryStore) History(_ context.Context, accountID string, since time.Time) ([]Operation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var ops []Operation
+	for _, op := range s.history[accountID] {
+		if !op.At.Before(since) {
+			ops = append(ops, op)
+		}
+	}
+	return ops, nil
+}
@@ -0,0 +1,42 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package risk - Store Tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package risk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStoreHistoryWindow(t *testing.T) {
+	store := NewMemoryStore(time.Hour)
+	ctx := context.Background()
+	base := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	_ = store.Record(ctx, "acc-1", Operation{Amount: 10, At: base})
+	_ = store.Record(ctx, "acc-1", Operation{Amount: 20, At: base.Add(10 * time.Minute)})
+	_ = store.Record(ctx, "acc-2", Operation{Amount: 30, At: base})
+
+	ops, err := store.History(ctx, "acc-1", base.Add(5*time.Minute))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Operation{{Amount: 20, At: base.Add(10 * time.Minute)}}, ops)
+}
+
+func TestMemoryStoreDropsExpiredOperations(t *testing.T) {
+	store := NewMemoryStore(time.Hour)
+	ctx := context.Background()
+	base := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	_ = store.Record(ctx, "acc-1", Operation{Amount: 10, At: base})
+	_ = store.Record(ctx, "acc-1", Operation{Amount: 20, At: base.Add(2 * time.Hour)})
+
+	ops, _ := store.History(ctx, "acc-1", time.Time{})
+
+	assert.Len(t, ops, 1)
+	assert.Equal(t, 20.0, ops[0].Amount)
+}
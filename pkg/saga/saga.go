@@ -0,0 +1,495 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package saga - Saga orchestration for multi-step, compensable workflows
+// ═══════════════════════════════════════════════════════════════════════════
+
+package saga
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fintech-bank-platform/pkg/events"
+)
+
+// DefaultStepTimeout is used by steps that do not define their own timeout
+const DefaultStepTimeout = 30 * time.Second
+
+var (
+	// ErrStepTimeout is returned when a step does not finish within its timeout
+	ErrStepTimeout = errors.New("saga: step timed out")
+	// ErrUnknownSaga is returned when no definition is registered for a saga name
+	ErrUnknownSaga = errors.New("saga: unknown saga definition")
+	// ErrSagaNotFound is returned by stores when a saga does not exist
+	ErrSagaNotFound = errors.New("saga: not found")
+	// ErrNoHandler is returned by MemoryDispatcher for unhandled command types
+	ErrNoHandler = errors.New("saga: no handler for command")
+	// errNoCommand is returned by execute when a step has nothing to dispatch
+	errNoCommand = errors.New("saga: step has no command")
+)
+
+// ═══════════════════════════════════════════════════════════════════════════
+// STATE
+// ═══════════════════════════════════════════════════════════════════════════
+
+// Status represents the state of a saga
+type Status string
+
+const (
+	StatusRunning            Status = "running"
+	StatusCompensating       Status = "compensating"
+	StatusCompleted          Status = "completed"
+	StatusFailed             Status = "failed"
+	StatusCompensationFailed Status = "compensation_failed"
+)
+
+// IsFinal checks if the saga has finished (successfully or not)
+func (s Status) IsFinal() bool {
+	return s == StatusCompleted || s == StatusFailed || s == StatusCompensationFailed
+}
+
+// State is the persisted progress of a saga instance. CompletedSteps lists,
+// in order, the steps that may have been applied: the ones that succeeded and
+// the ones that timed out (also in TimedOutSteps). SkippedSteps lists the
+// optional steps that failed and the steps without a command for this saga. OutcomePublished is set
+// once the completed or failed event was published, so Resume retries a
+// publish that failed.
+type State struct {
+	ID               string          `json:"id"`
+	Name             string          `json:"name"`
+	Status           Status          `json:"status"`
+	Data             json.RawMessage `json:"data"`
+	CurrentStep      int             `json:"current_step"`
+	CompletedSteps   []string        `json:"completed_steps,omitempty"`
+	TimedOutSteps    []string        `json:"timed_out_steps,omitempty"`
+	SkippedSteps     []string        `json:"skipped_steps,omitempty"`
+	CompensatedSteps []string        `json:"compensated_steps,omitempty"`
+	FailedStep       string          `json:"failed_step,omitempty"`
+	FailureReason    string          `json:"failure_reason,omitempty"`
+	OutcomePublished bool            `json:"outcome_published,omitempty"`
+	StartedAt        time.Time       `json:"started_at"`
+	UpdatedAt        time.Time       `json:"updated_at"`
+}
+
+// Decode unmarshals the saga data into v
+func (s *State) Decode(v interface{}) error {
+	return json.Unmarshal(s.Data, v)
+}
+
+// clone returns a deep copy of the state
+func (s *State) clone() *State {
+	copied := *s
+	copied.Data = append(json.RawMessage(nil), s.Data...)
+	copied.CompletedSteps = append([]string(nil), s.CompletedSteps...)
+	copied.TimedOutSteps = append([]string(nil), s.TimedOutSteps...)
+	copied.SkippedSteps = append([]string(nil), s.SkippedSteps...)
+	copied.CompensatedSteps = append([]string(nil), s.CompensatedSteps...)
+	return &copied
+}
+
+// isCompensated checks if a step has already been compensated
+func (s *State) isCompensated(step string) bool {
+	for _, name := range s.CompensatedSteps {
+		if name == step {
+			return true
+		}
+	}
+	return false
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// DEFINITION
+// ═══════════════════════════════════════════════════════════════════════════
+
+// CommandFunc builds the command event for a step from the saga state. A
+// step command may return a nil event when the step does not apply to the
+// saga, which skips the step.
+type CommandFunc func(state *State) (*events.Event, error)
+
+// Step is a single action of a saga with its compensating action
+type Step struct {
+	Name string
+	// Command is dispatched to execute the step
+	Command CommandFunc
+	// Compensation is dispatched to undo the step (nil when nothing to undo).
+	// It must be idempotent and succeed when the step was never applied: a
+	// step that timed out may or may not have been applied, so it is
+	// compensated as well.
+	Compensation CommandFunc
+	// Timeout bounds the dispatch of the command or compensation
+	Timeout time.Duration
+	// Optional steps do not trigger compensations when they fail
+	Optional bool
+}
+
+// Definition describes a saga type
+type Definition struct {
+	Name  string
+	Steps []Step
+	// Topic receives the outcome events
+	Topic string
+	// OnCompleted builds the event published when every step succeeded
+	OnCompleted CommandFunc
+	// OnFailed builds the event published after compensations ran
+	OnFailed CommandFunc
+}
+
+// step returns the step with the given name
+func (d *Definition) step(name string) (Step, bool) {
+	for _, step := range d.Steps {
+		if step.Name == name {
+			return step, true
+		}
+	}
+	return Step{}, false
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// STORE
+// ═══════════════════════════════════════════════════════════════════════════
+
+// Store persists saga state
+type Store interface {
+	Save(ctx context.Context, state *State) error
+	Get(ctx context.Context, id string) (*State, error)
+}
+
+// MemoryStore is an in-memory Store used in tests and local development
+type MemoryStore struct {
+	mu     sync.RWMutex
+	states map[string]*State
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{states: make(map[string]*State)}
+}
+
+// Save stores a snapshot of the state
+func (s *MemoryStore) Save(_ context.Context, state *State) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.states[state.ID] = state.clone()
+	return nil
+}
+
+// Get returns a copy of the stored state or ErrSagaNotFound
+func (s *MemoryStore) Get(_ context.Context, id string) (*State, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	state, ok := s.states[id]
+	if !ok {
+		return nil, ErrSagaNotFound
+	}
+	return state.clone(), nil
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// DISPATCHER
+// ═══════════════════════════════════════════════════════════════════════════
+
+// Dispatcher sends a step command and reports whether it succeeded
+type Dispatcher interface {
+	Dispatch(ctx context.Context, command *events.Event) error
+}
+
+// MemoryDispatcher routes commands to in-process handlers by event type
+type MemoryDispatcher struct {
+	mu       sync.RWMutex
+	handlers map[string]events.Handler
+}
+
+// NewMemoryDispatcher creates a dispatcher without handlers
+func NewMemoryDispatcher() *MemoryDispatcher {
+	return &MemoryDispatcher{handlers: make(map[string]events.Handler)}
+}
+
+// Handle registers the handler for a command type
+func (d *MemoryDispatcher) Handle(eventType string, handler events.Handler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.handlers[eventType] = handler
+}
+
+// Dispatch runs the handler registered for the command type
+func (d *MemoryDispatcher) Dispatch(ctx context.Context, command *events.Event) error {
+	d.mu.RLock()
+	handler, ok := d.handlers[command.Type]
+	d.mu.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNoHandler, command.Type)
+	}
+	return handler(ctx, command)
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// ORCHESTRATOR
+// ═══════════════════════════════════════════════════════════════════════════
+
+// Orchestrator runs saga definitions, persisting state after every step
+type Orchestrator struct {
+	store       Store
+	dispatcher  Dispatcher
+	publisher   events.Publisher
+	timeout     time.Duration
+	now         func() time.Time
+	mu          sync.RWMutex
+	definitions map[string]Definition
+}
+
+// Option configures an Orchestrator
+type Option func(*Orchestrator)
+
+// WithDefaultTimeout sets the timeout for steps without their own timeout
+func WithDefaultTimeout(timeout time.Duration) Option {
+	return func(o *Orchestrator) {
+		o.timeout = timeout
+	}
+}
+
+// WithClock sets the clock used to timestamp state changes
+func WithClock(now func() time.Time) Option {
+	return func(o *Orchestrator) {
+		o.now = now
+	}
+}
+
+// NewOrchestrator creates an orchestrator
+func NewOrchestrator(store Store, dispatcher Dispatcher, publisher events.Publisher, opts ...Option) *Orchestrator {
+	o := &Orchestrator{
+		store:       store,
+		dispatcher:  dispatcher,
+		publisher:   publisher,
+		timeout:     DefaultStepTimeout,
+		now:         func() time.Time { return time.Now().UTC() },
+		definitions: make(map[string]Definition),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// Register adds a saga definition
+func (o *Orchestrator) Register(def Definition) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.definitions[def.Name] = def
+}
+
+// Start runs a new saga instance. Starting an existing ID resumes it instead,
+// so redelivered commands are idempotent.
+func (o *Orchestrator) Start(ctx context.Context, name, id string, data interface{}) (*State, error) {
+	def, err := o.definition(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing, err := o.store.Get(ctx, id); err == nil {
+		return o.run(ctx, def, existing)
+	} else if !errors.Is(err, ErrSagaNotFound) {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	startedAt := o.now()
+	state := &State{
+		ID:        id,
+		Name:      name,
+		Status:    StatusRunning,
+		Data:      raw,
+		StartedAt: startedAt,
+		UpdatedAt: startedAt,
+	}
+
+	if err := o.store.Save(ctx, state); err != nil {
+		return nil, err
+	}
+
+	return o.run(ctx, def, state)
+}
+
+// Resume continues a persisted saga (e.g. after a restart)
+func (o *Orchestrator) Resume(ctx context.Context, id string) (*State, error) {
+	state, err := o.store.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	def, err := o.definition(state.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return o.run(ctx, def, state)
+}
+
+func (o *Orchestrator) definition(name string) (Definition, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	def, ok := o.definitions[name]
+	if !ok {
+		return Definition{}, fmt.Errorf("%w: %s", ErrUnknownSaga, name)
+	}
+	return def, nil
+}
+
+// run drives the saga forward from its persisted position
+func (o *Orchestrator) run(ctx context.Context, def Definition, state *State) (*State, error) {
+	if state.Status == StatusRunning {
+		if err := o.forward(ctx, def, state); err != nil {
+			return state, err
+		}
+	}
+
+	if state.Status == StatusCompensating {
+		if err := o.compensate(ctx, def, state); err != nil {
+			return state, err
+		}
+	}
+
+	if state.Status.IsFinal() && !state.OutcomePublished {
+		if err := o.publishOutcome(ctx, def, state); err != nil {
+			return state, err
+		}
+	}
+
+	return state, nil
+}
+
+// forward executes the remaining steps, switching to compensation on failure
+func (o *Orchestrator) forward(ctx context.Context, def Definition, state *State) error {
+	for state.CurrentStep < len(def.Steps) {
+		step := def.Steps[state.CurrentStep]
+
+		err := o.execute(ctx, step, step.Command, state)
+		switch {
+		case err == nil:
+			state.CompletedSteps = append(state.CompletedSteps, step.Name)
+		case errors.Is(err, ErrStepTimeout):
+			// The command may still land after the timeout
+			state.CompletedSteps = append(state.CompletedSteps, step.Name)
+			state.TimedOutSteps = append(state.TimedOutSteps, step.Name)
+		case errors.Is(err, errNoCommand):
+			state.SkippedSteps = append(state.SkippedSteps, step.Name)
+			err = nil
+		case step.Optional:
+			state.SkippedSteps = append(state.SkippedSteps, step.Name)
+		}
+		if err != nil && !step.Optional {
+			state.Status = StatusCompensating
+			state.FailedStep = step.Name
+			state.FailureReason = err.Error()
+			return o.save(ctx, state)
+		}
+
+		state.CurrentStep++
+		if err := o.save(ctx, state); err != nil {
+			return err
+		}
+	}
+
+	state.Status = StatusCompleted
+	return o.save(ctx, state)
+}
+
+// compensate undoes completed steps in reverse order
+func (o *Orchestrator) compensate(ctx context.Context, def Definition, state *State) error {
+	for i := len(state.CompletedSteps) - 1; i >= 0; i-- {
+		name := state.CompletedSteps[i]
+		if state.isCompensated(name) {
+			continue
+		}
+
+		step, _ := def.step(name)
+		if step.Compensation != nil {
+			if err := o.execute(ctx, step, step.Compensation, state); err != nil {
+				state.Status = StatusCompensationFailed
+				state.FailureReason = fmt.Sprintf("%s; compensation of %s failed: %v", state.FailureReason, name, err)
+				return o.save(ctx, state)
+			}
+		}
+
+		state.CompensatedSteps = append(state.CompensatedSteps, name)
+		if err := o.save(ctx, state); err != nil {
+			return err
+		}
+	}
+
+	state.Status = StatusFailed
+	return o.save(ctx, state)
+}
+
+// execute dispatches a step command, bounded by the step timeout
+func (o *Orchestrator) execute(ctx context.Context, step Step, build CommandFunc, state *State) error {
+	command, err := build(state)
+	if err != nil {
+		return err
+	}
+	if command == nil {
+		return errNoCommand
+	}
+	command.WithTraceID(state.ID).
+		WithMetadata("saga_id", state.ID).
+		WithMetadata("saga_step", step.Name)
+
+	timeout := step.Timeout
+	if timeout <= 0 {
+		timeout = o.timeout
+	}
+
+	stepCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- o.dispatcher.Dispatch(stepCtx, command)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-stepCtx.Done():
+		return fmt.Errorf("%w: %s after %s", ErrStepTimeout, step.Name, timeout)
+	}
+}
+
+// publishOutcome sends the completed or failed event, if the definition
+// provides one, and records that it was sent
+func (o *Orchestrator) publishOutcome(ctx context.Context, def Definition, state *State) error {
+	build := def.OnFailed
+	if state.Status == StatusCompleted {
+		build = def.OnCompleted
+	}
+
+	if build != nil {
+		event, err := build(state)
+		if err != nil {
+			return err
+		}
+		event.WithTraceID(state.ID).WithMetadata("saga_id", state.ID)
+
+		if err := o.publisher.Publish(ctx, def.Topic, event); err != nil {
+			return err
+		}
+	}
+
+	state.OutcomePublished = true
+	return o.save(ctx, state)
+}
+
+// save persists the state with an updated timestamp
+func (o *Orchestrator) save(ctx context.Context, state *State) error {
+	state.UpdatedAt = o.now()
+	return o.store.Save(ctx, state)
+}
@@ -0,0 +1,503 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package saga - Tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package saga
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fintech-bank-platform/pkg/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════
+// TEST HELPERS
+// ═══════════════════════════════════════════════════════════════════════════
+
+var errBoom = errors.New("boom")
+
+func command(eventType string) CommandFunc {
+	return func(state *State) (*events.Event, error) {
+		return events.NewEvent(eventType, "test", nil), nil
+	}
+}
+
+func failingCommand(state *State) (*events.Event, error) {
+	return nil, errBoom
+}
+
+func outcome(eventType string) CommandFunc {
+	return func(state *State) (*events.Event, error) {
+		return events.NewEvent(eventType, "test", state.FailureReason), nil
+	}
+}
+
+func testDefinition() Definition {
+	return Definition{
+		Name:  "test",
+		Topic: "test.events",
+		Steps: []Step{
+			{Name: "one", Command: command("one"), Compensation: command("undo_one")},
+			{Name: "two", Command: command("two")},
+			{Name: "three", Command: command("three"), Compensation: command("undo_three")},
+		},
+		OnCompleted: outcome("completed"),
+		OnFailed:    outcome("failed"),
+	}
+}
+
+type harness struct {
+	store        *MemoryStore
+	dispatcher   *MemoryDispatcher
+	bus          *events.MemoryBus
+	orchestrator *Orchestrator
+	calls        []string
+}
+
+func newHarness(defs ...Definition) *harness {
+	h := &harness{
+		store:      NewMemoryStore(),
+		dispatcher: NewMemoryDispatcher(),
+		bus:        events.NewMemoryBus(),
+	}
+	h.orchestrator = NewOrchestrator(h.store, h.dispatcher, h.bus, WithDefaultTimeout(time.Second))
+	for _, def := range defs {
+		h.orchestrator.Register(def)
+	}
+	for _, eventType := range []string{"one", "two", "three", "undo_one", "undo_three"} {
+		h.succeed(eventType)
+	}
+	return h
+}
+
+func (h *harness) succeed(eventType string) {
+	h.dispatcher.Handle(eventType, func(ctx context.Context, event *events.Event) error {
+		h.calls = append(h.calls, event.Type)
+		return nil
+	})
+}
+
+func (h *harness) fail(eventType string) {
+	h.dispatcher.Handle(eventType, func(ctx context.Context, event *events.Event) error {
+		h.calls = append(h.calls, event.Type)
+		return errBoom
+	})
+}
+
+func (h *harness) outcomes() []string {
+	var types []string
+	for _, event := range h.bus.Published("test.events") {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+// failingPublisher fails the first fail publishes
+type failingPublisher struct {
+	*events.MemoryBus
+	fail int
+}
+
+func (p *failingPublisher) Publish(ctx context.Context, topic string, event *events.Event) error {
+	if p.fail > 0 {
+		p.fail--
+		return errBoom
+	}
+	return p.MemoryBus.Publish(ctx, topic, event)
+}
+
+// flakyStore fails the nth call to Save
+type flakyStore struct {
+	*MemoryStore
+	failAt int
+	saves  int
+	getErr error
+}
+
+func (s *flakyStore) Save(ctx context.Context, state *State) error {
+	s.saves++
+	if s.saves == s.failAt {
+		return errBoom
+	}
+	return s.MemoryStore.Save(ctx, state)
+}
+
+func (s *flakyStore) Get(ctx context.Context, id string) (*State, error) {
+	if s.getErr != nil {
+		return nil, s.getErr
+	}
+	return s.MemoryStore.Get(ctx, id)
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// ORCHESTRATION TESTS
+// ═══════════════════════════════════════════════════════════════════════════
+
+func TestSagaCompletes(t *testing.T) {
+	h := newHarness(testDefinition())
+
+	state, err := h.orchestrator.Start(context.Background(), "test", "saga-1", map[string]string{"key": "value"})
+
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, state.Status)
+	assert.Equal(t, []string{"one", "two", "three"}, state.CompletedSteps)
+	assert.Equal(t, []string{"one", "two", "three"}, h.calls)
+	assert.Equal(t, []string{"completed"}, h.outcomes())
+
+	stored, err := h.store.Get(context.Background(), "saga-1")
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, stored.Status)
+
+	var data map[string]string
+	require.NoError(t, stored.Decode(&data))
+	assert.Equal(t, "value", data["key"])
+}
+
+func TestSagaCommandsCarrySagaMetadata(t *testing.T) {
+	h := newHarness(testDefinition())
+	var received *events.Event
+	h.dispatcher.Handle("one", func(ctx context.Context, event *events.Event) error {
+		received = event
+		return nil
+	})
+
+	_, err := h.orchestrator.Start(context.Background(), "test", "saga-1", nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, "saga-1", received.TraceID)
+	assert.Equal(t, "saga-1", received.Metadata["saga_id"])
+	assert.Equal(t, "one", received.Metadata["saga_step"])
+	assert.Equal(t, "saga-1", h.bus.Published("test.events")[0].Metadata["saga_id"])
+}
+
+func TestSagaCompensatesInReverseOrder(t *testing.T) {
+	def := testDefinition()
+	def.Steps = append(def.Steps, Step{Name: "four", Command: command("four")})
+	h := newHarness(def)
+	h.fail("four")
+
+	state, err := h.orchestrator.Start(context.Background(), "test", "saga-1", nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, StatusFailed, state.Status)
+	assert.Equal(t, "four", state.FailedStep)
+	assert.Equal(t, "boom", state.FailureReason)
+	assert.Equal(t, []string{"three", "two", "one"}, state.CompensatedSteps)
+	assert.Equal(t, []string{"one", "two", "three", "four", "undo_three", "undo_one"}, h.calls)
+	assert.Equal(t, []string{"failed"}, h.outcomes())
+	assert.Equal(t, "boom", h.bus.Published("test.events")[0].Payload)
+}
+
+func TestSagaOptionalStepFailureDoesNotCompensate(t *testing.T) {
+	def := testDefinition()
+	def.Steps[1].Optional = true
+	h := newHarness(def)
+	h.fail("two")
+
+	state, err := h.orchestrator.Start(context.Background(), "test", "saga-1", nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, state.Status)
+	assert.Equal(t, []string{"two"}, state.SkippedSteps)
+	assert.Equal(t, []string{"one", "three"}, state.CompletedSteps)
+}
+
+func TestSagaStepTimeout(t *testing.T) {
+	def := testDefinition()
+	def.Steps[1].Timeout = 20 * time.Millisecond
+	def.Steps[1].Compensation = command("undo_two")
+	h := newHarness(def)
+	h.succeed("undo_two")
+	release := make(chan struct{})
+	defer close(release)
+	h.dispatcher.Handle("two", func(ctx context.Context, event *events.Event) error {
+		<-release
+		return nil
+	})
+
+	state, err := h.orchestrator.Start(context.Background(), "test", "saga-1", nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, StatusFailed, state.Status)
+	assert.Equal(t, "two", state.FailedStep)
+	assert.Contains(t, state.FailureReason, ErrStepTimeout.Error())
+	assert.Equal(t, []string{"two"}, state.TimedOutSteps)
+	assert.Equal(t, []string{"undo_two", "undo_one"}, h.calls[1:], "the timed out step may have been applied")
+	assert.Equal(t, []string{"two", "one"}, state.CompensatedSteps)
+}
+
+func TestSagaOptionalStepTimeoutIsCompensatedOnFailure(t *testing.T) {
+	def := testDefinition()
+	def.Steps[1].Timeout = 20 * time.Millisecond
+	def.Steps[1].Optional = true
+	def.Steps[1].Compensation = command("undo_two")
+	h := newHarness(def)
+	h.succeed("undo_two")
+	h.fail("three")
+	release := make(chan struct{})
+	defer close(release)
+	h.dispatcher.Handle("two", func(ctx context.Context, event *events.Event) error {
+		<-release
+		return nil
+	})
+
+	state, err := h.orchestrator.Start(context.Background(), "test", "saga-1", nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, StatusFailed, state.Status)
+	assert.Empty(t, state.SkippedSteps)
+	assert.Equal(t, []string{"two", "one"}, state.CompensatedSteps)
+}
+
+func TestSagaCompensationFailure(t *testing.T) {
+	h := newHarness(testDefinition())
+	h.fail("three")
+	h.fail("undo_one")
+
+	state, err := h.orchestrator.Start(context.Background(), "test", "saga-1", nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompensationFailed, state.Status)
+	assert.Contains(t, state.FailureReason, "compensation of one failed")
+	assert.Equal(t, []string{"two"}, state.CompensatedSteps)
+	assert.Equal(t, []string{"failed"}, h.outcomes())
+}
+
+func TestSagaCommandBuildErrorFailsStep(t *testing.T) {
+	def := testDefinition()
+	def.Steps[1].Command = failingCommand
+	h := newHarness(def)
+
+	state, err := h.orchestrator.Start(context.Background(), "test", "saga-1", nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, StatusFailed, state.Status)
+	assert.Equal(t, "boom", state.FailureReason)
+}
+
+func TestSagaWithoutOutcomeEvents(t *testing.T) {
+	def := testDefinition()
+	def.OnCompleted = nil
+	h := newHarness(def)
+
+	state, err := h.orchestrator.Start(context.Background(), "test", "saga-1", nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, state.Status)
+	assert.Empty(t, h.outcomes())
+}
+
+func TestSagaOutcomeBuildError(t *testing.T) {
+	def := testDefinition()
+	def.OnCompleted = failingCommand
+	h := newHarness(def)
+
+	_, err := h.orchestrator.Start(context.Background(), "test", "saga-1", nil)
+
+	assert.Equal(t, errBoom, err)
+}
+
+func TestSagaPublishError(t *testing.T) {
+	h := newHarness(testDefinition())
+	h.bus.Subscribe("test.events", func(ctx context.Context, event *events.Event) error {
+		return errBoom
+	})
+
+	_, err := h.orchestrator.Start(context.Background(), "test", "saga-1", nil)
+
+	assert.ErrorIs(t, err, errBoom)
+}
+
+func TestResumeRetriesOutcomePublish(t *testing.T) {
+	h := newHarness(testDefinition())
+	bus := &failingPublisher{MemoryBus: h.bus, fail: 1}
+	o := NewOrchestrator(h.store, h.dispatcher, bus)
+	o.Register(testDefinition())
+
+	state, err := o.Start(context.Background(), "test", "saga-1", nil)
+	require.ErrorIs(t, err, errBoom)
+	assert.Equal(t, StatusCompleted, state.Status)
+	assert.False(t, state.OutcomePublished)
+
+	state, err = o.Resume(context.Background(), "saga-1")
+	require.NoError(t, err)
+	assert.True(t, state.OutcomePublished)
+	assert.Equal(t, []string{"completed"}, h.outcomes())
+
+	_, err = o.Resume(context.Background(), "saga-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"completed"}, h.outcomes(), "the outcome is published once")
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// START / RESUME TESTS
+// ═══════════════════════════════════════════════════════════════════════════
+
+func TestStartUnknownSaga(t *testing.T) {
+	h := newHarness()
+
+	_, err := h.orchestrator.Start(context.Background(), "missing", "saga-1", nil)
+
+	assert.ErrorIs(t, err, ErrUnknownSaga)
+}
+
+func TestStartExistingSagaIsIdempotent(t *testing.T) {
+	h := newHarness(testDefinition())
+	_, err := h.orchestrator.Start(context.Background(), "test", "saga-1", nil)
+	require.NoError(t, err)
+
+	state, err := h.orchestrator.Start(context.Background(), "test", "saga-1", nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, state.Status)
+	assert.Len(t, h.calls, 3)
+	assert.Len(t, h.outcomes(), 1)
+}
+
+func TestStartInvalidData(t *testing.T) {
+	h := newHarness(testDefinition())
+
+	_, err := h.orchestrator.Start(context.Background(), "test", "saga-1", make(chan int))
+
+	assert.Error(t, err)
+}
+
+func TestStartStoreGetError(t *testing.T) {
+	store := &flakyStore{MemoryStore: NewMemoryStore(), getErr: errBoom}
+	o := NewOrchestrator(store, NewMemoryDispatcher(), events.NewMemoryBus())
+	o.Register(testDefinition())
+
+	_, err := o.Start(context.Background(), "test", "saga-1", nil)
+
+	assert.Equal(t, errBoom, err)
+}
+
+func TestResumeRunningSaga(t *testing.T) {
+	h := newHarness(testDefinition())
+	require.NoError(t, h.store.Save(context.Background(), &State{
+		ID:             "saga-1",
+		Name:           "test",
+		Status:         StatusRunning,
+		CurrentStep:    2,
+		CompletedSteps: []string{"one", "two"},
+	}))
+
+	state, err := h.orchestrator.Resume(context.Background(), "saga-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, state.Status)
+	assert.Equal(t, []string{"three"}, h.calls)
+}
+
+func TestResumeCompensatingSaga(t *testing.T) {
+	h := newHarness(testDefinition())
+	require.NoError(t, h.store.Save(context.Background(), &State{
+		ID:               "saga-1",
+		Name:             "test",
+		Status:           StatusCompensating,
+		CurrentStep:      2,
+		CompletedSteps:   []string{"one", "three", "removed"},
+		CompensatedSteps: []string{"three"},
+		FailedStep:       "four",
+	}))
+
+	state, err := h.orchestrator.Resume(context.Background(), "saga-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, StatusFailed, state.Status)
+	assert.Equal(t, []string{"undo_one"}, h.calls)
+	assert.Equal(t, []string{"three", "removed", "one"}, state.CompensatedSteps)
+}
+
+func TestResumeUnknownSaga(t *testing.T) {
+	h := newHarness(testDefinition())
+
+	_, err := h.orchestrator.Resume(context.Background(), "missing")
+	assert.ErrorIs(t, err, ErrSagaNotFound)
+
+	require.NoError(t, h.store.Save(context.Background(), &State{ID: "saga-2", Name: "other"}))
+	_, err = h.orchestrator.Resume(context.Background(), "saga-2")
+	assert.ErrorIs(t, err, ErrUnknownSaga)
+}
+
+func TestSagaStoreErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		failAt int
+		fail   []string
+	}{
+		{"initial save", 1, nil},
+		{"step save", 2, nil},
+		{"completed save", 5, nil},
+		{"outcome published save", 6, nil},
+		{"compensating save", 3, []string{"two"}},
+		{"compensated step save", 4, []string{"two"}},
+		{"failed save", 5, []string{"two"}},
+		{"compensation failed save", 4, []string{"two", "undo_one"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newHarness()
+			for _, eventType := range tt.fail {
+				h.fail(eventType)
+			}
+			store := &flakyStore{MemoryStore: NewMemoryStore(), failAt: tt.failAt}
+			o := NewOrchestrator(store, h.dispatcher, h.bus)
+			o.Register(testDefinition())
+
+			_, err := o.Start(context.Background(), "test", "saga-1", nil)
+
+			assert.Equal(t, errBoom, err)
+		})
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// COMPONENT TESTS
+// ═══════════════════════════════════════════════════════════════════════════
+
+func TestStatusIsFinal(t *testing.T) {
+	assert.False(t, StatusRunning.IsFinal())
+	assert.False(t, StatusCompensating.IsFinal())
+	assert.True(t, StatusCompleted.IsFinal())
+	assert.True(t, StatusFailed.IsFinal())
+	assert.True(t, StatusCompensationFailed.IsFinal())
+}
+
+func TestMemoryDispatcherWithoutHandler(t *testing.T) {
+	err := NewMemoryDispatcher().Dispatch(context.Background(), events.NewEvent("unknown", "test", nil))
+
+	assert.ErrorIs(t, err, ErrNoHandler)
+}
+
+func TestMemoryStoreReturnsCopies(t *testing.T) {
+	store := NewMemoryStore()
+	state := &State{ID: "saga-1", CompletedSteps: []string{"one"}}
+	require.NoError(t, store.Save(context.Background(), state))
+
+	state.CompletedSteps[0] = "changed"
+	stored, err := store.Get(context.Background(), "saga-1")
+	require.NoError(t, err)
+	stored.CompletedSteps = append(stored.CompletedSteps, "two")
+
+	again, _ := store.Get(context.Background(), "saga-1")
+	assert.Equal(t, []string{"one"}, again.CompletedSteps)
+}
+
+func TestWithClock(t *testing.T) {
+	fixed := time.Date(2025, time.June, 10, 12, 0, 0, 0, time.UTC)
+	o := NewOrchestrator(NewMemoryStore(), NewMemoryDispatcher(), events.NewMemoryBus(), WithClock(func() time.Time { return fixed }))
+	o.Register(Definition{Name: "empty"})
+
+	state, err := o.Start(context.Background(), "empty", "saga-1", nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, fixed, state.StartedAt)
+	assert.Equal(t, fixed, state.UpdatedAt)
+}
@@ -0,0 +1,192 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package saga - Transfer saga definition
+// ═══════════════════════════════════════════════════════════════════════════
+
+package saga
+
+import (
+	"fmt"
+
+	"github.com/fintech-bank-platform/pkg/events"
+	"github.com/fintech-bank-platform/pkg/ledger"
+)
+
+// TransferSagaName is the registered name of the transfer saga
+const TransferSagaName = "transfer"
+
+// Transfer saga step names
+const (
+	StepDebitSource       = "debit_source"
+	StepFXPositionIn      = "fx_position_in"
+	StepFXPositionOut     = "fx_position_out"
+	StepCreditDestination = "credit_destination"
+	StepNotifySender      = "notify_sender"
+	StepNotifyRecipient   = "notify_recipient"
+)
+
+// TransferDefinition returns the saga that moves money between two accounts:
+// debit the source, credit the destination and notify both parties.
+// Cross-currency transfers also post the ledger.NewConversion legs of
+// ledger.FXPositionAccount, which receives the source amount and pays the
+// converted one; the FX position steps are skipped otherwise.
+// Notifications are optional: failing to notify does not undo the transfer.
+// Compensations carry the idempotency key of the movement they undo in the
+// "compensates" metadata, so a movement that timed out and never landed is
+// not undone.
+func TransferDefinition() Definition {
+	return Definition{
+		Name:  TransferSagaName,
+		Topic: events.Topics.TransactionEvents,
+		Steps: []Step{
+			{
+				Name:         StepDebitSource,
+				Command:      transferMovement(StepDebitSource, "debit", false),
+				Compensation: transferMovement(StepDebitSource, "debit", true),
+			},
+			{
+				Name:         StepFXPositionIn,
+				Command:      transferMovement(StepFXPositionIn, "fx_in", false),
+				Compensation: transferMovement(StepFXPositionIn, "fx_in", true),
+			},
+			{
+				Name:         StepFXPositionOut,
+				Command:      transferMovement(StepFXPositionOut, "fx_out", false),
+				Compensation: transferMovement(StepFXPositionOut, "fx_out", true),
+			},
+			{
+				Name:         StepCreditDestination,
+				Command:      transferMovement(StepCreditDestination, "credit", false),
+				Compensation: transferMovement(StepCreditDestination, "credit", true),
+			},
+			{
+				Name:     StepNotifySender,
+				Command:  transferNotification(true),
+				Optional: true,
+			},
+			{
+				Name:     StepNotifyRecipient,
+				Command:  transferNotification(false),
+				Optional: true,
+			},
+		},
+		OnCompleted: transferCompleted,
+		OnFailed:    transferFailed,
+	}
+}
+
+// transferMovement builds the CreateTransaction command that posts, or
+// undoes when compensation is set, the ledger entry of a movement step. It
+// returns no command when the step has no entry in the transfer.
+func transferMovement(step, keySuffix string, compensation bool) CommandFunc {
+	return func(state *State) (*events.Event, error) {
+		var transfer events.ProcessTransferPayload
+		if err := state.Decode(&transfer); err != nil {
+			return nil, err
+		}
+
+		entry, ok := transferEntries(transfer)[step]
+		if !ok {
+			return nil, nil
+		}
+		key := fmt.Sprintf("%s:%s", transfer.IdempotencyKey, keySuffix)
+		if compensation {
+			entry.Direction = entry.Direction.Opposite()
+			key += ":compensation"
+		}
+
+		command := events.NewTransactionCommand(events.EventTypes.CreateTransaction, events.CreateTransactionPayload{
+			AccountID:      entry.AccountID,
+			Type:           string(entry.Direction),
+			Amount:         entry.Amount,
+			Currency:       entry.Currency,
+			Description:    transfer.Description,
+			IdempotencyKey: key,
+		})
+		if compensation {
+			command.WithMetadata("compensates", fmt.Sprintf("%s:%s", transfer.IdempotencyKey, keySuffix))
+		}
+		return command, nil
+	}
+}
+
+// transferEntries returns the ledger entry posted by each movement step
+func transferEntries(transfer events.ProcessTransferPayload) map[string]ledger.Entry {
+	if transfer.FX == nil {
+		entries := ledger.NewTransfer(transfer.FromAccountID, transfer.ToAccountID, transfer.Amount, transfer.Currency, transfer.IdempotencyKey)
+		return map[string]ledger.Entry{StepDebitSource: entries[0], StepCreditDestination: entries[1]}
+	}
+
+	entries := ledger.NewConversion(transfer.FromAccountID, transfer.ToAccountID, transfer.Amount, transfer.Currency,
+		transfer.FX.TargetAmount, transfer.FX.TargetCurrency, transfer.IdempotencyKey)
+	return map[string]ledger.Entry{
+		StepDebitSource:       entries[0],
+		StepFXPositionIn:      entries[1],
+		StepFXPositionOut:     entries[2],
+		StepCreditDestination: entries[3],
+	}
+}
+
+// transferNotification builds the push notification for one party
+func transferNotification(sender bool) CommandFunc {
+	return func(state *State) (*events.Event, error) {
+		var transfer events.ProcessTransferPayload
+		if err := state.Decode(&transfer); err != nil {
+			return nil, err
+		}
+
+		accountID, title := transfer.ToAccountID, "Transferência recebida"
+		amount, currency := transfer.Credited()
+		if sender {
+			accountID, title = transfer.FromAccountID, "Transferência enviada"
+			amount, currency = transfer.Amount, transfer.Currency
+		}
+
+		// The notification service resolves the account owner from account_id
+		return events.NewNotificationEvent(events.EventTypes.SendPush, events.SendPushPayload{
+			UserID: accountID,
+			Title:  title,
+			Body:   fmt.Sprintf("%s %.2f", currency, amount),
+			Data: map[string]string{
+				"account_id":  accountID,
+				"transfer_id": state.ID,
+			},
+		}), nil
+	}
+}
+
+func transferCompleted(state *State) (*events.Event, error) {
+	var transfer events.ProcessTransferPayload
+	if err := state.Decode(&transfer); err != nil {
+		return nil, err
+	}
+
+	return events.NewTransactionEvent(events.EventTypes.TransferCompleted, events.TransferCompletedPayload{
+		TransferID:    state.ID,
+		FromAccountID: transfer.FromAccountID,
+		ToAccountID:   transfer.ToAccountID,
+		Amount:        transfer.Amount,
+		Currency:      transfer.Currency,
+		FX:            transfer.FX,
+		CompletedAt:   state.UpdatedAt,
+	}), nil
+}
+
+func transferFailed(state *State) (*events.Event, error) {
+	var transfer events.ProcessTransferPayload
+	if err := state.Decode(&transfer); err != nil {
+		return nil, err
+	}
+
+	return events.NewTransactionEvent(events.EventTypes.TransferFailed, events.TransferFailedPayload{
+		TransferID:       state.ID,
+		FromAccountID:    transfer.FromAccountID,
+		ToAccountID:      transfer.ToAccountID,
+		Amount:           transfer.Amount,
+		Currency:         transfer.Currency,
+		FX:               transfer.FX,
+		FailedStep:       state.FailedStep,
+		Reason:           state.FailureReason,
+		CompensatedSteps: state.CompensatedSteps,
+		FailedAt:         state.UpdatedAt,
+	}), nil
+}
@@ -0,0 +1,252 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package saga - Transfer saga tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package saga
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fintech-bank-platform/pkg/events"
+	"github.com/fintech-bank-platform/pkg/ledger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func transferPayload() events.ProcessTransferPayload {
+	return events.ProcessTransferPayload{
+		FromAccountID:  "acc-from",
+		ToAccountID:    "acc-to",
+		Amount:         150.50,
+		Currency:       "BRL",
+		Description:    "Aluguel",
+		IdempotencyKey: "transfer-key",
+	}
+}
+
+type transferHarness struct {
+	orchestrator *Orchestrator
+	dispatcher   *MemoryDispatcher
+	bus          *events.MemoryBus
+	commands     []*events.Event
+}
+
+func newTransferHarness() *transferHarness {
+	h := &transferHarness{
+		dispatcher: NewMemoryDispatcher(),
+		bus:        events.NewMemoryBus(),
+	}
+	h.orchestrator = NewOrchestrator(NewMemoryStore(), h.dispatcher, h.bus)
+	h.orchestrator.Register(TransferDefinition())
+
+	record := func(ctx context.Context, event *events.Event) error {
+		h.commands = append(h.commands, event)
+		return nil
+	}
+	h.dispatcher.Handle(events.EventTypes.CreateTransaction, record)
+	h.dispatcher.Handle(events.EventTypes.SendPush, record)
+	return h
+}
+
+func (h *transferHarness) outcome(t *testing.T) *events.Event {
+	published := h.bus.Published(events.Topics.TransactionEvents)
+	require.Len(t, published, 1)
+	return published[0]
+}
+
+func TestTransferSagaCompletes(t *testing.T) {
+	h := newTransferHarness()
+
+	state, err := h.orchestrator.Start(context.Background(), TransferSagaName, "transfer-1", transferPayload())
+
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, state.Status)
+	require.Len(t, h.commands, 4)
+
+	debit := h.commands[0].Payload.(events.CreateTransactionPayload)
+	assert.Equal(t, "acc-from", debit.AccountID)
+	assert.Equal(t, "debit", debit.Type)
+	assert.Equal(t, 150.50, debit.Amount)
+	assert.Equal(t, "transfer-key:debit", debit.IdempotencyKey)
+
+	credit := h.commands[1].Payload.(events.CreateTransactionPayload)
+	assert.Equal(t, "acc-to", credit.AccountID)
+	assert.Equal(t, "credit", credit.Type)
+	assert.Equal(t, "transfer-key:credit", credit.IdempotencyKey)
+
+	sender := h.commands[2].Payload.(events.SendPushPayload)
+	assert.Equal(t, "acc-from", sender.Data["account_id"])
+	assert.Equal(t, "transfer-1", sender.Data["transfer_id"])
+	recipient := h.commands[3].Payload.(events.SendPushPayload)
+	assert.Equal(t, "acc-to", recipient.Data["account_id"])
+
+	completed := h.outcome(t)
+	assert.Equal(t, events.EventTypes.TransferCompleted, completed.Type)
+	payload := completed.Payload.(events.TransferCompletedPayload)
+	assert.Equal(t, "transfer-1", payload.TransferID)
+	assert.Equal(t, "acc-from", payload.FromAccountID)
+	assert.Equal(t, "acc-to", payload.ToAccountID)
+}
+
+func TestTransferSagaCreditsConvertedAmount(t *testing.T) {
+	h := newTransferHarness()
+	transfer := transferPayload()
+	transfer.FX = &events.FXConversion{QuoteID: "quote-1", Rate: "0.18", TargetAmount: 27.09, TargetCurrency: "USD"}
+
+	state, err := h.orchestrator.Start(context.Background(), TransferSagaName, "transfer-1", transfer)
+
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, state.Status)
+	assert.Empty(t, state.SkippedSteps)
+	require.Len(t, h.commands, 6)
+
+	var movements []events.CreateTransactionPayload
+	for _, command := range h.commands[:4] {
+		movements = append(movements, command.Payload.(events.CreateTransactionPayload))
+	}
+	assert.Equal(t, []events.CreateTransactionPayload{
+		{AccountID: "acc-from", Type: "debit", Amount: 150.50, Currency: "BRL", Description: "Aluguel", IdempotencyKey: "transfer-key:debit"},
+		{AccountID: ledger.FXPositionAccount, Type: "credit", Amount: 150.50, Currency: "BRL", Description: "Aluguel", IdempotencyKey: "transfer-key:fx_in"},
+		{AccountID: ledger.FXPositionAccount, Type: "debit", Amount: 27.09, Currency: "USD", Description: "Aluguel", IdempotencyKey: "transfer-key:fx_out"},
+		{AccountID: "acc-to", Type: "credit", Amount: 27.09, Currency: "USD", Description: "Aluguel", IdempotencyKey: "transfer-key:credit"},
+	}, movements)
+
+	assert.Equal(t, "BRL 150.50", h.commands[4].Payload.(events.SendPushPayload).Body)
+	assert.Equal(t, "USD 27.09", h.commands[5].Payload.(events.SendPushPayload).Body)
+
+	payload := h.outcome(t).Payload.(events.TransferCompletedPayload)
+	assert.Equal(t, 150.50, payload.Amount)
+	assert.Equal(t, "BRL", payload.Currency)
+	assert.Equal(t, transfer.FX, payload.FX)
+}
+
+func TestTransferSagaCreditFailureRefundsSource(t *testing.T) {
+	h := newTransferHarness()
+	h.dispatcher.Handle(events.EventTypes.CreateTransaction, func(ctx context.Context, event *events.Event) error {
+		h.commands = append(h.commands, event)
+		if event.Payload.(events.CreateTransactionPayload).IdempotencyKey == "transfer-key:credit" {
+			return errBoom
+		}
+		return nil
+	})
+
+	state, err := h.orchestrator.Start(context.Background(), TransferSagaName, "transfer-1", transferPayload())
+
+	require.NoError(t, err)
+	assert.Equal(t, StatusFailed, state.Status)
+	require.Len(t, h.commands, 3)
+
+	refund := h.commands[2].Payload.(events.CreateTransactionPayload)
+	assert.Equal(t, "acc-from", refund.AccountID)
+	assert.Equal(t, "credit", refund.Type)
+	assert.Equal(t, "transfer-key:debit:compensation", refund.IdempotencyKey)
+
+	failed := h.outcome(t)
+	assert.Equal(t, events.EventTypes.TransferFailed, failed.Type)
+	payload := failed.Payload.(events.TransferFailedPayload)
+	assert.Equal(t, StepCreditDestination, payload.FailedStep)
+	assert.Equal(t, "boom", payload.Reason)
+	assert.Equal(t, []string{StepDebitSource}, payload.CompensatedSteps)
+	assert.Nil(t, payload.FX)
+}
+
+func TestTransferSagaConversionFailureUndoesFXPosition(t *testing.T) {
+	h := newTransferHarness()
+	transfer := transferPayload()
+	transfer.FX = &events.FXConversion{QuoteID: "quote-1", Rate: "0.18", TargetAmount: 27.09, TargetCurrency: "USD"}
+	h.dispatcher.Handle(events.EventTypes.CreateTransaction, func(ctx context.Context, event *events.Event) error {
+		h.commands = append(h.commands, event)
+		if event.Payload.(events.CreateTransactionPayload).IdempotencyKey == "transfer-key:credit" {
+			return errBoom
+		}
+		return nil
+	})
+
+	state, err := h.orchestrator.Start(context.Background(), TransferSagaName, "transfer-1", transfer)
+
+	require.NoError(t, err)
+	assert.Equal(t, StatusFailed, state.Status)
+	assert.Equal(t, []string{StepFXPositionOut, StepFXPositionIn, StepDebitSource}, state.CompensatedSteps)
+	require.Len(t, h.commands, 7)
+
+	var entries []ledger.Entry
+	for _, command := range h.commands {
+		if command.Payload.(events.CreateTransactionPayload).IdempotencyKey == "transfer-key:credit" {
+			continue
+		}
+		movement := command.Payload.(events.CreateTransactionPayload)
+		entries = append(entries, ledger.Entry{
+			AccountID: movement.AccountID, Direction: ledger.Direction(movement.Type), Amount: movement.Amount, Currency: movement.Currency,
+		})
+	}
+	assert.True(t, ledger.IsBalanced(entries), "the FX position is back where it was")
+	assert.Equal(t, "transfer-key:fx_out", h.commands[4].Metadata["compensates"])
+}
+
+func TestTransferSagaCreditTimeoutIsCompensated(t *testing.T) {
+	h := newTransferHarness()
+	def := TransferDefinition()
+	def.Steps[3].Timeout = 20 * time.Millisecond
+	require.Equal(t, StepCreditDestination, def.Steps[3].Name)
+	h.orchestrator.Register(def)
+	release := make(chan struct{})
+	defer close(release)
+	h.dispatcher.Handle(events.EventTypes.CreateTransaction, func(ctx context.Context, event *events.Event) error {
+		if event.Payload.(events.CreateTransactionPayload).IdempotencyKey == "transfer-key:credit" {
+			// The credit lands after the saga gave up on it
+			<-release
+			return nil
+		}
+		h.commands = append(h.commands, event)
+		return nil
+	})
+
+	state, err := h.orchestrator.Start(context.Background(), TransferSagaName, "transfer-1", transferPayload())
+
+	require.NoError(t, err)
+	assert.Equal(t, StatusFailed, state.Status)
+	require.Len(t, h.commands, 3)
+	reversal := h.commands[1].Payload.(events.CreateTransactionPayload)
+	assert.Equal(t, "acc-to", reversal.AccountID)
+	assert.Equal(t, "debit", reversal.Type)
+	assert.Equal(t, "transfer-key:credit:compensation", reversal.IdempotencyKey)
+	assert.Equal(t, "transfer-key:credit", h.commands[1].Metadata["compensates"])
+	refund := h.commands[2].Payload.(events.CreateTransactionPayload)
+	assert.Equal(t, "transfer-key:debit:compensation", refund.IdempotencyKey)
+	assert.Equal(t, []string{StepCreditDestination, StepDebitSource}, state.CompensatedSteps)
+}
+
+func TestTransferSagaNotificationFailureStillCompletes(t *testing.T) {
+	h := newTransferHarness()
+	h.dispatcher.Handle(events.EventTypes.SendPush, func(ctx context.Context, event *events.Event) error {
+		return errBoom
+	})
+
+	state, err := h.orchestrator.Start(context.Background(), TransferSagaName, "transfer-1", transferPayload())
+
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, state.Status)
+	assert.Equal(t, []string{StepFXPositionIn, StepFXPositionOut, StepNotifySender, StepNotifyRecipient}, state.SkippedSteps)
+	assert.Equal(t, events.EventTypes.TransferCompleted, h.outcome(t).Type)
+}
+
+func TestTransferCommandsRejectInvalidData(t *testing.T) {
+	state := &State{ID: "transfer-1", Data: []byte("{")}
+	def := TransferDefinition()
+
+	commands := []CommandFunc{def.OnCompleted, def.OnFailed}
+	for _, step := range def.Steps {
+		commands = append(commands, step.Command)
+		if step.Compensation != nil {
+			commands = append(commands, step.Compensation)
+		}
+	}
+
+	for _, command := range commands {
+		event, err := command(state)
+		assert.Error(t, err)
+		assert.Nil(t, event)
+	}
+}
@@ -0,0 +1,57 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package signature - Nonce store
+// ═══════════════════════════════════════════════════════════════════════════
+
+package signature
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// NonceStore remembers the nonces of accepted signatures. Gateways running
+// several instances need a shared store.
+type NonceStore interface {
+	// Remember records nonce for ttl. It returns false when the nonce is
+	// already recorded and has not expired.
+	Remember(ctx context.Context, nonce string, ttl time.Duration) (bool, error)
+}
+
+// sweepInterval is how often MemoryNonceStore drops expired nonces
+const sweepInterval = time.Minute
+
+// MemoryNonceStore is an in-memory NonceStore for a single instance
+type MemoryNonceStore struct {
+	mu      sync.Mutex
+	nonces  map[string]time.Time
+	sweepAt time.Time
+	now     func() time.Time
+}
+
+// NewMemoryNonceStore creates an empty in-memory nonce store
+func NewMemoryNonceStore() *MemoryNonceStore {
+	return &MemoryNonceStore{nonces: make(map[string]time.Time), now: time.Now}
+}
+
+// Remember implements NonceStore
+func (s *MemoryNonceStore) Remember(_ context.Context, nonce string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.After(s.sweepAt) {
+		for n, expiresAt := range s.nonces {
+			if !now.Before(expiresAt) {
+				delete(s.nonces, n)
+			}
+		}
+		s.sweepAt = now.Add(sweepInterval)
+	}
+
+	if expiresAt, ok := s.nonces[nonce]; ok && now.Before(expiresAt) {
+		return false, nil
+	}
+	s.nonces[nonce] = now.Add(ttl)
+	return true, nil
+}
@@ -0,0 +1,46 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package signature - Nonce store tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package signature
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryNonceStore(t *testing.T) {
+	store := NewMemoryNonceStore()
+	now := epoch
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	fresh, _ := store.Remember(ctx, "a", 10*time.Second)
+	assert.True(t, fresh)
+	fresh, _ = store.Remember(ctx, "a", 10*time.Second)
+	assert.False(t, fresh)
+
+	now = now.Add(10 * time.Second)
+	fresh, _ = store.Remember(ctx, "a", 10*time.Second)
+	assert.True(t, fresh, "expired nonces can be used again")
+}
+
+func TestMemoryNonceStoreSweepsExpiredNonces(t *testing.T) {
+	store := NewMemoryNonceStore()
+	now := epoch
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	store.Remember(ctx, "a", time.Second)
+	store.Remember(ctx, "b", time.Hour)
+	assert.Len(t, store.nonces, 2)
+
+	now = now.Add(sweepInterval + time.Second)
+	store.Remember(ctx, "c", time.Second)
+
+	assert.Len(t, store.nonces, 2, "a expired and was swept")
+	assert.Contains(t, store.nonces, "b")
+}
@@ -0,0 +1,122 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package signature - Signer and Verifier tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package signature
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fintech-bank-platform/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var epoch = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+var secrets = map[string][]byte{"partner-bank-341": []byte("s3cr3t")}
+
+const body = `{"amount":150000,"currency":"BRL"}`
+
+func signedRequest(at time.Time) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/v1/payments?dry_run=false", strings.NewReader(body))
+	signer := NewSigner("partner-bank-341", secrets["partner-bank-341"])
+	signer.now = func() time.Time { return at }
+	signer.Sign(req, []byte(body))
+	return req
+}
+
+func newVerifier(opts ...Option) *Verifier {
+	return NewVerifier(secrets, NewMemoryNonceStore(), append([]Option{WithClock(func() time.Time { return epoch })}, opts...)...)
+}
+
+type failingNonceStore struct{}
+
+func (failingNonceStore) Remember(context.Context, string, time.Duration) (bool, error) {
+	return false, stderrors.New("redis down")
+}
+
+func TestSignAndVerify(t *testing.T) {
+	req := signedRequest(epoch)
+
+	assert.Equal(t, "1792411200", req.Header.Get(HeaderTimestamp))
+	assert.True(t, strings.HasPrefix(req.Header.Get(HeaderSignature), "v1="))
+
+	keyID, err := newVerifier().Verify(context.Background(), req, []byte(body))
+	require.NoError(t, err)
+	assert.Equal(t, "partner-bank-341", keyID)
+}
+
+func TestVerifyRejectsReplays(t *testing.T) {
+	v := newVerifier()
+	req := signedRequest(epoch)
+
+	_, err := v.Verify(context.Background(), req, []byte(body))
+	require.NoError(t, err)
+	_, err = v.Verify(context.Background(), req, []byte(body))
+	assert.ErrorIs(t, err, errors.ErrSignatureReplayed)
+
+	_, err = v.Verify(context.Background(), signedRequest(epoch), []byte(body))
+	assert.NoError(t, err, "a new nonce is accepted")
+}
+
+func TestVerifyClockSkew(t *testing.T) {
+	v := newVerifier(WithClockSkew(time.Minute))
+
+	for offset, expected := range map[time.Duration]error{
+		time.Minute:                nil,
+		-time.Minute:               nil,
+		time.Minute + time.Second:  errors.ErrSignatureExpired,
+		-time.Minute - time.Second: errors.ErrSignatureExpired,
+	} {
+		_, err := v.Verify(context.Background(), signedRequest(epoch.Add(offset)), []byte(body))
+		if expected == nil {
+			assert.NoError(t, err, offset)
+		} else {
+			assert.ErrorIs(t, err, expected, offset)
+		}
+	}
+}
+
+func TestVerifyRejectsInvalidSignatures(t *testing.T) {
+	for name, tamper := range map[string]func(*http.Request) []byte{
+		"body":      func(*http.Request) []byte { return []byte(`{"amount":990000,"currency":"BRL"}`) },
+		"path":      func(r *http.Request) []byte { r.URL.Path = "/v1/transfers"; return []byte(body) },
+		"query":     func(r *http.Request) []byte { r.URL.RawQuery = "dry_run=true"; return []byte(body) },
+		"method":    func(r *http.Request) []byte { r.Method = http.MethodPut; return []byte(body) },
+		"nonce":     func(r *http.Request) []byte { r.Header.Set(HeaderNonce, "other"); return []byte(body) },
+		"unknown":   func(r *http.Request) []byte { r.Header.Set(HeaderKeyID, "partner-bank-999"); return []byte(body) },
+		"timestamp": func(r *http.Request) []byte { r.Header.Set(HeaderTimestamp, "yesterday"); return []byte(body) },
+		"missing":   func(r *http.Request) []byte { r.Header.Del(HeaderSignature); return []byte(body) },
+	} {
+		req := signedRequest(epoch)
+		signed := tamper(req)
+
+		_, err := newVerifier().Verify(context.Background(), req, signed)
+
+		assert.ErrorIs(t, err, errors.ErrInvalidSignature, name)
+	}
+	assert.Empty(t, errors.ErrInvalidSignature.Details, "the sentinel is not modified")
+}
+
+func TestVerifyRequiresSignature(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/v1/payments", strings.NewReader(body))
+
+	assert.False(t, Signed(req))
+	_, err := newVerifier().Verify(context.Background(), req, []byte(body))
+	assert.ErrorIs(t, err, errors.ErrSignatureRequired)
+}
+
+func TestVerifyNonceStoreError(t *testing.T) {
+	v := NewVerifier(secrets, failingNonceStore{}, WithClock(func() time.Time { return epoch }))
+
+	_, err := v.Verify(context.Background(), signedRequest(epoch), []byte(body))
+
+	assert.EqualError(t, err, "redis down")
+}
@@ -0,0 +1,322 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package transaction - Transactions and reversals with compensating entries
+// ═══════════════════════════════════════════════════════════════════════════
+
+package transaction
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/fintech-bank-platform/pkg/errors"
+	"github.com/fintech-bank-platform/pkg/events"
+	"github.com/fintech-bank-platform/pkg/ledger"
+	"github.com/google/uuid"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════
+// TRANSACTION
+// ═══════════════════════════════════════════════════════════════════════════
+
+// Status represents the state of a transaction
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+	StatusReversed  Status = "reversed"
+)
+
+// Reason codes accepted for reversals
+const (
+	ReasonMistakenCredit  = "MISTAKEN_CREDIT"
+	ReasonDuplicate       = "DUPLICATE"
+	ReasonFraud           = "FRAUD"
+	ReasonCustomerRequest = "CUSTOMER_REQUEST"
+)
+
+var reasons = map[string]bool{
+	ReasonMistakenCredit:  true,
+	ReasonDuplicate:       true,
+	ReasonFraud:           true,
+	ReasonCustomerRequest: true,
+}
+
+// Transaction represents a posted set of ledger movements
+type Transaction struct {
+	ID          string         `json:"id"`
+	AccountID   string         `json:"account_id"`
+	Type        string         `json:"type"`
+	Amount      float64        `json:"amount"`
+	Currency    string         `json:"currency"`
+	Status      Status         `json:"status"`
+	Entries     []ledger.Entry `json:"entries"`
+	ReversalOf  string         `json:"reversal_of,omitempty"`
+	ReversedBy  string         `json:"reversed_by,omitempty"`
+	ReasonCode  string         `json:"reason_code,omitempty"`
+	OperatorID  string         `json:"operator_id,omitempty"`
+	Description string         `json:"description,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+// IsReversal checks if the transaction compensates another one
+func (t *Transaction) IsReversal() bool {
+	return t.ReversalOf != ""
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// STORE
+// ═══════════════════════════════════════════════════════════════════════════
+
+// Store persists transactions and account balances
+type Store interface {
+	// Get returns a transaction or errors.ErrTransactionNotFound
+	Get(ctx context.Context, id string) (*Transaction, error)
+	// Balance returns the current balance of an account in a currency
+	Balance(ctx context.Context, accountID, currency string) (float64, error)
+	// Balances returns the sub-balance of every currency the account holds
+	Balances(ctx context.Context, accountID string) (map[string]float64, error)
+	// SaveReversal atomically stores the reversal, posts its entries and marks
+	// the original as reversed. It must return errors.ErrTransactionAlreadyReversed
+	// if the original was reversed concurrently and, when requireFunds is set,
+	// errors.ErrInsufficientFunds if a debited account cannot cover its entries
+	// at the time they are posted.
+	SaveReversal(ctx context.Context, originalID string, reversal *Transaction, requireFunds bool) error
+}
+
+// MemoryStore is an in-memory Store used in tests and local development
+type MemoryStore struct {
+	mu           sync.Mutex
+	transactions map[string]*Transaction
+	balances     map[string]map[string]int64
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		transactions: make(map[string]*Transaction),
+		balances:     make(map[string]map[string]int64),
+	}
+}
+
+// Post stores a completed transaction and applies its entries to balances
+func (s *MemoryStore) Post(t *Transaction) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.transactions[t.ID] = t
+	s.apply(t.Entries)
+}
+
+// Get returns a copy of a stored transaction
+func (s *MemoryStore) Get(_ context.Context, id string) (*Transaction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.transactions[id]
+	if !ok {
+		return nil, errors.ErrTransactionNotFound
+	}
+
+	copied := *t
+	return &copied, nil
+}
+
+// Balance returns the balance of an account in a currency
+func (s *MemoryStore) Balance(_ context.Context, accountID, currency string) (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return ledger.FromMinor(s.balances[accountID][currency], currency), nil
+}
+
+// Balances returns the sub-balances of an account by currency
+func (s *MemoryStore) Balances(_ context.Context, accountID string) (map[string]float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	balances := make(map[string]float64, len(s.balances[accountID]))
+	for currency, minor := range s.balances[accountID] {
+		balances[currency] = ledger.FromMinor(minor, currency)
+	}
+	return balances, nil
+}
+
+// SaveReversal stores the reversal and marks the original as reversed
+func (s *MemoryStore) SaveReversal(_ context.Context, originalID string, reversal *Transaction, requireFunds bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	original, ok := s.transactions[originalID]
+	if !ok {
+		return errors.ErrTransactionNotFound
+	}
+	if original.ReversedBy != "" {
+		return errors.ErrTransactionAlreadyReversed
+	}
+	if requireFunds && !s.covers(reversal.Entries) {
+		return errors.ErrInsufficientFunds
+	}
+
+	original.ReversedBy = reversal.ID
+	original.Status = StatusReversed
+	s.transactions[reversal.ID] = reversal
+	s.apply(reversal.Entries)
+
+	return nil
+}
+
+// covers reports whether every debited account can cover its debits
+func (s *MemoryStore) covers(entries []ledger.Entry) bool {
+	debits := make(map[[2]string]int64)
+	for _, entry := range entries {
+		if entry.Direction == ledger.Debit {
+			debits[[2]string{entry.AccountID, entry.Currency}] += ledger.ToMinor(entry.Amount, entry.Currency)
+		}
+	}
+
+	for key, amount := range debits {
+		if s.balances[key[0]][key[1]] < amount {
+			return false
+		}
+	}
+	return true
+}
+
+// apply posts entries to balances (credits increase, debits decrease)
+func (s *MemoryStore) apply(entries []ledger.Entry) {
+	for _, entry := range entries {
+		amount := ledger.ToMinor(entry.Amount, entry.Currency)
+		if entry.Direction == ledger.Debit {
+			amount = -amount
+		}
+		if s.balances[entry.AccountID] == nil {
+			s.balances[entry.AccountID] = make(map[string]int64)
+		}
+		s.balances[entry.AccountID][entry.Currency] += amount
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// REVERSAL
+// ═══════════════════════════════════════════════════════════════════════════
+
+// InsufficientFundsPolicy decides what happens when the account that received
+// the original credit no longer has the funds to be debited back
+type InsufficientFundsPolicy string
+
+const (
+	// PolicyReject refuses the reversal with errors.ErrInsufficientFunds
+	PolicyReject InsufficientFundsPolicy = "reject"
+	// PolicyAllowNegative posts the reversal leaving the balance negative
+	PolicyAllowNegative InsufficientFundsPolicy = "allow_negative"
+)
+
+// Reverser creates compensating transactions for reversal commands
+type Reverser struct {
+	store  Store
+	policy InsufficientFundsPolicy
+	now    func() time.Time
+}
+
+// Option configures a Reverser
+type Option func(*Reverser)
+
+// WithInsufficientFundsPolicy sets the policy applied when funds are missing
+func WithInsufficientFundsPolicy(policy InsufficientFundsPolicy) Option {
+	return func(r *Reverser) {
+		r.policy = policy
+	}
+}
+
+// WithClock sets the clock used to timestamp reversals
+func WithClock(now func() time.Time) Option {
+	return func(r *Reverser) {
+		r.now = now
+	}
+}
+
+// NewReverser creates a Reverser (PolicyReject by default)
+func NewReverser(store Store, opts ...Option) *Reverser {
+	r := &Reverser{
+		store:  store,
+		policy: PolicyReject,
+		now:    func() time.Time { return time.Now().UTC() },
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Result is the outcome of a successful reversal
+type Result struct {
+	Reversal *Transaction
+	Event    *events.Event
+}
+
+// Reverse posts the exact compensating transaction of cmd.TransactionID and
+// returns the TransactionReversed event linking both IDs
+func (r *Reverser) Reverse(ctx context.Context, cmd events.ReverseTransactionPayload) (*Result, error) {
+	if cmd.TransactionID == "" || cmd.ReasonCode == "" || cmd.OperatorID == "" {
+		return nil, errors.ErrMissingField
+	}
+	if !reasons[cmd.ReasonCode] {
+		return nil, errors.BadRequest(errors.ErrValidation.Code, errors.ErrValidation.Message).
+			WithDetails(map[string]string{"field": "reason_code", "reason": "unknown"})
+	}
+
+	original, err := r.store.Get(ctx, cmd.TransactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if original.IsReversal() {
+		return nil, errors.ErrReversalNotAllowed
+	}
+	if original.ReversedBy != "" || original.Status == StatusReversed {
+		return nil, errors.ErrTransactionAlreadyReversed
+	}
+	if original.Status != StatusCompleted {
+		return nil, errors.ErrReversalNotAllowed
+	}
+
+	reversalID := uuid.NewString()
+	entries := ledger.Compensate(original.Entries, reversalID)
+
+	reversal := &Transaction{
+		ID:          reversalID,
+		AccountID:   original.AccountID,
+		Type:        "reversal",
+		Amount:      original.Amount,
+		Currency:    original.Currency,
+		Status:      StatusCompleted,
+		Entries:     entries,
+		ReversalOf:  original.ID,
+		ReasonCode:  cmd.ReasonCode,
+		OperatorID:  cmd.OperatorID,
+		Description: cmd.Description,
+		CreatedAt:   r.now(),
+	}
+
+	if err := r.store.SaveReversal(ctx, original.ID, reversal, r.policy == PolicyReject); err != nil {
+		return nil, err
+	}
+
+	event := events.NewTransactionEvent(events.EventTypes.TransactionReversed, events.TransactionReversedPayload{
+		ReversalID:            reversal.ID,
+		OriginalTransactionID: original.ID,
+		AccountID:             reversal.AccountID,
+		Amount:                reversal.Amount,
+		Currency:              reversal.Currency,
+		ReasonCode:            reversal.ReasonCode,
+		OperatorID:            reversal.OperatorID,
+		ReversedAt:            reversal.CreatedAt,
+	}).
+		WithMetadata("transaction_id", original.ID).
+		WithMetadata("reversal_id", reversal.ID)
+
+	return &Result{Reversal: reversal, Event: event}, nil
+}
@@ -0,0 +1,315 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package transaction - Tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package transaction
+
+import (
+	"context"
+	stdErrors "errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fintech-bank-platform/pkg/errors"
+	"github.com/fintech-bank-platform/pkg/events"
+	"github.com/fintech-bank-platform/pkg/ledger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fundingAccount is the external side of the test credits
+const fundingAccount = "funding"
+
+func setupStore(t *testing.T, credit float64) *MemoryStore {
+	t.Helper()
+	store := NewMemoryStore()
+	store.Post(&Transaction{
+		ID:        "seed",
+		AccountID: fundingAccount,
+		Status:    StatusCompleted,
+		Entries:   []ledger.Entry{{AccountID: fundingAccount, Direction: ledger.Credit, Amount: 10000, Currency: "BRL", Reference: "seed"}},
+	})
+	store.Post(&Transaction{
+		ID:        "txn-1",
+		AccountID: "acc-1",
+		Type:      "credit",
+		Amount:    credit,
+		Currency:  "BRL",
+		Status:    StatusCompleted,
+		Entries:   ledger.NewTransfer(fundingAccount, "acc-1", credit, "BRL", "txn-1"),
+	})
+	return store
+}
+
+func reverseCmd(id string) events.ReverseTransactionPayload {
+	return events.ReverseTransactionPayload{
+		TransactionID:  id,
+		ReasonCode:     ReasonMistakenCredit,
+		OperatorID:     "operator-1",
+		IdempotencyKey: "idem-1",
+	}
+}
+
+func balance(t *testing.T, store Store, account string) float64 {
+	t.Helper()
+	value, err := store.Balance(context.Background(), account, "BRL")
+	require.NoError(t, err)
+	return value
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// REVERSAL TESTS
+// ═══════════════════════════════════════════════════════════════════════════
+
+func TestReverseCreatesExactCompensatingTransaction(t *testing.T) {
+	store := setupStore(t, 150.75)
+	fixed := time.Date(2025, time.June, 10, 12, 0, 0, 0, time.UTC)
+	reverser := NewReverser(store, WithClock(func() time.Time { return fixed }))
+
+	result, err := reverser.Reverse(context.Background(), reverseCmd("txn-1"))
+
+	require.NoError(t, err)
+	reversal := result.Reversal
+	assert.Equal(t, "txn-1", reversal.ReversalOf)
+	assert.True(t, reversal.IsReversal())
+	assert.Equal(t, 150.75, reversal.Amount)
+	assert.Equal(t, ReasonMistakenCredit, reversal.ReasonCode)
+	assert.Equal(t, "operator-1", reversal.OperatorID)
+	assert.Equal(t, fixed, reversal.CreatedAt)
+	assert.Equal(t, ledger.Compensate(ledger.NewTransfer(fundingAccount, "acc-1", 150.75, "BRL", "txn-1"), reversal.ID), reversal.Entries)
+
+	assert.Equal(t, 0.0, balance(t, store, "acc-1"))
+	assert.Equal(t, 10000.0, balance(t, store, fundingAccount))
+
+	original, err := store.Get(context.Background(), "txn-1")
+	require.NoError(t, err)
+	assert.Equal(t, StatusReversed, original.Status)
+	assert.Equal(t, reversal.ID, original.ReversedBy)
+}
+
+func TestReverseEmitsTransactionReversed(t *testing.T) {
+	reverser := NewReverser(setupStore(t, 100))
+
+	result, err := reverser.Reverse(context.Background(), reverseCmd("txn-1"))
+
+	require.NoError(t, err)
+	assert.Equal(t, events.EventTypes.TransactionReversed, result.Event.Type)
+	assert.Equal(t, "transaction-service", result.Event.Source)
+	assert.Equal(t, "txn-1", result.Event.Metadata["transaction_id"])
+	assert.Equal(t, result.Reversal.ID, result.Event.Metadata["reversal_id"])
+
+	payload := result.Event.Payload.(events.TransactionReversedPayload)
+	assert.Equal(t, "txn-1", payload.OriginalTransactionID)
+	assert.Equal(t, result.Reversal.ID, payload.ReversalID)
+	assert.Equal(t, ReasonMistakenCredit, payload.ReasonCode)
+	assert.Equal(t, "operator-1", payload.OperatorID)
+}
+
+func TestReverseRefusesDoubleReversal(t *testing.T) {
+	reverser := NewReverser(setupStore(t, 100))
+	_, err := reverser.Reverse(context.Background(), reverseCmd("txn-1"))
+	require.NoError(t, err)
+
+	_, err = reverser.Reverse(context.Background(), reverseCmd("txn-1"))
+
+	assert.Equal(t, errors.ErrTransactionAlreadyReversed, err)
+}
+
+func TestReverseRefusesReversingAReversal(t *testing.T) {
+	reverser := NewReverser(setupStore(t, 100), WithInsufficientFundsPolicy(PolicyAllowNegative))
+	result, err := reverser.Reverse(context.Background(), reverseCmd("txn-1"))
+	require.NoError(t, err)
+
+	_, err = reverser.Reverse(context.Background(), reverseCmd(result.Reversal.ID))
+
+	assert.Equal(t, errors.ErrReversalNotAllowed, err)
+}
+
+func TestReverseConcurrentRequestsOnlyOneSucceeds(t *testing.T) {
+	reverser := NewReverser(setupStore(t, 100))
+
+	var wg sync.WaitGroup
+	results := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := reverser.Reverse(context.Background(), reverseCmd("txn-1"))
+			results <- err
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	succeeded := 0
+	for err := range results {
+		if err == nil {
+			succeeded++
+		}
+	}
+	assert.Equal(t, 1, succeeded)
+}
+
+func TestReverseNonCompletedTransaction(t *testing.T) {
+	store := NewMemoryStore()
+	store.Post(&Transaction{ID: "txn-p", Status: StatusPending})
+
+	_, err := NewReverser(store).Reverse(context.Background(), reverseCmd("txn-p"))
+
+	assert.Equal(t, errors.ErrReversalNotAllowed, err)
+}
+
+func TestReverseUnknownTransaction(t *testing.T) {
+	_, err := NewReverser(NewMemoryStore()).Reverse(context.Background(), reverseCmd("missing"))
+
+	assert.Equal(t, errors.ErrTransactionNotFound, err)
+}
+
+func TestReverseMissingFields(t *testing.T) {
+	reverser := NewReverser(NewMemoryStore())
+
+	for _, cmd := range []events.ReverseTransactionPayload{
+		{ReasonCode: "X", OperatorID: "op"},
+		{TransactionID: "txn-1", OperatorID: "op"},
+		{TransactionID: "txn-1", ReasonCode: "X"},
+	} {
+		_, err := reverser.Reverse(context.Background(), cmd)
+		assert.Equal(t, errors.ErrMissingField, err)
+	}
+}
+
+func TestReverseUnknownReasonCode(t *testing.T) {
+	store := setupStore(t, 100)
+	cmd := reverseCmd("txn-1")
+	cmd.ReasonCode = "CHANGED_MY_MIND"
+
+	_, err := NewReverser(store).Reverse(context.Background(), cmd)
+
+	assert.True(t, stdErrors.Is(err, errors.ErrValidation))
+	assert.Equal(t, "reason_code", err.(*errors.AppError).Details["field"])
+	assert.Empty(t, errors.ErrValidation.Details)
+	assert.Equal(t, 100.0, balance(t, store, "acc-1"))
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// INSUFFICIENT FUNDS POLICY TESTS
+// ═══════════════════════════════════════════════════════════════════════════
+
+func spend(store *MemoryStore, amount float64) {
+	store.Post(&Transaction{
+		ID:      "spend",
+		Status:  StatusCompleted,
+		Entries: ledger.NewTransfer("acc-1", "merchant", amount, "BRL", "spend"),
+	})
+}
+
+func TestReverseRejectsWhenCreditedAccountLacksFunds(t *testing.T) {
+	store := setupStore(t, 100)
+	spend(store, 60)
+
+	_, err := NewReverser(store).Reverse(context.Background(), reverseCmd("txn-1"))
+
+	assert.Equal(t, errors.ErrInsufficientFunds, err)
+	assert.Equal(t, 40.0, balance(t, store, "acc-1"))
+
+	original, _ := store.Get(context.Background(), "txn-1")
+	assert.Equal(t, StatusCompleted, original.Status)
+}
+
+// spendingStore spends from the credited account between the Reverser reading
+// the original and the reversal being saved
+type spendingStore struct {
+	*MemoryStore
+}
+
+func (s spendingStore) SaveReversal(ctx context.Context, originalID string, reversal *Transaction, requireFunds bool) error {
+	spend(s.MemoryStore, 60)
+	return s.MemoryStore.SaveReversal(ctx, originalID, reversal, requireFunds)
+}
+
+func TestReverseChecksFundsWhenSaving(t *testing.T) {
+	store := setupStore(t, 100)
+
+	_, err := NewReverser(spendingStore{store}).Reverse(context.Background(), reverseCmd("txn-1"))
+
+	assert.Equal(t, errors.ErrInsufficientFunds, err)
+	assert.Equal(t, 40.0, balance(t, store, "acc-1"))
+	original, _ := store.Get(context.Background(), "txn-1")
+	assert.Equal(t, StatusCompleted, original.Status)
+}
+
+func TestReverseAllowNegativePolicy(t *testing.T) {
+	store := setupStore(t, 100)
+	spend(store, 60)
+
+	_, err := NewReverser(store, WithInsufficientFundsPolicy(PolicyAllowNegative)).
+		Reverse(context.Background(), reverseCmd("txn-1"))
+
+	require.NoError(t, err)
+	assert.Equal(t, -60.0, balance(t, store, "acc-1"))
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// STORE ERROR TESTS
+// ═══════════════════════════════════════════════════════════════════════════
+
+type failingStore struct {
+	*MemoryStore
+	saveErr error
+}
+
+func (s *failingStore) SaveReversal(ctx context.Context, originalID string, reversal *Transaction, requireFunds bool) error {
+	if s.saveErr != nil {
+		return s.saveErr
+	}
+	return s.MemoryStore.SaveReversal(ctx, originalID, reversal, requireFunds)
+}
+
+func TestReverseSaveError(t *testing.T) {
+	boom := stdErrors.New("database down")
+	store := &failingStore{MemoryStore: setupStore(t, 100), saveErr: boom}
+
+	_, err := NewReverser(store).Reverse(context.Background(), reverseCmd("txn-1"))
+
+	assert.Equal(t, boom, err)
+}
+
+func TestMemoryStoreSaveReversalUnknownOriginal(t *testing.T) {
+	err := NewMemoryStore().SaveReversal(context.Background(), "missing", &Transaction{ID: "rev"}, true)
+
+	assert.Equal(t, errors.ErrTransactionNotFound, err)
+}
+
+func TestMemoryStoreSaveReversalAlreadyReversed(t *testing.T) {
+	store := setupStore(t, 100)
+	require.NoError(t, store.SaveReversal(context.Background(), "txn-1", &Transaction{ID: "rev-1"}, true))
+
+	err := store.SaveReversal(context.Background(), "txn-1", &Transaction{ID: "rev-2"}, true)
+
+	assert.Equal(t, errors.ErrTransactionAlreadyReversed, err)
+}
+
+func TestMemoryStoreBalancesByCurrency(t *testing.T) {
+	store := NewMemoryStore()
+	store.Post(&Transaction{
+		ID:      "fx-1",
+		Status:  StatusCompleted,
+		Entries: ledger.NewConversion("acc-1", "acc-2", 100, "BRL", 18.25, "USD", "fx-1"),
+	})
+	store.Post(&Transaction{
+		ID:      "txn-2",
+		Status:  StatusCompleted,
+		Entries: ledger.NewTransfer("acc-3", "acc-2", 50, "BRL", "txn-2"),
+	})
+
+	balances, err := store.Balances(context.Background(), "acc-2")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"USD": 18.25, "BRL": 50}, balances)
+
+	position, _ := store.Balances(context.Background(), ledger.FXPositionAccount)
+	assert.Equal(t, map[string]float64{"BRL": 100, "USD": -18.25}, position)
+
+	empty, _ := store.Balances(context.Background(), "acc-unknown")
+	assert.Empty(t, empty)
+}
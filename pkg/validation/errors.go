@@ -0,0 +1,180 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package validation - AppError conversion
+// ═══════════════════════════════════════════════════════════════════════════
+
+package validation
+
+import (
+	stderrors "errors"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/fintech-bank-platform/pkg/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+// Messages holds the message of a tag in each locale. {param} is replaced
+// by the tag parameter, e.g. the 8 of min=8.
+type Messages map[errors.Locale]string
+
+// sizedTags have one message per kind of field: strings count characters,
+// slices and maps count items and numbers compare values
+var sizedTags = map[string]bool{
+	"min": true, "max": true, "len": true,
+	"gt": true, "gte": true, "lt": true, "lte": true,
+}
+
+// messagesMu guards tagMessages, which RegisterMessages may change while
+// requests are being validated
+var messagesMu sync.RWMutex
+
+var tagMessages = map[string]Messages{
+	// Presence
+	"required":             {errors.LocalePtBR: "é obrigatório", errors.LocaleEN: "is required"},
+	"required_if":          {errors.LocalePtBR: "é obrigatório", errors.LocaleEN: "is required"},
+	"required_unless":      {errors.LocalePtBR: "é obrigatório", errors.LocaleEN: "is required"},
+	"required_with":        {errors.LocalePtBR: "é obrigatório", errors.LocaleEN: "is required"},
+	"required_with_all":    {errors.LocalePtBR: "é obrigatório", errors.LocaleEN: "is required"},
+	"required_without":     {errors.LocalePtBR: "é obrigatório", errors.LocaleEN: "is required"},
+	"required_without_all": {errors.LocalePtBR: "é obrigatório", errors.LocaleEN: "is required"},
+
+	// Formats
+	"email":    {errors.LocalePtBR: "deve ser um e-mail válido", errors.LocaleEN: "must be a valid email address"},
+	"url":      {errors.LocalePtBR: "deve ser uma URL válida", errors.LocaleEN: "must be a valid URL"},
+	"uuid":     {errors.LocalePtBR: "deve ser um UUID válido", errors.LocaleEN: "must be a valid UUID"},
+	"uuid4":    {errors.LocalePtBR: "deve ser um UUID válido", errors.LocaleEN: "must be a valid UUID"},
+	"numeric":  {errors.LocalePtBR: "deve ser numérico", errors.LocaleEN: "must be numeric"},
+	"number":   {errors.LocalePtBR: "deve ser numérico", errors.LocaleEN: "must be numeric"},
+	"alpha":    {errors.LocalePtBR: "deve conter apenas letras", errors.LocaleEN: "must contain only letters"},
+	"alphanum": {errors.LocalePtBR: "deve conter apenas letras e números", errors.LocaleEN: "must contain only letters and numbers"},
+	"datetime": {errors.LocalePtBR: "deve ser uma data no formato {param}", errors.LocaleEN: "must be a date in the format {param}"},
+	"e164":     {errors.LocalePtBR: "deve ser um telefone no formato E.164", errors.LocaleEN: "must be a phone number in E.164 format"},
+	"iso4217":  {errors.LocalePtBR: "deve ser um código de moeda ISO 4217", errors.LocaleEN: "must be an ISO 4217 currency code"},
+
+	// Comparisons
+	"oneof":   {errors.LocalePtBR: "deve ser um de: {param}", errors.LocaleEN: "must be one of: {param}"},
+	"eq":      {errors.LocalePtBR: "deve ser igual a {param}", errors.LocaleEN: "must be equal to {param}"},
+	"ne":      {errors.LocalePtBR: "não pode ser igual a {param}", errors.LocaleEN: "must not be equal to {param}"},
+	"eqfield": {errors.LocalePtBR: "deve ser igual a {param}", errors.LocaleEN: "must match {param}"},
+	"nefield": {errors.LocalePtBR: "deve ser diferente de {param}", errors.LocaleEN: "must differ from {param}"},
+
+	// Sizes
+	"min":        {errors.LocalePtBR: "deve ser no mínimo {param}", errors.LocaleEN: "must be at least {param}"},
+	"min_string": {errors.LocalePtBR: "deve ter no mínimo {param} caracteres", errors.LocaleEN: "must be at least {param} characters long"},
+	"min_items":  {errors.LocalePtBR: "deve conter no mínimo {param} itens", errors.LocaleEN: "must contain at least {param} items"},
+	"max":        {errors.LocalePtBR: "deve ser no máximo {param}", errors.LocaleEN: "must be at most {param}"},
+	"max_string": {errors.LocalePtBR: "deve ter no máximo {param} caracteres", errors.LocaleEN: "must be at most {param} characters long"},
+	"max_items":  {errors.LocalePtBR: "deve conter no máximo {param} itens", errors.LocaleEN: "must contain at most {param} items"},
+	"len":        {errors.LocalePtBR: "deve ser igual a {param}", errors.LocaleEN: "must be equal to {param}"},
+	"len_string": {errors.LocalePtBR: "deve ter exatamente {param} caracteres", errors.LocaleEN: "must be exactly {param} characters long"},
+	"len_items":  {errors.LocalePtBR: "deve conter exatamente {param} itens", errors.LocaleEN: "must contain exactly {param} items"},
+	"gt":         {errors.LocalePtBR: "deve ser maior que {param}", errors.LocaleEN: "must be greater than {param}"},
+	"gt_string":  {errors.LocalePtBR: "deve ter mais de {param} caracteres", errors.LocaleEN: "must be longer than {param} characters"},
+	"gt_items":   {errors.LocalePtBR: "deve conter mais de {param} itens", errors.LocaleEN: "must contain more than {param} items"},
+	"gte":        {errors.LocalePtBR: "deve ser maior ou igual a {param}", errors.LocaleEN: "must be greater than or equal to {param}"},
+	"gte_string": {errors.LocalePtBR: "deve ter no mínimo {param} caracteres", errors.LocaleEN: "must be at least {param} characters long"},
+	"gte_items":  {errors.LocalePtBR: "deve conter no mínimo {param} itens", errors.LocaleEN: "must contain at least {param} items"},
+	"lt":         {errors.LocalePtBR: "deve ser menor que {param}", errors.LocaleEN: "must be less than {param}"},
+	"lt_string":  {errors.LocalePtBR: "deve ter menos de {param} caracteres", errors.LocaleEN: "must be shorter than {param} characters"},
+	"lt_items":   {errors.LocalePtBR: "deve conter menos de {param} itens", errors.LocaleEN: "must contain fewer than {param} items"},
+	"lte":        {errors.LocalePtBR: "deve ser menor ou igual a {param}", errors.LocaleEN: "must be less than or equal to {param}"},
+	"lte_string": {errors.LocalePtBR: "deve ter no máximo {param} caracteres", errors.LocaleEN: "must be at most {param} characters long"},
+	"lte_items":  {errors.LocalePtBR: "deve conter no máximo {param} itens", errors.LocaleEN: "must contain at most {param} items"},
+
+	// Custom validators
+	"cpf":               {errors.LocalePtBR: "deve ser um CPF válido", errors.LocaleEN: "must be a valid CPF"},
+	"cnpj":              {errors.LocalePtBR: "deve ser um CNPJ válido", errors.LocaleEN: "must be a valid CNPJ"},
+	"phone_br":          {errors.LocalePtBR: "deve ser um telefone brasileiro válido", errors.LocaleEN: "must be a valid Brazilian phone number"},
+	"currency":          {errors.LocalePtBR: "deve ser um código de moeda suportado", errors.LocaleEN: "must be a supported currency code"},
+	"password_strength": {errors.LocalePtBR: "deve ter no mínimo 8 caracteres, com letras maiúsculas e minúsculas, um número e um caractere especial", errors.LocaleEN: "must have at least 8 characters with uppercase and lowercase letters, a digit and a special character"},
+	"account_number":    {errors.LocalePtBR: "deve ser um número de conta válido", errors.LocaleEN: "must be a valid account number"},
+	"agency_number":     {errors.LocalePtBR: "deve ser um número de agência válido", errors.LocaleEN: "must be a valid agency number"},
+	"pix_key":           {errors.LocalePtBR: "deve ser uma chave PIX válida (CPF, CNPJ, e-mail, telefone ou chave aleatória)", errors.LocaleEN: "must be a valid PIX key (CPF, CNPJ, email, phone or random key)"},
+	"business_day":      {errors.LocalePtBR: "deve ser um dia útil bancário", errors.LocaleEN: "must be a banking business day"},
+}
+
+// fallbackMessages describe tags without messages of their own
+var fallbackMessages = Messages{
+	errors.LocalePtBR: "é inválido ({tag})",
+	errors.LocaleEN:   "is invalid ({tag})",
+}
+
+// RegisterMessages sets the messages of a tag, for validators that
+// services register on GetValidator. It is safe to call concurrently with
+// validation.
+func RegisterMessages(tag string, messages Messages) {
+	messagesMu.Lock()
+	defer messagesMu.Unlock()
+	tagMessages[tag] = messages
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// CONVERSION
+// ═══════════════════════════════════════════════════════════════════════════
+
+// ToAppError converts the result of Validate into a VALIDATION_ERROR
+// AppError whose Details map each failed field to an English message. It
+// returns nil for a nil error.
+func ToAppError(err error) *errors.AppError {
+	return ToAppErrorIn(err, errors.LocaleEN)
+}
+
+// ToAppErrorIn is ToAppError with field messages in locale. Fields are
+// named after their JSON tags; nested structs and slice elements use dotted
+// paths such as "address.zip_code" or "items.0.amount". Errors that are not
+// validation failures, like validating a nil pointer, become internal
+// errors.
+func ToAppErrorIn(err error, locale errors.Locale) *errors.AppError {
+	if err == nil {
+		return nil
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !stderrors.As(err, &validationErrors) {
+		return errors.InternalServer(errors.ErrInternalServer.Code, errors.ErrInternalServer.Message).Wrap(err)
+	}
+
+	details := make(map[string]string, len(validationErrors))
+	for _, fieldError := range validationErrors {
+		details[FieldPath(fieldError)] = FieldMessage(fieldError, locale)
+	}
+	return errors.BadRequest(errors.ErrValidation.Code, errors.ErrValidation.Message).WithDetails(details).Wrap(err)
+}
+
+// FieldPath returns the dotted path of a failed field without the name of
+// the validated struct, e.g. "items.0.amount"
+func FieldPath(fieldError validator.FieldError) string {
+	namespace := fieldError.Namespace()
+	if _, path, found := strings.Cut(namespace, "."); found {
+		namespace = path
+	}
+	return strings.NewReplacer("[", ".", "]", "").Replace(namespace)
+}
+
+// FieldMessage describes why a field failed in locale, falling back to
+// English for locales without messages
+func FieldMessage(fieldError validator.FieldError, locale errors.Locale) string {
+	tag := fieldError.Tag()
+	key := tag
+	if sizedTags[tag] {
+		switch fieldError.Kind() {
+		case reflect.String:
+			key += "_string"
+		case reflect.Slice, reflect.Array, reflect.Map:
+			key += "_items"
+		}
+	}
+
+	messagesMu.RLock()
+	messages, ok := tagMessages[key]
+	messagesMu.RUnlock()
+	if !ok {
+		messages = fallbackMessages
+	}
+	message, ok := messages[locale]
+	if !ok {
+		message = messages[errors.LocaleEN]
+	}
+	return strings.NewReplacer("{param}", fieldError.Param(), "{tag}", tag).Replace(message)
+}
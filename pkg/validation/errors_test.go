@@ -0,0 +1,192 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Package validation - AppError conversion tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package validation
+
+import (
+	stderrors "errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fintech-bank-platform/pkg/errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testAddress struct {
+	ZipCode string `json:"zip_code" validate:"required,len=8"`
+}
+
+type testItem struct {
+	Amount float64 `json:"amount" validate:"gt=0"`
+}
+
+type testCustomer struct {
+	Name     string            `json:"name,omitempty" validate:"required,min=3"`
+	Document string            `json:"document" validate:"cpf"`
+	PixKey   string            `json:"pix_key" validate:"pix_key"`
+	Currency string            `json:"currency" validate:"currency"`
+	Password string            `json:"password" validate:"password_strength"`
+	Address  testAddress       `json:"address"`
+	Items    []testItem        `json:"items" validate:"min=1,dive"`
+	Tags     map[string]string `json:"tags" validate:"dive,keys,alpha,endkeys,required"`
+	Internal string            `json:"-" validate:"required"`
+	Untagged string            `validate:"oneof=a b"`
+}
+
+func validCustomer() testCustomer {
+	return testCustomer{
+		Name:     "Maria",
+		Document: "529.982.247-25",
+		PixKey:   "maria@example.com",
+		Currency: "BRL",
+		Password: "Str0ng!Pass",
+		Address:  testAddress{ZipCode: "01310100"},
+		Items:    []testItem{{Amount: 10}},
+		Internal: "set",
+		Untagged: "a",
+	}
+}
+
+func TestToAppErrorNil(t *testing.T) {
+	assert.Nil(t, ToAppError(Validate(validCustomer())))
+}
+
+func TestToAppErrorMapsFieldsByJSONName(t *testing.T) {
+	customer := validCustomer()
+	customer.Name = "Al"
+	customer.Document = "111.111.111-11"
+	customer.PixKey = "not a key"
+	customer.Currency = "XYZ"
+	customer.Password = "weak"
+	customer.Address.ZipCode = "123"
+	customer.Items = []testItem{{Amount: 10}, {Amount: 0}}
+	customer.Tags = map[string]string{"vip": ""}
+	customer.Untagged = "c"
+
+	appErr := ToAppError(Validate(customer))
+
+	require.NotNil(t, appErr)
+	assert.Equal(t, "VALIDATION_ERROR", appErr.Code)
+	assert.Equal(t, http.StatusBadRequest, appErr.HTTPStatus)
+	assert.True(t, stderrors.Is(appErr, errors.ErrValidation))
+	assert.Equal(t, map[string]string{
+		"name":             "must be at least 3 characters long",
+		"document":         "must be a valid CPF",
+		"pix_key":          "must be a valid PIX key (CPF, CNPJ, email, phone or random key)",
+		"currency":         "must be a supported currency code",
+		"password":         "must have at least 8 characters with uppercase and lowercase letters, a digit and a special character",
+		"address.zip_code": "must be exactly 8 characters long",
+		"items.1.amount":   "must be greater than 0",
+		"tags.vip":         "is required",
+		"Untagged":         "must be one of: a b",
+	}, appErr.Details)
+
+	var validationErrors validator.ValidationErrors
+	assert.True(t, stderrors.As(appErr, &validationErrors), "the validator errors stay wrapped")
+}
+
+func TestToAppErrorInPortuguese(t *testing.T) {
+	customer := validCustomer()
+	customer.Name = ""
+	customer.Items = nil
+
+	appErr := ToAppErrorIn(Validate(customer), errors.LocalePtBR)
+
+	assert.Equal(t, map[string]string{
+		"name":  "é obrigatório",
+		"items": "deve conter no mínimo 1 itens",
+	}, appErr.Details)
+}
+
+func TestToAppErrorInvalidValidation(t *testing.T) {
+	appErr := ToAppError(Validate(nil))
+
+	assert.Equal(t, "INTERNAL_ERROR", appErr.Code)
+	assert.Equal(t, http.StatusInternalServerError, appErr.HTTPStatus)
+	assert.Error(t, appErr.Unwrap())
+}
+
+func TestFieldMessageKinds(t *testing.T) {
+	type sized struct {
+		Count int      `json:"count" validate:"max=5"`
+		Note  string   `json:"note" validate:"lte=2"`
+		List  []string `json:"list" validate:"lt=1"`
+	}
+
+	appErr := ToAppError(Validate(sized{Count: 6, Note: "long", List: []string{"a"}}))
+
+	assert.Equal(t, map[string]string{
+		"count": "must be at most 5",
+		"note":  "must be at most 2 characters long",
+		"list":  "must contain fewer than 1 items",
+	}, appErr.Details)
+}
+
+func TestFieldMessageFallbacks(t *testing.T) {
+	type unusual struct {
+		Color string    `json:"color" validate:"hexcolor"`
+		Day   time.Time `json:"day" validate:"business_day"`
+	}
+	value := unusual{Color: "blue", Day: time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)}
+
+	english := ToAppError(Validate(value))
+	french := ToAppErrorIn(Validate(value), errors.Locale("fr"))
+
+	assert.Equal(t, "is invalid (hexcolor)", english.Details["color"])
+	assert.Equal(t, "must be a banking business day", english.Details["day"])
+	assert.Equal(t, english.Details, french.Details, "unknown locales fall back to English")
+}
+
+func TestRegisterMessages(t *testing.T) {
+	validate.RegisterValidation("even", func(fl validator.FieldLevel) bool { return fl.Field().Int()%2 == 0 })
+	RegisterMessages("even", Messages{errors.LocalePtBR: "deve ser par", errors.LocaleEN: "must be even"})
+	t.Cleanup(func() { delete(tagMessages, "even") })
+
+	type numbers struct {
+		Value int `json:"value" validate:"even"`
+	}
+
+	assert.Equal(t, "deve ser par", ToAppErrorIn(Validate(numbers{Value: 3}), errors.LocalePtBR).Details["value"])
+}
+
+func TestRegisterMessagesWhileValidating(t *testing.T) {
+	t.Cleanup(func() { delete(tagMessages, "concurrent") })
+	type account struct {
+		Name string `json:"name" validate:"required"`
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			RegisterMessages("concurrent", Messages{errors.LocaleEN: "is concurrent"})
+		}()
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, "is required", ToAppError(Validate(account{})).Details["name"])
+		}()
+	}
+	wg.Wait()
+}
+
+func TestEveryCustomValidatorHasMessages(t *testing.T) {
+	for tag := range customValidators {
+		for _, locale := range errors.Locales {
+			assert.NotEmpty(t, tagMessages[tag][locale], "%s lacks a %s message", tag, locale)
+		}
+	}
+}
+
+func TestEveryMessageIsTranslated(t *testing.T) {
+	for tag, messages := range tagMessages {
+		for _, locale := range errors.Locales {
+			assert.NotEmpty(t, messages[locale], "%s lacks a %s message", tag, locale)
+		}
+	}
+}
@@ -5,10 +5,14 @@
 package validation
 
 import (
+	"reflect"
 	"regexp"
 	"strings"
+	"time"
 	"unicode"
 
+	"github.com/fintech-bank-platform/pkg/calendar"
+	"github.com/fintech-bank-platform/pkg/currency"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -20,6 +24,7 @@ var validate *validator.Validate
 
 func init() {
 	validate = validator.New()
+	validate.RegisterTagNameFunc(jsonFieldName)
 	registerCustomValidators()
 }
 
@@ -32,15 +37,37 @@ func GetValidator() *validator.Validate {
 // CUSTOM VALIDATORS REGISTRATION
 // ═══════════════════════════════════════════════════════════════════════════
 
+// customValidators maps each custom tag to its validation; every tag needs
+// messages in errors.go
+var customValidators = map[string]validator.Func{
+	"cpf":               validateCPF,
+	"cnpj":              validateCNPJ,
+	"phone_br":          validateBrazilianPhone,
+	"currency":          validateCurrency,
+	"password_strength": validatePasswordStrength,
+	"account_number":    validateAccountNumber,
+	"agency_number":     validateAgencyNumber,
+	"pix_key":           validatePixKey,
+	"business_day":      validateBusinessDay,
+}
+
 func registerCustomValidators() {
-	validate.RegisterValidation("cpf", validateCPF)
-	validate.RegisterValidation("cnpj", validateCNPJ)
-	validate.RegisterValidation("phone_br", validateBrazilianPhone)
-	validate.RegisterValidation("currency", validateCurrency)
-	validate.RegisterValidation("password_strength", validatePasswordStrength)
-	validate.RegisterValidation("account_number", validateAccountNumber)
-	validate.RegisterValidation("agency_number", validateAgencyNumber)
-	validate.RegisterValidation("pix_key", validatePixKey)
+	for tag, fn := range customValidators {
+		validate.RegisterValidation(tag, fn)
+	}
+}
+
+// jsonFieldName names fields after their JSON tag so validation errors use
+// the names clients send. Fields without a tag keep their Go name.
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	}
+	return name
 }
 
 // ═══════════════════════════════════════════════════════════════════════════
@@ -213,14 +240,10 @@ func validateCurrency(fl validator.FieldLevel) bool {
 	return IsValidCurrency(currency)
 }
 
-// IsValidCurrency checks if a currency code is valid (common codes)
+// IsValidCurrency checks if a currency code is supported by the platform
+// (see currency.SetSupported)
 func IsValidCurrency(code string) bool {
-	validCurrencies := map[string]bool{
-		"BRL": true, "USD": true, "EUR": true, "GBP": true,
-		"JPY": true, "CNY": true, "ARS": true, "CLP": true,
-		"COP": true, "MXN": true, "PEN": true, "UYU": true,
-	}
-	return validCurrencies[strings.ToUpper(code)]
+	return currency.IsSupported(code)
 }
 
 // ═══════════════════════════════════════════════════════════════════════════
@@ -344,6 +367,34 @@ func IsValidPixKey(key string) bool {
 	return false
 }
 
+// ═══════════════════════════════════════════════════════════════════════════
+// BUSINESS DAY VALIDATION
+// ═══════════════════════════════════════════════════════════════════════════
+
+// validateBusinessDay validates that a date (time.Time or YYYY-MM-DD string) is a banking business day
+func validateBusinessDay(fl validator.FieldLevel) bool {
+	field := fl.Field()
+
+	if field.Kind() == reflect.String {
+		return IsBusinessDay(field.String())
+	}
+
+	if date, ok := field.Interface().(time.Time); ok {
+		return calendar.IsBusinessDay(date)
+	}
+
+	return false
+}
+
+// IsBusinessDay checks if a YYYY-MM-DD date is a banking business day
+func IsBusinessDay(date string) bool {
+	parsed, err := time.ParseInLocation("2006-01-02", date, calendar.Default().Location())
+	if err != nil {
+		return false
+	}
+	return calendar.IsBusinessDay(parsed)
+}
+
 // ═══════════════════════════════════════════════════════════════════════════
 // HELPER FUNCTIONS
 // ═══════════════════════════════════════════════════════════════════════════
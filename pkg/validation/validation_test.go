@@ -6,8 +6,11 @@ package validation
 
 import (
 	"testing"
+	"time"
 
+	"github.com/fintech-bank-platform/pkg/currency"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // ═══════════════════════════════════════════════════════════════════════════
@@ -203,6 +206,7 @@ func TestIsValidCurrency(t *testing.T) {
 		{"valid USD", "USD", true},
 		{"valid EUR lowercase", "eur", true},
 		{"invalid currency", "XXX", false},
+		{"ISO but not supported", "CHF", false},
 		{"invalid empty", "", false},
 	}
 
@@ -230,6 +234,14 @@ func TestCurrencyValidator(t *testing.T) {
 		err := Validate(s)
 		assert.Error(t, err)
 	})
+
+	t.Run("follows the supported list", func(t *testing.T) {
+		require.NoError(t, currency.SetSupported("CHF"))
+		t.Cleanup(func() { require.NoError(t, currency.SetSupported(currency.DefaultSupported...)) })
+
+		assert.NoError(t, Validate(TestStruct{Currency: "CHF"}))
+		assert.Error(t, Validate(TestStruct{Currency: "BRL"}))
+	})
 }
 
 // ═══════════════════════════════════════════════════════════════════════════
@@ -516,3 +528,46 @@ func TestIsAllSameDigits(t *testing.T) {
 		})
 	}
 }
+
+// ═══════════════════════════════════════════════════════════════════════════
+// BUSINESS DAY TESTS
+// ═══════════════════════════════════════════════════════════════════════════
+
+func TestIsBusinessDay(t *testing.T) {
+	tests := []struct {
+		name     string
+		date     string
+		expected bool
+	}{
+		{"regular weekday", "2025-06-10", true},
+		{"weekend", "2025-06-14", false},
+		{"national holiday", "2025-04-21", false},
+		{"invalid format", "10/06/2025", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsBusinessDay(tt.date))
+		})
+	}
+}
+
+func TestBusinessDayValidator(t *testing.T) {
+	type StringDate struct {
+		DueDate string `validate:"business_day"`
+	}
+
+	type TimeDate struct {
+		DueDate time.Time `validate:"business_day"`
+	}
+
+	type IntDate struct {
+		DueDate int `validate:"business_day"`
+	}
+
+	assert.NoError(t, Validate(StringDate{DueDate: "2025-06-10"}))
+	assert.Error(t, Validate(StringDate{DueDate: "2025-12-25"}))
+	assert.NoError(t, Validate(TimeDate{DueDate: time.Date(2025, time.June, 10, 12, 0, 0, 0, time.UTC)}))
+	assert.Error(t, Validate(TimeDate{DueDate: time.Date(2025, time.June, 14, 12, 0, 0, 0, time.UTC)}))
+	assert.Error(t, Validate(IntDate{DueDate: 20250610}))
+}
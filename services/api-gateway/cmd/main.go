@@ -1,13 +1,23 @@
 package main
 
 import (
+	"context"
 	"os"
+	"time"
 
 	"github.com/fintech-bank-platform/api-gateway/internal/config"
 	"github.com/fintech-bank-platform/api-gateway/internal/infrastructure/http"
+	"github.com/fintech-bank-platform/api-gateway/internal/infrastructure/http/middleware"
+	"github.com/fintech-bank-platform/pkg/apikey"
+	pkgconfig "github.com/fintech-bank-platform/pkg/config"
+	"github.com/fintech-bank-platform/pkg/limits"
 	"github.com/rs/zerolog"
 )
 
+// configWatchInterval is how often CONFIG_FILE is checked for changes.
+// SIGHUP reloads immediately.
+const configWatchInterval = 5 * time.Second
+
 func main() {
 	logger := zerolog.New(os.Stdout).With().Timestamp().Logger()
 
@@ -15,10 +25,37 @@ func main() {
 	if err != nil {
 		logger.Fatal().Err(err).Msg("Failed to load configuration")
 	}
+	logger.Debug().Interface("config", pkgconfig.Dump(cfg)).Msg("Effective configuration")
+
+	store := config.NewStore(cfg)
+	go store.Watch(context.Background(), logger, os.Getenv("CONFIG_FILE"), configWatchInterval)
 
 	server := http.NewServer(cfg, logger)
+	if cfg.TLS.Enabled() {
+		tlsConfig, err := http.NewTLSConfig(cfg.TLS, logger)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Failed to configure TLS")
+		}
+		server.UseTLS(tlsConfig)
+	}
+
+	keys := apikey.NewManager(apikey.NewMemoryStore(), apikey.WithOverlap(cfg.APIKeys.RotationOverlap))
+	if cfg.APIKeys.AdminKey != "" {
+		if _, err := keys.Import(context.Background(), cfg.APIKeys.AdminKey, apikey.CreateRequest{
+			Name:   "bootstrap admin",
+			Owner:  "platform",
+			Scopes: []string{apikey.ScopeAdmin},
+		}); err != nil {
+			logger.Fatal().Err(err).Msg("Failed to import the admin API key")
+		}
+	}
+
+	http.SetupRouter(server.Router(), store, keys)
 
-	http.SetupRouter(server.Router(), cfg)
+	limitStore := limits.NewMemoryStore()
+	// Account owners come from the account service; until the gateway reaches
+	// it, only accounts:admin principals manage limits
+	http.SetupLimitRoutes(server.Router(), limits.NewManager(limitStore, limitStore), middleware.NewMemoryAccountOwners())
 
 	if err := server.Start(); err != nil {
 		logger.Fatal().Err(err).Msg("Server failed")
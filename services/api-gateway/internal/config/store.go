@@ -0,0 +1,181 @@
+package config
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/fintech-bank-platform/api-gateway/internal/contracts"
+	pkgconfig "github.com/fintech-bank-platform/pkg/config"
+	"github.com/rs/zerolog"
+)
+
+// Store holds the live configuration. Middlewares read the current snapshot
+// on every request, so a reload takes effect without restarting the gateway.
+// Server, TLS and API key settings are fixed at startup: a reload that
+// changes them is logged but not applied. Rotated certificate files are
+// picked up by the TLS listener itself.
+type Store struct {
+	current   atomic.Pointer[Config]
+	cors      atomic.Pointer[contracts.CORSConfig]
+	rateLimit atomic.Pointer[contracts.RateLimitConfig]
+	signing   atomic.Pointer[contracts.SigningConfig]
+	mu        sync.Mutex
+}
+
+// Change is one effective setting that differs between two snapshots.
+// Secret values are redacted.
+type Change struct {
+	Key string
+	Old string
+	New string
+}
+
+func NewStore(cfg *Config) *Store {
+	s := &Store{}
+	s.current.Store(cfg)
+	s.cors.Store(&cfg.CORS)
+	s.rateLimit.Store(&cfg.RateLimit)
+	s.signing.Store(&cfg.Signing)
+	return s
+}
+
+func (s *Store) Current() *Config {
+	return s.current.Load()
+}
+
+// CORS returns the current CORS section. The pointer only changes when a
+// reload changes the section, so unrelated reloads keep middleware state.
+func (s *Store) CORS() *contracts.CORSConfig {
+	return s.cors.Load()
+}
+
+// RateLimit returns the current rate-limit section, with the same pointer
+// stability as CORS.
+func (s *Store) RateLimit() *contracts.RateLimitConfig {
+	return s.rateLimit.Load()
+}
+
+// Signing returns the current request-signing section, with the same
+// pointer stability as CORS.
+func (s *Store) Signing() *contracts.SigningConfig {
+	return s.signing.Load()
+}
+
+// Reload loads and validates the configuration again and swaps it in. On
+// error the previous snapshot stays active. The returned changes cover the
+// reloadable sections; restart reports server settings that changed but
+// were kept.
+func (s *Store) Reload() (changes []Change, restart []Change, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	next, err := New()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	previous := s.Current()
+	restart = append(diff(previous.Server, next.Server), diff(previous.TLS, next.TLS)...)
+	restart = append(restart, diff(previous.APIKeys, next.APIKeys)...)
+	next.Server, next.TLS, next.APIKeys = previous.Server, previous.TLS, previous.APIKeys
+
+	cors := diff(previous.CORS, next.CORS)
+	if len(cors) > 0 {
+		s.cors.Store(&next.CORS)
+	}
+	rateLimit := diff(previous.RateLimit, next.RateLimit)
+	if len(rateLimit) > 0 {
+		s.rateLimit.Store(&next.RateLimit)
+	}
+	signing := diff(previous.Signing, next.Signing)
+	if len(signing) > 0 {
+		s.signing.Store(&next.Signing)
+	}
+	s.current.Store(next)
+	return append(append(cors, rateLimit...), signing...), restart, nil
+}
+
+// Watch reloads the configuration on SIGHUP and, when path is set, whenever
+// the file's modification time changes (checked every interval). It returns
+// when ctx is done.
+func (s *Store) Watch(ctx context.Context, logger zerolog.Logger, path string, interval time.Duration) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var ticks <-chan time.Time
+	modified := modTime(path)
+	if path != "" {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		ticks = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			s.reload(logger, "signal")
+		case <-ticks:
+			if m := modTime(path); !m.Equal(modified) {
+				modified = m
+				s.reload(logger, "file")
+			}
+		}
+	}
+}
+
+func (s *Store) reload(logger zerolog.Logger, trigger string) {
+	changes, restart, err := s.Reload()
+	if err != nil {
+		logger.Error().Err(err).Str("trigger", trigger).Msg("Configuration reload rejected, keeping previous configuration")
+		return
+	}
+
+	for _, c := range restart {
+		logger.Warn().Str("key", c.Key).Str("old", c.Old).Str("new", c.New).Msg("Configuration change requires a restart")
+	}
+	for _, c := range changes {
+		logger.Info().Str("key", c.Key).Str("old", c.Old).Str("new", c.New).Msg("Configuration changed")
+	}
+	logger.Info().Str("trigger", trigger).Int("changes", len(changes)).Msg("Configuration reloaded")
+}
+
+func diff(previous, next interface{}) []Change {
+	old, updated := pkgconfig.Dump(previous), pkgconfig.Dump(next)
+
+	var changes []Change
+	for key, value := range updated {
+		if old[key] != value {
+			changes = append(changes, Change{Key: key, Old: old[key], New: value})
+		}
+	}
+
+	// Dump redacts secrets, so a rotated secret looks unchanged there
+	p, n := reflect.ValueOf(previous), reflect.ValueOf(next)
+	for i := 0; i < p.NumField(); i++ {
+		field := p.Type().Field(i)
+		key := field.Tag.Get("env")
+		if field.Tag.Get("secret") == "true" && old[key] == updated[key] && !reflect.DeepEqual(p.Field(i).Interface(), n.Field(i).Interface()) {
+			changes = append(changes, Change{Key: key, Old: old[key], New: updated[key]})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes
+}
+
+func modTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
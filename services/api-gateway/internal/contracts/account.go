@@ -0,0 +1,13 @@
+package contracts
+
+import "context"
+
+// ScopeAccountsAdmin lets operators act on any account
+const ScopeAccountsAdmin = "accounts:admin"
+
+// AccountOwners resolves who owns an account, for ownership checks
+type AccountOwners interface {
+	// Owner returns the principal subject that owns the account, or
+	// errors.ErrAccountNotFound
+	Owner(ctx context.Context, accountID string) (string, error)
+}
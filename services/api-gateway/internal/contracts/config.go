@@ -1,14 +1,17 @@
 package contracts
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 type ServerConfig struct {
-	Host            string
-	Port            string
-	ReadTimeout     time.Duration
-	WriteTimeout    time.Duration
-	IdleTimeout     time.Duration
-	ShutdownTimeout time.Duration
+	Host            string        `env:"SERVER_HOST" yaml:"host" default:"0.0.0.0" validate:"required"`
+	Port            string        `env:"SERVER_PORT" yaml:"port" default:"8080" validate:"required,numeric"`
+	ReadTimeout     time.Duration `env:"SERVER_READ_TIMEOUT" yaml:"read_timeout" default:"30s" validate:"gt=0"`
+	WriteTimeout    time.Duration `env:"SERVER_WRITE_TIMEOUT" yaml:"write_timeout" default:"30s" validate:"gt=0"`
+	IdleTimeout     time.Duration `env:"SERVER_IDLE_TIMEOUT" yaml:"idle_timeout" default:"120s" validate:"gt=0"`
+	ShutdownTimeout time.Duration `env:"SERVER_SHUTDOWN_TIMEOUT" yaml:"shutdown_timeout" default:"10s" validate:"gt=0"`
 }
 
 func (s ServerConfig) Address() string {
@@ -16,15 +19,85 @@ func (s ServerConfig) Address() string {
 }
 
 type CORSConfig struct {
-	AllowedOrigins   []string
-	AllowedMethods   []string
-	AllowedHeaders   []string
-	ExposedHeaders   []string
-	AllowCredentials bool
-	MaxAge           int
+	AllowedOrigins   []string `env:"CORS_ALLOWED_ORIGINS" yaml:"allowed_origins" default:"*"`
+	AllowedMethods   []string `env:"CORS_ALLOWED_METHODS" yaml:"allowed_methods" default:"GET,POST,PUT,DELETE,OPTIONS"`
+	AllowedHeaders   []string `env:"CORS_ALLOWED_HEADERS" yaml:"allowed_headers" default:"Accept,Authorization,Content-Type,X-Request-ID"`
+	ExposedHeaders   []string `env:"CORS_EXPOSED_HEADERS" yaml:"exposed_headers" default:"Link"`
+	AllowCredentials bool     `env:"CORS_ALLOW_CREDENTIALS" yaml:"allow_credentials" default:"true"`
+	MaxAge           int      `env:"CORS_MAX_AGE" yaml:"max_age" default:"300" validate:"gte=0"`
 }
 
+// RateLimitConfig limits requests per window by client IP, or by principal
+// for authenticated callers. API keys get the limit of their tier.
 type RateLimitConfig struct {
-	Requests int
-	Window   time.Duration
+	Requests     int           `env:"RATE_LIMIT_REQUESTS" yaml:"requests" default:"100" validate:"gt=0"`
+	Window       time.Duration `env:"RATE_LIMIT_WINDOW" yaml:"window" default:"1m" validate:"gt=0"`
+	TierStandard int           `env:"RATE_LIMIT_TIER_STANDARD" yaml:"tier_standard" default:"600" validate:"gt=0"`
+	TierPremium  int           `env:"RATE_LIMIT_TIER_PREMIUM" yaml:"tier_premium" default:"3000" validate:"gt=0"`
+}
+
+// TierRequests returns the limit of an API key tier, falling back to
+// Requests for unknown or unset tiers
+func (r RateLimitConfig) TierRequests(tier string) int {
+	limits := map[string]int{"standard": r.TierStandard, "premium": r.TierPremium}
+	if limit := limits[tier]; limit > 0 {
+		return limit
+	}
+	return r.Requests
+}
+
+// APIKeysConfig seeds AdminKey (a token in the fk_<prefix>_<secret> format)
+// as a key with the admin scope so the first keys can be created.
+type APIKeysConfig struct {
+	AdminKey        string        `env:"API_KEYS_ADMIN_KEY" yaml:"admin_key" secret:"true"`
+	RotationOverlap time.Duration `env:"API_KEYS_ROTATION_OVERLAP" yaml:"rotation_overlap" default:"24h" validate:"gt=0"`
+}
+
+// SigningConfig enables HMAC request signatures (see pkg/signature). Keys
+// are "key-id:secret" pairs shared with partners. Requests to RequiredRoutes
+// ("METHOD /path") must be signed; other requests are verified only when
+// they carry a signature. KeyOwners are "key-id:owner" pairs naming the
+// principal each key belongs to (the API key owner, or the certificate
+// common name for mTLS); keys not listed belong to the owner named like them.
+type SigningConfig struct {
+	Keys           []string      `env:"SIGNING_KEYS" yaml:"keys" secret:"true" validate:"dive,contains=:"`
+	KeyOwners      []string      `env:"SIGNING_KEY_OWNERS" yaml:"key_owners" validate:"dive,contains=:"`
+	ClockSkew      time.Duration `env:"SIGNING_CLOCK_SKEW" yaml:"clock_skew" default:"5m" validate:"gt=0"`
+	RequiredRoutes []string      `env:"SIGNING_REQUIRED_ROUTES" yaml:"required_routes"`
+}
+
+// Secrets returns the signing secrets by key ID
+func (s SigningConfig) Secrets() map[string][]byte {
+	secrets := make(map[string][]byte, len(s.Keys))
+	for _, pair := range s.Keys {
+		id, secret, _ := strings.Cut(pair, ":")
+		secrets[id] = []byte(secret)
+	}
+	return secrets
+}
+
+// Owner returns the owner of a signing key
+func (s SigningConfig) Owner(keyID string) string {
+	for _, pair := range s.KeyOwners {
+		if id, owner, _ := strings.Cut(pair, ":"); id == keyID {
+			return owner
+		}
+	}
+	return keyID
+}
+
+// TLSConfig enables HTTPS when CertFile is set. ClientAuth "optional" verifies
+// client certificates when presented and "require" rejects connections
+// without one; both verify against ClientCAFile.
+type TLSConfig struct {
+	CertFile     string   `env:"TLS_CERT_FILE" yaml:"cert_file" validate:"required_with=KeyFile"`
+	KeyFile      string   `env:"TLS_KEY_FILE" yaml:"key_file" validate:"required_with=CertFile"`
+	MinVersion   string   `env:"TLS_MIN_VERSION" yaml:"min_version" default:"1.2" validate:"oneof=1.2 1.3"`
+	CipherSuites []string `env:"TLS_CIPHER_SUITES" yaml:"cipher_suites"`
+	ClientAuth   string   `env:"TLS_CLIENT_AUTH" yaml:"client_auth" default:"none" validate:"oneof=none optional require"`
+	ClientCAFile string   `env:"TLS_CLIENT_CA_FILE" yaml:"client_ca_file" validate:"required_unless=ClientAuth none"`
+}
+
+func (t TLSConfig) Enabled() bool {
+	return t.CertFile != ""
 }
@@ -4,6 +4,9 @@ type ContextKey string
 
 const (
 	RequestIDKey ContextKey = "request_id"
+	PrincipalKey ContextKey = "principal"
+	// SigningKeyIDKey holds the ID of the key that signed the request
+	SigningKeyIDKey ContextKey = "signing_key_id"
 )
 
 const RequestIDHeader = "X-Request-ID"
@@ -0,0 +1,25 @@
+package contracts
+
+const (
+	AuthMethodMTLS   = "mtls"
+	AuthMethodAPIKey = "api_key"
+)
+
+// Principal is the authenticated caller of a request. Scopes and Tier are
+// set for API keys; mTLS principals have neither.
+type Principal struct {
+	ID      string   `json:"id"`
+	Method  string   `json:"method"`
+	Subject string   `json:"subject,omitempty"`
+	Scopes  []string `json:"scopes,omitempty"`
+	Tier    string   `json:"tier,omitempty"`
+}
+
+func (p Principal) HasScope(scope string) bool {
+	for _, granted := range p.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
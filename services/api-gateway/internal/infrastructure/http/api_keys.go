@@ -0,0 +1,91 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/fintech-bank-platform/api-gateway/internal/infrastructure/http/middleware"
+	"github.com/fintech-bank-platform/pkg/apikey"
+	"github.com/fintech-bank-platform/pkg/errors"
+	"github.com/fintech-bank-platform/pkg/request"
+	"github.com/fintech-bank-platform/pkg/response"
+	"github.com/go-chi/chi/v5"
+)
+
+type APIKeysHandler struct {
+	keys *apikey.Manager
+}
+
+func NewAPIKeysHandler(keys *apikey.Manager) *APIKeysHandler {
+	return &APIKeysHandler{keys: keys}
+}
+
+// SetupAPIKeyRoutes mounts the key admin API, which needs a principal with
+// apikey.ScopeAdmin
+func SetupAPIKeyRoutes(router chi.Router, keys *apikey.Manager) {
+	handler := NewAPIKeysHandler(keys)
+
+	router.Route("/v1/admin/api-keys", func(r chi.Router) {
+		r.Use(middleware.RequireScope(apikey.ScopeAdmin))
+		r.Get("/", handler.List)
+		r.Post("/", handler.Create)
+		r.Post("/{keyID}/rotate", handler.Rotate)
+		r.Delete("/{keyID}", handler.Revoke)
+	})
+}
+
+// List returns every key, or the keys of the owner query parameter
+func (h *APIKeysHandler) List(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.keys.List(r.Context(), r.URL.Query().Get("owner"))
+	if err != nil {
+		response.FromErrorFor(w, r, err)
+		return
+	}
+	response.OK(w, keys)
+}
+
+// Create issues a key; the token in the response is not shown again
+func (h *APIKeysHandler) Create(w http.ResponseWriter, r *http.Request) {
+	req, appErr := request.Bind[apikey.CreateRequest](r)
+	if appErr != nil {
+		response.AppErrorFor(w, r, appErr)
+		return
+	}
+
+	issued, err := h.keys.Create(r.Context(), req)
+	if err != nil {
+		response.FromErrorFor(w, r, err)
+		return
+	}
+	response.Created(w, issued)
+}
+
+// Rotate issues a replacement key; the old one keeps working for the overlap
+// query parameter (a duration such as 1h) or the configured default
+func (h *APIKeysHandler) Rotate(w http.ResponseWriter, r *http.Request) {
+	var overlap time.Duration
+	if value := r.URL.Query().Get("overlap"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed <= 0 {
+			response.AppErrorFor(w, r, errors.BadRequest(errors.ErrInvalidField.Code, errors.ErrInvalidField.Message).WithDetail("overlap", value))
+			return
+		}
+		overlap = parsed
+	}
+
+	issued, err := h.keys.Rotate(r.Context(), chi.URLParam(r, "keyID"), overlap)
+	if err != nil {
+		response.FromErrorFor(w, r, err)
+		return
+	}
+	response.Created(w, issued)
+}
+
+func (h *APIKeysHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	key, err := h.keys.Revoke(r.Context(), chi.URLParam(r, "keyID"))
+	if err != nil {
+		response.FromErrorFor(w, r, err)
+		return
+	}
+	response.OK(w, key)
+}
@@ -0,0 +1,84 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/fintech-bank-platform/api-gateway/internal/contracts"
+	"github.com/fintech-bank-platform/api-gateway/internal/infrastructure/http/middleware"
+	"github.com/fintech-bank-platform/pkg/limits"
+	"github.com/fintech-bank-platform/pkg/request"
+	"github.com/fintech-bank-platform/pkg/response"
+	"github.com/go-chi/chi/v5"
+)
+
+type LimitChangeRequest struct {
+	Method         string  `json:"method" validate:"required"`
+	PerTransaction float64 `json:"per_transaction"`
+	Daily          float64 `json:"daily"`
+	Monthly        float64 `json:"monthly"`
+	Night          float64 `json:"night"`
+}
+
+type LimitsHandler struct {
+	manager *limits.Manager
+}
+
+func NewLimitsHandler(manager *limits.Manager) *LimitsHandler {
+	return &LimitsHandler{manager: manager}
+}
+
+// SetupLimitRoutes mounts the limits of an account, which only its owner
+// (or an accounts:admin principal) may read or change
+func SetupLimitRoutes(router chi.Router, manager *limits.Manager, owners contracts.AccountOwners) {
+	handler := NewLimitsHandler(manager)
+
+	router.Route("/v1/accounts/{accountID}/limits", func(r chi.Router) {
+		r.Use(middleware.RequireAccountOwner(owners))
+		r.Get("/", handler.Show)
+		r.Get("/requests", handler.ListRequests)
+		r.Post("/requests", handler.RequestChange)
+	})
+}
+
+func (h *LimitsHandler) Show(w http.ResponseWriter, r *http.Request) {
+	result, err := h.manager.Limits(r.Context(), chi.URLParam(r, "accountID"))
+	if err != nil {
+		response.FromErrorFor(w, r, err)
+		return
+	}
+	response.OK(w, result)
+}
+
+func (h *LimitsHandler) ListRequests(w http.ResponseWriter, r *http.Request) {
+	changes, err := h.manager.Changes(r.Context(), chi.URLParam(r, "accountID"))
+	if err != nil {
+		response.FromErrorFor(w, r, err)
+		return
+	}
+	response.OK(w, changes)
+}
+
+func (h *LimitsHandler) RequestChange(w http.ResponseWriter, r *http.Request) {
+	req, appErr := request.Bind[LimitChangeRequest](r)
+	if appErr != nil {
+		response.AppErrorFor(w, r, appErr)
+		return
+	}
+
+	change, err := h.manager.RequestChange(r.Context(), chi.URLParam(r, "accountID"), req.Method, limits.Policy{
+		PerTransaction: req.PerTransaction,
+		Daily:          req.Daily,
+		Monthly:        req.Monthly,
+		Night:          req.Night,
+	})
+	if err != nil {
+		response.FromErrorFor(w, r, err)
+		return
+	}
+
+	if change.Status == limits.ChangePending {
+		response.Accepted(w, change)
+		return
+	}
+	response.OK(w, change)
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/fintech-bank-platform/api-gateway/internal/contracts"
+	"github.com/fintech-bank-platform/pkg/errors"
+	"github.com/fintech-bank-platform/pkg/response"
+	"github.com/go-chi/chi/v5"
+)
+
+// RequireAccountOwner rejects unauthenticated requests with 401 and
+// principals that do not own the {accountID} of the route with 403.
+// Principals with contracts.ScopeAccountsAdmin may act on any account.
+// Unknown accounts are also 403, so account IDs cannot be probed.
+func RequireAccountOwner(owners contracts.AccountOwners) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := GetPrincipal(r.Context())
+			if !ok {
+				response.AppErrorFor(w, r, errors.ErrUnauthorized)
+				return
+			}
+			if principal.HasScope(contracts.ScopeAccountsAdmin) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			owner, err := owners.Owner(r.Context(), chi.URLParam(r, "accountID"))
+			if err != nil && !errors.ErrAccountNotFound.Is(err) {
+				response.FromErrorFor(w, r, err)
+				return
+			}
+			if err != nil || owner != principal.Subject {
+				response.AppErrorFor(w, r, errors.ErrForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// MemoryAccountOwners is an in-memory AccountOwners used in tests and until
+// the gateway can reach the account service
+type MemoryAccountOwners struct {
+	mu     sync.RWMutex
+	owners map[string]string
+}
+
+func NewMemoryAccountOwners() *MemoryAccountOwners {
+	return &MemoryAccountOwners{owners: make(map[string]string)}
+}
+
+// Set records the owner of an account
+func (m *MemoryAccountOwners) Set(accountID, owner string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.owners[accountID] = owner
+}
+
+// Owner implements contracts.AccountOwners
+func (m *MemoryAccountOwners) Owner(_ context.Context, accountID string) (string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	owner, ok := m.owners[accountID]
+	if !ok {
+		return "", errors.ErrAccountNotFound
+	}
+	return owner, nil
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/fintech-bank-platform/api-gateway/internal/contracts"
+	"github.com/fintech-bank-platform/pkg/apikey"
+	"github.com/fintech-bank-platform/pkg/errors"
+	"github.com/fintech-bank-platform/pkg/response"
+)
+
+const apiKeyScheme = "ApiKey"
+
+// APIKey authenticates "Authorization: ApiKey <token>" requests as the key's
+// principal, whose ID is the key ID. Invalid, expired or revoked keys are
+// rejected with 401; requests with another or no scheme pass through.
+func APIKey(keys *apikey.Manager) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scheme, token, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+			if !strings.EqualFold(scheme, apiKeyScheme) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			key, err := keys.Authenticate(r.Context(), strings.TrimSpace(token))
+			if err != nil {
+				w.Header().Set("WWW-Authenticate", apiKeyScheme)
+				response.FromErrorFor(w, r, err)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), contracts.Principal{
+				ID:      key.ID,
+				Method:  contracts.AuthMethodAPIKey,
+				Subject: key.Owner,
+				Scopes:  key.Scopes,
+				Tier:    key.Tier,
+			})))
+		})
+	}
+}
+
+// RequireScope rejects unauthenticated requests with 401 and principals
+// without scope with 403
+func RequireScope(scope string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := GetPrincipal(r.Context())
+			if !ok {
+				response.AppErrorFor(w, r, errors.ErrUnauthorized)
+				return
+			}
+			if !principal.HasScope(scope) {
+				response.AppErrorFor(w, r, errors.Forbidden(errors.ErrInsufficientScope.Code, errors.ErrInsufficientScope.Message).WithDetail("scope", scope))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
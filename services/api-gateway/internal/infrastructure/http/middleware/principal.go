@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/fintech-bank-platform/api-gateway/internal/contracts"
+)
+
+// ClientCertificate authenticates requests that arrived over mutual TLS with
+// a verified client certificate. The certificate's common name becomes the
+// principal ID. Requests without one pass through unauthenticated.
+func ClientCertificate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 {
+			cert := r.TLS.VerifiedChains[0][0]
+			r = r.WithContext(WithPrincipal(r.Context(), contracts.Principal{
+				ID:      cert.Subject.CommonName,
+				Method:  contracts.AuthMethodMTLS,
+				Subject: cert.Subject.String(),
+			}))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func WithPrincipal(ctx context.Context, principal contracts.Principal) context.Context {
+	return context.WithValue(ctx, contracts.PrincipalKey, principal)
+}
+
+func GetPrincipal(ctx context.Context) (contracts.Principal, bool) {
+	principal, ok := ctx.Value(contracts.PrincipalKey).(contracts.Principal)
+	return principal, ok
+}
@@ -2,20 +2,129 @@ package middleware
 
 import (
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/fintech-bank-platform/api-gateway/internal/contracts"
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/httprate"
 )
 
+// RateLimit limits requests per API key principal, or per IP for anonymous
+// requests, to cfg.Requests (or the key tier's limit) every cfg.Window
 func RateLimit(cfg contracts.RateLimitConfig) func(next http.Handler) http.Handler {
+	return ReloadableRateLimit(func() *contracts.RateLimitConfig { return &cfg })
+}
+
+// ReloadableRateLimit is RateLimit over the section that current returns.
+// Limits are read on every request, so reloading them keeps the counters;
+// only a new window starts them from zero.
+func ReloadableRateLimit(current func() *contracts.RateLimitConfig) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		var (
+			mu      sync.RWMutex
+			window  time.Duration
+			limited http.Handler
+		)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cfg := current()
+
+			mu.RLock()
+			h := limited
+			fresh := window == cfg.Window
+			mu.RUnlock()
+
+			if !fresh {
+				mu.Lock()
+				if window != cfg.Window {
+					window, limited = cfg.Window, windowLimit(cfg.Window)(next)
+				}
+				h = limited
+				mu.Unlock()
+			}
+
+			limit := cfg.Requests
+			if principal, ok := GetPrincipal(r.Context()); ok {
+				limit = cfg.TierRequests(principal.Tier)
+			}
+			h.ServeHTTP(w, r.WithContext(httprate.WithRequestLimit(r.Context(), limit)))
+		})
+	}
+}
+
+// windowLimit counts requests per key over window; the limit itself comes
+// from the request context
+func windowLimit(window time.Duration) func(next http.Handler) http.Handler {
 	return httprate.Limit(
-		cfg.Requests,
-		cfg.Window,
-		httprate.WithKeyFuncs(httprate.KeyByIP),
-		httprate.WithLimitHandler(func(w http.ResponseWriter, r *http.Request) {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusTooManyRequests)
-			w.Write([]byte(`{"success":false,"error":{"code":"RATE_LIMIT_EXCEEDED","message":"Too many requests"}}`))
-		}),
+		1,
+		window,
+		httprate.WithKeyFuncs(keyByPrincipalOrIP),
+		httprate.WithLimitHandler(tooManyRequests),
 	)
 }
+
+func tooManyRequests(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusTooManyRequests)
+	w.Write([]byte(`{"success":false,"error":{"code":"RATE_LIMIT_EXCEEDED","message":"Too many requests"}}`))
+}
+
+// AuthFailureLimit counts the requests that next rejects with 401 per client
+// IP and answers 429, without calling next, once an IP was rejected
+// cfg.Requests times in cfg.Window. It goes before APIKey, which must run
+// ahead of RateLimit to find the key tier, so keys cannot be guessed at an
+// unlimited rate.
+func AuthFailureLimit(cfg contracts.RateLimitConfig) func(next http.Handler) http.Handler {
+	return ReloadableAuthFailureLimit(func() *contracts.RateLimitConfig { return &cfg })
+}
+
+// ReloadableAuthFailureLimit is AuthFailureLimit over the section that
+// current returns; a new window starts the counters from zero.
+func ReloadableAuthFailureLimit(current func() *contracts.RateLimitConfig) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		var (
+			mu       sync.RWMutex
+			window   time.Duration
+			failures *httprate.RateLimiter
+		)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cfg := current()
+
+			mu.RLock()
+			limiter := failures
+			fresh := window == cfg.Window
+			mu.RUnlock()
+
+			if !fresh {
+				mu.Lock()
+				if window != cfg.Window {
+					window, failures = cfg.Window, httprate.NewRateLimiter(cfg.Requests, cfg.Window)
+				}
+				limiter = failures
+				mu.Unlock()
+			}
+
+			// The in-memory counter of the limiter never fails
+			ip, _ := httprate.KeyByIP(r)
+			if _, rejected, _ := limiter.Status(ip); rejected >= float64(cfg.Requests) {
+				tooManyRequests(w, r)
+				return
+			}
+
+			ww := chiMiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r)
+			if ww.Status() == http.StatusUnauthorized {
+				limiter.Counter().IncrementBy(ip, time.Now().UTC().Truncate(cfg.Window), 1)
+			}
+		})
+	}
+}
+
+func keyByPrincipalOrIP(r *http.Request) (string, error) {
+	if principal, ok := GetPrincipal(r.Context()); ok {
+		return principal.Method + ":" + principal.ID, nil
+	}
+	return httprate.KeyByIP(r)
+}
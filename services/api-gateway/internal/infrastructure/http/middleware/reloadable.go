@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"net/http"
+	"sync"
+)
+
+// Reloadable applies the middleware built by build from the snapshot that
+// current returns. It rebuilds when current returns a different pointer, so
+// config reloads take effect on the next request. Rebuilding drops any state
+// the middleware keeps, which is why the rate limiter uses
+// ReloadableRateLimit instead.
+func Reloadable[T any](current func() *T, build func(T) func(http.Handler) http.Handler) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		var (
+			mu       sync.RWMutex
+			snapshot *T
+			handler  http.Handler
+		)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cfg := current()
+
+			mu.RLock()
+			h := handler
+			fresh := snapshot == cfg
+			mu.RUnlock()
+
+			if !fresh {
+				mu.Lock()
+				if snapshot != cfg {
+					snapshot, handler = cfg, build(*cfg)(next)
+				}
+				h = handler
+				mu.Unlock()
+			}
+
+			h.ServeHTTP(w, r)
+		})
+	}
+}
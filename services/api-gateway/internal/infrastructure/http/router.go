@@ -1,19 +1,33 @@
 package http
 
 import (
+	"net/http"
+
 	"github.com/fintech-bank-platform/api-gateway/internal/config"
+	"github.com/fintech-bank-platform/api-gateway/internal/contracts"
 	"github.com/fintech-bank-platform/api-gateway/internal/infrastructure/http/middleware"
+	"github.com/fintech-bank-platform/pkg/apikey"
+	"github.com/fintech-bank-platform/pkg/signature"
 	"github.com/go-chi/chi/v5"
 	chiMiddleware "github.com/go-chi/chi/v5/middleware"
 )
 
-func SetupRouter(router *chi.Mux, cfg *config.Config) {
+func SetupRouter(router *chi.Mux, store *config.Store, keys *apikey.Manager) {
+	nonces := signature.NewMemoryNonceStore()
+
 	router.Use(middleware.RequestID)
 	router.Use(middleware.Recovery)
 	router.Use(chiMiddleware.RealIP)
-	router.Use(middleware.CORS(cfg.CORS))
-	router.Use(middleware.RateLimit(cfg.RateLimit))
+	router.Use(middleware.ClientCertificate)
+	router.Use(middleware.Reloadable(store.CORS, middleware.CORS))
+	router.Use(middleware.ReloadableAuthFailureLimit(store.RateLimit))
+	router.Use(middleware.APIKey(keys))
+	router.Use(middleware.ReloadableRateLimit(store.RateLimit))
+	router.Use(middleware.Reloadable(store.Signing, func(cfg contracts.SigningConfig) func(http.Handler) http.Handler {
+		return middleware.Signature(cfg, nonces)
+	}))
 	router.Use(chiMiddleware.StripSlashes)
 
 	router.Get("/health", healthHandler)
+	SetupAPIKeyRoutes(router, keys)
 }
@@ -2,6 +2,7 @@ package http
 
 import (
 	"context"
+	"crypto/tls"
 	"net/http"
 	"os"
 	"os/signal"
@@ -19,8 +20,14 @@ type Server struct {
 	logger zerolog.Logger
 }
 
+// NewServer attaches logger to every request context (see zerolog.Ctx)
 func NewServer(cfg *config.Config, logger zerolog.Logger) *Server {
 	router := chi.NewRouter()
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(logger.WithContext(r.Context())))
+		})
+	})
 
 	srv := &Server{
 		router: router,
@@ -43,6 +50,11 @@ func (s *Server) Router() *chi.Mux {
 	return s.router
 }
 
+// UseTLS makes Start serve HTTPS with tlsConfig (see NewTLSConfig).
+func (s *Server) UseTLS(tlsConfig *tls.Config) {
+	s.server.TLSConfig = tlsConfig
+}
+
 func (s *Server) Start() error {
 	return s.StartWithSignals(syscall.SIGINT, syscall.SIGTERM)
 }
@@ -54,9 +66,9 @@ func (s *Server) StartWithSignals(signals ...os.Signal) error {
 	serverErrors := make(chan error, 1)
 
 	go func() {
-		s.logger.Info().Str("address", s.config.Server.Address()).Msg("Server starting")
+		s.logger.Info().Str("address", s.config.Server.Address()).Bool("tls", s.server.TLSConfig != nil).Msg("Server starting")
 
-		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		if err := s.listen(); err != nil && err != http.ErrServerClosed {
 			serverErrors <- err
 		}
 	}()
@@ -78,6 +90,13 @@ func (s *Server) StartWithSignals(signals ...os.Signal) error {
 	return nil
 }
 
+func (s *Server) listen() error {
+	if s.server.TLSConfig != nil {
+		return s.server.ListenAndServeTLS("", "")
+	}
+	return s.server.ListenAndServe()
+}
+
 func (s *Server) Shutdown(ctx context.Context) error {
 	return s.server.Shutdown(ctx)
 }
@@ -0,0 +1,151 @@
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/fintech-bank-platform/api-gateway/internal/contracts"
+	"github.com/rs/zerolog"
+)
+
+var ErrInvalidTLSConfig = errors.New("invalid TLS configuration")
+
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+var clientAuthTypes = map[string]tls.ClientAuthType{
+	"none":     tls.NoClientCert,
+	"optional": tls.VerifyClientCertIfGiven,
+	"require":  tls.RequireAndVerifyClientCert,
+}
+
+// NewTLSConfig builds the server TLS configuration with HTTP/2 enabled. The
+// certificate is reloaded on the first handshake after CertFile or KeyFile
+// changes, so rotated certificates apply without a restart.
+func NewTLSConfig(cfg contracts.TLSConfig, logger zerolog.Logger) (*tls.Config, error) {
+	version, ok := tlsVersions[cfg.MinVersion]
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported minimum version %q", ErrInvalidTLSConfig, cfg.MinVersion)
+	}
+	clientAuth, ok := clientAuthTypes[cfg.ClientAuth]
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported client auth %q", ErrInvalidTLSConfig, cfg.ClientAuth)
+	}
+	suites, err := cipherSuites(cfg.CipherSuites)
+	if err != nil {
+		return nil, err
+	}
+
+	certificates := &certificateReloader{certFile: cfg.CertFile, keyFile: cfg.KeyFile, logger: logger}
+	if err := certificates.load(); err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:     version,
+		CipherSuites:   suites,
+		ClientAuth:     clientAuth,
+		GetCertificate: certificates.GetCertificate,
+		NextProtos:     []string{"h2", "http/1.1"},
+	}
+
+	if clientAuth != tls.NoClientCert {
+		pem, err := os.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: reading client CA: %v", ErrInvalidTLSConfig, err)
+		}
+		tlsConfig.ClientCAs = x509.NewCertPool()
+		if !tlsConfig.ClientCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%w: no certificates in %s", ErrInvalidTLSConfig, cfg.ClientCAFile)
+		}
+	}
+
+	return tlsConfig, nil
+}
+
+// cipherSuites resolves suite names such as
+// TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256. Only suites Go considers secure are
+// accepted; TLS 1.3 suites are not configurable.
+func cipherSuites(names []string) ([]uint16, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	known := make(map[string]uint16)
+	for _, suite := range tls.CipherSuites() {
+		known[suite.Name] = suite.ID
+	}
+
+	ids := make([]uint16, 0, len(names))
+	for _, name := range names {
+		id, ok := known[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: unsupported cipher suite %q", ErrInvalidTLSConfig, name)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+type certificateReloader struct {
+	certFile string
+	keyFile  string
+	logger   zerolog.Logger
+
+	mu       sync.RWMutex
+	cert     *tls.Certificate
+	modified time.Time
+}
+
+func (c *certificateReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	c.mu.RLock()
+	cert, modified := c.cert, c.modified
+	c.mu.RUnlock()
+
+	if !c.modTime().Equal(modified) {
+		// A failed reload usually means the pair is mid-rotation; keep
+		// serving the previous certificate and retry on the next handshake.
+		if err := c.load(); err != nil {
+			c.logger.Warn().Err(err).Msg("TLS certificate reload failed, keeping previous certificate")
+			return cert, nil
+		}
+		c.logger.Info().Str("cert_file", c.certFile).Msg("TLS certificate reloaded")
+
+		c.mu.RLock()
+		cert = c.cert
+		c.mu.RUnlock()
+	}
+
+	return cert, nil
+}
+
+func (c *certificateReloader) load() error {
+	modified := c.modTime()
+	cert, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidTLSConfig, err)
+	}
+
+	c.mu.Lock()
+	c.cert, c.modified = &cert, modified
+	c.mu.Unlock()
+	return nil
+}
+
+// modTime is the latest modification time of the certificate and key.
+func (c *certificateReloader) modTime() time.Time {
+	var latest time.Time
+	for _, path := range []string{c.certFile, c.keyFile} {
+		if info, err := os.Stat(path); err == nil && info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest
+}
@@ -0,0 +1,149 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Feature Test: API Keys
+// ═══════════════════════════════════════════════════════════════════════════
+
+package feature
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/fintech-bank-platform/api-gateway/tests"
+	"github.com/fintech-bank-platform/pkg/apikey"
+	"github.com/fintech-bank-platform/pkg/errors"
+	"github.com/stretchr/testify/suite"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════
+// Test Suite
+// ═══════════════════════════════════════════════════════════════════════════
+
+type APIKeysTestSuite struct {
+	tests.TestCase
+	adminToken string
+}
+
+func TestAPIKeysSuite(t *testing.T) {
+	suite.Run(t, new(APIKeysTestSuite))
+}
+
+func (s *APIKeysTestSuite) SetupSuite() {
+	s.TestCase.SetupSuite()
+
+	issued, err := s.APIKeys.Create(context.Background(), apikey.CreateRequest{
+		Name: "bootstrap admin", Owner: "platform", Scopes: []string{apikey.ScopeAdmin},
+	})
+	s.Require().NoError(err)
+	s.adminToken = issued.Token
+}
+
+func (s *APIKeysTestSuite) createKey(owner string) (id, token string) {
+	res := s.WithAPIKey(s.adminToken).Post("/v1/admin/api-keys", map[string]interface{}{
+		"name":   "settlement",
+		"owner":  owner,
+		"scopes": []string{"transfers:write"},
+		"tier":   "premium",
+	}).AssertCreated()
+
+	return res.Json()["data"].(map[string]interface{})["key"].(map[string]interface{})["id"].(string),
+		res.Json()["data"].(map[string]interface{})["token"].(string)
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// Tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+func (s *APIKeysTestSuite) TestCreateReturnsTokenOnce() {
+	owner := tests.UUID()
+
+	s.WithAPIKey(s.adminToken).Post("/v1/admin/api-keys", map[string]interface{}{
+		"name":   "settlement",
+		"owner":  owner,
+		"scopes": []string{"transfers:write"},
+	}).
+		AssertCreated().
+		AssertJsonHas("data.token").
+		AssertJsonPath("data.key.owner", owner).
+		AssertJsonPath("data.key.tier", "standard").
+		AssertJsonMissing("data.key.hash")
+
+	s.WithAPIKey(s.adminToken).Get("/v1/admin/api-keys?owner="+owner).
+		AssertOk().
+		AssertJsonCount(1, "data").
+		AssertJsonMissing("data.0.token")
+}
+
+func (s *APIKeysTestSuite) TestCreateValidatesRequest() {
+	s.WithAPIKey(s.adminToken).Post("/v1/admin/api-keys", map[string]interface{}{
+		"name":  "settlement",
+		"owner": tests.UUID(),
+	}).
+		AssertBadRequest()
+}
+
+func (s *APIKeysTestSuite) TestRotateKeepsOldKeyDuringOverlap() {
+	id, token := s.createKey(tests.UUID())
+
+	res := s.WithAPIKey(s.adminToken).Post("/v1/admin/api-keys/"+id+"/rotate?overlap=1h", nil).
+		AssertCreated().
+		AssertJsonPath("data.key.tier", "premium").
+		AssertJsonHas("data.token")
+
+	newID := res.Json()["data"].(map[string]interface{})["key"].(map[string]interface{})["id"].(string)
+	old, err := s.APIKeys.Get(context.Background(), id)
+	s.Require().NoError(err)
+	s.Equal(newID, old.ReplacedBy)
+	s.NotNil(old.ExpiresAt)
+
+	_, err = s.APIKeys.Authenticate(context.Background(), token)
+	s.NoError(err, "the old key works during the overlap")
+}
+
+func (s *APIKeysTestSuite) TestRotateRejectsInvalidOverlap() {
+	id, _ := s.createKey(tests.UUID())
+
+	for _, overlap := range []string{"soon", "-1h"} {
+		s.WithAPIKey(s.adminToken).Post("/v1/admin/api-keys/"+id+"/rotate?overlap="+overlap, nil).
+			AssertBadRequest().
+			AssertErrorCode("INVALID_FIELD")
+	}
+	s.Empty(errors.ErrInvalidField.Details, "the sentinel is not modified")
+}
+
+func (s *APIKeysTestSuite) TestRevokeDisablesKey() {
+	id, token := s.createKey(tests.UUID())
+
+	s.WithAPIKey(s.adminToken).Delete("/v1/admin/api-keys/" + id).
+		AssertOk().
+		AssertJsonHas("data.revoked_at")
+
+	s.WithAPIKey(token).Get("/health").
+		AssertUnauthorized().
+		AssertHeader("WWW-Authenticate", "ApiKey").
+		AssertErrorCode("API_KEY_REVOKED")
+}
+
+func (s *APIKeysTestSuite) TestUnknownKeyIsNotFound() {
+	s.WithAPIKey(s.adminToken).Delete("/v1/admin/api-keys/" + tests.UUID()).
+		AssertNotFound().
+		AssertErrorCode("API_KEY_NOT_FOUND")
+}
+
+func (s *APIKeysTestSuite) TestAdminRoutesRequireAdminScope() {
+	s.Get("/v1/admin/api-keys").
+		AssertUnauthorized()
+
+	_, token := s.createKey(tests.UUID())
+
+	s.WithAPIKey(token).Get("/v1/admin/api-keys").
+		AssertForbidden().
+		AssertErrorCode("INSUFFICIENT_SCOPE")
+}
+
+func (s *APIKeysTestSuite) TestValidKeyAuthenticatesRequests() {
+	_, token := s.createKey(tests.UUID())
+
+	s.WithAPIKey(token).Get("/health").
+		AssertStatus(http.StatusOK)
+}
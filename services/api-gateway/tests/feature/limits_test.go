@@ -0,0 +1,210 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Feature Test: Account Limits
+// ═══════════════════════════════════════════════════════════════════════════
+
+package feature
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/fintech-bank-platform/api-gateway/tests"
+	"github.com/fintech-bank-platform/pkg/apikey"
+	"github.com/fintech-bank-platform/pkg/limits"
+	"github.com/fintech-bank-platform/pkg/response"
+	"github.com/stretchr/testify/suite"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════
+// Test Suite
+// ═══════════════════════════════════════════════════════════════════════════
+
+type LimitsTestSuite struct {
+	tests.TestCase
+	customerToken string
+}
+
+func TestLimitsSuite(t *testing.T) {
+	suite.Run(t, new(LimitsTestSuite))
+}
+
+const limitsCustomer = "customer-1"
+
+func (s *LimitsTestSuite) SetupSuite() {
+	s.TestCase.SetupSuite()
+	s.customerToken = s.issueKey(limitsCustomer, "accounts:read")
+}
+
+func (s *LimitsTestSuite) issueKey(owner string, scopes ...string) string {
+	issued, err := s.APIKeys.Create(context.Background(), apikey.CreateRequest{Name: "limits", Owner: owner, Scopes: scopes})
+	s.Require().NoError(err)
+	return issued.Token
+}
+
+// ownAccount returns a new account of the suite's customer and
+// authenticates the next requests as that customer
+func (s *LimitsTestSuite) ownAccount() string {
+	accountID := tests.UUID()
+	s.Accounts.Set(accountID, limitsCustomer)
+	s.WithAPIKey(s.customerToken)
+	return accountID
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// Tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+func (s *LimitsTestSuite) TestShowLimitsReturnsPoliciesAndUsage() {
+	accountID := s.ownAccount()
+	s.Require().NoError(s.Limits.Reserve(context.Background(), limits.Reservation{
+		ID: tests.UUID(), AccountID: accountID, Method: limits.MethodPIX, Amount: 150,
+	}))
+
+	s.Get("/v1/accounts/"+accountID+"/limits").
+		AssertOk().
+		AssertSuccess().
+		AssertJsonCount(4, "data").
+		AssertJsonPath("data.1.method", "pix").
+		AssertJsonPath("data.1.policy.night", 1000.0).
+		AssertJsonPath("data.1.usage.daily", 150.0)
+}
+
+func (s *LimitsTestSuite) TestDecreaseIsAppliedImmediately() {
+	accountID := s.ownAccount()
+
+	s.Post("/v1/accounts/"+accountID+"/limits/requests", map[string]interface{}{
+		"method":          "pix",
+		"per_transaction": 500,
+		"daily":           1000,
+		"monthly":         5000,
+		"night":           200,
+	}).
+		AssertOk().
+		AssertJsonPath("data.status", "applied")
+
+	s.Get("/v1/accounts/"+accountID+"/limits").
+		AssertOk().
+		AssertJsonPath("data.1.policy.daily", 1000.0)
+}
+
+func (s *LimitsTestSuite) TestIncreaseIsPending() {
+	accountID := s.ownAccount()
+
+	s.Post("/v1/accounts/"+accountID+"/limits/requests", map[string]interface{}{
+		"method":          "pix",
+		"per_transaction": 10000,
+		"daily":           50000,
+		"monthly":         100000,
+		"night":           1000,
+	}).
+		AssertAccepted().
+		AssertJsonPath("data.status", "pending").
+		AssertJsonHas("data.effective_at")
+
+	s.Get("/v1/accounts/"+accountID+"/limits/requests").
+		AssertOk().
+		AssertJsonCount(1, "data").
+		AssertJsonPath("data.0.method", "pix")
+}
+
+func (s *LimitsTestSuite) TestRequestChangeRequiresMethod() {
+	s.Post("/v1/accounts/"+s.ownAccount()+"/limits/requests", map[string]interface{}{"daily": 100}).
+		AssertBadRequest().
+		AssertErrorCode("VALIDATION_ERROR").
+		AssertJsonPath("error.details.method", "is required")
+}
+
+func (s *LimitsTestSuite) TestRequestChangeRejectsNegativeLimits() {
+	s.Post("/v1/accounts/"+s.ownAccount()+"/limits/requests", map[string]interface{}{"method": "pix", "daily": -1}).
+		AssertBadRequest().
+		AssertErrorCode("INVALID_FIELD")
+}
+
+func (s *LimitsTestSuite) TestRequestChangeRejectsInvalidJson() {
+	s.WithContentType("application/json").
+		Post("/v1/accounts/"+s.ownAccount()+"/limits/requests", "not-an-object").
+		AssertBadRequest().
+		AssertErrorCode("INVALID_JSON")
+}
+
+func (s *LimitsTestSuite) TestRequestChangeRejectsUnknownFields() {
+	s.Post("/v1/accounts/"+s.ownAccount()+"/limits/requests", map[string]interface{}{"method": "pix", "yearly": 100}).
+		AssertBadRequest().
+		AssertErrorCode("INVALID_FIELD").
+		AssertJsonPath("error.details.field", "yearly")
+}
+
+func (s *LimitsTestSuite) TestRequestChangeRequiresJsonContentType() {
+	s.WithContentType("text/plain").
+		Post("/v1/accounts/"+s.ownAccount()+"/limits/requests", map[string]interface{}{"method": "pix"}).
+		AssertStatus(http.StatusUnsupportedMediaType).
+		AssertErrorCode("UNSUPPORTED_MEDIA_TYPE")
+}
+
+func (s *LimitsTestSuite) TestErrorsAsProblemDetails() {
+	s.WithHeader("Accept", response.ProblemContentType).
+		WithHeader("X-Request-ID", "req-limits-1").
+		Post("/v1/accounts/"+s.ownAccount()+"/limits/requests", map[string]interface{}{"daily": 100}).
+		AssertBadRequest().
+		AssertProblem().
+		AssertErrorCode("VALIDATION_ERROR").
+		AssertErrorMessage("Validation failed").
+		AssertJsonPath("type", "https://docs.fintech-bank-platform.com/errors/validation-error").
+		AssertJsonPath("title", "Bad Request").
+		AssertJsonPath("instance", "req-limits-1").
+		AssertJsonPath("method", "is required").
+		AssertJsonMissing("success")
+}
+
+func (s *LimitsTestSuite) TestErrorsKeepLegacyEnvelopeByDefault() {
+	s.WithHeader("Accept", "application/json, */*").
+		Post("/v1/accounts/"+s.ownAccount()+"/limits/requests", map[string]interface{}{"method": "pix", "daily": -1}).
+		AssertBadRequest().
+		AssertContentType("application/json").
+		AssertError().
+		AssertErrorCode("INVALID_FIELD").
+		AssertJsonMissing("type")
+}
+
+func (s *LimitsTestSuite) TestErrorsFollowAcceptLanguage() {
+	s.WithHeader("Accept-Language", "pt-BR,pt;q=0.9,en;q=0.8").
+		Post("/v1/accounts/"+s.ownAccount()+"/limits/requests", map[string]interface{}{"daily": 100}).
+		AssertBadRequest().
+		AssertErrorCode("VALIDATION_ERROR").
+		AssertErrorMessage("Falha na validação").
+		AssertJsonPath("error.details.method", "é obrigatório")
+}
+
+func (s *LimitsTestSuite) TestLimitsRequireAuthentication() {
+	s.Get("/v1/accounts/" + tests.UUID() + "/limits").
+		AssertUnauthorized().
+		AssertErrorCode("UNAUTHORIZED")
+}
+
+func (s *LimitsTestSuite) TestLimitsRequireAccountOwnership() {
+	other := tests.UUID()
+	s.Accounts.Set(other, "customer-2")
+	s.ownAccount()
+
+	s.Get("/v1/accounts/" + other + "/limits").
+		AssertForbidden().
+		AssertErrorCode("FORBIDDEN")
+	s.Post("/v1/accounts/"+other+"/limits/requests", map[string]interface{}{"method": "pix", "daily": 1}).
+		AssertForbidden()
+	s.Get("/v1/accounts/" + tests.UUID() + "/limits").
+		AssertForbidden()
+}
+
+func (s *LimitsTestSuite) TestAccountsAdminManagesAnyAccount() {
+	s.WithAPIKey(s.issueKey("operator", "accounts:admin")).
+		Get("/v1/accounts/" + tests.UUID() + "/limits").
+		AssertOk()
+}
+
+func (s *LimitsTestSuite) TestUnversionedRouteIsGone() {
+	s.ownAccount()
+
+	s.Get("/accounts/" + tests.UUID() + "/limits").
+		AssertNotFound()
+}
@@ -7,9 +7,11 @@ package tests
 import (
 	"encoding/json"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
 
+	"github.com/fintech-bank-platform/pkg/response"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -219,22 +221,43 @@ func (r *TestResponse) AssertBodyEmpty() *TestResponse {
 // Success/Error Assertions
 // ═══════════════════════════════════════════════════════════════════════════
 
+// The error assertions accept both the legacy envelope and RFC 7807
+// problem details, where the code is an extension member and the message
+// is the detail
+
 func (r *TestResponse) AssertSuccess() *TestResponse {
 	return r.AssertJsonPath("success", true)
 }
 
 func (r *TestResponse) AssertError() *TestResponse {
+	if r.isProblem() {
+		return r.AssertJsonPath("status", float64(r.recorder.Code))
+	}
 	return r.AssertJsonPath("success", false)
 }
 
 func (r *TestResponse) AssertErrorCode(code string) *TestResponse {
+	if r.isProblem() {
+		return r.AssertJsonPath("code", code)
+	}
 	return r.AssertJsonPath("error.code", code)
 }
 
 func (r *TestResponse) AssertErrorMessage(message string) *TestResponse {
+	if r.isProblem() {
+		return r.AssertJsonPath("detail", message)
+	}
 	return r.AssertJsonPath("error.message", message)
 }
 
+func (r *TestResponse) AssertProblem() *TestResponse {
+	return r.AssertContentType(response.ProblemContentType).AssertError()
+}
+
+func (r *TestResponse) isProblem() bool {
+	return strings.HasPrefix(r.recorder.Header().Get("Content-Type"), response.ProblemContentType)
+}
+
 // ═══════════════════════════════════════════════════════════════════════════
 // Getters
 // ═══════════════════════════════════════════════════════════════════════════
@@ -275,6 +298,12 @@ func (r *TestResponse) getJsonPath(path string) interface{} {
 			if !exists {
 				return nil
 			}
+		case []interface{}:
+			index, err := strconv.Atoi(part)
+			if err != nil || index < 0 || index >= len(v) {
+				return nil
+			}
+			current = v[index]
 		default:
 			return nil
 		}
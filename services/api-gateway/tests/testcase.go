@@ -11,6 +11,9 @@ import (
 	"github.com/fintech-bank-platform/api-gateway/internal/config"
 	"github.com/fintech-bank-platform/api-gateway/internal/contracts"
 	appHttp "github.com/fintech-bank-platform/api-gateway/internal/infrastructure/http"
+	"github.com/fintech-bank-platform/api-gateway/internal/infrastructure/http/middleware"
+	"github.com/fintech-bank-platform/pkg/apikey"
+	"github.com/fintech-bank-platform/pkg/limits"
 	"github.com/go-chi/chi/v5"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/suite"
@@ -22,10 +25,13 @@ import (
 
 type TestCase struct {
 	suite.Suite
-	Router  *chi.Mux
-	Config  *config.Config
-	Logger  zerolog.Logger
-	headers map[string]string
+	Router   *chi.Mux
+	Config   *config.Config
+	Logger   zerolog.Logger
+	Limits   *limits.Manager
+	Accounts *middleware.MemoryAccountOwners
+	APIKeys  *apikey.Manager
+	headers  map[string]string
 }
 
 // ═══════════════════════════════════════════════════════════════════════════
@@ -37,8 +43,14 @@ func (tc *TestCase) SetupSuite() {
 	tc.Logger = zerolog.Nop()
 	tc.headers = make(map[string]string)
 
+	tc.APIKeys = apikey.NewManager(apikey.NewMemoryStore())
 	tc.Router = chi.NewRouter()
-	appHttp.SetupRouter(tc.Router, tc.Config)
+	appHttp.SetupRouter(tc.Router, config.NewStore(tc.Config), tc.APIKeys)
+
+	limitStore := limits.NewMemoryStore()
+	tc.Limits = limits.NewManager(limitStore, limitStore)
+	tc.Accounts = middleware.NewMemoryAccountOwners()
+	appHttp.SetupLimitRoutes(tc.Router, tc.Limits, tc.Accounts)
 }
 
 func (tc *TestCase) SetupTest() {
@@ -109,6 +121,10 @@ func (tc *TestCase) WithToken(token string) *TestCase {
 	return tc.WithHeader("Authorization", "Bearer "+token)
 }
 
+func (tc *TestCase) WithAPIKey(token string) *TestCase {
+	return tc.WithHeader("Authorization", "ApiKey "+token)
+}
+
 func (tc *TestCase) WithContentType(contentType string) *TestCase {
 	return tc.WithHeader("Content-Type", contentType)
 }
@@ -161,6 +177,7 @@ func testConfig() *config.Config {
 		Server:    testServerConfig(),
 		CORS:      testCORSConfig(),
 		RateLimit: testRateLimitConfig(),
+		Signing:   contracts.SigningConfig{ClockSkew: 5 * time.Minute},
 	}
 }
 
@@ -188,7 +205,9 @@ func testCORSConfig() contracts.CORSConfig {
 
 func testRateLimitConfig() contracts.RateLimitConfig {
 	return contracts.RateLimitConfig{
-		Requests: 1000,
-		Window:   1 * time.Minute,
+		Requests:     1000,
+		Window:       1 * time.Minute,
+		TierStandard: 1000,
+		TierPremium:  5000,
 	}
 }
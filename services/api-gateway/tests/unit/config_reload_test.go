@@ -0,0 +1,217 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Unit Test: Config Reload
+// ═══════════════════════════════════════════════════════════════════════════
+
+package unit
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/fintech-bank-platform/api-gateway/internal/config"
+	appHttp "github.com/fintech-bank-platform/api-gateway/internal/infrastructure/http"
+	"github.com/fintech-bank-platform/pkg/apikey"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type logBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *logBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *logBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func newStore(t *testing.T) *config.Store {
+	t.Helper()
+	cfg, err := config.New()
+	require.NoError(t, err)
+	return config.NewStore(cfg)
+}
+
+func preflight(router http.Handler, origin string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodOptions, "/health", nil)
+	req.Header.Set("Origin", origin)
+	req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestConfigReloadAppliesCORS(t *testing.T) {
+	t.Setenv("CORS_ALLOWED_ORIGINS", "https://app.fintech.local")
+	store := newStore(t)
+	router := chi.NewRouter()
+	appHttp.SetupRouter(router, store, apikey.NewManager(apikey.NewMemoryStore()))
+	rateLimit := store.RateLimit()
+
+	assert.Empty(t, preflight(router, "https://admin.fintech.local").Header().Get("Access-Control-Allow-Origin"))
+
+	t.Setenv("CORS_ALLOWED_ORIGINS", "https://app.fintech.local,https://admin.fintech.local")
+	changes, restart, err := store.Reload()
+
+	require.NoError(t, err)
+	assert.Empty(t, restart)
+	assert.Equal(t, []config.Change{{
+		Key: "CORS_ALLOWED_ORIGINS",
+		Old: "https://app.fintech.local",
+		New: "https://app.fintech.local,https://admin.fintech.local",
+	}}, changes)
+	assert.Equal(t, "https://admin.fintech.local", preflight(router, "https://admin.fintech.local").Header().Get("Access-Control-Allow-Origin"))
+	assert.Same(t, rateLimit, store.RateLimit(), "unchanged sections keep their snapshot")
+}
+
+func TestConfigReloadAppliesRateLimit(t *testing.T) {
+	store := newStore(t)
+	router := chi.NewRouter()
+	appHttp.SetupRouter(router, store, apikey.NewManager(apikey.NewMemoryStore()))
+
+	t.Setenv("RATE_LIMIT_REQUESTS", "1")
+	changes, _, err := store.Reload()
+	require.NoError(t, err)
+	assert.Equal(t, []config.Change{{Key: "RATE_LIMIT_REQUESTS", Old: "100", New: "1"}}, changes)
+
+	codes := make([]int, 2)
+	for i := range codes {
+		req := httptest.NewRequest(http.MethodGet, "/health", nil)
+		req.RemoteAddr = "10.0.0.45:12345"
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		codes[i] = rec.Code
+	}
+	assert.Equal(t, []int{http.StatusOK, http.StatusTooManyRequests}, codes)
+}
+
+func TestConfigReloadKeepsRateLimitCounters(t *testing.T) {
+	t.Setenv("RATE_LIMIT_REQUESTS", "1")
+	store := newStore(t)
+	router := chi.NewRouter()
+	appHttp.SetupRouter(router, store, apikey.NewManager(apikey.NewMemoryStore()))
+	get := func() int {
+		req := httptest.NewRequest(http.MethodGet, "/health", nil)
+		req.RemoteAddr = "10.0.0.46:12345"
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	require.Equal(t, http.StatusOK, get())
+
+	t.Setenv("CORS_ALLOWED_ORIGINS", "https://admin.fintech.local")
+	changes, _, err := store.Reload()
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+
+	assert.Equal(t, http.StatusTooManyRequests, get(), "a CORS reload keeps the rate limiter")
+
+	t.Setenv("RATE_LIMIT_REQUESTS", "2")
+	_, _, err = store.Reload()
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, get(), "a higher limit applies to the same counters")
+	assert.Equal(t, http.StatusTooManyRequests, get())
+
+	t.Setenv("RATE_LIMIT_WINDOW", "2m")
+	_, _, err = store.Reload()
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, get(), "a new window starts the counters from zero")
+}
+
+func TestConfigReloadRejectsInvalidConfig(t *testing.T) {
+	store := newStore(t)
+	previous := store.Current()
+
+	t.Setenv("RATE_LIMIT_WINDOW", "1 min")
+	_, _, err := store.Reload()
+
+	assert.ErrorContains(t, err, `RATE_LIMIT_WINDOW: invalid duration "1 min"`)
+	assert.Same(t, previous, store.Current())
+}
+
+func TestConfigReloadKeepsServerSettings(t *testing.T) {
+	store := newStore(t)
+
+	t.Setenv("SERVER_PORT", "9090")
+	t.Setenv("SERVER_HOST", "127.0.0.1")
+	changes, restart, err := store.Reload()
+
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+	assert.Equal(t, []config.Change{
+		{Key: "SERVER_HOST", Old: "0.0.0.0", New: "127.0.0.1"},
+		{Key: "SERVER_PORT", Old: "8080", New: "9090"},
+	}, restart)
+	assert.Equal(t, "8080", store.Current().Server.Port)
+}
+
+func TestConfigWatch(t *testing.T) {
+	// Keep SIGHUP from terminating the test binary before Watch subscribes.
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	path := filepath.Join(t.TempDir(), "gateway.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("rate_limit:\n  requests: 10\n"), 0o600))
+	t.Setenv("CONFIG_FILE", path)
+	store := newStore(t)
+	logs := &logBuffer{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		store.Watch(ctx, zerolog.New(logs), path, 5*time.Millisecond)
+		close(done)
+	}()
+
+	require.NoError(t, os.WriteFile(path, []byte("server:\n  port: \"9090\"\nrate_limit:\n  requests: 20\n"), 0o600))
+	modified := time.Now()
+	require.Eventually(t, func() bool {
+		modified = modified.Add(time.Second)
+		require.NoError(t, os.Chtimes(path, modified, modified))
+		return store.RateLimit().Requests == 20
+	}, time.Second, 10*time.Millisecond)
+
+	t.Setenv("RATE_LIMIT_REQUESTS", "0")
+	require.Eventually(t, func() bool {
+		require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+		return strings.Contains(logs.String(), `"trigger":"signal","message":"Configuration reload rejected`)
+	}, time.Second, 10*time.Millisecond)
+
+	cancel()
+	<-done
+	assert.Equal(t, 20, store.RateLimit().Requests)
+	assert.Contains(t, logs.String(), `"key":"RATE_LIMIT_REQUESTS","old":"10","new":"20","message":"Configuration changed"`)
+	assert.Contains(t, logs.String(), `"key":"SERVER_PORT","old":"8080","new":"9090","message":"Configuration change requires a restart"`)
+	assert.Contains(t, logs.String(), `"trigger":"file","changes":1`)
+	assert.Contains(t, logs.String(), "RATE_LIMIT_REQUESTS: must be greater than 0")
+}
+
+func TestConfigWatchWithoutFile(t *testing.T) {
+	store := newStore(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	store.Watch(ctx, zerolog.Nop(), "", time.Millisecond)
+
+	assert.Equal(t, 100, store.RateLimit().Requests)
+}
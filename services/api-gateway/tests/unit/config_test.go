@@ -6,11 +6,14 @@ package unit
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/fintech-bank-platform/api-gateway/internal/config"
+	pkgconfig "github.com/fintech-bank-platform/pkg/config"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // ═══════════════════════════════════════════════════════════════════════════
@@ -102,3 +105,49 @@ func TestConfigDefaults(t *testing.T) {
 	assert.Greater(t, cfg.RateLimit.Requests, 0)
 	assert.Greater(t, cfg.RateLimit.Window, time.Duration(0))
 }
+
+func TestConfigReportsEveryInvalidValue(t *testing.T) {
+	t.Setenv("RATE_LIMIT_WINDOW", "1 min")
+	t.Setenv("RATE_LIMIT_REQUESTS", "0")
+	t.Setenv("CORS_MAX_AGE", "ten")
+
+	cfg, err := config.New()
+
+	assert.Nil(t, cfg)
+	var configErr *pkgconfig.Error
+	require.ErrorAs(t, err, &configErr)
+	assert.Equal(t, []string{
+		`CORS_MAX_AGE: invalid integer "ten" (from environment)`,
+		`RATE_LIMIT_WINDOW: invalid duration "1 min" (from environment)`,
+		"RATE_LIMIT_REQUESTS: must be greater than 0",
+	}, configErr.Problems)
+}
+
+func TestConfigFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gateway.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+server:
+  port: "9090"
+cors:
+  allowed_origins: [https://app.fintech.local]
+rate_limit:
+  window: 30s
+`), 0o600))
+	t.Setenv("CONFIG_FILE", path)
+	t.Setenv("RATE_LIMIT_REQUESTS_FILE", writeSecret(t, "250\n"))
+
+	cfg, err := config.New()
+
+	require.NoError(t, err)
+	assert.Equal(t, "9090", cfg.Server.Port)
+	assert.Equal(t, []string{"https://app.fintech.local"}, cfg.CORS.AllowedOrigins)
+	assert.Equal(t, 30*time.Second, cfg.RateLimit.Window)
+	assert.Equal(t, 250, cfg.RateLimit.Requests)
+}
+
+func writeSecret(t *testing.T, value string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(path, []byte(value), 0o600))
+	return path
+}
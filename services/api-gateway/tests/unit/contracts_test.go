@@ -28,3 +28,23 @@ func TestServerConfigAddressWithDifferentValues(t *testing.T) {
 
 	assert.Equal(t, "0.0.0.0:3000", cfg.Address())
 }
+
+func TestTLSConfigEnabled(t *testing.T) {
+	assert.False(t, contracts.TLSConfig{}.Enabled())
+	assert.True(t, contracts.TLSConfig{CertFile: "/etc/gateway/tls.crt"}.Enabled())
+}
+
+func TestPrincipalHasScope(t *testing.T) {
+	principal := contracts.Principal{Scopes: []string{"accounts:read", "transfers:write"}}
+
+	assert.True(t, principal.HasScope("transfers:write"))
+	assert.False(t, principal.HasScope("api-keys:admin"))
+}
+
+func TestRateLimitTierRequests(t *testing.T) {
+	cfg := contracts.RateLimitConfig{Requests: 100, TierStandard: 600, TierPremium: 3000}
+
+	assert.Equal(t, 600, cfg.TierRequests("standard"))
+	assert.Equal(t, 3000, cfg.TierRequests("premium"))
+	assert.Equal(t, 100, cfg.TierRequests(""))
+}
@@ -0,0 +1,104 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Unit Test: Limits Handler
+// ═══════════════════════════════════════════════════════════════════════════
+
+package unit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fintech-bank-platform/api-gateway/internal/contracts"
+	appHttp "github.com/fintech-bank-platform/api-gateway/internal/infrastructure/http"
+	"github.com/fintech-bank-platform/api-gateway/internal/infrastructure/http/middleware"
+	"github.com/fintech-bank-platform/pkg/limits"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+var errStore = errors.New("store unavailable")
+
+type failingLimitStore struct{}
+
+func (failingLimitStore) Update(context.Context, string, func([]limits.Reservation) ([]limits.Reservation, error)) error {
+	return errStore
+}
+
+func (failingLimitStore) Reservations(context.Context, string) ([]limits.Reservation, error) {
+	return nil, errStore
+}
+
+func (failingLimitStore) Policy(context.Context, string, string) (*limits.Policy, error) {
+	return nil, errStore
+}
+
+func (failingLimitStore) SetPolicy(context.Context, string, string, limits.Policy) error {
+	return errStore
+}
+
+func (failingLimitStore) SaveChange(context.Context, *limits.ChangeRequest) error { return errStore }
+
+func (failingLimitStore) Changes(context.Context, string) ([]*limits.ChangeRequest, error) {
+	return nil, errStore
+}
+
+type failingAccountOwners struct{}
+
+func (failingAccountOwners) Owner(context.Context, string) (string, error) { return "", errStore }
+
+// asPrincipal authenticates every request as principal
+func asPrincipal(principal contracts.Principal) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(middleware.WithPrincipal(r.Context(), principal)))
+		})
+	}
+}
+
+func failingLimitsRouter() *chi.Mux {
+	router := chi.NewRouter()
+	router.Use(asPrincipal(contracts.Principal{ID: "key-1", Scopes: []string{contracts.ScopeAccountsAdmin}}))
+	appHttp.SetupLimitRoutes(router, limits.NewManager(failingLimitStore{}, failingLimitStore{}), failingAccountOwners{})
+	return router
+}
+
+func TestLimitsHandlerAccountOwnersError(t *testing.T) {
+	router := chi.NewRouter()
+	router.Use(asPrincipal(contracts.Principal{ID: "key-1", Subject: "customer-1"}))
+	store := limits.NewMemoryStore()
+	appHttp.SetupLimitRoutes(router, limits.NewManager(store, store), failingAccountOwners{})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/accounts/acc-1/limits", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestLimitsHandlerStoreErrors(t *testing.T) {
+	router := failingLimitsRouter()
+
+	for _, path := range []string{"/v1/accounts/acc-1/limits", "/v1/accounts/acc-1/limits/requests"} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code, path)
+		assert.Contains(t, rec.Body.String(), "INTERNAL_ERROR")
+	}
+}
+
+func TestLimitsHandlerRequestChangeStoreError(t *testing.T) {
+	router := failingLimitsRouter()
+	body := strings.NewReader(`{"method":"pix","daily":100}`)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/accounts/acc-1/limits/requests", body)
+	req.Header.Set("Content-Type", "application/json")
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
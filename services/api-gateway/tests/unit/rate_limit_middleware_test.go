@@ -88,3 +88,33 @@ func TestRateLimitMiddlewareReturnsJsonResponse(t *testing.T) {
 	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
 	assert.Contains(t, rec.Body.String(), `"success":false`)
 }
+
+func TestAuthFailureLimitCountsRejectionsByIP(t *testing.T) {
+	cfg := contracts.RateLimitConfig{Requests: 2, Window: time.Minute}
+	handler := middleware.AuthFailureLimit(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	serve := func(ip string, authorized bool) int {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = ip + ":12345"
+		if authorized {
+			req.Header.Set("Authorization", "ApiKey valid")
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	for range 3 {
+		assert.Equal(t, http.StatusOK, serve("10.0.0.130", true), "accepted requests are not counted")
+	}
+	assert.Equal(t, http.StatusUnauthorized, serve("10.0.0.130", false))
+	assert.Equal(t, http.StatusUnauthorized, serve("10.0.0.130", false))
+	assert.Equal(t, http.StatusTooManyRequests, serve("10.0.0.130", false))
+	assert.Equal(t, http.StatusTooManyRequests, serve("10.0.0.130", true), "the IP is blocked until the window passes")
+	assert.Equal(t, http.StatusUnauthorized, serve("10.0.0.131", false), "other IPs are counted separately")
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/fintech-bank-platform/api-gateway/internal/config"
 	"github.com/fintech-bank-platform/api-gateway/internal/contracts"
 	appHttp "github.com/fintech-bank-platform/api-gateway/internal/infrastructure/http"
+	"github.com/fintech-bank-platform/pkg/apikey"
 	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
 )
@@ -38,7 +39,7 @@ func TestSetupRouter(t *testing.T) {
 		},
 	}
 
-	appHttp.SetupRouter(router, cfg)
+	appHttp.SetupRouter(router, config.NewStore(cfg), apikey.NewManager(apikey.NewMemoryStore()))
 
 	req := httptest.NewRequest(http.MethodGet, "/health", nil)
 	rec := httptest.NewRecorder()
@@ -63,7 +64,7 @@ func TestSetupRouterHealthEndpoint(t *testing.T) {
 		},
 	}
 
-	appHttp.SetupRouter(router, cfg)
+	appHttp.SetupRouter(router, config.NewStore(cfg), apikey.NewManager(apikey.NewMemoryStore()))
 
 	req := httptest.NewRequest(http.MethodGet, "/health", nil)
 	rec := httptest.NewRecorder()
@@ -72,3 +73,22 @@ func TestSetupRouterHealthEndpoint(t *testing.T) {
 	assert.Equal(t, http.StatusOK, rec.Code)
 	assert.Contains(t, rec.Body.String(), "healthy")
 }
+
+func TestSetupRouterLimitsRejectedAPIKeys(t *testing.T) {
+	router := chi.NewRouter()
+	cfg := &config.Config{RateLimit: contracts.RateLimitConfig{Requests: 2, Window: time.Minute}}
+
+	appHttp.SetupRouter(router, config.NewStore(cfg), apikey.NewManager(apikey.NewMemoryStore()))
+
+	codes := make([]int, 3)
+	for i := range codes {
+		req := httptest.NewRequest(http.MethodGet, "/health", nil)
+		req.RemoteAddr = "10.0.0.140:12345"
+		req.Header.Set("Authorization", "ApiKey fk_guess_secret")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		codes[i] = rec.Code
+	}
+	assert.Equal(t, []int{http.StatusUnauthorized, http.StatusUnauthorized, http.StatusTooManyRequests}, codes,
+		"rejected keys never reach the principal rate limit")
+}
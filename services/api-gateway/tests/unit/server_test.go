@@ -14,6 +14,7 @@ import (
 	"github.com/fintech-bank-platform/api-gateway/internal/config"
 	"github.com/fintech-bank-platform/api-gateway/internal/contracts"
 	appHttp "github.com/fintech-bank-platform/api-gateway/internal/infrastructure/http"
+	"github.com/fintech-bank-platform/pkg/apikey"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 )
@@ -88,7 +89,7 @@ func TestServerShutdown(t *testing.T) {
 	logger := zerolog.Nop()
 
 	server := appHttp.NewServer(cfg, logger)
-	appHttp.SetupRouter(server.Router(), cfg)
+	appHttp.SetupRouter(server.Router(), config.NewStore(cfg), apikey.NewManager(apikey.NewMemoryStore()))
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -118,7 +119,7 @@ func TestServerStartWithSignals(t *testing.T) {
 	logger := zerolog.Nop()
 
 	server := appHttp.NewServer(cfg, logger)
-	appHttp.SetupRouter(server.Router(), cfg)
+	appHttp.SetupRouter(server.Router(), config.NewStore(cfg), apikey.NewManager(apikey.NewMemoryStore()))
 
 	done := make(chan error, 1)
 
@@ -201,7 +202,7 @@ func TestServerStart(t *testing.T) {
 	logger := zerolog.Nop()
 
 	server := appHttp.NewServer(cfg, logger)
-	appHttp.SetupRouter(server.Router(), cfg)
+	appHttp.SetupRouter(server.Router(), config.NewStore(cfg), apikey.NewManager(apikey.NewMemoryStore()))
 
 	done := make(chan error, 1)
 
@@ -248,7 +249,7 @@ func TestServerStartWithShutdownTimeout(t *testing.T) {
 	logger := zerolog.Nop()
 
 	server := appHttp.NewServer(cfg, logger)
-	appHttp.SetupRouter(server.Router(), cfg)
+	appHttp.SetupRouter(server.Router(), config.NewStore(cfg), apikey.NewManager(apikey.NewMemoryStore()))
 
 	server.Router().Get("/slow", func(w http.ResponseWriter, r *http.Request) {
 		time.Sleep(5 * time.Second)
@@ -0,0 +1,184 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Unit Test: Signature Middleware
+// ═══════════════════════════════════════════════════════════════════════════
+
+package unit
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/fintech-bank-platform/api-gateway/internal/config"
+	"github.com/fintech-bank-platform/api-gateway/internal/contracts"
+	"github.com/fintech-bank-platform/api-gateway/internal/infrastructure/http/middleware"
+	"github.com/fintech-bank-platform/pkg/signature"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const paymentBody = `{"amount":150000,"currency":"BRL"}`
+
+func signingConfig() contracts.SigningConfig {
+	return contracts.SigningConfig{
+		Keys:           []string{"partner-bank-341:s3cr3t"},
+		ClockSkew:      time.Minute,
+		RequiredRoutes: []string{"POST /v1/payments"},
+	}
+}
+
+func signedPayment(secret string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/v1/payments", strings.NewReader(paymentBody))
+	signature.NewSigner("partner-bank-341", []byte(secret)).Sign(req, []byte(paymentBody))
+	return req
+}
+
+// signatureHandler echoes the body the next handler receives
+func signatureHandler() http.Handler {
+	return middleware.Signature(signingConfig(), signature.NewMemoryNonceStore())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(w, r.Body)
+	}))
+}
+
+func serveSigned(handler http.Handler, req *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestSignatureMiddlewareAcceptsSignedRequests(t *testing.T) {
+	rec := serveSigned(signatureHandler(), signedPayment("s3cr3t"))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, paymentBody, rec.Body.String(), "the body is passed on after verification")
+}
+
+func TestSignatureMiddlewareRecordsKeyID(t *testing.T) {
+	var logs bytes.Buffer
+	var keyID string
+	handler := middleware.Signature(signingConfig(), signature.NewMemoryNonceStore())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keyID, _ = middleware.GetSigningKeyID(r.Context())
+	}))
+	req := signedPayment("s3cr3t")
+	req = req.WithContext(zerolog.New(&logs).WithContext(req.Context()))
+
+	rec := serveSigned(handler, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "partner-bank-341", keyID)
+	assert.Contains(t, logs.String(), `"key_id":"partner-bank-341"`)
+	assert.Contains(t, logs.String(), "Request signature verified")
+
+	_, ok := middleware.GetSigningKeyID(httptest.NewRequest(http.MethodGet, "/", nil).Context())
+	assert.False(t, ok)
+}
+
+func TestSignatureMiddlewareRequiresKeyOfPrincipal(t *testing.T) {
+	cfg := signingConfig()
+	cfg.Keys = append(cfg.Keys, "partner-bank-341-2026:n3w")
+	cfg.KeyOwners = []string{"partner-bank-341-2026:partner-bank-341"}
+	handler := middleware.Signature(cfg, signature.NewMemoryNonceStore())(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	sign := func(keyID, secret string, principal contracts.Principal) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/v1/payments", strings.NewReader(paymentBody))
+		signature.NewSigner(keyID, []byte(secret)).Sign(req, []byte(paymentBody))
+		return req.WithContext(middleware.WithPrincipal(req.Context(), principal))
+	}
+	partner := contracts.Principal{ID: "key-1", Method: contracts.AuthMethodAPIKey, Subject: "partner-bank-341"}
+	other := contracts.Principal{ID: "key-2", Method: contracts.AuthMethodAPIKey, Subject: "partner-bank-777"}
+	certificate := contracts.Principal{ID: "partner-bank-341", Method: contracts.AuthMethodMTLS, Subject: "CN=partner-bank-341"}
+
+	assert.Equal(t, http.StatusOK, serveSigned(handler, sign("partner-bank-341", "s3cr3t", partner)).Code)
+	assert.Equal(t, http.StatusOK, serveSigned(handler, sign("partner-bank-341-2026", "n3w", partner)).Code)
+	assert.Equal(t, http.StatusOK, serveSigned(handler, sign("partner-bank-341", "s3cr3t", certificate)).Code)
+
+	rec := serveSigned(handler, sign("partner-bank-341", "s3cr3t", other))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), "FORBIDDEN")
+}
+
+func TestSignatureMiddlewareRejectsFailures(t *testing.T) {
+	handler := signatureHandler()
+	replayed := signedPayment("s3cr3t")
+	serveSigned(handler, replayed.Clone(replayed.Context()))
+	replayed.Body = io.NopCloser(strings.NewReader(paymentBody))
+
+	for code, req := range map[string]*http.Request{
+		"SIGNATURE_REQUIRED": httptest.NewRequest(http.MethodPost, "/v1/payments/", strings.NewReader(paymentBody)),
+		"INVALID_SIGNATURE":  signedPayment("wrong"),
+		"SIGNATURE_REPLAYED": replayed,
+	} {
+		rec := serveSigned(handler, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, code)
+		assert.Contains(t, rec.Body.String(), code)
+	}
+}
+
+func TestSignatureMiddlewareVerifiesOptionalSignatures(t *testing.T) {
+	handler := signatureHandler()
+
+	unsigned := serveSigned(handler, httptest.NewRequest(http.MethodGet, "/v1/accounts", nil))
+	assert.Equal(t, http.StatusOK, unsigned.Code, "unsigned requests to other routes pass")
+
+	signed := httptest.NewRequest(http.MethodGet, "/v1/accounts", nil)
+	signature.NewSigner("partner-bank-341", []byte("wrong")).Sign(signed, nil)
+	assert.Equal(t, http.StatusUnauthorized, serveSigned(handler, signed).Code)
+}
+
+func TestSignatureMiddlewareBodyErrors(t *testing.T) {
+	handler := signatureHandler()
+
+	large := httptest.NewRequest(http.MethodPost, "/v1/payments", strings.NewReader(strings.Repeat("x", 1<<20+1)))
+	rec := serveSigned(handler, large)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+
+	broken := httptest.NewRequest(http.MethodPost, "/v1/payments", iotest.ErrReader(io.ErrUnexpectedEOF))
+	rec = serveSigned(handler, broken)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestSigningConfigSecrets(t *testing.T) {
+	cfg := contracts.SigningConfig{Keys: []string{"partner-bank-341:s3:cr3t", "partner-bank-777:other"}}
+
+	assert.Equal(t, map[string][]byte{
+		"partner-bank-341": []byte("s3:cr3t"),
+		"partner-bank-777": []byte("other"),
+	}, cfg.Secrets())
+}
+
+func TestSigningConfigOwner(t *testing.T) {
+	cfg := contracts.SigningConfig{KeyOwners: []string{"settlement-2026:partner-bank-341"}}
+
+	assert.Equal(t, "partner-bank-341", cfg.Owner("settlement-2026"))
+	assert.Equal(t, "partner-bank-777", cfg.Owner("partner-bank-777"))
+}
+
+func TestConfigSigningValidation(t *testing.T) {
+	t.Setenv("SIGNING_KEYS", "partner-bank-341")
+
+	_, err := config.New()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "SIGNING_KEYS")
+	assert.NotContains(t, err.Error(), "partner-bank-341", "secrets are not echoed")
+}
+
+func TestConfigReloadRotatesSigningSecret(t *testing.T) {
+	t.Setenv("SIGNING_KEYS", "partner-bank-341:old")
+	store := newStore(t)
+	previous := store.Signing()
+
+	t.Setenv("SIGNING_KEYS", "partner-bank-341:new")
+	changes, _, err := store.Reload()
+
+	require.NoError(t, err)
+	assert.Equal(t, []config.Change{{Key: "SIGNING_KEYS", Old: "[REDACTED]", New: "[REDACTED]"}}, changes)
+	assert.NotSame(t, previous, store.Signing())
+	assert.Equal(t, []byte("new"), store.Signing().Secrets()["partner-bank-341"])
+}
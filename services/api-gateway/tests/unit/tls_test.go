@@ -0,0 +1,252 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Unit Test: TLS and mutual TLS
+// ═══════════════════════════════════════════════════════════════════════════
+
+package unit
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/fintech-bank-platform/api-gateway/internal/config"
+	"github.com/fintech-bank-platform/api-gateway/internal/contracts"
+	appHttp "github.com/fintech-bank-platform/api-gateway/internal/infrastructure/http"
+	"github.com/fintech-bank-platform/api-gateway/internal/infrastructure/http/middleware"
+	"github.com/fintech-bank-platform/pkg/apikey"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Fintech Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns PEM-encoded certificate and key signed by the CA.
+func (ca *testCA) issue(t *testing.T, subject pkix.Name, usage x509.ExtKeyUsage) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      subject,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func writeFile(t *testing.T, dir, name string, content []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, content, 0o600))
+	return path
+}
+
+func serverTLSConfig(t *testing.T, ca *testCA, clientAuth string) contracts.TLSConfig {
+	t.Helper()
+	dir := t.TempDir()
+	cert, key := ca.issue(t, pkix.Name{CommonName: "gateway.fintech.local"}, x509.ExtKeyUsageServerAuth)
+	return contracts.TLSConfig{
+		CertFile:     writeFile(t, dir, "tls.crt", cert),
+		KeyFile:      writeFile(t, dir, "tls.key", key),
+		MinVersion:   "1.2",
+		ClientAuth:   clientAuth,
+		ClientCAFile: writeFile(t, dir, "ca.crt", ca.pem),
+	}
+}
+
+func TestServerMutualTLS(t *testing.T) {
+	ca := newTestCA(t)
+	cfg := testServerConfig()
+	cfg.Server.Port = "18083"
+	tlsConfig, err := appHttp.NewTLSConfig(serverTLSConfig(t, ca, "require"), zerolog.Nop())
+	require.NoError(t, err)
+
+	server := appHttp.NewServer(cfg, zerolog.Nop())
+	server.UseTLS(tlsConfig)
+	appHttp.SetupRouter(server.Router(), config.NewStore(cfg), apikey.NewManager(apikey.NewMemoryStore()))
+	server.Router().Get("/whoami", func(w http.ResponseWriter, r *http.Request) {
+		principal, _ := middleware.GetPrincipal(r.Context())
+		json.NewEncoder(w).Encode(principal)
+	})
+
+	done := make(chan error, 1)
+	go func() {
+		done <- server.StartWithSignals(syscall.SIGUSR1)
+	}()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	certPEM, keyPEM := ca.issue(t, pkix.Name{CommonName: "partner-bank-341", Organization: []string{"Partner Bank"}}, x509.ExtKeyUsageClientAuth)
+	clientCert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+	client := &http.Client{Transport: &http.Transport{
+		TLSClientConfig:   &tls.Config{RootCAs: roots, Certificates: []tls.Certificate{clientCert}},
+		ForceAttemptHTTP2: true,
+	}}
+
+	var resp *http.Response
+	require.Eventually(t, func() bool {
+		resp, err = client.Get("https://127.0.0.1:18083/whoami")
+		return err == nil
+	}, 5*time.Second, 20*time.Millisecond)
+	defer resp.Body.Close()
+
+	var principal contracts.Principal
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&principal))
+	assert.Equal(t, "HTTP/2.0", resp.Proto)
+	assert.Equal(t, contracts.Principal{
+		ID:      "partner-bank-341",
+		Method:  contracts.AuthMethodMTLS,
+		Subject: "CN=partner-bank-341,O=Partner Bank",
+	}, principal)
+
+	anonymous := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots}}}
+	_, err = anonymous.Get("https://127.0.0.1:18083/whoami")
+	assert.Error(t, err, "connections without a client certificate are rejected")
+
+	syscall.Kill(syscall.Getpid(), syscall.SIGUSR1)
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("Server did not shut down in time")
+	}
+}
+
+func TestTLSCertificateReload(t *testing.T) {
+	ca := newTestCA(t)
+	cfg := serverTLSConfig(t, ca, "none")
+	tlsConfig, err := appHttp.NewTLSConfig(cfg, zerolog.Nop())
+	require.NoError(t, err)
+	assert.Nil(t, tlsConfig.ClientCAs)
+
+	first, err := tlsConfig.GetCertificate(nil)
+	require.NoError(t, err)
+
+	cert, key := ca.issue(t, pkix.Name{CommonName: "gateway.fintech.local"}, x509.ExtKeyUsageServerAuth)
+	touch := func(path string, content []byte, offset time.Duration) {
+		require.NoError(t, os.WriteFile(path, content, 0o600))
+		modified := time.Now().Add(offset)
+		require.NoError(t, os.Chtimes(path, modified, modified))
+	}
+
+	touch(cfg.CertFile, cert, time.Second)
+	mid, err := tlsConfig.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.Same(t, first, mid, "a mismatched pair mid-rotation keeps the previous certificate")
+
+	touch(cfg.KeyFile, key, 2*time.Second)
+	rotated, err := tlsConfig.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.Certificate[0], rotated.Certificate[0])
+
+	again, _ := tlsConfig.GetCertificate(nil)
+	assert.Same(t, rotated, again)
+}
+
+func TestNewTLSConfigOptions(t *testing.T) {
+	ca := newTestCA(t)
+	cfg := serverTLSConfig(t, ca, "optional")
+	cfg.MinVersion = "1.3"
+	cfg.CipherSuites = []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256"}
+
+	tlsConfig, err := appHttp.NewTLSConfig(cfg, zerolog.Nop())
+
+	require.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS13), tlsConfig.MinVersion)
+	assert.Equal(t, []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256, tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256}, tlsConfig.CipherSuites)
+	assert.Equal(t, tls.VerifyClientCertIfGiven, tlsConfig.ClientAuth)
+	assert.Equal(t, []string{"h2", "http/1.1"}, tlsConfig.NextProtos)
+}
+
+func TestNewTLSConfigErrors(t *testing.T) {
+	ca := newTestCA(t)
+	valid := serverTLSConfig(t, ca, "require")
+
+	for name, mutate := range map[string]func(*contracts.TLSConfig){
+		"min version":  func(c *contracts.TLSConfig) { c.MinVersion = "1.0" },
+		"client auth":  func(c *contracts.TLSConfig) { c.ClientAuth = "sometimes" },
+		"cipher suite": func(c *contracts.TLSConfig) { c.CipherSuites = []string{"TLS_RSA_WITH_RC4_128_SHA"} },
+		"key pair":     func(c *contracts.TLSConfig) { c.KeyFile = c.ClientCAFile },
+		"missing CA":   func(c *contracts.TLSConfig) { c.ClientCAFile = filepath.Join(t.TempDir(), "missing.crt") },
+		"empty CA file": func(c *contracts.TLSConfig) {
+			c.ClientCAFile = writeFile(t, t.TempDir(), "ca.crt", []byte("not a certificate"))
+		},
+	} {
+		cfg := valid
+		mutate(&cfg)
+
+		_, err := appHttp.NewTLSConfig(cfg, zerolog.Nop())
+
+		assert.ErrorIs(t, err, appHttp.ErrInvalidTLSConfig, name)
+	}
+}
+
+func TestClientCertificateMiddlewareWithoutTLS(t *testing.T) {
+	var authenticated bool
+	handler := middleware.ClientCertificate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, authenticated = middleware.GetPrincipal(r.Context())
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.False(t, authenticated)
+}
+
+func TestConfigTLSValidation(t *testing.T) {
+	t.Setenv("TLS_CERT_FILE", "/etc/gateway/tls.crt")
+	t.Setenv("TLS_CLIENT_AUTH", "require")
+
+	_, err := config.New()
+
+	assert.EqualError(t, err, "config: TLS_KEY_FILE: is required; TLS_CLIENT_CA_FILE: is required")
+}
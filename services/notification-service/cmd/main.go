@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/fintech-bank-platform/notification-service/internal/config"
+	"github.com/fintech-bank-platform/notification-service/internal/contracts"
+	"github.com/fintech-bank-platform/notification-service/internal/infrastructure/channels"
+	"github.com/fintech-bank-platform/notification-service/internal/notification"
+	"github.com/fintech-bank-platform/notification-service/internal/preferences"
+	"github.com/fintech-bank-platform/notification-service/internal/queue"
+	"github.com/fintech-bank-platform/notification-service/internal/rules"
+	"github.com/fintech-bank-platform/notification-service/internal/templates"
+	pkgconfig "github.com/fintech-bank-platform/pkg/config"
+	"github.com/fintech-bank-platform/pkg/events"
+	"github.com/rs/zerolog"
+)
+
+func main() {
+	logger := zerolog.New(os.Stdout).With().Timestamp().Logger()
+
+	cfg, err := config.New()
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to load configuration")
+	}
+	logger.Debug().Interface("config", pkgconfig.Dump(cfg)).Msg("Effective configuration")
+
+	renderer, err := templates.New(cfg.Templates)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to load templates")
+	}
+
+	sms, err := channels.NewOutputProvider(contracts.ChannelSMS, cfg.Channels.SMSOutput)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to open SMS output")
+	}
+	push, err := channels.NewOutputProvider(contracts.ChannelPush, cfg.Channels.PushOutput)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to open push output")
+	}
+
+	q := queue.New([]contracts.Provider{channels.NewSMTPProvider(cfg.SMTP, nil), sms, push}, cfg.Queue.Size, logger)
+	service := notification.NewService(renderer, preferences.NewMemoryStore(), q, logger)
+
+	ruleSet, err := loadRules(cfg.Rules)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to load notification rules")
+	}
+
+	// The in-memory bus keeps the service runnable locally until the Kafka
+	// consumer is available behind the same events.Subscriber interface
+	bus := events.NewMemoryBus()
+	engine, err := rules.NewEngine(ruleSet, bus, logger,
+		rules.WithDryRun(cfg.Rules.DryRun),
+		rules.WithDeduplicator(rules.NewMemoryDeduplicator(cfg.Rules.DedupTTL)),
+	)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to compile notification rules")
+	}
+	engine.Subscribe(bus)
+	service.Subscribe(bus)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	go q.RunReleaser(ctx, cfg.Queue.ReleaseInterval)
+
+	logger.Info().Int("workers", cfg.Queue.Workers).Msg("Notification service started")
+	q.Run(ctx, cfg.Queue.Workers)
+	logger.Info().Msg("Notification service stopped")
+}
+
+func loadRules(cfg contracts.RulesConfig) (*rules.Config, error) {
+	if cfg.File == "" {
+		return rules.Default()
+	}
+	return rules.LoadFile(cfg.File)
+}
@@ -0,0 +1,24 @@
+package config
+
+import (
+	"os"
+
+	"github.com/fintech-bank-platform/notification-service/internal/contracts"
+	pkgconfig "github.com/fintech-bank-platform/pkg/config"
+)
+
+type Config struct {
+	SMTP      contracts.SMTPConfig     `yaml:"smtp"`
+	Channels  contracts.ChannelConfig  `yaml:"channels"`
+	Templates contracts.TemplateConfig `yaml:"templates"`
+	Queue     contracts.QueueConfig    `yaml:"queue"`
+	Rules     contracts.RulesConfig    `yaml:"rules"`
+}
+
+func New() (*Config, error) {
+	var cfg Config
+	if err := pkgconfig.Load(&cfg, pkgconfig.WithFile(os.Getenv("CONFIG_FILE"))); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
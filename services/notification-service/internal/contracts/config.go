@@ -0,0 +1,35 @@
+package contracts
+
+import "time"
+
+type SMTPConfig struct {
+	Host string `env:"SMTP_HOST" yaml:"host" default:"localhost" validate:"required"`
+	Port string `env:"SMTP_PORT" yaml:"port" default:"1025" validate:"required,numeric"`
+	From string `env:"SMTP_FROM" yaml:"from" default:"no-reply@fintech.local" validate:"required,email"`
+}
+
+func (s SMTPConfig) Address() string {
+	return s.Host + ":" + s.Port
+}
+
+type ChannelConfig struct {
+	SMSOutput  string `env:"SMS_OUTPUT" yaml:"sms_output" default:"stdout"`
+	PushOutput string `env:"PUSH_OUTPUT" yaml:"push_output" default:"stdout"`
+}
+
+type TemplateConfig struct {
+	Dir           string `env:"TEMPLATES_DIR" yaml:"dir"`
+	DefaultLocale string `env:"DEFAULT_LOCALE" yaml:"default_locale" default:"pt-BR" validate:"required"`
+}
+
+type QueueConfig struct {
+	Size            int           `env:"QUEUE_SIZE" yaml:"size" default:"1000" validate:"gt=0"`
+	Workers         int           `env:"QUEUE_WORKERS" yaml:"workers" default:"4" validate:"gt=0"`
+	ReleaseInterval time.Duration `env:"QUEUE_RELEASE_INTERVAL" yaml:"release_interval" default:"1m" validate:"gt=0"`
+}
+
+type RulesConfig struct {
+	File     string        `env:"RULES_FILE" yaml:"file"`
+	DryRun   bool          `env:"RULES_DRY_RUN" yaml:"dry_run"`
+	DedupTTL time.Duration `env:"RULES_DEDUP_TTL" yaml:"dedup_ttl" default:"24h" validate:"gte=0"`
+}
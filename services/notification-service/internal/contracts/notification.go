@@ -0,0 +1,127 @@
+package contracts
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+type Channel string
+
+const (
+	ChannelEmail Channel = "email"
+	ChannelSMS   Channel = "sms"
+	ChannelPush  Channel = "push"
+)
+
+type Priority string
+
+const (
+	PriorityHigh   Priority = "high"
+	PriorityNormal Priority = "normal"
+	PriorityLow    Priority = "low"
+)
+
+// ParsePriority maps the payload priority to a queue; unknown values are normal
+func ParsePriority(value string) Priority {
+	switch Priority(value) {
+	case PriorityHigh, PriorityLow:
+		return Priority(value)
+	default:
+		return PriorityNormal
+	}
+}
+
+type Message struct {
+	ID        string            `json:"id"`
+	EventID   string            `json:"event_id"`
+	Channel   Channel           `json:"channel"`
+	Priority  Priority          `json:"priority"`
+	UserID    string            `json:"user_id,omitempty"`
+	Locale    string            `json:"locale"`
+	To        string            `json:"to"`
+	Subject   string            `json:"subject,omitempty"`
+	Body      string            `json:"body"`
+	Data      map[string]string `json:"data,omitempty"`
+	DeliverAt time.Time         `json:"deliver_at,omitempty"`
+}
+
+type Provider interface {
+	Channel() Channel
+	Send(ctx context.Context, msg *Message) error
+}
+
+// QuietHours is a daily window, in minutes since midnight, during which only
+// high priority messages are delivered. Start > End wraps past midnight.
+type QuietHours struct {
+	Start    int
+	End      int
+	Location *time.Location
+}
+
+// ParseQuietHours builds a window from "HH:MM" strings
+func ParseQuietHours(start, end string, loc *time.Location) (*QuietHours, error) {
+	startMinute, err := parseClock(start)
+	if err != nil {
+		return nil, err
+	}
+	endMinute, err := parseClock(end)
+	if err != nil {
+		return nil, err
+	}
+	return &QuietHours{Start: startMinute, End: endMinute, Location: loc}, nil
+}
+
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q: %w", value, err)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func (q QuietHours) location() *time.Location {
+	if q.Location == nil {
+		return time.UTC
+	}
+	return q.Location
+}
+
+func (q QuietHours) Contains(t time.Time) bool {
+	local := t.In(q.location())
+	minute := local.Hour()*60 + local.Minute()
+	if q.Start <= q.End {
+		return minute >= q.Start && minute < q.End
+	}
+	return minute >= q.Start || minute < q.End
+}
+
+// EndAfter returns the first end of the window after t
+func (q QuietHours) EndAfter(t time.Time) time.Time {
+	local := t.In(q.location())
+	end := time.Date(local.Year(), local.Month(), local.Day(), q.End/60, q.End%60, 0, 0, q.location())
+	if !end.After(local) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return end
+}
+
+type Preferences struct {
+	Locale     string
+	Disabled   []Channel
+	QuietHours *QuietHours
+}
+
+func (p *Preferences) Allows(channel Channel) bool {
+	for _, disabled := range p.Disabled {
+		if disabled == channel {
+			return false
+		}
+	}
+	return true
+}
+
+// PreferenceStore returns nil preferences for users that never set any
+type PreferenceStore interface {
+	Get(ctx context.Context, userID string) (*Preferences, error)
+}
@@ -0,0 +1,42 @@
+package channels
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/smtp"
+	"strings"
+
+	"github.com/fintech-bank-platform/notification-service/internal/contracts"
+)
+
+// SMTPProvider sends HTML email through an SMTP server. Locally it points
+// at the Mailpit sink from docker-compose, which needs no authentication.
+type SMTPProvider struct {
+	config contracts.SMTPConfig
+	auth   smtp.Auth
+}
+
+func NewSMTPProvider(cfg contracts.SMTPConfig, auth smtp.Auth) *SMTPProvider {
+	return &SMTPProvider{config: cfg, auth: auth}
+}
+
+func (p *SMTPProvider) Channel() contracts.Channel {
+	return contracts.ChannelEmail
+}
+
+func (p *SMTPProvider) Send(ctx context.Context, msg *contracts.Message) error {
+	return smtp.SendMail(p.config.Address(), p.auth, p.config.From, []string{msg.To}, p.build(msg))
+}
+
+func (p *SMTPProvider) build(msg *contracts.Message) []byte {
+	headers := []string{
+		"From: " + p.config.From,
+		"To: " + msg.To,
+		"Subject: " + mime.QEncoding.Encode("utf-8", msg.Subject),
+		"MIME-Version: 1.0",
+		"Content-Type: text/html; charset=UTF-8",
+		"X-Notification-ID: " + msg.ID,
+	}
+	return []byte(fmt.Sprintf("%s\r\n\r\n%s\r\n", strings.Join(headers, "\r\n"), msg.Body))
+}
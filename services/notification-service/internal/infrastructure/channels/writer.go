@@ -0,0 +1,47 @@
+package channels
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/fintech-bank-platform/notification-service/internal/contracts"
+)
+
+// WriterProvider is the SMS/push fake for local development and tests: it
+// writes each message as a JSON line instead of calling a real gateway.
+type WriterProvider struct {
+	channel contracts.Channel
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+func NewWriterProvider(channel contracts.Channel, w io.Writer) *WriterProvider {
+	return &WriterProvider{channel: channel, encoder: json.NewEncoder(w)}
+}
+
+// NewOutputProvider writes to stdout when output is "stdout", otherwise
+// appends to the file at output
+func NewOutputProvider(channel contracts.Channel, output string) (*WriterProvider, error) {
+	if output == "stdout" {
+		return NewWriterProvider(channel, os.Stdout), nil
+	}
+
+	file, err := os.OpenFile(output, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return NewWriterProvider(channel, file), nil
+}
+
+func (p *WriterProvider) Channel() contracts.Channel {
+	return p.channel
+}
+
+func (p *WriterProvider) Send(ctx context.Context, msg *contracts.Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.encoder.Encode(msg)
+}
@@ -0,0 +1,147 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fintech-bank-platform/notification-service/internal/contracts"
+	"github.com/fintech-bank-platform/notification-service/internal/queue"
+	"github.com/fintech-bank-platform/notification-service/internal/templates"
+	"github.com/fintech-bank-platform/pkg/events"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+var ErrTemplateRequired = errors.New("email notifications require a template")
+
+// Service consumes notification.events, renders the message for the
+// recipient's locale, applies their preferences and queues it for delivery
+type Service struct {
+	renderer    *templates.Renderer
+	preferences contracts.PreferenceStore
+	queue       *queue.Queue
+	logger      zerolog.Logger
+	now         func() time.Time
+}
+
+type Option func(*Service)
+
+func WithClock(now func() time.Time) Option {
+	return func(s *Service) {
+		s.now = now
+	}
+}
+
+func NewService(renderer *templates.Renderer, preferences contracts.PreferenceStore, q *queue.Queue, logger zerolog.Logger, opts ...Option) *Service {
+	s := &Service{
+		renderer:    renderer,
+		preferences: preferences,
+		queue:       q,
+		logger:      logger,
+		now:         time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *Service) Subscribe(subscriber events.Subscriber) {
+	subscriber.Subscribe(events.Topics.NotificationEvents, s.Handle)
+}
+
+func (s *Service) Handle(ctx context.Context, event *events.Event) error {
+	logger := s.logger.With().Str("event_id", event.ID).Str("event_type", event.Type).Logger()
+
+	msg, template, err := decode(event)
+	if err != nil {
+		return err
+	}
+	if msg == nil {
+		logger.Debug().Msg("Ignoring event")
+		return nil
+	}
+
+	var prefs *contracts.Preferences
+	if msg.UserID != "" {
+		if prefs, err = s.preferences.Get(ctx, msg.UserID); err != nil {
+			return err
+		}
+	}
+	if prefs != nil && !prefs.Allows(msg.Channel) {
+		logger.Info().Str("user_id", msg.UserID).Str("channel", string(msg.Channel)).Msg("Channel disabled by user")
+		return nil
+	}
+
+	if msg.Locale == "" && prefs != nil {
+		msg.Locale = prefs.Locale
+	}
+	if msg.Locale == "" {
+		msg.Locale = s.renderer.DefaultLocale()
+	}
+
+	if template != "" {
+		rendered, err := s.renderer.Render(msg.Channel, template, msg.Locale, msg.Data)
+		if err != nil {
+			return err
+		}
+		if rendered.Subject != "" {
+			msg.Subject = rendered.Subject
+		}
+		msg.Body = rendered.Body
+	}
+
+	// High priority messages (codes, security alerts) ignore quiet hours
+	now := s.now()
+	if prefs != nil && prefs.QuietHours != nil && msg.Priority != contracts.PriorityHigh && prefs.QuietHours.Contains(now) {
+		msg.DeliverAt = prefs.QuietHours.EndAfter(now)
+		s.queue.Defer(msg)
+		logger.Info().Str("message_id", msg.ID).Time("deliver_at", msg.DeliverAt).Msg("Notification deferred by quiet hours")
+		return nil
+	}
+
+	return s.queue.Enqueue(msg)
+}
+
+// decode maps a notification event to a message and the template to render.
+// Events of other types yield a nil message.
+func decode(event *events.Event) (*contracts.Message, string, error) {
+	msg := &contracts.Message{ID: uuid.NewString(), EventID: event.ID}
+
+	switch event.Type {
+	case events.EventTypes.SendEmail:
+		var payload events.SendEmailPayload
+		if err := event.DecodePayload(&payload); err != nil {
+			return nil, "", fmt.Errorf("decode %s: %w", event.Type, err)
+		}
+		if payload.Template == "" {
+			return nil, "", ErrTemplateRequired
+		}
+		msg.Channel, msg.UserID, msg.To, msg.Subject = contracts.ChannelEmail, payload.UserID, payload.To, payload.Subject
+		msg.Locale, msg.Data, msg.Priority = payload.Locale, payload.Data, contracts.ParsePriority(payload.Priority)
+		return msg, payload.Template, nil
+
+	case events.EventTypes.SendSMS:
+		var payload events.SendSMSPayload
+		if err := event.DecodePayload(&payload); err != nil {
+			return nil, "", fmt.Errorf("decode %s: %w", event.Type, err)
+		}
+		msg.Channel, msg.UserID, msg.To, msg.Body = contracts.ChannelSMS, payload.UserID, payload.To, payload.Message
+		msg.Locale, msg.Data, msg.Priority = payload.Locale, payload.Data, contracts.ParsePriority(payload.Priority)
+		return msg, payload.Template, nil
+
+	case events.EventTypes.SendPush:
+		var payload events.SendPushPayload
+		if err := event.DecodePayload(&payload); err != nil {
+			return nil, "", fmt.Errorf("decode %s: %w", event.Type, err)
+		}
+		msg.Channel, msg.UserID, msg.To, msg.Subject, msg.Body = contracts.ChannelPush, payload.UserID, payload.UserID, payload.Title, payload.Body
+		msg.Locale, msg.Data, msg.Priority = payload.Locale, payload.Data, contracts.ParsePriority(payload.Priority)
+		return msg, payload.Template, nil
+
+	default:
+		return nil, "", nil
+	}
+}
@@ -0,0 +1,29 @@
+package preferences
+
+import (
+	"context"
+	"sync"
+
+	"github.com/fintech-bank-platform/notification-service/internal/contracts"
+)
+
+type MemoryStore struct {
+	mu    sync.RWMutex
+	users map[string]*contracts.Preferences
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{users: make(map[string]*contracts.Preferences)}
+}
+
+func (s *MemoryStore) Set(userID string, prefs *contracts.Preferences) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users[userID] = prefs
+}
+
+func (s *MemoryStore) Get(ctx context.Context, userID string) (*contracts.Preferences, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.users[userID], nil
+}
@@ -0,0 +1,175 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fintech-bank-platform/notification-service/internal/contracts"
+	"github.com/rs/zerolog"
+)
+
+var (
+	ErrQueueFull  = errors.New("notification queue is full")
+	ErrNoProvider = errors.New("no provider for channel")
+)
+
+// Queue keeps one buffered queue per priority. Workers always drain high
+// before normal and normal before low. Messages with a future DeliverAt
+// (quiet hours) wait in a deferred list until Release moves them in.
+type Queue struct {
+	queues    map[contracts.Priority]chan *contracts.Message
+	providers map[contracts.Channel]contracts.Provider
+	logger    zerolog.Logger
+
+	mu       sync.Mutex
+	deferred []*contracts.Message
+}
+
+func New(providers []contracts.Provider, size int, logger zerolog.Logger) *Queue {
+	q := &Queue{
+		queues: map[contracts.Priority]chan *contracts.Message{
+			contracts.PriorityHigh:   make(chan *contracts.Message, size),
+			contracts.PriorityNormal: make(chan *contracts.Message, size),
+			contracts.PriorityLow:    make(chan *contracts.Message, size),
+		},
+		providers: make(map[contracts.Channel]contracts.Provider),
+		logger:    logger,
+	}
+	for _, provider := range providers {
+		q.providers[provider.Channel()] = provider
+	}
+	return q
+}
+
+func (q *Queue) Enqueue(msg *contracts.Message) error {
+	if _, ok := q.providers[msg.Channel]; !ok {
+		return fmt.Errorf("%w: %s", ErrNoProvider, msg.Channel)
+	}
+
+	select {
+	case q.queues[contracts.ParsePriority(string(msg.Priority))] <- msg:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrQueueFull, msg.Priority)
+	}
+}
+
+func (q *Queue) Defer(msg *contracts.Message) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.deferred = append(q.deferred, msg)
+}
+
+func (q *Queue) Deferred() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.deferred)
+}
+
+func (q *Queue) Len(priority contracts.Priority) int {
+	return len(q.queues[priority])
+}
+
+// Release enqueues the deferred messages due at now, oldest first
+func (q *Queue) Release(now time.Time) int {
+	q.mu.Lock()
+	var due, pending []*contracts.Message
+	for _, msg := range q.deferred {
+		if msg.DeliverAt.After(now) {
+			pending = append(pending, msg)
+		} else {
+			due = append(due, msg)
+		}
+	}
+	q.deferred = pending
+	q.mu.Unlock()
+
+	sort.SliceStable(due, func(i, j int) bool { return due[i].DeliverAt.Before(due[j].DeliverAt) })
+
+	released := 0
+	for _, msg := range due {
+		if err := q.Enqueue(msg); err != nil {
+			q.logger.Warn().Err(err).Str("message_id", msg.ID).Msg("Deferred notification kept")
+			q.Defer(msg)
+			continue
+		}
+		released++
+	}
+	return released
+}
+
+// RunReleaser calls Release every interval until ctx is done
+func (q *Queue) RunReleaser(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			q.Release(now)
+		}
+	}
+}
+
+// Run starts the workers and blocks until ctx is done
+func (q *Queue) Run(ctx context.Context, workers int) {
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				msg, ok := q.Next(ctx)
+				if !ok {
+					return
+				}
+				q.Deliver(ctx, msg)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// Next returns the next message by priority, blocking until one is
+// available or ctx is done
+func (q *Queue) Next(ctx context.Context) (*contracts.Message, bool) {
+	for _, priority := range []contracts.Priority{contracts.PriorityHigh, contracts.PriorityNormal} {
+		select {
+		case msg := <-q.queues[priority]:
+			return msg, true
+		default:
+		}
+	}
+
+	select {
+	case msg := <-q.queues[contracts.PriorityHigh]:
+		return msg, true
+	case msg := <-q.queues[contracts.PriorityNormal]:
+		return msg, true
+	case msg := <-q.queues[contracts.PriorityLow]:
+		return msg, true
+	case <-ctx.Done():
+		return nil, false
+	}
+}
+
+func (q *Queue) Deliver(ctx context.Context, msg *contracts.Message) {
+	logger := q.logger.With().
+		Str("message_id", msg.ID).
+		Str("event_id", msg.EventID).
+		Str("channel", string(msg.Channel)).
+		Str("priority", string(msg.Priority)).
+		Logger()
+
+	if err := q.providers[msg.Channel].Send(ctx, msg); err != nil {
+		logger.Error().Err(err).Msg("Notification delivery failed")
+		return
+	}
+	logger.Info().Msg("Notification delivered")
+}
@@ -0,0 +1,76 @@
+package rules
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultDedupTTL is how long processed event IDs are remembered
+const DefaultDedupTTL = 24 * time.Hour
+
+// Deduplicator remembers processed event IDs so redelivered events do not
+// notify the customer twice
+type Deduplicator interface {
+	// Claim returns false when the event was already claimed
+	Claim(eventID string) bool
+	// Release forgets a claim so a failed event can be retried
+	Release(eventID string)
+}
+
+// MemoryDeduplicator remembers IDs in a map. Expired IDs are swept once there
+// have been as many claims as IDs were left by the previous sweep, which
+// keeps Claim amortised O(1) and the map within twice the IDs still inside
+// the TTL at the last sweep.
+type MemoryDeduplicator struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu         sync.Mutex
+	seen       map[string]time.Time
+	claims     int
+	sweepAfter int
+}
+
+func NewMemoryDeduplicator(ttl time.Duration) *MemoryDeduplicator {
+	return &MemoryDeduplicator{ttl: ttl, now: time.Now, seen: make(map[string]time.Time)}
+}
+
+func (d *MemoryDeduplicator) Claim(eventID string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	if d.claims++; d.claims >= d.sweepAfter {
+		d.sweep(now)
+	}
+
+	if expires, ok := d.seen[eventID]; ok && expires.After(now) {
+		return false
+	}
+	d.seen[eventID] = now.Add(d.ttl)
+	return true
+}
+
+// sweep drops expired IDs; d.mu must be held
+func (d *MemoryDeduplicator) sweep(now time.Time) {
+	for id, expires := range d.seen {
+		if !expires.After(now) {
+			delete(d.seen, id)
+		}
+	}
+	d.claims, d.sweepAfter = 0, len(d.seen)
+}
+
+// Len returns the number of remembered IDs, including expired IDs that were
+// not swept yet
+func (d *MemoryDeduplicator) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.seen)
+}
+
+func (d *MemoryDeduplicator) Release(eventID string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.seen, eventID)
+}
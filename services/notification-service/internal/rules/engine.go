@@ -0,0 +1,271 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/fintech-bank-platform/notification-service/internal/contracts"
+	"github.com/fintech-bank-platform/pkg/currency"
+	"github.com/fintech-bank-platform/pkg/events"
+	"github.com/rs/zerolog"
+)
+
+// DomainTopics are the topics the engine listens to
+var DomainTopics = []string{
+	events.Topics.AccountEvents,
+	events.Topics.TransactionEvents,
+	events.Topics.PaymentEvents,
+}
+
+var templateFuncs = template.FuncMap{
+	// money rounds to the minor units of the currency code, when given
+	// and known, or to two decimals
+	"money": func(value interface{}, code ...string) string {
+		amount, _ := toFloat(value)
+		unit := currency.Currency{MinorUnits: 2}
+		if len(code) > 0 {
+			if known, ok := currency.Lookup(code[0]); ok {
+				unit = known
+			}
+		}
+		return unit.Decimal(amount)
+	},
+}
+
+// Engine turns domain events into notification commands
+type Engine struct {
+	rules     map[string][]compiledRule
+	publisher events.Publisher
+	dedup     Deduplicator
+	dryRun    bool
+	logger    zerolog.Logger
+}
+
+type compiledRule struct {
+	Rule
+	notifications []compiledNotification
+}
+
+type compiledNotification struct {
+	Notification
+	fields map[string]*template.Template
+	data   map[string]*template.Template
+}
+
+type Option func(*Engine)
+
+// WithDryRun logs the decisions without publishing notifications
+func WithDryRun(dryRun bool) Option {
+	return func(e *Engine) {
+		e.dryRun = dryRun
+	}
+}
+
+func WithDeduplicator(dedup Deduplicator) Option {
+	return func(e *Engine) {
+		e.dedup = dedup
+	}
+}
+
+func NewEngine(cfg *Config, publisher events.Publisher, logger zerolog.Logger, opts ...Option) (*Engine, error) {
+	e := &Engine{
+		rules:     make(map[string][]compiledRule),
+		publisher: publisher,
+		dedup:     NewMemoryDeduplicator(DefaultDedupTTL),
+		logger:    logger,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	for _, rule := range cfg.Rules {
+		compiled, err := compile(rule)
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: %w", rule.Name, err)
+		}
+		e.rules[rule.Event] = append(e.rules[rule.Event], compiled)
+	}
+	return e, nil
+}
+
+func (e *Engine) Subscribe(subscriber events.Subscriber) {
+	for _, topic := range DomainTopics {
+		subscriber.Subscribe(topic, e.Handle)
+	}
+}
+
+func (e *Engine) Handle(ctx context.Context, event *events.Event) error {
+	rules := e.rules[event.Type]
+	if len(rules) == 0 {
+		return nil
+	}
+
+	logger := e.logger.With().Str("event_id", event.ID).Str("event_type", event.Type).Bool("dry_run", e.dryRun).Logger()
+
+	if !e.dedup.Claim(event.ID) {
+		logger.Info().Msg("Duplicate event ignored")
+		return nil
+	}
+
+	commands, err := e.evaluate(event, rules, logger)
+	if err == nil {
+		err = e.publish(ctx, commands)
+	}
+	if err != nil {
+		e.dedup.Release(event.ID)
+		return err
+	}
+	return nil
+}
+
+func (e *Engine) evaluate(event *events.Event, rules []compiledRule, logger zerolog.Logger) ([]*events.Event, error) {
+	var payload map[string]interface{}
+	if err := event.DecodePayload(&payload); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", event.Type, err)
+	}
+
+	var commands []*events.Event
+	for _, rule := range rules {
+		if !rule.Matches(payload) {
+			logger.Debug().Str("rule", rule.Name).Msg("Rule did not match")
+			continue
+		}
+
+		for _, notification := range rule.notifications {
+			command, err := notification.build(payload)
+			if err != nil {
+				return nil, fmt.Errorf("rule %s: %w", rule.Name, err)
+			}
+			command.WithMetadata("rule", rule.Name).WithMetadata("source_event_id", event.ID)
+			if event.TraceID != "" {
+				command.WithTraceID(event.TraceID)
+			}
+
+			logger.Info().
+				Str("rule", rule.Name).
+				Str("channel", string(notification.Channel)).
+				Str("template", notification.Template).
+				Msg("Notification decided")
+			commands = append(commands, command)
+		}
+	}
+
+	if e.dryRun {
+		return nil, nil
+	}
+	return commands, nil
+}
+
+func (e *Engine) publish(ctx context.Context, commands []*events.Event) error {
+	for _, command := range commands {
+		if err := e.publisher.Publish(ctx, events.Topics.NotificationEvents, command); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func compile(rule Rule) (compiledRule, error) {
+	compiled := compiledRule{Rule: rule}
+	for _, notification := range rule.Notifications {
+		fields := map[string]string{
+			"user_id": notification.UserID,
+			"to":      notification.To,
+			"subject": notification.Subject,
+			"body":    notification.Body,
+			"locale":  notification.Locale,
+		}
+
+		n := compiledNotification{
+			Notification: notification,
+			fields:       make(map[string]*template.Template),
+			data:         make(map[string]*template.Template),
+		}
+		for name, text := range fields {
+			tmpl, err := parse(name, text)
+			if err != nil {
+				return compiledRule{}, err
+			}
+			n.fields[name] = tmpl
+		}
+		for key, text := range notification.Data {
+			tmpl, err := parse(key, text)
+			if err != nil {
+				return compiledRule{}, err
+			}
+			n.data[key] = tmpl
+		}
+		compiled.notifications = append(compiled.notifications, n)
+	}
+	return compiled, nil
+}
+
+func parse(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+}
+
+func render(tmpl *template.Template, payload map[string]interface{}) (string, error) {
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, payload); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+func (n compiledNotification) build(payload map[string]interface{}) (*events.Event, error) {
+	fields := make(map[string]string, len(n.fields))
+	for name, tmpl := range n.fields {
+		value, err := render(tmpl, payload)
+		if err != nil {
+			return nil, err
+		}
+		fields[name] = value
+	}
+
+	var data map[string]string
+	if len(n.data) > 0 {
+		data = make(map[string]string, len(n.data))
+		for key, tmpl := range n.data {
+			value, err := render(tmpl, payload)
+			if err != nil {
+				return nil, err
+			}
+			data[key] = value
+		}
+	}
+
+	switch n.Channel {
+	case contracts.ChannelEmail:
+		return events.NewNotificationEvent(events.EventTypes.SendEmail, events.SendEmailPayload{
+			UserID:   fields["user_id"],
+			To:       fields["to"],
+			Subject:  fields["subject"],
+			Template: n.Template,
+			Locale:   fields["locale"],
+			Data:     data,
+			Priority: n.Priority,
+		}), nil
+	case contracts.ChannelSMS:
+		return events.NewNotificationEvent(events.EventTypes.SendSMS, events.SendSMSPayload{
+			UserID:   fields["user_id"],
+			To:       fields["to"],
+			Message:  fields["body"],
+			Template: n.Template,
+			Locale:   fields["locale"],
+			Data:     data,
+			Priority: n.Priority,
+		}), nil
+	default:
+		return events.NewNotificationEvent(events.EventTypes.SendPush, events.SendPushPayload{
+			UserID:   fields["user_id"],
+			Title:    fields["subject"],
+			Body:     fields["body"],
+			Template: n.Template,
+			Locale:   fields["locale"],
+			Data:     data,
+			Priority: n.Priority,
+		}), nil
+	}
+}
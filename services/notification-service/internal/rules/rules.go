@@ -0,0 +1,209 @@
+package rules
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/fintech-bank-platform/notification-service/internal/contracts"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed rules.yaml
+var defaultRules []byte
+
+var ErrInvalidRule = errors.New("invalid notification rule")
+
+// Operators supported in conditions
+const (
+	OpEq     = "eq"
+	OpNe     = "ne"
+	OpGt     = "gt"
+	OpGte    = "gte"
+	OpLt     = "lt"
+	OpLte    = "lte"
+	OpExists = "exists"
+)
+
+// Config is the YAML rules file
+type Config struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// Rule emits notifications for an event type when all conditions match
+type Rule struct {
+	Name          string         `yaml:"name"`
+	Event         string         `yaml:"event"`
+	When          []Condition    `yaml:"when"`
+	Notifications []Notification `yaml:"notifications"`
+}
+
+// Condition compares a payload field (dotted path) with a value
+type Condition struct {
+	Field string      `yaml:"field"`
+	Op    string      `yaml:"op"`
+	Value interface{} `yaml:"value"`
+}
+
+// Notification describes one SendEmail/SendSMS/SendPush command. Every
+// string field except Channel and Template is a Go template over the payload.
+type Notification struct {
+	Channel  contracts.Channel `yaml:"channel"`
+	Template string            `yaml:"template"`
+	UserID   string            `yaml:"user_id"`
+	To       string            `yaml:"to"`
+	Subject  string            `yaml:"subject"`
+	Body     string            `yaml:"body"`
+	Locale   string            `yaml:"locale"`
+	Priority string            `yaml:"priority"`
+	Data     map[string]string `yaml:"data"`
+}
+
+// Default returns the rules bundled with the service
+func Default() (*Config, error) {
+	return Load(strings.NewReader(string(defaultRules)))
+}
+
+func LoadFile(path string) (*Config, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Load(file)
+}
+
+func Load(r io.Reader) (*Config, error) {
+	var cfg Config
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("parse rules: %w", err)
+	}
+
+	for i, rule := range cfg.Rules {
+		if err := rule.validate(); err != nil {
+			return nil, fmt.Errorf("rule %d (%s): %w", i, rule.Name, err)
+		}
+	}
+	return &cfg, nil
+}
+
+func (r Rule) validate() error {
+	if r.Name == "" || r.Event == "" {
+		return fmt.Errorf("%w: name and event are required", ErrInvalidRule)
+	}
+	if len(r.Notifications) == 0 {
+		return fmt.Errorf("%w: at least one notification is required", ErrInvalidRule)
+	}
+
+	for _, condition := range r.When {
+		switch condition.Op {
+		case OpEq, OpNe, OpExists:
+		case OpGt, OpGte, OpLt, OpLte:
+			if _, ok := toFloat(condition.Value); !ok {
+				return fmt.Errorf("%w: %s needs a numeric value", ErrInvalidRule, condition.Op)
+			}
+		default:
+			return fmt.Errorf("%w: unknown operator %q", ErrInvalidRule, condition.Op)
+		}
+		if condition.Field == "" {
+			return fmt.Errorf("%w: condition without field", ErrInvalidRule)
+		}
+	}
+
+	for _, notification := range r.Notifications {
+		switch notification.Channel {
+		case contracts.ChannelEmail:
+			if notification.Template == "" {
+				return fmt.Errorf("%w: email notifications require a template", ErrInvalidRule)
+			}
+		case contracts.ChannelSMS, contracts.ChannelPush:
+		default:
+			return fmt.Errorf("%w: unknown channel %q", ErrInvalidRule, notification.Channel)
+		}
+	}
+	return nil
+}
+
+// Matches reports whether every condition holds for the payload
+func (r Rule) Matches(payload map[string]interface{}) bool {
+	for _, condition := range r.When {
+		if !condition.matches(payload) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c Condition) matches(payload map[string]interface{}) bool {
+	actual, found := lookup(payload, c.Field)
+
+	switch c.Op {
+	case OpExists:
+		want, _ := c.Value.(bool)
+		return found == (c.Value == nil || want)
+	case OpEq:
+		return found && equal(actual, c.Value)
+	case OpNe:
+		return !found || !equal(actual, c.Value)
+	}
+
+	left, ok := toFloat(actual)
+	if !found || !ok {
+		return false
+	}
+	right, _ := toFloat(c.Value)
+
+	switch c.Op {
+	case OpGt:
+		return left > right
+	case OpGte:
+		return left >= right
+	case OpLt:
+		return left < right
+	default:
+		return left <= right
+	}
+}
+
+func lookup(payload map[string]interface{}, path string) (interface{}, bool) {
+	var current interface{} = payload
+	for _, key := range strings.Split(path, ".") {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = object[key]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// equal compares JSON numbers numerically and everything else as text
+func equal(actual, expected interface{}) bool {
+	if left, ok := actual.(float64); ok {
+		right, numeric := toFloat(expected)
+		return numeric && left == right
+	}
+	return fmt.Sprint(actual) == fmt.Sprint(expected)
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,149 @@
+package templates
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"html"
+	htmltemplate "html/template"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/fintech-bank-platform/notification-service/internal/contracts"
+)
+
+//go:embed files
+var embedded embed.FS
+
+var ErrTemplateNotFound = errors.New("template not found")
+
+type Rendered struct {
+	Subject string
+	Body    string
+}
+
+// Renderer renders <locale>/<name>.<channel>.tmpl files. A template may
+// define "subject" (email subject or push title) and "body"; without a
+// "body" block the whole file is the body. Email bodies are HTML-escaped.
+type Renderer struct {
+	fsys          fs.FS
+	defaultLocale string
+}
+
+func New(cfg contracts.TemplateConfig) (*Renderer, error) {
+	if cfg.Dir == "" {
+		files, _ := fs.Sub(embedded, "files")
+		return NewFromFS(files, cfg.DefaultLocale), nil
+	}
+
+	info, err := os.Stat(cfg.Dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("templates dir %s is not a directory", cfg.Dir)
+	}
+	return NewFromFS(os.DirFS(cfg.Dir), cfg.DefaultLocale), nil
+}
+
+func NewFromFS(fsys fs.FS, defaultLocale string) *Renderer {
+	return &Renderer{fsys: fsys, defaultLocale: defaultLocale}
+}
+
+func (r *Renderer) DefaultLocale() string {
+	return r.defaultLocale
+}
+
+// Render falls back to the default locale when the requested one has no template
+func (r *Renderer) Render(channel contracts.Channel, name, locale string, data map[string]string) (*Rendered, error) {
+	content, err := r.load(channel, name, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := parse(channel, name, content)
+	if err != nil {
+		return nil, err
+	}
+
+	rendered := &Rendered{}
+	if subject := tmpl.lookup("subject"); subject != nil {
+		if rendered.Subject, err = execute(subject, data); err != nil {
+			return nil, err
+		}
+		// Subjects and titles are plain text even when the body is HTML
+		rendered.Subject = html.UnescapeString(rendered.Subject)
+	}
+
+	bodyTemplate := tmpl.lookup("body")
+	if bodyTemplate == nil {
+		bodyTemplate = tmpl.root
+	}
+	if rendered.Body, err = execute(bodyTemplate, data); err != nil {
+		return nil, err
+	}
+	return rendered, nil
+}
+
+func (r *Renderer) load(channel contracts.Channel, name, locale string) (string, error) {
+	if strings.ContainsAny(name, `/\`) || strings.Contains(name, "..") {
+		return "", fmt.Errorf("%w: %s", ErrTemplateNotFound, name)
+	}
+
+	for _, candidate := range []string{locale, r.defaultLocale} {
+		if candidate == "" {
+			continue
+		}
+		content, err := fs.ReadFile(r.fsys, fmt.Sprintf("%s/%s.%s.tmpl", candidate, name, channel))
+		if err == nil {
+			return string(content), nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s.%s (%s)", ErrTemplateNotFound, name, channel, locale)
+}
+
+type executor interface {
+	Execute(w io.Writer, data any) error
+}
+
+type parsed struct {
+	root   executor
+	lookup func(name string) executor
+}
+
+func parse(channel contracts.Channel, name, content string) (*parsed, error) {
+	if channel == contracts.ChannelEmail {
+		tmpl, err := htmltemplate.New(name).Option("missingkey=zero").Parse(content)
+		if err != nil {
+			return nil, err
+		}
+		return &parsed{root: tmpl, lookup: func(name string) executor {
+			if block := tmpl.Lookup(name); block != nil {
+				return block
+			}
+			return nil
+		}}, nil
+	}
+
+	tmpl, err := texttemplate.New(name).Option("missingkey=zero").Parse(content)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed{root: tmpl, lookup: func(name string) executor {
+		if block := tmpl.Lookup(name); block != nil {
+			return block
+		}
+		return nil
+	}}, nil
+}
+
+func execute(tmpl executor, data map[string]string) (string, error) {
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(sb.String()), nil
+}
@@ -0,0 +1,125 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Feature Test: Notification Flow (event → template → queue → provider)
+// ═══════════════════════════════════════════════════════════════════════════
+
+package feature
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fintech-bank-platform/notification-service/internal/contracts"
+	"github.com/fintech-bank-platform/notification-service/internal/infrastructure/channels"
+	"github.com/fintech-bank-platform/notification-service/internal/notification"
+	"github.com/fintech-bank-platform/notification-service/internal/preferences"
+	"github.com/fintech-bank-platform/notification-service/internal/queue"
+	"github.com/fintech-bank-platform/notification-service/internal/rules"
+	"github.com/fintech-bank-platform/notification-service/internal/templates"
+	"github.com/fintech-bank-platform/notification-service/tests"
+	"github.com/fintech-bank-platform/pkg/events"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestNotificationEventsAreDelivered(t *testing.T) {
+	sink, err := tests.NewSMTPSink()
+	require.NoError(t, err)
+	defer sink.Close()
+
+	var sms, push syncBuffer
+	renderer, err := templates.New(contracts.TemplateConfig{DefaultLocale: "pt-BR"})
+	require.NoError(t, err)
+
+	q := queue.New([]contracts.Provider{
+		channels.NewSMTPProvider(sink.Config("no-reply@fintech.local"), nil),
+		channels.NewWriterProvider(contracts.ChannelSMS, &sms),
+		channels.NewWriterProvider(contracts.ChannelPush, &push),
+	}, 10, zerolog.Nop())
+
+	store := preferences.NewMemoryStore()
+	store.Set("user-en", &contracts.Preferences{Locale: "en"})
+
+	bus := events.NewMemoryBus()
+	notification.NewService(renderer, store, q, zerolog.Nop()).Subscribe(bus)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go q.Run(ctx, 2)
+
+	publish := func(eventType string, payload interface{}) {
+		require.NoError(t, bus.Publish(ctx, events.Topics.NotificationEvents, events.NewNotificationEvent(eventType, payload)))
+	}
+	publish(events.EventTypes.SendEmail, events.SendEmailPayload{
+		To: "ana@example.com", Template: "welcome", Data: map[string]string{"name": "Ana"},
+	})
+	publish(events.EventTypes.SendSMS, events.SendSMSPayload{
+		UserID: "user-en", To: "11999887766", Template: "otp", Data: map[string]string{"code": "777777"}, Priority: "high",
+	})
+	publish(events.EventTypes.SendPush, events.SendPushPayload{
+		UserID: "user-1", Template: "security_alert", Data: map[string]string{"device": "Pixel"},
+	})
+
+	assert.Eventually(t, func() bool {
+		return len(sink.Mails()) == 1 && sms.String() != "" && push.String() != ""
+	}, 2*time.Second, 10*time.Millisecond)
+
+	assert.Contains(t, sink.Mails()[0], "<p>Olá, Ana!</p>")
+	assert.Contains(t, sms.String(), "Your verification code is 777777")
+	assert.True(t, strings.Contains(push.String(), "Alerta de segurança"))
+}
+
+func TestDomainEventsBecomeCustomerNotifications(t *testing.T) {
+	var push syncBuffer
+	renderer, err := templates.New(contracts.TemplateConfig{DefaultLocale: "pt-BR"})
+	require.NoError(t, err)
+	q := queue.New([]contracts.Provider{channels.NewWriterProvider(contracts.ChannelPush, &push)}, 10, zerolog.Nop())
+
+	bus := events.NewMemoryBus()
+	ruleSet, err := rules.Default()
+	require.NoError(t, err)
+	engine, err := rules.NewEngine(ruleSet, bus, zerolog.Nop())
+	require.NoError(t, err)
+	engine.Subscribe(bus)
+	notification.NewService(renderer, preferences.NewMemoryStore(), q, zerolog.Nop()).Subscribe(bus)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go q.Run(ctx, 1)
+
+	require.NoError(t, bus.Publish(ctx, events.Topics.TransactionEvents, events.NewTransactionEvent(events.EventTypes.TransferCompleted, events.TransferCompletedPayload{
+		TransferID:    "transfer-1",
+		FromAccountID: "acc-from",
+		ToAccountID:   "acc-to",
+		Amount:        250,
+		Currency:      "BRL",
+	})))
+
+	assert.Eventually(t, func() bool {
+		return strings.Count(push.String(), "\n") == 2
+	}, 2*time.Second, 10*time.Millisecond)
+
+	assert.Contains(t, push.String(), "Você enviou BRL 250.00.")
+	assert.Contains(t, push.String(), "Você recebeu BRL 250.00.")
+}
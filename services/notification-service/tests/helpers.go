@@ -0,0 +1,149 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Test Helpers - Fakes shared by unit and feature tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package tests
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/fintech-bank-platform/notification-service/internal/contracts"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════
+// Recording Provider
+// ═══════════════════════════════════════════════════════════════════════════
+
+type RecordingProvider struct {
+	channel contracts.Channel
+	err     error
+
+	mu       sync.Mutex
+	messages []*contracts.Message
+	sent     chan *contracts.Message
+}
+
+func NewRecordingProvider(channel contracts.Channel) *RecordingProvider {
+	return &RecordingProvider{channel: channel, sent: make(chan *contracts.Message, 100)}
+}
+
+func NewFailingProvider(channel contracts.Channel, err error) *RecordingProvider {
+	provider := NewRecordingProvider(channel)
+	provider.err = err
+	return provider
+}
+
+func (p *RecordingProvider) Channel() contracts.Channel {
+	return p.channel
+}
+
+func (p *RecordingProvider) Send(ctx context.Context, msg *contracts.Message) error {
+	p.mu.Lock()
+	p.messages = append(p.messages, msg)
+	p.mu.Unlock()
+	p.sent <- msg
+	return p.err
+}
+
+func (p *RecordingProvider) Messages() []*contracts.Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]*contracts.Message(nil), p.messages...)
+}
+
+// Sent delivers every message as it is sent
+func (p *RecordingProvider) Sent() <-chan *contracts.Message {
+	return p.sent
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// SMTP Sink
+// ═══════════════════════════════════════════════════════════════════════════
+
+// SMTPSink is a minimal SMTP server that records the DATA of each mail
+type SMTPSink struct {
+	listener net.Listener
+
+	mu    sync.Mutex
+	mails []string
+}
+
+func NewSMTPSink() (*SMTPSink, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	sink := &SMTPSink{listener: listener}
+	go sink.serve()
+	return sink, nil
+}
+
+func (s *SMTPSink) Config(from string) contracts.SMTPConfig {
+	host, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	return contracts.SMTPConfig{Host: host, Port: port, From: from}
+}
+
+func (s *SMTPSink) Mails() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.mails...)
+}
+
+func (s *SMTPSink) Close() error {
+	return s.listener.Close()
+}
+
+func (s *SMTPSink) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.handle(conn)
+	}
+}
+
+func (s *SMTPSink) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 sink ready")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		switch command := strings.ToUpper(strings.TrimSpace(line)); {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250 sink")
+		case command == "DATA":
+			reply("354 end with .")
+			var data strings.Builder
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil || dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(dataLine)
+			}
+			s.mu.Lock()
+			s.mails = append(s.mails, data.String())
+			s.mu.Unlock()
+			reply("250 queued")
+		case command == "QUIT":
+			reply("221 bye")
+			return
+		case strings.HasPrefix(command, "RCPT") && strings.Contains(command, "REJECT"):
+			reply("550 rejected")
+		default:
+			reply("250 ok")
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Unit Test: Channel Providers
+// ═══════════════════════════════════════════════════════════════════════════
+
+package unit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fintech-bank-platform/notification-service/internal/contracts"
+	"github.com/fintech-bank-platform/notification-service/internal/infrastructure/channels"
+	"github.com/fintech-bank-platform/notification-service/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriterProviderWritesJSONLines(t *testing.T) {
+	var buf bytes.Buffer
+	provider := channels.NewWriterProvider(contracts.ChannelSMS, &buf)
+
+	require.NoError(t, provider.Send(context.Background(), &contracts.Message{ID: "1", To: "11999887766", Body: "hi"}))
+	require.NoError(t, provider.Send(context.Background(), &contracts.Message{ID: "2", To: "11999887766", Body: "bye"}))
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+	var msg contracts.Message
+	require.NoError(t, json.Unmarshal(lines[1], &msg))
+	assert.Equal(t, "bye", msg.Body)
+	assert.Equal(t, contracts.ChannelSMS, provider.Channel())
+}
+
+func TestOutputProviderStdout(t *testing.T) {
+	provider, err := channels.NewOutputProvider(contracts.ChannelPush, "stdout")
+
+	require.NoError(t, err)
+	assert.Equal(t, contracts.ChannelPush, provider.Channel())
+}
+
+func TestOutputProviderAppendsToFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "push.log")
+	provider, err := channels.NewOutputProvider(contracts.ChannelPush, path)
+	require.NoError(t, err)
+
+	require.NoError(t, provider.Send(context.Background(), &contracts.Message{ID: "1", Body: "hi"}))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `"id":"1"`)
+}
+
+func TestOutputProviderInvalidPath(t *testing.T) {
+	_, err := channels.NewOutputProvider(contracts.ChannelSMS, filepath.Join(t.TempDir(), "missing", "sms.log"))
+
+	assert.Error(t, err)
+}
+
+func TestSMTPProviderSendsHTMLMail(t *testing.T) {
+	sink, err := tests.NewSMTPSink()
+	require.NoError(t, err)
+	defer sink.Close()
+	provider := channels.NewSMTPProvider(sink.Config("bank@example.com"), nil)
+
+	err = provider.Send(context.Background(), &contracts.Message{
+		ID:      "msg-1",
+		To:      "ana@example.com",
+		Subject: "Transferência recebida",
+		Body:    "<p>Olá</p>",
+	})
+
+	require.NoError(t, err)
+	require.Len(t, sink.Mails(), 1)
+	mail := sink.Mails()[0]
+	assert.Contains(t, mail, "From: bank@example.com")
+	assert.Contains(t, mail, "To: ana@example.com")
+	assert.Contains(t, mail, "Subject: =?utf-8?q?Transfer=C3=AAncia_recebida?=")
+	assert.Contains(t, mail, "Content-Type: text/html; charset=UTF-8")
+	assert.Contains(t, mail, "X-Notification-ID: msg-1")
+	assert.Contains(t, mail, "<p>Olá</p>")
+	assert.Equal(t, contracts.ChannelEmail, provider.Channel())
+}
+
+func TestSMTPProviderReturnsServerErrors(t *testing.T) {
+	sink, err := tests.NewSMTPSink()
+	require.NoError(t, err)
+	defer sink.Close()
+	provider := channels.NewSMTPProvider(sink.Config("bank@example.com"), nil)
+
+	err = provider.Send(context.Background(), &contracts.Message{To: "reject@example.com"})
+
+	assert.Error(t, err)
+	assert.Empty(t, sink.Mails())
+}
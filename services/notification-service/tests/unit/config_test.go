@@ -0,0 +1,87 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Unit Test: Config
+// ═══════════════════════════════════════════════════════════════════════════
+
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fintech-bank-platform/notification-service/internal/config"
+	pkgconfig "github.com/fintech-bank-platform/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigDefaults(t *testing.T) {
+	cfg, err := config.New()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "localhost:1025", cfg.SMTP.Address())
+	assert.Equal(t, "stdout", cfg.Channels.SMSOutput)
+	assert.Equal(t, "stdout", cfg.Channels.PushOutput)
+	assert.Equal(t, "", cfg.Templates.Dir)
+	assert.Equal(t, "pt-BR", cfg.Templates.DefaultLocale)
+	assert.Equal(t, 1000, cfg.Queue.Size)
+	assert.Equal(t, 4, cfg.Queue.Workers)
+	assert.Equal(t, time.Minute, cfg.Queue.ReleaseInterval)
+}
+
+func TestConfigWithEnvVars(t *testing.T) {
+	t.Setenv("SMTP_HOST", "mailpit")
+	t.Setenv("SMTP_PORT", "2525")
+	t.Setenv("SMTP_FROM", "bank@example.com")
+	t.Setenv("SMS_OUTPUT", "/tmp/sms.log")
+	t.Setenv("DEFAULT_LOCALE", "en")
+	t.Setenv("QUEUE_SIZE", "10")
+	t.Setenv("QUEUE_RELEASE_INTERVAL", "30s")
+
+	cfg, _ := config.New()
+
+	assert.Equal(t, "mailpit:2525", cfg.SMTP.Address())
+	assert.Equal(t, "bank@example.com", cfg.SMTP.From)
+	assert.Equal(t, "/tmp/sms.log", cfg.Channels.SMSOutput)
+	assert.Equal(t, "en", cfg.Templates.DefaultLocale)
+	assert.Equal(t, 10, cfg.Queue.Size)
+	assert.Equal(t, 30*time.Second, cfg.Queue.ReleaseInterval)
+}
+
+func TestConfigReportsInvalidValues(t *testing.T) {
+	t.Setenv("QUEUE_WORKERS", "many")
+	t.Setenv("QUEUE_RELEASE_INTERVAL", "soon")
+	t.Setenv("RULES_DRY_RUN", "maybe")
+	t.Setenv("SMTP_FROM", "bank")
+
+	cfg, err := config.New()
+
+	assert.Nil(t, cfg)
+	var configErr *pkgconfig.Error
+	require.ErrorAs(t, err, &configErr)
+	assert.Equal(t, []string{
+		`QUEUE_WORKERS: invalid integer "many" (from environment)`,
+		`QUEUE_RELEASE_INTERVAL: invalid duration "soon" (from environment)`,
+		`RULES_DRY_RUN: invalid boolean "maybe" (from environment)`,
+		"SMTP_FROM: must be a valid email address",
+	}, configErr.Problems)
+}
+
+func TestConfigRules(t *testing.T) {
+	t.Setenv("RULES_FILE", "/etc/notification/rules.yaml")
+	t.Setenv("RULES_DRY_RUN", "true")
+	t.Setenv("RULES_DEDUP_TTL", "1h")
+
+	cfg, _ := config.New()
+
+	assert.Equal(t, "/etc/notification/rules.yaml", cfg.Rules.File)
+	assert.True(t, cfg.Rules.DryRun)
+	assert.Equal(t, time.Hour, cfg.Rules.DedupTTL)
+}
+
+func TestConfigRulesDefaults(t *testing.T) {
+	cfg, _ := config.New()
+
+	assert.Empty(t, cfg.Rules.File)
+	assert.False(t, cfg.Rules.DryRun)
+	assert.Equal(t, 24*time.Hour, cfg.Rules.DedupTTL)
+}
@@ -0,0 +1,74 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Unit Test: Contracts
+// ═══════════════════════════════════════════════════════════════════════════
+
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fintech-bank-platform/notification-service/internal/contracts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var saoPaulo = time.FixedZone("BRT", -3*60*60)
+
+func TestParsePriority(t *testing.T) {
+	assert.Equal(t, contracts.PriorityHigh, contracts.ParsePriority("high"))
+	assert.Equal(t, contracts.PriorityLow, contracts.ParsePriority("low"))
+	assert.Equal(t, contracts.PriorityNormal, contracts.ParsePriority("normal"))
+	assert.Equal(t, contracts.PriorityNormal, contracts.ParsePriority(""))
+	assert.Equal(t, contracts.PriorityNormal, contracts.ParsePriority("urgent"))
+}
+
+func TestParseQuietHours(t *testing.T) {
+	quiet, err := contracts.ParseQuietHours("22:00", "07:30", saoPaulo)
+
+	require.NoError(t, err)
+	assert.Equal(t, 22*60, quiet.Start)
+	assert.Equal(t, 7*60+30, quiet.End)
+
+	_, err = contracts.ParseQuietHours("25:00", "07:00", saoPaulo)
+	assert.Error(t, err)
+	_, err = contracts.ParseQuietHours("22:00", "7h", saoPaulo)
+	assert.Error(t, err)
+}
+
+func TestQuietHoursWrappingMidnight(t *testing.T) {
+	quiet, _ := contracts.ParseQuietHours("22:00", "07:00", saoPaulo)
+
+	assert.True(t, quiet.Contains(time.Date(2026, 3, 10, 23, 0, 0, 0, saoPaulo)))
+	assert.True(t, quiet.Contains(time.Date(2026, 3, 10, 6, 59, 0, 0, saoPaulo)))
+	assert.False(t, quiet.Contains(time.Date(2026, 3, 10, 7, 0, 0, 0, saoPaulo)))
+	assert.False(t, quiet.Contains(time.Date(2026, 3, 10, 12, 0, 0, 0, saoPaulo)))
+
+	// 01:00 UTC is 22:00 in São Paulo
+	assert.True(t, quiet.Contains(time.Date(2026, 3, 11, 1, 0, 0, 0, time.UTC)))
+}
+
+func TestQuietHoursSameDay(t *testing.T) {
+	quiet, _ := contracts.ParseQuietHours("12:00", "14:00", nil)
+
+	assert.True(t, quiet.Contains(time.Date(2026, 3, 10, 13, 0, 0, 0, time.UTC)))
+	assert.False(t, quiet.Contains(time.Date(2026, 3, 10, 14, 0, 0, 0, time.UTC)))
+	assert.False(t, quiet.Contains(time.Date(2026, 3, 10, 11, 59, 0, 0, time.UTC)))
+}
+
+func TestQuietHoursEndAfter(t *testing.T) {
+	quiet, _ := contracts.ParseQuietHours("22:00", "07:00", saoPaulo)
+
+	lateNight := time.Date(2026, 3, 10, 23, 0, 0, 0, saoPaulo)
+	earlyMorning := time.Date(2026, 3, 11, 5, 0, 0, 0, saoPaulo)
+
+	assert.True(t, time.Date(2026, 3, 11, 7, 0, 0, 0, saoPaulo).Equal(quiet.EndAfter(lateNight)))
+	assert.True(t, time.Date(2026, 3, 11, 7, 0, 0, 0, saoPaulo).Equal(quiet.EndAfter(earlyMorning)))
+}
+
+func TestPreferencesAllows(t *testing.T) {
+	prefs := &contracts.Preferences{Disabled: []contracts.Channel{contracts.ChannelSMS}}
+
+	assert.True(t, prefs.Allows(contracts.ChannelEmail))
+	assert.False(t, prefs.Allows(contracts.ChannelSMS))
+}
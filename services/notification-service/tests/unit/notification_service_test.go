@@ -0,0 +1,256 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Unit Test: Notification Service
+// ═══════════════════════════════════════════════════════════════════════════
+
+package unit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fintech-bank-platform/notification-service/internal/contracts"
+	"github.com/fintech-bank-platform/notification-service/internal/notification"
+	"github.com/fintech-bank-platform/notification-service/internal/preferences"
+	"github.com/fintech-bank-platform/notification-service/internal/queue"
+	"github.com/fintech-bank-platform/notification-service/internal/templates"
+	"github.com/fintech-bank-platform/notification-service/tests"
+	"github.com/fintech-bank-platform/pkg/events"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var noon = time.Date(2026, 3, 10, 12, 0, 0, 0, saoPaulo)
+
+type serviceHarness struct {
+	service     *notification.Service
+	queue       *queue.Queue
+	preferences *preferences.MemoryStore
+}
+
+func newServiceHarness(t *testing.T, now time.Time) *serviceHarness {
+	renderer, err := templates.New(contracts.TemplateConfig{DefaultLocale: "pt-BR"})
+	require.NoError(t, err)
+
+	h := &serviceHarness{
+		queue: queue.New([]contracts.Provider{
+			tests.NewRecordingProvider(contracts.ChannelEmail),
+			tests.NewRecordingProvider(contracts.ChannelSMS),
+			tests.NewRecordingProvider(contracts.ChannelPush),
+		}, 10, zerolog.Nop()),
+		preferences: preferences.NewMemoryStore(),
+	}
+	h.service = notification.NewService(renderer, h.preferences, h.queue, zerolog.Nop(),
+		notification.WithClock(func() time.Time { return now }))
+	return h
+}
+
+func (h *serviceHarness) next(t *testing.T) *contracts.Message {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	msg, ok := h.queue.Next(ctx)
+	require.True(t, ok, "expected a queued message")
+	return msg
+}
+
+func TestServiceRendersEmailTemplate(t *testing.T) {
+	h := newServiceHarness(t, noon)
+	event := events.NewNotificationEvent(events.EventTypes.SendEmail, events.SendEmailPayload{
+		To:       "ana@example.com",
+		Subject:  "ignored",
+		Template: "welcome",
+		Data:     map[string]string{"name": "Ana"},
+		Priority: "low",
+	})
+
+	require.NoError(t, h.service.Handle(context.Background(), event))
+
+	msg := h.next(t)
+	assert.Equal(t, contracts.ChannelEmail, msg.Channel)
+	assert.Equal(t, contracts.PriorityLow, msg.Priority)
+	assert.Equal(t, event.ID, msg.EventID)
+	assert.NotEmpty(t, msg.ID)
+	assert.Equal(t, "pt-BR", msg.Locale)
+	assert.Equal(t, "Bem-vindo(a), Ana!", msg.Subject)
+	assert.Contains(t, msg.Body, "Ana")
+}
+
+func TestServiceUsesPreferredLocale(t *testing.T) {
+	h := newServiceHarness(t, noon)
+	h.preferences.Set("user-1", &contracts.Preferences{Locale: "en"})
+
+	event := events.NewNotificationEvent(events.EventTypes.SendSMS, events.SendSMSPayload{
+		UserID:   "user-1",
+		To:       "11999887766",
+		Template: "otp",
+		Data:     map[string]string{"code": "654321"},
+	})
+
+	require.NoError(t, h.service.Handle(context.Background(), event))
+
+	msg := h.next(t)
+	assert.Equal(t, "en", msg.Locale)
+	assert.Equal(t, "Your verification code is 654321. Do not share it with anyone.", msg.Body)
+}
+
+func TestServicePayloadLocaleWins(t *testing.T) {
+	h := newServiceHarness(t, noon)
+	h.preferences.Set("user-1", &contracts.Preferences{Locale: "en"})
+
+	event := events.NewNotificationEvent(events.EventTypes.SendPush, events.SendPushPayload{
+		UserID:   "user-1",
+		Template: "security_alert",
+		Locale:   "pt-BR",
+		Data:     map[string]string{"device": "iPhone"},
+	})
+
+	require.NoError(t, h.service.Handle(context.Background(), event))
+
+	msg := h.next(t)
+	assert.Equal(t, "Alerta de segurança", msg.Subject)
+	assert.Equal(t, "user-1", msg.To)
+}
+
+func TestServiceSendsPlainMessagesWithoutTemplate(t *testing.T) {
+	h := newServiceHarness(t, noon)
+
+	require.NoError(t, h.service.Handle(context.Background(), events.NewNotificationEvent(events.EventTypes.SendPush, events.SendPushPayload{
+		UserID: "user-1",
+		Title:  "Olá",
+		Body:   "Mensagem",
+		Data:   map[string]string{"screen": "home"},
+	})))
+	require.NoError(t, h.service.Handle(context.Background(), events.NewNotificationEvent(events.EventTypes.SendSMS, events.SendSMSPayload{
+		To:      "11999887766",
+		Message: "Código 1234",
+	})))
+
+	push := h.next(t)
+	assert.Equal(t, "Olá", push.Subject)
+	assert.Equal(t, "Mensagem", push.Body)
+	assert.Equal(t, "home", push.Data["screen"])
+	assert.Equal(t, "Código 1234", h.next(t).Body)
+}
+
+func TestServiceSkipsDisabledChannels(t *testing.T) {
+	h := newServiceHarness(t, noon)
+	h.preferences.Set("user-1", &contracts.Preferences{Disabled: []contracts.Channel{contracts.ChannelSMS}})
+
+	err := h.service.Handle(context.Background(), events.NewNotificationEvent(events.EventTypes.SendSMS, events.SendSMSPayload{
+		UserID:  "user-1",
+		To:      "11999887766",
+		Message: "promo",
+	}))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, h.queue.Len(contracts.PriorityNormal))
+}
+
+func TestServiceDefersDuringQuietHours(t *testing.T) {
+	night := time.Date(2026, 3, 10, 23, 30, 0, 0, saoPaulo)
+	h := newServiceHarness(t, night)
+	quiet, _ := contracts.ParseQuietHours("22:00", "07:00", saoPaulo)
+	h.preferences.Set("user-1", &contracts.Preferences{QuietHours: quiet})
+
+	err := h.service.Handle(context.Background(), events.NewNotificationEvent(events.EventTypes.SendPush, events.SendPushPayload{
+		UserID: "user-1",
+		Title:  "Novidade",
+		Body:   "Confira",
+	}))
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, h.queue.Len(contracts.PriorityNormal))
+	assert.Equal(t, 1, h.queue.Deferred())
+
+	assert.Equal(t, 0, h.queue.Release(night))
+	assert.Equal(t, 1, h.queue.Release(time.Date(2026, 3, 11, 7, 0, 0, 0, saoPaulo)))
+	assert.Equal(t, "Novidade", h.next(t).Subject)
+}
+
+func TestServiceHighPriorityIgnoresQuietHours(t *testing.T) {
+	h := newServiceHarness(t, time.Date(2026, 3, 10, 23, 30, 0, 0, saoPaulo))
+	quiet, _ := contracts.ParseQuietHours("22:00", "07:00", saoPaulo)
+	h.preferences.Set("user-1", &contracts.Preferences{QuietHours: quiet})
+
+	err := h.service.Handle(context.Background(), events.NewNotificationEvent(events.EventTypes.SendSMS, events.SendSMSPayload{
+		UserID:   "user-1",
+		To:       "11999887766",
+		Message:  "Código 1234",
+		Priority: "high",
+	}))
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, h.queue.Len(contracts.PriorityHigh))
+	assert.Equal(t, 0, h.queue.Deferred())
+}
+
+func TestServiceDecodesJSONEvents(t *testing.T) {
+	h := newServiceHarness(t, noon)
+	data, _ := events.NewNotificationEvent(events.EventTypes.SendSMS, events.SendSMSPayload{To: "11999887766", Message: "hi"}).ToJSON()
+	event, _ := events.FromJSON(data)
+
+	require.NoError(t, h.service.Handle(context.Background(), event))
+
+	assert.Equal(t, "hi", h.next(t).Body)
+}
+
+func TestServiceIgnoresOtherEventTypes(t *testing.T) {
+	h := newServiceHarness(t, noon)
+
+	err := h.service.Handle(context.Background(), events.NewAccountEvent(events.EventTypes.AccountCreated, nil))
+
+	assert.NoError(t, err)
+}
+
+func TestServiceErrors(t *testing.T) {
+	cases := map[string]*events.Event{
+		"invalid email payload":  events.NewNotificationEvent(events.EventTypes.SendEmail, "text"),
+		"invalid sms payload":    events.NewNotificationEvent(events.EventTypes.SendSMS, "text"),
+		"invalid push payload":   events.NewNotificationEvent(events.EventTypes.SendPush, "text"),
+		"email without template": events.NewNotificationEvent(events.EventTypes.SendEmail, events.SendEmailPayload{To: "ana@example.com"}),
+		"unknown template":       events.NewNotificationEvent(events.EventTypes.SendSMS, events.SendSMSPayload{To: "1", Template: "missing"}),
+	}
+
+	for name, event := range cases {
+		h := newServiceHarness(t, noon)
+		assert.Error(t, h.service.Handle(context.Background(), event), name)
+	}
+}
+
+func TestServiceReturnsQueueErrors(t *testing.T) {
+	renderer, _ := templates.New(contracts.TemplateConfig{DefaultLocale: "pt-BR"})
+	service := notification.NewService(renderer, preferences.NewMemoryStore(), queue.New(nil, 1, zerolog.Nop()), zerolog.Nop())
+
+	err := service.Handle(context.Background(), events.NewNotificationEvent(events.EventTypes.SendSMS, events.SendSMSPayload{To: "1", Message: "hi"}))
+
+	assert.ErrorIs(t, err, queue.ErrNoProvider)
+}
+
+type failingPreferences struct{}
+
+func (failingPreferences) Get(ctx context.Context, userID string) (*contracts.Preferences, error) {
+	return nil, errors.New("preferences unavailable")
+}
+
+func TestServiceReturnsPreferenceErrors(t *testing.T) {
+	renderer, _ := templates.New(contracts.TemplateConfig{DefaultLocale: "pt-BR"})
+	service := notification.NewService(renderer, failingPreferences{}, queue.New(nil, 1, zerolog.Nop()), zerolog.Nop())
+
+	err := service.Handle(context.Background(), events.NewNotificationEvent(events.EventTypes.SendSMS, events.SendSMSPayload{UserID: "user-1", To: "1"}))
+
+	assert.EqualError(t, err, "preferences unavailable")
+}
+
+func TestServiceSubscribesToNotificationTopic(t *testing.T) {
+	h := newServiceHarness(t, noon)
+	bus := events.NewMemoryBus()
+	h.service.Subscribe(bus)
+
+	err := bus.Publish(context.Background(), events.Topics.NotificationEvents,
+		events.NewNotificationEvent(events.EventTypes.SendSMS, events.SendSMSPayload{To: "1", Message: "hi"}))
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, h.queue.Len(contracts.PriorityNormal))
+}
@@ -0,0 +1,27 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Unit Test: Preferences
+// ═══════════════════════════════════════════════════════════════════════════
+
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fintech-bank-platform/notification-service/internal/contracts"
+	"github.com/fintech-bank-platform/notification-service/internal/preferences"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryPreferenceStore(t *testing.T) {
+	store := preferences.NewMemoryStore()
+	store.Set("user-1", &contracts.Preferences{Locale: "en"})
+
+	prefs, err := store.Get(context.Background(), "user-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "en", prefs.Locale)
+
+	missing, err := store.Get(context.Background(), "user-2")
+	assert.NoError(t, err)
+	assert.Nil(t, missing)
+}
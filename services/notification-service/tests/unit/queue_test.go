@@ -0,0 +1,163 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Unit Test: Priority Queue
+// ═══════════════════════════════════════════════════════════════════════════
+
+package unit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fintech-bank-platform/notification-service/internal/contracts"
+	"github.com/fintech-bank-platform/notification-service/internal/queue"
+	"github.com/fintech-bank-platform/notification-service/tests"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func message(id string, priority contracts.Priority) *contracts.Message {
+	return &contracts.Message{ID: id, Channel: contracts.ChannelSMS, Priority: priority}
+}
+
+func TestQueueDrainsByPriority(t *testing.T) {
+	q := queue.New([]contracts.Provider{tests.NewRecordingProvider(contracts.ChannelSMS)}, 10, zerolog.Nop())
+
+	require.NoError(t, q.Enqueue(message("low", contracts.PriorityLow)))
+	require.NoError(t, q.Enqueue(message("normal", contracts.PriorityNormal)))
+	require.NoError(t, q.Enqueue(message("high", contracts.PriorityHigh)))
+	require.NoError(t, q.Enqueue(message("unknown", "")))
+
+	var order []string
+	for i := 0; i < 4; i++ {
+		msg, ok := q.Next(context.Background())
+		require.True(t, ok)
+		order = append(order, msg.ID)
+	}
+
+	assert.Equal(t, []string{"high", "normal", "unknown", "low"}, order)
+}
+
+func TestQueueNextStopsWithContext(t *testing.T) {
+	q := queue.New(nil, 1, zerolog.Nop())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	msg, ok := q.Next(ctx)
+
+	assert.False(t, ok)
+	assert.Nil(t, msg)
+}
+
+func TestQueueRejectsUnknownChannel(t *testing.T) {
+	q := queue.New(nil, 1, zerolog.Nop())
+
+	err := q.Enqueue(message("1", contracts.PriorityHigh))
+
+	assert.ErrorIs(t, err, queue.ErrNoProvider)
+}
+
+func TestQueueFull(t *testing.T) {
+	q := queue.New([]contracts.Provider{tests.NewRecordingProvider(contracts.ChannelSMS)}, 1, zerolog.Nop())
+
+	require.NoError(t, q.Enqueue(message("1", contracts.PriorityNormal)))
+	err := q.Enqueue(message("2", contracts.PriorityNormal))
+
+	assert.ErrorIs(t, err, queue.ErrQueueFull)
+	assert.Equal(t, 1, q.Len(contracts.PriorityNormal))
+	assert.NoError(t, q.Enqueue(message("3", contracts.PriorityHigh)), "each priority has its own queue")
+}
+
+func TestQueueReleasesDueDeferredMessages(t *testing.T) {
+	q := queue.New([]contracts.Provider{tests.NewRecordingProvider(contracts.ChannelSMS)}, 10, zerolog.Nop())
+	now := time.Date(2026, 3, 10, 7, 0, 0, 0, time.UTC)
+
+	later := message("later", contracts.PriorityNormal)
+	later.DeliverAt = now.Add(time.Hour)
+	second := message("second", contracts.PriorityNormal)
+	second.DeliverAt = now
+	first := message("first", contracts.PriorityNormal)
+	first.DeliverAt = now.Add(-time.Minute)
+	q.Defer(later)
+	q.Defer(second)
+	q.Defer(first)
+
+	released := q.Release(now)
+
+	assert.Equal(t, 2, released)
+	assert.Equal(t, 1, q.Deferred())
+	msg, _ := q.Next(context.Background())
+	assert.Equal(t, "first", msg.ID)
+	msg, _ = q.Next(context.Background())
+	assert.Equal(t, "second", msg.ID)
+}
+
+func TestQueueKeepsDeferredMessagesWhenFull(t *testing.T) {
+	q := queue.New([]contracts.Provider{tests.NewRecordingProvider(contracts.ChannelSMS)}, 1, zerolog.Nop())
+	require.NoError(t, q.Enqueue(message("queued", contracts.PriorityLow)))
+	q.Defer(message("deferred", contracts.PriorityLow))
+
+	released := q.Release(time.Now())
+
+	assert.Equal(t, 0, released)
+	assert.Equal(t, 1, q.Deferred())
+}
+
+func TestQueueRunDeliversUntilCancelled(t *testing.T) {
+	provider := tests.NewRecordingProvider(contracts.ChannelSMS)
+	failing := tests.NewFailingProvider(contracts.ChannelPush, errors.New("gateway down"))
+	q := queue.New([]contracts.Provider{provider, failing}, 10, zerolog.Nop())
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		q.Run(ctx, 2)
+		close(done)
+	}()
+
+	require.NoError(t, q.Enqueue(message("sms", contracts.PriorityNormal)))
+	require.NoError(t, q.Enqueue(&contracts.Message{ID: "push", Channel: contracts.ChannelPush}))
+
+	assert.Equal(t, "sms", (<-provider.Sent()).ID)
+	assert.Equal(t, "push", (<-failing.Sent()).ID)
+
+	cancel()
+	<-done
+}
+
+func TestQueueReleaserRunsOnInterval(t *testing.T) {
+	provider := tests.NewRecordingProvider(contracts.ChannelSMS)
+	q := queue.New([]contracts.Provider{provider}, 10, zerolog.Nop())
+	q.Defer(message("deferred", contracts.PriorityNormal))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		q.RunReleaser(ctx, 5*time.Millisecond)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool { return q.Len(contracts.PriorityNormal) == 1 }, time.Second, 5*time.Millisecond)
+
+	cancel()
+	<-done
+}
+
+func TestQueueNextWaitsForAnyPriority(t *testing.T) {
+	q := queue.New([]contracts.Provider{tests.NewRecordingProvider(contracts.ChannelSMS)}, 10, zerolog.Nop())
+
+	for _, priority := range []contracts.Priority{contracts.PriorityHigh, contracts.PriorityNormal, contracts.PriorityLow} {
+		received := make(chan *contracts.Message)
+		go func() {
+			msg, _ := q.Next(context.Background())
+			received <- msg
+		}()
+
+		time.Sleep(5 * time.Millisecond)
+		require.NoError(t, q.Enqueue(message(string(priority), priority)))
+
+		assert.Equal(t, string(priority), (<-received).ID)
+	}
+}
@@ -0,0 +1,309 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Unit Test: Notification Rules Engine
+// ═══════════════════════════════════════════════════════════════════════════
+
+package unit
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/fintech-bank-platform/notification-service/internal/rules"
+	"github.com/fintech-bank-platform/pkg/events"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEngine(t *testing.T, opts ...rules.Option) (*rules.Engine, *events.MemoryBus) {
+	cfg, err := rules.Default()
+	require.NoError(t, err)
+
+	bus := events.NewMemoryBus()
+	engine, err := rules.NewEngine(cfg, bus, zerolog.Nop(), opts...)
+	require.NoError(t, err)
+	return engine, bus
+}
+
+func transferCompleted(amount float64) *events.Event {
+	return events.NewTransactionEvent(events.EventTypes.TransferCompleted, events.TransferCompletedPayload{
+		TransferID:    "transfer-1",
+		FromAccountID: "acc-from",
+		ToAccountID:   "acc-to",
+		Amount:        amount,
+		Currency:      "BRL",
+	})
+}
+
+func published(bus *events.MemoryBus) []*events.Event {
+	return bus.Published(events.Topics.NotificationEvents)
+}
+
+func TestEngineNotifiesBothSidesOfTransfer(t *testing.T) {
+	engine, bus := newEngine(t)
+	event := transferCompleted(150.5).WithTraceID("trace-1")
+
+	require.NoError(t, engine.Handle(context.Background(), event))
+
+	commands := published(bus)
+	require.Len(t, commands, 2)
+
+	var sender events.SendPushPayload
+	require.NoError(t, commands[0].DecodePayload(&sender))
+	assert.Equal(t, events.EventTypes.SendPush, commands[0].Type)
+	assert.Equal(t, "acc-from", sender.UserID)
+	assert.Equal(t, "transfer_sent", sender.Template)
+	assert.Equal(t, map[string]string{"amount": "150.50", "currency": "BRL", "transfer_id": "transfer-1"}, sender.Data)
+
+	var recipient events.SendPushPayload
+	require.NoError(t, commands[1].DecodePayload(&recipient))
+	assert.Equal(t, "acc-to", recipient.UserID)
+	assert.Equal(t, "transfer_received", recipient.Template)
+	assert.Equal(t, map[string]string{"amount": "150.50", "currency": "BRL", "transfer_id": "transfer-1"}, recipient.Data)
+
+	assert.Equal(t, "trace-1", commands[0].TraceID)
+	assert.Equal(t, "transfer_completed", commands[0].Metadata["rule"])
+	assert.Equal(t, event.ID, commands[0].Metadata["source_event_id"])
+}
+
+func TestEngineNotifiesCreditedCurrencyOfFXTransfer(t *testing.T) {
+	engine, bus := newEngine(t)
+	event := events.NewTransactionEvent(events.EventTypes.TransferCompleted, events.TransferCompletedPayload{
+		TransferID:    "transfer-1",
+		FromAccountID: "acc-from",
+		ToAccountID:   "acc-to",
+		Amount:        10000,
+		Currency:      "BRL",
+		FX:            &events.FXConversion{Rate: "0.18", TargetAmount: 1800, TargetCurrency: "USD"},
+	})
+
+	require.NoError(t, engine.Handle(context.Background(), event))
+
+	commands := published(bus)
+	require.Len(t, commands, 3)
+	var sender, recipient events.SendPushPayload
+	require.NoError(t, commands[0].DecodePayload(&sender))
+	require.NoError(t, commands[1].DecodePayload(&recipient))
+	assert.Equal(t, map[string]string{"amount": "10000.00", "currency": "BRL", "transfer_id": "transfer-1"}, sender.Data)
+	assert.Equal(t, map[string]string{"amount": "1800.00", "currency": "USD", "transfer_id": "transfer-1"}, recipient.Data)
+}
+
+func TestEngineThresholdOnlyForReais(t *testing.T) {
+	engine, bus := newEngine(t)
+	event := events.NewTransactionEvent(events.EventTypes.TransferCompleted, events.TransferCompletedPayload{
+		TransferID:    "transfer-1",
+		FromAccountID: "acc-from",
+		ToAccountID:   "acc-to",
+		Amount:        5000,
+		Currency:      "JPY",
+	})
+
+	require.NoError(t, engine.Handle(context.Background(), event))
+
+	assert.Len(t, published(bus), 2)
+}
+
+func TestEngineThresholdCondition(t *testing.T) {
+	engine, bus := newEngine(t)
+
+	require.NoError(t, engine.Handle(context.Background(), transferCompleted(5000)))
+
+	commands := published(bus)
+	require.Len(t, commands, 3)
+	var alert events.SendPushPayload
+	require.NoError(t, commands[2].DecodePayload(&alert))
+	assert.Equal(t, "large_transfer", alert.Template)
+	assert.Equal(t, "high", alert.Priority)
+}
+
+func TestEngineMoneyUsesCurrencyMinorUnits(t *testing.T) {
+	cfg := &rules.Config{Rules: []rules.Rule{{
+		Name:  "money",
+		Event: "e",
+		Notifications: []rules.Notification{{Channel: "push", Data: map[string]string{
+			"plain":   "{{money .amount}}",
+			"yen":     `{{money .amount "JPY"}}`,
+			"dinar":   `{{money .amount "KWD"}}`,
+			"unknown": `{{money .amount "ZZZ"}}`,
+		}}},
+	}}}
+	bus := events.NewMemoryBus()
+	engine, err := rules.NewEngine(cfg, bus, zerolog.Nop())
+	require.NoError(t, err)
+
+	require.NoError(t, engine.Handle(context.Background(), events.NewEvent("e", "test", map[string]float64{"amount": 1234.5})))
+
+	var push events.SendPushPayload
+	require.NoError(t, published(bus)[0].DecodePayload(&push))
+	assert.Equal(t, map[string]string{"plain": "1234.50", "yen": "1235", "dinar": "1234.500", "unknown": "1234.50"}, push.Data)
+}
+
+func TestEngineHandlesJSONEvents(t *testing.T) {
+	engine, bus := newEngine(t)
+	data, _ := events.NewPaymentEvent(events.EventTypes.PaymentFailed, events.PaymentFailedPayload{
+		PaymentID:    "pay-1",
+		AccountID:    "acc-1",
+		ErrorMessage: "saldo insuficiente",
+	}).ToJSON()
+	event, _ := events.FromJSON(data)
+
+	require.NoError(t, engine.Handle(context.Background(), event))
+
+	commands := published(bus)
+	require.Len(t, commands, 1)
+	var push events.SendPushPayload
+	require.NoError(t, commands[0].DecodePayload(&push))
+	assert.Equal(t, "acc-1", push.UserID)
+	assert.Equal(t, "saldo insuficiente", push.Data["reason"])
+}
+
+func TestEngineEmailRule(t *testing.T) {
+	engine, bus := newEngine(t)
+
+	require.NoError(t, engine.Handle(context.Background(), events.NewAccountEvent(events.EventTypes.KYCCompleted, events.KYCCompletedPayload{
+		AccountID: "acc-1", UserID: "user-1", Name: "Ana", Email: "ana@example.com",
+	})))
+	require.NoError(t, engine.Handle(context.Background(), events.NewAccountEvent(events.EventTypes.KYCCompleted, events.KYCCompletedPayload{
+		AccountID: "acc-2", UserID: "user-2", Name: "Bia",
+	})))
+
+	commands := published(bus)
+	require.Len(t, commands, 1, "no email address, no email")
+	var email events.SendEmailPayload
+	require.NoError(t, commands[0].DecodePayload(&email))
+	assert.Equal(t, events.EventTypes.SendEmail, commands[0].Type)
+	assert.Equal(t, "ana@example.com", email.To)
+	assert.Equal(t, "user-1", email.UserID)
+	assert.Equal(t, "kyc_completed", email.Template)
+	assert.Equal(t, "Ana", email.Data["name"])
+}
+
+func TestEngineSMSRule(t *testing.T) {
+	cfg, err := rules.Load(strings.NewReader(`
+rules:
+  - name: welcome_sms
+    event: account.created
+    notifications:
+      - channel: sms
+        to: "{{.phone}}"
+        body: "Conta {{.account_number}} criada"
+        locale: "{{.locale}}"
+`))
+	require.NoError(t, err)
+	bus := events.NewMemoryBus()
+	engine, err := rules.NewEngine(cfg, bus, zerolog.Nop())
+	require.NoError(t, err)
+
+	err = engine.Handle(context.Background(), events.NewAccountEvent(events.EventTypes.AccountCreated, map[string]string{
+		"phone": "11999887766", "account_number": "12345", "locale": "en",
+	}))
+
+	require.NoError(t, err)
+	var sms events.SendSMSPayload
+	require.NoError(t, published(bus)[0].DecodePayload(&sms))
+	assert.Equal(t, "11999887766", sms.To)
+	assert.Equal(t, "Conta 12345 criada", sms.Message)
+	assert.Equal(t, "en", sms.Locale)
+	assert.Nil(t, sms.Data)
+}
+
+func TestEngineDeduplicatesByEventID(t *testing.T) {
+	engine, bus := newEngine(t)
+	event := transferCompleted(100)
+
+	require.NoError(t, engine.Handle(context.Background(), event))
+	require.NoError(t, engine.Handle(context.Background(), event))
+
+	assert.Len(t, published(bus), 2)
+}
+
+func TestEngineDryRunPublishesNothing(t *testing.T) {
+	engine, bus := newEngine(t, rules.WithDryRun(true))
+
+	require.NoError(t, engine.Handle(context.Background(), transferCompleted(9000)))
+
+	assert.Empty(t, published(bus))
+}
+
+func TestEngineIgnoresEventsWithoutRules(t *testing.T) {
+	engine, bus := newEngine(t)
+
+	require.NoError(t, engine.Handle(context.Background(), events.NewAccountEvent(events.EventTypes.AccountDeleted, nil)))
+
+	assert.Empty(t, published(bus))
+}
+
+func TestEngineReleasesEventOnFailure(t *testing.T) {
+	engine, bus := newEngine(t)
+	event := events.NewPaymentEvent(events.EventTypes.PaymentFailed, "not an object")
+
+	assert.Error(t, engine.Handle(context.Background(), event))
+
+	event.Payload = events.PaymentFailedPayload{PaymentID: "pay-1", AccountID: "acc-1"}
+	assert.NoError(t, engine.Handle(context.Background(), event), "a failed event can be retried")
+	assert.Len(t, published(bus), 1)
+}
+
+func TestEngineMissingTemplateFieldFails(t *testing.T) {
+	engine, bus := newEngine(t)
+
+	err := engine.Handle(context.Background(), events.NewPaymentEvent(events.EventTypes.PaymentFailed, map[string]string{"payment_id": "pay-1"}))
+
+	assert.ErrorContains(t, err, "payment_failed")
+	assert.Empty(t, published(bus))
+}
+
+type failingPublisher struct{}
+
+func (failingPublisher) Publish(ctx context.Context, topic string, event *events.Event) error {
+	return errors.New("broker unavailable")
+}
+
+func TestEnginePublishFailure(t *testing.T) {
+	cfg, _ := rules.Default()
+	dedup := rules.NewMemoryDeduplicator(rules.DefaultDedupTTL)
+	engine, err := rules.NewEngine(cfg, failingPublisher{}, zerolog.Nop(), rules.WithDeduplicator(dedup))
+	require.NoError(t, err)
+	event := transferCompleted(100)
+
+	assert.EqualError(t, engine.Handle(context.Background(), event), "broker unavailable")
+	assert.True(t, dedup.Claim(event.ID), "claim released after failure")
+}
+
+func TestEngineRejectsInvalidTemplates(t *testing.T) {
+	for name, notification := range map[string]rules.Notification{
+		"field": {Channel: "push", UserID: "{{.account_id"},
+		"data":  {Channel: "push", Data: map[string]string{"amount": "{{money"}},
+	} {
+		cfg := &rules.Config{Rules: []rules.Rule{{Name: "broken", Event: "e", Notifications: []rules.Notification{notification}}}}
+
+		_, err := rules.NewEngine(cfg, events.NewMemoryBus(), zerolog.Nop())
+
+		assert.ErrorContains(t, err, "rule broken", name)
+	}
+}
+
+func TestEngineDataTemplateFailure(t *testing.T) {
+	cfg := &rules.Config{Rules: []rules.Rule{{
+		Name:          "data",
+		Event:         "e",
+		Notifications: []rules.Notification{{Channel: "push", Data: map[string]string{"x": "{{.missing}}"}}},
+	}}}
+	engine, err := rules.NewEngine(cfg, events.NewMemoryBus(), zerolog.Nop())
+	require.NoError(t, err)
+
+	assert.Error(t, engine.Handle(context.Background(), events.NewEvent("e", "test", map[string]string{})))
+}
+
+func TestEngineSubscribesToDomainTopics(t *testing.T) {
+	engine, bus := newEngine(t)
+	engine.Subscribe(bus)
+
+	require.NoError(t, bus.Publish(context.Background(), events.Topics.TransactionEvents, transferCompleted(10)))
+	require.NoError(t, bus.Publish(context.Background(), events.Topics.PaymentEvents,
+		events.NewPaymentEvent(events.EventTypes.PaymentFailed, events.PaymentFailedPayload{AccountID: "acc-1"})))
+
+	assert.Len(t, published(bus), 3)
+}
@@ -0,0 +1,155 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Unit Test: Notification Rules (YAML + conditions)
+// ═══════════════════════════════════════════════════════════════════════════
+
+package unit
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fintech-bank-platform/notification-service/internal/rules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultRulesLoad(t *testing.T) {
+	cfg, err := rules.Default()
+
+	require.NoError(t, err)
+	var names []string
+	for _, rule := range cfg.Rules {
+		names = append(names, rule.Name)
+	}
+	assert.Equal(t, []string{"transfer_completed", "large_transfer_alert", "payment_failed", "kyc_completed"}, names)
+}
+
+func TestLoadRulesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+rules:
+  - name: otp
+    event: account.created
+    notifications:
+      - channel: sms
+        to: "{{.phone}}"
+        body: "hello"
+`), 0o644))
+
+	cfg, err := rules.LoadFile(path)
+
+	require.NoError(t, err)
+	require.Len(t, cfg.Rules, 1)
+	assert.Equal(t, "{{.phone}}", cfg.Rules[0].Notifications[0].To)
+
+	_, err = rules.LoadFile(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestLoadRulesRejectsInvalidRules(t *testing.T) {
+	cases := map[string]string{
+		"malformed yaml":   `rules: [`,
+		"unknown field":    `rules: [{name: a, event: e, colour: red}]`,
+		"missing event":    `rules: [{name: a, notifications: [{channel: push}]}]`,
+		"no notifications": `rules: [{name: a, event: e}]`,
+		"unknown operator": `rules: [{name: a, event: e, when: [{field: amount, op: like, value: 1}],
+		                      notifications: [{channel: push}]}]`,
+		"non numeric threshold": `rules: [{name: a, event: e, when: [{field: amount, op: gt, value: lots}],
+		                           notifications: [{channel: push}]}]`,
+		"condition without field": `rules: [{name: a, event: e, when: [{op: exists}], notifications: [{channel: push}]}]`,
+		"email without template":  `rules: [{name: a, event: e, notifications: [{channel: email}]}]`,
+		"unknown channel":         `rules: [{name: a, event: e, notifications: [{channel: fax}]}]`,
+	}
+
+	for name, content := range cases {
+		_, err := rules.Load(strings.NewReader(content))
+		assert.Error(t, err, name)
+	}
+}
+
+func TestRuleConditions(t *testing.T) {
+	payload := map[string]interface{}{
+		"amount":   7500.0,
+		"currency": "BRL",
+		"status":   "failed",
+		"details":  map[string]interface{}{"channel": "pix"},
+	}
+
+	cases := []struct {
+		condition rules.Condition
+		want      bool
+	}{
+		{rules.Condition{Field: "amount", Op: rules.OpGt, Value: 5000}, true},
+		{rules.Condition{Field: "amount", Op: rules.OpGt, Value: 7500.0}, false},
+		{rules.Condition{Field: "amount", Op: rules.OpGte, Value: "7500"}, true},
+		{rules.Condition{Field: "amount", Op: rules.OpLt, Value: 10000}, true},
+		{rules.Condition{Field: "amount", Op: rules.OpLte, Value: 100}, false},
+		{rules.Condition{Field: "currency", Op: rules.OpGt, Value: 1}, false},
+		{rules.Condition{Field: "missing", Op: rules.OpGt, Value: 1}, false},
+		{rules.Condition{Field: "amount", Op: rules.OpEq, Value: 7500}, true},
+		{rules.Condition{Field: "amount", Op: rules.OpEq, Value: "abc"}, false},
+		{rules.Condition{Field: "currency", Op: rules.OpEq, Value: "BRL"}, true},
+		{rules.Condition{Field: "missing", Op: rules.OpEq, Value: "BRL"}, false},
+		{rules.Condition{Field: "status", Op: rules.OpNe, Value: "completed"}, true},
+		{rules.Condition{Field: "status", Op: rules.OpNe, Value: "failed"}, false},
+		{rules.Condition{Field: "missing", Op: rules.OpNe, Value: "failed"}, true},
+		{rules.Condition{Field: "details.channel", Op: rules.OpEq, Value: "pix"}, true},
+		{rules.Condition{Field: "currency.code", Op: rules.OpExists}, false},
+		{rules.Condition{Field: "details", Op: rules.OpExists}, true},
+		{rules.Condition{Field: "details", Op: rules.OpExists, Value: true}, true},
+		{rules.Condition{Field: "refund", Op: rules.OpExists, Value: false}, true},
+	}
+
+	for _, tc := range cases {
+		rule := rules.Rule{When: []rules.Condition{tc.condition}}
+		assert.Equal(t, tc.want, rule.Matches(payload), "%+v", tc.condition)
+	}
+}
+
+func TestMemoryDeduplicator(t *testing.T) {
+	dedup := rules.NewMemoryDeduplicator(time.Hour)
+
+	assert.True(t, dedup.Claim("event-1"))
+	assert.False(t, dedup.Claim("event-1"))
+
+	dedup.Release("event-1")
+	assert.True(t, dedup.Claim("event-1"))
+}
+
+func TestMemoryDeduplicatorExpires(t *testing.T) {
+	dedup := rules.NewMemoryDeduplicator(time.Millisecond)
+
+	assert.True(t, dedup.Claim("event-1"))
+	time.Sleep(5 * time.Millisecond)
+
+	assert.True(t, dedup.Claim("event-1"))
+}
+
+func TestMemoryDeduplicatorSweepsExpiredIDs(t *testing.T) {
+	dedup := rules.NewMemoryDeduplicator(10 * time.Millisecond)
+	for n := range 4 {
+		assert.True(t, dedup.Claim(fmt.Sprint("old-", n)))
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	for n := range 10 {
+		assert.True(t, dedup.Claim(fmt.Sprint("new-", n)))
+	}
+
+	assert.LessOrEqual(t, dedup.Len(), 10, "new IDs alone still trigger the sweep")
+}
+
+func TestMemoryDeduplicatorExpiresBetweenSweeps(t *testing.T) {
+	dedup := rules.NewMemoryDeduplicator(20 * time.Millisecond)
+	for _, id := range []string{"event-1", "event-2", "event-3"} {
+		assert.True(t, dedup.Claim(id))
+	}
+	time.Sleep(30 * time.Millisecond)
+
+	assert.True(t, dedup.Claim("event-1"), "an expired ID is claimable before the next sweep")
+	assert.False(t, dedup.Claim("event-1"))
+}
@@ -0,0 +1,142 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Unit Test: Templates
+// ═══════════════════════════════════════════════════════════════════════════
+
+package unit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/fintech-bank-platform/notification-service/internal/contracts"
+	"github.com/fintech-bank-platform/notification-service/internal/templates"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddedTemplatesPerLocale(t *testing.T) {
+	renderer, err := templates.New(contracts.TemplateConfig{DefaultLocale: "pt-BR"})
+	require.NoError(t, err)
+
+	pt, err := renderer.Render(contracts.ChannelEmail, "welcome", "pt-BR", map[string]string{"name": "Ana"})
+	require.NoError(t, err)
+	assert.Equal(t, "Bem-vindo(a), Ana!", pt.Subject)
+	assert.Contains(t, pt.Body, "<p>Olá, Ana!</p>")
+
+	en, err := renderer.Render(contracts.ChannelEmail, "welcome", "en", map[string]string{"name": "Ana"})
+	require.NoError(t, err)
+	assert.Equal(t, "Welcome, Ana!", en.Subject)
+}
+
+func TestTemplatesFallBackToDefaultLocale(t *testing.T) {
+	renderer, _ := templates.New(contracts.TemplateConfig{DefaultLocale: "pt-BR"})
+
+	rendered, err := renderer.Render(contracts.ChannelSMS, "otp", "es", map[string]string{"code": "123456"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Seu código de verificação é 123456. Não compartilhe com ninguém.", rendered.Body)
+	assert.Empty(t, rendered.Subject)
+	assert.Equal(t, "pt-BR", renderer.DefaultLocale())
+}
+
+func TestTemplatesPushTitleAndBody(t *testing.T) {
+	renderer, _ := templates.New(contracts.TemplateConfig{DefaultLocale: "pt-BR"})
+
+	rendered, err := renderer.Render(contracts.ChannelPush, "security_alert", "en", map[string]string{"device": "Pixel 9"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Security alert", rendered.Subject)
+	assert.Contains(t, rendered.Body, "Pixel 9")
+}
+
+func TestTemplatesEscapeEmailBodyOnly(t *testing.T) {
+	renderer := templates.NewFromFS(fstest.MapFS{
+		"en/note.email.tmpl": {Data: []byte(`{{define "subject"}}{{.title}}{{end}}{{define "body"}}<p>{{.text}}</p>{{end}}`)},
+		"en/note.sms.tmpl":   {Data: []byte(`{{.text}}`)},
+	}, "en")
+	data := map[string]string{"title": "Tom & Jerry", "text": "<b>hi</b>"}
+
+	email, err := renderer.Render(contracts.ChannelEmail, "note", "en", data)
+	require.NoError(t, err)
+	assert.Equal(t, "Tom & Jerry", email.Subject)
+	assert.Equal(t, "<p>&lt;b&gt;hi&lt;/b&gt;</p>", email.Body)
+
+	sms, err := renderer.Render(contracts.ChannelSMS, "note", "en", data)
+	require.NoError(t, err)
+	assert.Equal(t, "<b>hi</b>", sms.Body)
+}
+
+func TestTemplatesMissingDataRendersEmpty(t *testing.T) {
+	renderer := templates.NewFromFS(fstest.MapFS{
+		"en/plain.email.tmpl": {Data: []byte(`Hello {{.name}}`)},
+	}, "en")
+
+	rendered, err := renderer.Render(contracts.ChannelEmail, "plain", "en", nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Hello", rendered.Body)
+}
+
+func TestTemplatesErrors(t *testing.T) {
+	renderer := templates.NewFromFS(fstest.MapFS{
+		"en/broken.email.tmpl":       {Data: []byte(`{{.name`)},
+		"en/broken.sms.tmpl":         {Data: []byte(`{{.name`)},
+		"en/bad_subject.email.tmpl":  {Data: []byte(`{{define "subject"}}{{index .name 5}}{{end}}`)},
+		"en/bad_body.email.tmpl":     {Data: []byte(`{{index .name 5}}`)},
+		"en/bad_body.sms.tmpl":       {Data: []byte(`{{index .name 5}}`)},
+		"en/bad_subject.push.tmpl":   {Data: []byte(`{{define "subject"}}{{index .name 5}}{{end}}`)},
+		"en/../secret.email.tmpl":    {Data: []byte(`secret`)},
+		"en/missing_locale.sms.tmpl": {Data: []byte(`ok`)},
+	}, "")
+	data := map[string]string{"name": "x"}
+
+	cases := []struct {
+		channel contracts.Channel
+		name    string
+	}{
+		{contracts.ChannelEmail, "broken"},
+		{contracts.ChannelSMS, "broken"},
+		{contracts.ChannelEmail, "bad_subject"},
+		{contracts.ChannelEmail, "bad_body"},
+		{contracts.ChannelSMS, "bad_body"},
+		{contracts.ChannelPush, "bad_subject"},
+	}
+	for _, tc := range cases {
+		_, err := renderer.Render(tc.channel, tc.name, "en", data)
+		assert.Error(t, err, "%s.%s", tc.name, tc.channel)
+	}
+
+	_, err := renderer.Render(contracts.ChannelEmail, "../secret", "en", data)
+	assert.ErrorIs(t, err, templates.ErrTemplateNotFound)
+
+	_, err = renderer.Render(contracts.ChannelSMS, "missing_locale", "", data)
+	assert.ErrorIs(t, err, templates.ErrTemplateNotFound)
+
+	_, err = renderer.Render(contracts.ChannelPush, "unknown", "en", data)
+	assert.ErrorIs(t, err, templates.ErrTemplateNotFound)
+}
+
+func TestTemplatesFromDirectory(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "en"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "en", "hello.sms.tmpl"), []byte("Hello {{.name}}"), 0o644))
+
+	renderer, err := templates.New(contracts.TemplateConfig{Dir: dir, DefaultLocale: "en"})
+	require.NoError(t, err)
+
+	rendered, err := renderer.Render(contracts.ChannelSMS, "hello", "en", map[string]string{"name": "Ana"})
+	require.NoError(t, err)
+	assert.Equal(t, "Hello Ana", rendered.Body)
+}
+
+func TestTemplatesInvalidDirectory(t *testing.T) {
+	_, err := templates.New(contracts.TemplateConfig{Dir: filepath.Join(t.TempDir(), "missing")})
+	assert.Error(t, err)
+
+	file := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(file, nil, 0o644))
+	_, err = templates.New(contracts.TemplateConfig{Dir: file})
+	assert.Error(t, err)
+}
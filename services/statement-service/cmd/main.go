@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/fintech-bank-platform/pkg/apikey"
+	"github.com/fintech-bank-platform/pkg/calendar"
+	pkgconfig "github.com/fintech-bank-platform/pkg/config"
+	"github.com/fintech-bank-platform/pkg/events"
+	"github.com/fintech-bank-platform/statement-service/internal/config"
+	"github.com/fintech-bank-platform/statement-service/internal/contracts"
+	"github.com/fintech-bank-platform/statement-service/internal/export"
+	appHttp "github.com/fintech-bank-platform/statement-service/internal/infrastructure/http"
+	"github.com/fintech-bank-platform/statement-service/internal/projection"
+	"github.com/fintech-bank-platform/statement-service/internal/statement"
+	"github.com/fintech-bank-platform/statement-service/internal/storage"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+)
+
+func main() {
+	logger := zerolog.New(os.Stdout).With().Timestamp().Logger()
+
+	cfg, err := config.New()
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to load configuration")
+	}
+	logger.Debug().Interface("config", pkgconfig.Dump(cfg)).Msg("Effective configuration")
+
+	if cfg.Statement.CursorSecret == "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			logger.Fatal().Err(err).Msg("Failed to generate cursor secret")
+		}
+		cfg.Statement.CursorSecret = string(secret)
+		logger.Warn().Msg("STATEMENT_CURSOR_SECRET is not set; cursors will not survive restarts or work across instances")
+	}
+
+	repository := storage.NewMemoryRepository()
+
+	// The in-memory bus keeps the service runnable locally until the Kafka
+	// consumer is available behind the same events.Subscriber interface
+	bus := events.NewMemoryBus()
+	projection.NewProjector(repository, logger).Subscribe(bus)
+	projection.NewOwnerProjector(repository, logger).Subscribe(bus)
+
+	// The key store is shared with the API gateway once a persistent
+	// apikey.Store is available; until then only the admin key is accepted
+	keys := apikey.NewManager(apikey.NewMemoryStore())
+	if cfg.APIKeys.AdminKey != "" {
+		if _, err := keys.Import(context.Background(), cfg.APIKeys.AdminKey, apikey.CreateRequest{
+			Name:   "statement admin",
+			Owner:  "platform",
+			Scopes: []string{contracts.ScopeAccountsAdmin},
+		}); err != nil {
+			logger.Fatal().Err(err).Msg("Failed to import the admin API key")
+		}
+	}
+
+	handler := appHttp.NewStatementHandler(
+		statement.NewService(repository),
+		export.New(cfg.Statement.BankID, calendar.Default().Location()),
+		cfg.Statement,
+		logger,
+	)
+	router := chi.NewRouter()
+	appHttp.SetupRouter(router, handler, keys, repository)
+
+	server := &http.Server{
+		Addr:         cfg.Server.Address(),
+		Handler:      router,
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
+		IdleTimeout:  cfg.Server.IdleTimeout,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		logger.Info().Str("address", cfg.Server.Address()).Msg("Statement service started")
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Fatal().Err(err).Msg("Server failed")
+		}
+	}()
+
+	<-ctx.Done()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Error().Err(err).Msg("Shutdown failed")
+	}
+	logger.Info().Msg("Statement service stopped")
+}
@@ -0,0 +1,22 @@
+package config
+
+import (
+	"os"
+
+	pkgconfig "github.com/fintech-bank-platform/pkg/config"
+	"github.com/fintech-bank-platform/statement-service/internal/contracts"
+)
+
+type Config struct {
+	Server    contracts.ServerConfig    `yaml:"server"`
+	Statement contracts.StatementConfig `yaml:"statement"`
+	APIKeys   contracts.APIKeysConfig   `yaml:"api_keys"`
+}
+
+func New() (*Config, error) {
+	var cfg Config
+	if err := pkgconfig.Load(&cfg, pkgconfig.WithFile(os.Getenv("CONFIG_FILE"))); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
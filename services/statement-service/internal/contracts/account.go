@@ -0,0 +1,14 @@
+package contracts
+
+import "context"
+
+// ScopeAccountsAdmin lets operators read the statement of any account
+const ScopeAccountsAdmin = "accounts:admin"
+
+// OwnerRepository keeps who owns each account, projected from account.events
+type OwnerRepository interface {
+	SaveOwner(ctx context.Context, accountID, owner string) error
+	// Owner returns the user that owns the account, or
+	// errors.ErrAccountNotFound
+	Owner(ctx context.Context, accountID string) (string, error)
+}
@@ -0,0 +1,30 @@
+package contracts
+
+import "time"
+
+type ServerConfig struct {
+	Host            string        `env:"SERVER_HOST" yaml:"host" default:"0.0.0.0" validate:"required"`
+	Port            string        `env:"SERVER_PORT" yaml:"port" default:"8086" validate:"required,numeric"`
+	ReadTimeout     time.Duration `env:"SERVER_READ_TIMEOUT" yaml:"read_timeout" default:"30s" validate:"gt=0"`
+	WriteTimeout    time.Duration `env:"SERVER_WRITE_TIMEOUT" yaml:"write_timeout" default:"30s" validate:"gt=0"`
+	IdleTimeout     time.Duration `env:"SERVER_IDLE_TIMEOUT" yaml:"idle_timeout" default:"120s" validate:"gt=0"`
+	ShutdownTimeout time.Duration `env:"SERVER_SHUTDOWN_TIMEOUT" yaml:"shutdown_timeout" default:"10s" validate:"gt=0"`
+}
+
+func (s ServerConfig) Address() string {
+	return s.Host + ":" + s.Port
+}
+
+type StatementConfig struct {
+	DefaultPageSize int    `env:"STATEMENT_PAGE_SIZE" yaml:"page_size" default:"50" validate:"gt=0"`
+	MaxPageSize     int    `env:"STATEMENT_MAX_PAGE_SIZE" yaml:"max_page_size" default:"200" validate:"gt=0"`
+	Currency        string `env:"STATEMENT_CURRENCY" yaml:"currency" default:"BRL" validate:"required,len=3"`
+	BankID          string `env:"STATEMENT_BANK_ID" yaml:"bank_id" default:"0001" validate:"required"`
+	CursorSecret    string `env:"STATEMENT_CURSOR_SECRET" yaml:"cursor_secret" secret:"true"`
+}
+
+// APIKeysConfig seeds AdminKey (a token in the fk_<prefix>_<secret> format)
+// as a key with ScopeAccountsAdmin so operators can read any statement.
+type APIKeysConfig struct {
+	AdminKey string `env:"API_KEYS_ADMIN_KEY" yaml:"admin_key" secret:"true"`
+}
@@ -0,0 +1,76 @@
+package contracts
+
+import (
+	"context"
+	"time"
+
+	"github.com/fintech-bank-platform/pkg/ledger"
+)
+
+type Direction string
+
+const (
+	Credit Direction = "credit"
+	Debit  Direction = "debit"
+)
+
+// Entry is one movement of the statement read model. Amount is always
+// positive; Direction gives its sign.
+type Entry struct {
+	ID          string    `json:"id"`
+	AccountID   string    `json:"account_id"`
+	EventID     string    `json:"event_id"`
+	Kind        string    `json:"kind"`
+	Direction   Direction `json:"direction"`
+	Amount      float64   `json:"amount"`
+	Currency    string    `json:"currency"`
+	Description string    `json:"description,omitempty"`
+	Reference   string    `json:"reference,omitempty"`
+	PostedAt    time.Time `json:"posted_at"`
+}
+
+// Signed returns the amount in minor units, negative for debits
+func (e *Entry) Signed() int64 {
+	if e.Direction == Debit {
+		return -ledger.ToMinor(e.Amount, e.Currency)
+	}
+	return ledger.ToMinor(e.Amount, e.Currency)
+}
+
+// Before orders entries by posting time, then ID
+func (e *Entry) Before(postedAt time.Time, id string) bool {
+	if e.PostedAt.Equal(postedAt) {
+		return e.ID < id
+	}
+	return e.PostedAt.Before(postedAt)
+}
+
+type EntryRepository interface {
+	// Save stores the entry and returns false when the account already has
+	// an entry with the same ID
+	Save(ctx context.Context, entry *Entry) (bool, error)
+	// Find returns the account entry with the given ID, or nil
+	Find(ctx context.Context, accountID, id string) (*Entry, error)
+	// Entries returns the account entries posted before the given time,
+	// ordered by posting time then ID
+	Entries(ctx context.Context, accountID string, before time.Time) ([]*Entry, error)
+}
+
+type Line struct {
+	Entry
+	Balance float64 `json:"balance"`
+}
+
+// Statement covers the half-open range [From, To). Balances and totals
+// always refer to the whole range, even when Lines holds a single page.
+type Statement struct {
+	AccountID      string    `json:"account_id"`
+	Currency       string    `json:"currency"`
+	From           time.Time `json:"from"`
+	To             time.Time `json:"to"`
+	OpeningBalance float64   `json:"opening_balance"`
+	ClosingBalance float64   `json:"closing_balance"`
+	TotalCredits   float64   `json:"total_credits"`
+	TotalDebits    float64   `json:"total_debits"`
+	Lines          []Line    `json:"entries"`
+}
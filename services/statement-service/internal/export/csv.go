@@ -0,0 +1,32 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+
+	"github.com/fintech-bank-platform/statement-service/internal/contracts"
+)
+
+var csvHeader = []string{"date", "id", "kind", "description", "reference", "amount", "currency", "balance"}
+
+// CSV writes one row per entry with signed amounts and the running balance
+func (e *Exporter) CSV(w io.Writer, st *contracts.Statement) error {
+	records := make([][]string, 0, len(st.Lines)+1)
+	records = append(records, csvHeader)
+	cur := currencyOf(st)
+
+	for _, line := range st.Lines {
+		records = append(records, []string{
+			line.PostedAt.In(e.Location).Format("2006-01-02 15:04:05"),
+			line.ID,
+			line.Kind,
+			line.Description,
+			line.Reference,
+			cur.Decimal(signed(line)),
+			line.Currency,
+			cur.Decimal(line.Balance),
+		})
+	}
+
+	return csv.NewWriter(w).WriteAll(records)
+}
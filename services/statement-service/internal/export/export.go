@@ -0,0 +1,73 @@
+package export
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fintech-bank-platform/pkg/currency"
+	"github.com/fintech-bank-platform/statement-service/internal/contracts"
+)
+
+type Format string
+
+const (
+	FormatCSV Format = "csv"
+	FormatOFX Format = "ofx"
+	FormatPDF Format = "pdf"
+)
+
+// ContentType returns the MIME type of the format
+func (f Format) ContentType() string {
+	switch f {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatOFX:
+		return "application/x-ofx"
+	default:
+		return "application/pdf"
+	}
+}
+
+// Exporter writes statements as files. Dates are shown in Location.
+type Exporter struct {
+	BankID   string
+	Location *time.Location
+}
+
+func New(bankID string, loc *time.Location) *Exporter {
+	return &Exporter{BankID: bankID, Location: loc}
+}
+
+// Filename names the export after the account and the inclusive date range
+func (e *Exporter) Filename(st *contracts.Statement, format Format) string {
+	return fmt.Sprintf("statement-%s-%s-%s.%s", st.AccountID,
+		st.From.In(e.Location).Format("20060102"), lastDay(st, e.Location).Format("20060102"), format)
+}
+
+// lastDay is the last calendar day covered by the half-open range
+func lastDay(st *contracts.Statement, loc *time.Location) time.Time {
+	return st.To.Add(-time.Nanosecond).In(loc)
+}
+
+func signed(line contracts.Line) float64 {
+	if line.Direction == contracts.Debit {
+		return -line.Amount
+	}
+	return line.Amount
+}
+
+// currencyOf returns the statement's currency. Codes outside ISO 4217 keep
+// two decimals, as in the ledger.
+func currencyOf(st *contracts.Statement) currency.Currency {
+	if c, ok := currency.Lookup(st.Currency); ok {
+		return c
+	}
+	return currency.Currency{Code: st.Currency, MinorUnits: 2}
+}
+
+func description(line contracts.Line) string {
+	if line.Description == "" {
+		return line.Kind
+	}
+	return line.Kind + " " + line.Description
+}
@@ -0,0 +1,122 @@
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/fintech-bank-platform/statement-service/internal/contracts"
+)
+
+const ofxHeader = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>` + "\n" +
+	`<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>` + "\n"
+
+type ofxDocument struct {
+	XMLName xml.Name `xml:"OFX"`
+	SignOn  struct {
+		Response struct {
+			Status   ofxStatus `xml:"STATUS"`
+			DTServer string    `xml:"DTSERVER"`
+			Language string    `xml:"LANGUAGE"`
+		} `xml:"SONRS"`
+	} `xml:"SIGNONMSGSRSV1"`
+	Bank struct {
+		Transaction struct {
+			UID       string       `xml:"TRNUID"`
+			Status    ofxStatus    `xml:"STATUS"`
+			Statement ofxStatement `xml:"STMTRS"`
+		} `xml:"STMTTRNRS"`
+	} `xml:"BANKMSGSRSV1"`
+}
+
+type ofxStatus struct {
+	Code     int    `xml:"CODE"`
+	Severity string `xml:"SEVERITY"`
+}
+
+type ofxStatement struct {
+	Currency string `xml:"CURDEF"`
+	Account  struct {
+		BankID  string `xml:"BANKID"`
+		AcctID  string `xml:"ACCTID"`
+		AcctTyp string `xml:"ACCTTYPE"`
+	} `xml:"BANKACCTFROM"`
+	List struct {
+		Start        string           `xml:"DTSTART"`
+		End          string           `xml:"DTEND"`
+		Transactions []ofxTransaction `xml:"STMTTRN"`
+	} `xml:"BANKTRANLIST"`
+	LedgerBalance struct {
+		Amount string `xml:"BALAMT"`
+		AsOf   string `xml:"DTASOF"`
+	} `xml:"LEDGERBAL"`
+}
+
+type ofxTransaction struct {
+	Type   string `xml:"TRNTYPE"`
+	Posted string `xml:"DTPOSTED"`
+	Amount string `xml:"TRNAMT"`
+	FITID  string `xml:"FITID"`
+	Memo   string `xml:"MEMO,omitempty"`
+	RefNum string `xml:"REFNUM,omitempty"`
+}
+
+// OFX writes an OFX 2.2 bank statement
+func (e *Exporter) OFX(w io.Writer, st *contracts.Statement, generatedAt time.Time) error {
+	var doc ofxDocument
+	ok := ofxStatus{Code: 0, Severity: "INFO"}
+
+	doc.SignOn.Response.Status = ok
+	doc.SignOn.Response.DTServer = e.ofxDate(generatedAt)
+	doc.SignOn.Response.Language = "POR"
+
+	doc.Bank.Transaction.UID = fmt.Sprintf("%s-%d", st.AccountID, generatedAt.Unix())
+	doc.Bank.Transaction.Status = ok
+
+	cur := currencyOf(st)
+	stmt := &doc.Bank.Transaction.Statement
+	stmt.Currency = st.Currency
+	stmt.Account.BankID = e.BankID
+	stmt.Account.AcctID = st.AccountID
+	stmt.Account.AcctTyp = "CHECKING"
+	stmt.List.Start = e.ofxDate(st.From)
+	stmt.List.End = e.ofxDate(lastDay(st, e.Location))
+	stmt.LedgerBalance.Amount = cur.Decimal(st.ClosingBalance)
+	stmt.LedgerBalance.AsOf = stmt.List.End
+
+	for _, line := range st.Lines {
+		trnType := "CREDIT"
+		if line.Direction == contracts.Debit {
+			trnType = "DEBIT"
+		}
+		stmt.List.Transactions = append(stmt.List.Transactions, ofxTransaction{
+			Type:   trnType,
+			Posted: e.ofxDate(line.PostedAt),
+			Amount: cur.Decimal(signed(line)),
+			FITID:  line.ID,
+			Memo:   description(line),
+			RefNum: line.Reference,
+		})
+	}
+
+	if _, err := io.WriteString(w, ofxHeader); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	return encoder.Encode(doc)
+}
+
+// ofxDate formats a time as YYYYMMDDHHMMSS.XXX[offset:TZ]. The TZ name is
+// optional in OFX and omitted for numeric abbreviations such as the "-03"
+// of America/Sao_Paulo, which would read as a second offset.
+func (e *Exporter) ofxDate(t time.Time) string {
+	local := t.In(e.Location)
+	name, offset := local.Zone()
+	if strings.HasPrefix(name, "+") || strings.HasPrefix(name, "-") {
+		return fmt.Sprintf("%s[%g]", local.Format("20060102150405.000"), float64(offset)/3600)
+	}
+	return fmt.Sprintf("%s[%g:%s]", local.Format("20060102150405.000"), float64(offset)/3600, name)
+}
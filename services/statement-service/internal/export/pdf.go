@@ -0,0 +1,154 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fintech-bank-platform/pkg/currency"
+	"github.com/fintech-bank-platform/statement-service/internal/contracts"
+)
+
+const (
+	pdfLinesPerPage = 60
+	pdfFontSize     = 9
+	pdfLeading      = 12
+	pdfPageWidth    = 595 // A4 in points
+	pdfPageHeight   = 842
+	pdfMargin       = 40
+)
+
+// PDF writes a plain A4 statement in a monospaced built-in font, so no font
+// files or external libraries are needed
+func (e *Exporter) PDF(w io.Writer, st *contracts.Statement) error {
+	lines := e.pdfLines(st)
+
+	var pages [][]string
+	for len(lines) > 0 {
+		n := min(len(lines), pdfLinesPerPage)
+		// Capped, so appending the footer cannot overwrite the next page
+		pages = append(pages, lines[:n:n])
+		lines = lines[n:]
+	}
+
+	doc := &pdfWriter{}
+	doc.write("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objects: 1 catalog, 2 page tree, 3 font, then a page and its content
+	// stream for each page
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 4+2*i)
+	}
+	doc.object("<< /Type /Catalog /Pages 2 0 R >>")
+	doc.object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	doc.object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range pages {
+		footer := fmt.Sprintf("Página %d/%d", i+1, len(pages))
+		content := pdfContent(append(page, "", footer))
+		doc.object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, 5+2*i))
+		doc.object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content))
+	}
+
+	doc.trailer()
+	_, err := w.Write(doc.buf.Bytes())
+	return err
+}
+
+func (e *Exporter) pdfLines(st *contracts.Statement) []string {
+	cur := currencyOf(st)
+	// number formats an amount as 1.234,56
+	number := func(amount float64) string {
+		return cur.FormatNumber(amount, currency.LocalePtBR)
+	}
+
+	lines := []string{
+		"EXTRATO DE CONTA",
+		"",
+		"Conta:   " + st.AccountID,
+		fmt.Sprintf("Período: %s a %s", st.From.In(e.Location).Format("02/01/2006"), lastDay(st, e.Location).Format("02/01/2006")),
+		"Moeda:   " + st.Currency,
+		"",
+		fmt.Sprintf("%-16s  %-40s  %14s  %14s", "Data", "Descrição", "Valor", "Saldo"),
+		strings.Repeat("-", 90),
+		fmt.Sprintf("%-16s  %-40s  %14s  %14s", "", "Saldo anterior", "", number(st.OpeningBalance)),
+	}
+
+	for _, line := range st.Lines {
+		lines = append(lines, fmt.Sprintf("%-16s  %-40s  %14s  %14s",
+			line.PostedAt.In(e.Location).Format("02/01/2006 15:04"),
+			truncate(description(line), 40),
+			number(signed(line)),
+			number(line.Balance),
+		))
+	}
+
+	return append(lines,
+		strings.Repeat("-", 90),
+		fmt.Sprintf("%-16s  %-40s  %14s", "", "Total de créditos", number(st.TotalCredits)),
+		fmt.Sprintf("%-16s  %-40s  %14s", "", "Total de débitos", number(-st.TotalDebits)),
+		fmt.Sprintf("%-16s  %-40s  %14s  %14s", "", "Saldo final", "", number(st.ClosingBalance)),
+	)
+}
+
+func truncate(value string, max int) string {
+	runes := []rune(value)
+	if len(runes) <= max {
+		return value
+	}
+	return string(runes[:max-3]) + "..."
+}
+
+func pdfContent(lines []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "BT\n/F1 %d Tf\n%d TL\n%d %d Td\n", pdfFontSize, pdfLeading, pdfMargin, pdfPageHeight-pdfMargin)
+	for _, line := range lines {
+		fmt.Fprintf(&b, "(%s) Tj T*\n", pdfText(line))
+	}
+	b.WriteString("ET")
+	return b.String()
+}
+
+// pdfText encodes a line as WinAnsi (Latin-1 for the accents used in
+// pt-BR) and escapes the string delimiters
+func pdfText(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 256:
+			b.WriteByte(byte(r))
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+type pdfWriter struct {
+	buf     bytes.Buffer
+	offsets []int
+}
+
+func (p *pdfWriter) write(s string) {
+	p.buf.WriteString(s)
+}
+
+func (p *pdfWriter) object(body string) {
+	p.offsets = append(p.offsets, p.buf.Len())
+	p.write(fmt.Sprintf("%d 0 obj\n%s\nendobj\n", len(p.offsets), body))
+}
+
+func (p *pdfWriter) trailer() {
+	xref := p.buf.Len()
+	p.write(fmt.Sprintf("xref\n0 %d\n0000000000 65535 f \n", len(p.offsets)+1))
+	for _, offset := range p.offsets {
+		p.write(fmt.Sprintf("%010d 00000 n \n", offset))
+	}
+	p.write(fmt.Sprintf("trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(p.offsets)+1, xref))
+}
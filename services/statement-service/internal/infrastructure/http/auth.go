@@ -0,0 +1,71 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/fintech-bank-platform/pkg/apikey"
+	"github.com/fintech-bank-platform/pkg/errors"
+	"github.com/fintech-bank-platform/pkg/response"
+	"github.com/fintech-bank-platform/statement-service/internal/contracts"
+	"github.com/go-chi/chi/v5"
+)
+
+const apiKeyScheme = "ApiKey"
+
+type keyContextKey struct{}
+
+// RequireAPIKey authenticates "Authorization: ApiKey <token>" requests and
+// rejects every other request with 401
+func RequireAPIKey(keys *apikey.Manager) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scheme, token, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+			if !strings.EqualFold(scheme, apiKeyScheme) {
+				w.Header().Set("WWW-Authenticate", apiKeyScheme)
+				response.AppErrorFor(w, r, errors.ErrUnauthorized)
+				return
+			}
+
+			key, err := keys.Authenticate(r.Context(), strings.TrimSpace(token))
+			if err != nil {
+				w.Header().Set("WWW-Authenticate", apiKeyScheme)
+				response.FromErrorFor(w, r, err)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), keyContextKey{}, key)))
+		})
+	}
+}
+
+// RequireAccountOwner rejects keys whose owner does not own the {accountID}
+// of the route with 403, unless they have contracts.ScopeAccountsAdmin.
+// Unknown accounts are also 403, so account IDs cannot be probed. It runs
+// after RequireAPIKey.
+func RequireAccountOwner(owners contracts.OwnerRepository) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key, ok := r.Context().Value(keyContextKey{}).(*apikey.Key)
+			if !ok {
+				response.AppErrorFor(w, r, errors.ErrUnauthorized)
+				return
+			}
+			if key.HasScope(contracts.ScopeAccountsAdmin) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			owner, err := owners.Owner(r.Context(), chi.URLParam(r, "accountID"))
+			if err != nil && !errors.ErrAccountNotFound.Is(err) {
+				response.FromErrorFor(w, r, err)
+				return
+			}
+			if err != nil || owner != key.Owner {
+				response.AppErrorFor(w, r, errors.ErrForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/fintech-bank-platform/pkg/errors"
+	"github.com/fintech-bank-platform/pkg/response"
+	"github.com/fintech-bank-platform/statement-service/internal/contracts"
+	"github.com/fintech-bank-platform/statement-service/internal/export"
+	"github.com/fintech-bank-platform/statement-service/internal/statement"
+	"github.com/go-chi/chi/v5"
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/rs/zerolog"
+)
+
+const dateLayout = "2006-01-02"
+
+type StatementHandler struct {
+	service  *statement.Service
+	exporter *export.Exporter
+	config   contracts.StatementConfig
+	cursors  *response.CursorCodec
+	logger   zerolog.Logger
+	now      func() time.Time
+}
+
+type Option func(*StatementHandler)
+
+func WithClock(now func() time.Time) Option {
+	return func(h *StatementHandler) {
+		h.now = now
+	}
+}
+
+func NewStatementHandler(service *statement.Service, exporter *export.Exporter, cfg contracts.StatementConfig, logger zerolog.Logger, opts ...Option) *StatementHandler {
+	h := &StatementHandler{
+		service:  service,
+		exporter: exporter,
+		config:   cfg,
+		cursors:  response.NewCursorCodec([]byte(cfg.CursorSecret)),
+		logger:   logger,
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+func healthHandler(w http.ResponseWriter, r *http.Request) {
+	response.OK(w, map[string]string{"status": "healthy"})
+}
+
+// Show serves GET /v1/accounts/{accountID}/statement?from=&to= as JSON
+// paginated by signed cursors and Link headers, or the whole range as a file
+// when format is csv, ofx or pdf. Dates are inclusive calendar days; the
+// default range is the current month.
+func (h *StatementHandler) Show(w http.ResponseWriter, r *http.Request) {
+	query, format, err := h.parse(r)
+	if err != nil {
+		response.FromErrorFor(w, r, err)
+		return
+	}
+
+	page, err := h.service.Statement(r.Context(), query)
+	if err != nil {
+		response.FromErrorFor(w, r, err)
+		return
+	}
+
+	if format == "" {
+		response.Paginated(w, r, page.Statement, &response.Meta{
+			RequestID:  chiMiddleware.GetReqID(r.Context()),
+			PerPage:    query.Limit,
+			NextCursor: h.encode(page.Next),
+			PrevCursor: h.encode(page.Prev),
+			HasMore:    page.HasMore,
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", `attachment; filename="`+h.exporter.Filename(page.Statement, format)+`"`)
+	switch format {
+	case export.FormatCSV:
+		err = h.exporter.CSV(w, page.Statement)
+	case export.FormatOFX:
+		err = h.exporter.OFX(w, page.Statement, h.now())
+	default:
+		err = h.exporter.PDF(w, page.Statement)
+	}
+	if err != nil {
+		h.logger.Warn().Err(err).Str("account_id", query.AccountID).Str("format", string(format)).Msg("Statement export interrupted")
+	}
+}
+
+func (h *StatementHandler) parse(r *http.Request) (statement.Query, export.Format, error) {
+	params := r.URL.Query()
+	loc := h.exporter.Location
+	today := h.now().In(loc)
+
+	from := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, loc)
+	to := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, loc)
+
+	var err error
+	if value := params.Get("from"); value != "" {
+		if from, err = time.ParseInLocation(dateLayout, value, loc); err != nil {
+			return statement.Query{}, "", invalidField("from")
+		}
+	}
+	if value := params.Get("to"); value != "" {
+		if to, err = time.ParseInLocation(dateLayout, value, loc); err != nil {
+			return statement.Query{}, "", invalidField("to")
+		}
+	}
+	if to.Before(from) {
+		return statement.Query{}, "", invalidField("to")
+	}
+
+	var cursor statement.Cursor
+	pageRequest, err := response.ParsePageRequest(r, response.PageLimits{
+		Default: h.config.DefaultPageSize,
+		Max:     h.config.MaxPageSize,
+	}, h.cursors, &cursor)
+	if err != nil {
+		return statement.Query{}, "", err
+	}
+
+	query := statement.Query{
+		AccountID: chi.URLParam(r, "accountID"),
+		Currency:  h.config.Currency,
+		From:      from,
+		To:        to.AddDate(0, 0, 1),
+		Limit:     pageRequest.Limit,
+	}
+	if pageRequest.HasCursor() {
+		query.Cursor = &cursor
+	}
+	if value := params.Get("currency"); value != "" {
+		query.Currency = value
+	}
+
+	format := export.Format(params.Get("format"))
+	switch format {
+	case "", "json":
+		return query, "", nil
+	case export.FormatCSV, export.FormatOFX, export.FormatPDF:
+		query.Cursor, query.Limit = nil, 0
+		return query, format, nil
+	}
+	return statement.Query{}, "", invalidField("format")
+}
+
+// encode signs a page cursor; statement.Cursor always marshals
+func (h *StatementHandler) encode(cursor *statement.Cursor) string {
+	if cursor == nil {
+		return ""
+	}
+	token, _ := h.cursors.Encode(cursor)
+	return token
+}
+
+func invalidField(field string) error {
+	return errors.BadRequest(errors.ErrInvalidField.Code, errors.ErrInvalidField.Message).WithDetail("field", field)
+}
@@ -0,0 +1,20 @@
+package http
+
+import (
+	"github.com/fintech-bank-platform/pkg/apikey"
+	"github.com/fintech-bank-platform/statement-service/internal/contracts"
+	"github.com/go-chi/chi/v5"
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+// SetupRouter serves statements only to API keys of the account owner or
+// with contracts.ScopeAccountsAdmin
+func SetupRouter(router *chi.Mux, handler *StatementHandler, keys *apikey.Manager, owners contracts.OwnerRepository) {
+	router.Use(chiMiddleware.RequestID)
+	router.Use(chiMiddleware.Recoverer)
+	router.Use(chiMiddleware.StripSlashes)
+
+	router.Get("/health", healthHandler)
+	router.With(RequireAPIKey(keys), RequireAccountOwner(owners)).
+		Get("/v1/accounts/{accountID}/statement", handler.Show)
+}
@@ -0,0 +1,40 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/fintech-bank-platform/pkg/events"
+	"github.com/fintech-bank-platform/statement-service/internal/contracts"
+	"github.com/rs/zerolog"
+)
+
+// OwnerProjector records the owner of each account from account.events, for
+// the ownership check of the statement routes
+type OwnerProjector struct {
+	owners contracts.OwnerRepository
+	logger zerolog.Logger
+}
+
+func NewOwnerProjector(owners contracts.OwnerRepository, logger zerolog.Logger) *OwnerProjector {
+	return &OwnerProjector{owners: owners, logger: logger}
+}
+
+func (p *OwnerProjector) Subscribe(subscriber events.Subscriber) {
+	subscriber.Subscribe(events.Topics.AccountEvents, p.Handle)
+}
+
+func (p *OwnerProjector) Handle(ctx context.Context, event *events.Event) error {
+	if event.Type != events.EventTypes.AccountCreated {
+		return nil
+	}
+	var payload events.AccountCreatedPayload
+	if err := event.DecodePayload(&payload); err != nil {
+		return err
+	}
+	if err := p.owners.SaveOwner(ctx, payload.AccountID, payload.UserID); err != nil {
+		return err
+	}
+
+	p.logger.Debug().Str("event_id", event.ID).Str("account_id", payload.AccountID).Msg("Account owner projected")
+	return nil
+}
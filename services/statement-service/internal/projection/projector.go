@@ -0,0 +1,149 @@
+package projection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fintech-bank-platform/pkg/events"
+	"github.com/fintech-bank-platform/statement-service/internal/contracts"
+	"github.com/rs/zerolog"
+)
+
+var ErrOriginalNotFound = errors.New("reversed transaction not found in the statement")
+
+// creditTypes are the transaction types that add to the balance; every
+// other type is a debit
+var creditTypes = map[string]bool{
+	"credit":      true,
+	"deposit":     true,
+	"refund":      true,
+	"transfer_in": true,
+}
+
+// Projector builds the statement read model from transaction.events and
+// payment.events
+type Projector struct {
+	repository contracts.EntryRepository
+	logger     zerolog.Logger
+}
+
+func NewProjector(repository contracts.EntryRepository, logger zerolog.Logger) *Projector {
+	return &Projector{repository: repository, logger: logger}
+}
+
+func (p *Projector) Subscribe(subscriber events.Subscriber) {
+	subscriber.Subscribe(events.Topics.TransactionEvents, p.Handle)
+	subscriber.Subscribe(events.Topics.PaymentEvents, p.Handle)
+}
+
+func (p *Projector) Handle(ctx context.Context, event *events.Event) error {
+	entry, err := p.entry(ctx, event)
+	if err != nil || entry == nil {
+		return err
+	}
+
+	entry.EventID = event.ID
+	if entry.PostedAt.IsZero() {
+		entry.PostedAt = event.Timestamp
+	}
+
+	saved, err := p.repository.Save(ctx, entry)
+	if err != nil {
+		return err
+	}
+
+	p.logger.Debug().
+		Str("event_id", event.ID).
+		Str("account_id", entry.AccountID).
+		Str("entry_id", entry.ID).
+		Bool("duplicate", !saved).
+		Msg("Statement entry projected")
+	return nil
+}
+
+func (p *Projector) entry(ctx context.Context, event *events.Event) (*contracts.Entry, error) {
+	switch event.Type {
+	case events.EventTypes.TransactionCompleted:
+		var payload events.TransactionCompletedPayload
+		if err := event.DecodePayload(&payload); err != nil {
+			return nil, err
+		}
+		direction := contracts.Debit
+		if creditTypes[payload.Type] {
+			direction = contracts.Credit
+		}
+		return &contracts.Entry{
+			ID:        payload.TransactionID,
+			AccountID: payload.AccountID,
+			Kind:      payload.Type,
+			Direction: direction,
+			Amount:    payload.Amount,
+			Currency:  payload.Currency,
+			PostedAt:  payload.CompletedAt,
+		}, nil
+
+	case events.EventTypes.TransactionReversed:
+		var payload events.TransactionReversedPayload
+		if err := event.DecodePayload(&payload); err != nil {
+			return nil, err
+		}
+		original, err := p.repository.Find(ctx, payload.AccountID, payload.OriginalTransactionID)
+		if err != nil {
+			return nil, err
+		}
+		if original == nil {
+			return nil, fmt.Errorf("%w: %s", ErrOriginalNotFound, payload.OriginalTransactionID)
+		}
+		direction := contracts.Credit
+		if original.Direction == contracts.Credit {
+			direction = contracts.Debit
+		}
+		return &contracts.Entry{
+			ID:          payload.ReversalID,
+			AccountID:   payload.AccountID,
+			Kind:        "reversal",
+			Direction:   direction,
+			Amount:      payload.Amount,
+			Currency:    payload.Currency,
+			Description: payload.ReasonCode,
+			Reference:   payload.OriginalTransactionID,
+			PostedAt:    payload.ReversedAt,
+		}, nil
+
+	case events.EventTypes.PaymentCompleted:
+		var payload events.PaymentCompletedPayload
+		if err := event.DecodePayload(&payload); err != nil {
+			return nil, err
+		}
+		return &contracts.Entry{
+			ID:          payload.PaymentID,
+			AccountID:   payload.AccountID,
+			Kind:        "payment",
+			Direction:   contracts.Debit,
+			Amount:      payload.Amount,
+			Currency:    payload.Currency,
+			Description: payload.PaymentMethod,
+			Reference:   payload.ExternalID,
+			PostedAt:    payload.CompletedAt,
+		}, nil
+
+	case events.EventTypes.PaymentRefunded:
+		var payload events.PaymentRefundedPayload
+		if err := event.DecodePayload(&payload); err != nil {
+			return nil, err
+		}
+		return &contracts.Entry{
+			ID:          payload.RefundID,
+			AccountID:   payload.AccountID,
+			Kind:        "refund",
+			Direction:   contracts.Credit,
+			Amount:      payload.Amount,
+			Currency:    payload.Currency,
+			Description: payload.Reason,
+			Reference:   payload.PaymentID,
+			PostedAt:    payload.RefundedAt,
+		}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,133 @@
+package statement
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/fintech-bank-platform/pkg/ledger"
+	"github.com/fintech-bank-platform/statement-service/internal/contracts"
+)
+
+// Cursor is the position of a page boundary: the posting time and ID of the
+// last line of the previous page, or of the first line of the next one when
+// Backward is set
+type Cursor struct {
+	At       time.Time `json:"at"`
+	ID       string    `json:"id"`
+	Backward bool      `json:"back,omitempty"`
+}
+
+// Query selects the entries of [From, To) in one currency. A zero Limit
+// returns the whole range, as used by the exports.
+type Query struct {
+	AccountID string
+	Currency  string
+	From      time.Time
+	To        time.Time
+	Cursor    *Cursor
+	Limit     int
+}
+
+type Page struct {
+	Statement *contracts.Statement
+	Next      *Cursor
+	Prev      *Cursor
+	HasMore   bool
+}
+
+type Service struct {
+	repository contracts.EntryRepository
+}
+
+func NewService(repository contracts.EntryRepository) *Service {
+	return &Service{repository: repository}
+}
+
+// Statement computes the balances from every entry before To, so they are
+// correct for any range regardless of the page requested
+func (s *Service) Statement(ctx context.Context, q Query) (*Page, error) {
+	entries, err := s.repository.Entries(ctx, q.AccountID, q.To)
+	if err != nil {
+		return nil, err
+	}
+
+	var opening, credits, debits int64
+	var inRange []*contracts.Entry
+	for _, entry := range entries {
+		if entry.Currency != q.Currency {
+			continue
+		}
+		if entry.PostedAt.Before(q.From) {
+			opening += entry.Signed()
+			continue
+		}
+		inRange = append(inRange, entry)
+		if entry.Direction == contracts.Credit {
+			credits += entry.Signed()
+		} else {
+			debits -= entry.Signed()
+		}
+	}
+
+	lines := make([]contracts.Line, len(inRange))
+	running := opening
+	for i, entry := range inRange {
+		running += entry.Signed()
+		lines[i] = contracts.Line{Entry: *entry, Balance: ledger.FromMinor(running, q.Currency)}
+	}
+
+	start, end := window(inRange, q.Cursor, q.Limit)
+	page := &Page{Statement: &contracts.Statement{
+		AccountID:      q.AccountID,
+		Currency:       q.Currency,
+		From:           q.From,
+		To:             q.To,
+		OpeningBalance: ledger.FromMinor(opening, q.Currency),
+		ClosingBalance: ledger.FromMinor(opening+credits-debits, q.Currency),
+		TotalCredits:   ledger.FromMinor(credits, q.Currency),
+		TotalDebits:    ledger.FromMinor(debits, q.Currency),
+		Lines:          lines[start:end],
+	}}
+
+	if start < end {
+		if end < len(lines) {
+			last := lines[end-1]
+			page.Next, page.HasMore = &Cursor{At: last.PostedAt, ID: last.ID}, true
+		}
+		if start > 0 {
+			first := lines[start]
+			page.Prev = &Cursor{At: first.PostedAt, ID: first.ID, Backward: true}
+		}
+	}
+	return page, nil
+}
+
+// window returns the bounds of the page: the limit entries after a forward
+// cursor, or before a backward one
+func window(entries []*contracts.Entry, cursor *Cursor, limit int) (int, int) {
+	start, end := 0, len(entries)
+	if cursor != nil {
+		// First entry at or after the cursor position
+		i := sort.Search(len(entries), func(i int) bool {
+			return !entries[i].Before(cursor.At, cursor.ID)
+		})
+		if cursor.Backward {
+			end = i
+		} else {
+			start = i
+			if i < len(entries) && entries[i].PostedAt.Equal(cursor.At) && entries[i].ID == cursor.ID {
+				start++
+			}
+		}
+	}
+
+	if limit > 0 {
+		if cursor != nil && cursor.Backward {
+			start = max(end-limit, 0)
+		} else {
+			end = min(start+limit, end)
+		}
+	}
+	return start, end
+}
@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fintech-bank-platform/pkg/errors"
+	"github.com/fintech-bank-platform/statement-service/internal/contracts"
+)
+
+// MemoryRepository keeps the entries of each account sorted by posting time
+// and the account owners
+type MemoryRepository struct {
+	mu      sync.RWMutex
+	entries map[string][]*contracts.Entry
+	owners  map[string]string
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{entries: make(map[string][]*contracts.Entry), owners: make(map[string]string)}
+}
+
+func (r *MemoryRepository) Save(_ context.Context, entry *contracts.Entry) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entries := r.entries[entry.AccountID]
+	for _, existing := range entries {
+		if existing.ID == entry.ID {
+			return false, nil
+		}
+	}
+
+	saved := *entry
+	i := sort.Search(len(entries), func(i int) bool { return !entries[i].Before(saved.PostedAt, saved.ID) })
+	entries = append(entries, nil)
+	copy(entries[i+1:], entries[i:])
+	entries[i] = &saved
+	r.entries[entry.AccountID] = entries
+	return true, nil
+}
+
+func (r *MemoryRepository) Find(_ context.Context, accountID, id string) (*contracts.Entry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, entry := range r.entries[accountID] {
+		if entry.ID == id {
+			found := *entry
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *MemoryRepository) Entries(_ context.Context, accountID string, before time.Time) ([]*contracts.Entry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*contracts.Entry
+	for _, entry := range r.entries[accountID] {
+		if !entry.PostedAt.Before(before) {
+			break
+		}
+		copied := *entry
+		result = append(result, &copied)
+	}
+	return result, nil
+}
+
+func (r *MemoryRepository) SaveOwner(_ context.Context, accountID, owner string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.owners[accountID] = owner
+	return nil
+}
+
+func (r *MemoryRepository) Owner(_ context.Context, accountID string) (string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	owner, ok := r.owners[accountID]
+	if !ok {
+		return "", errors.ErrAccountNotFound
+	}
+	return owner, nil
+}
@@ -0,0 +1,285 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Feature Test: Events → Statement → Exports
+// ═══════════════════════════════════════════════════════════════════════════
+
+package feature
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fintech-bank-platform/pkg/events"
+	"github.com/fintech-bank-platform/statement-service/tests"
+	"github.com/stretchr/testify/suite"
+)
+
+type StatementFlowTestSuite struct {
+	suite.Suite
+	app *tests.App
+}
+
+func TestStatementFlowSuite(t *testing.T) {
+	suite.Run(t, new(StatementFlowTestSuite))
+}
+
+func at(month time.Month, day, hour int) time.Time {
+	return time.Date(2026, month, day, hour, 0, 0, 0, tests.BRT)
+}
+
+func (s *StatementFlowTestSuite) SetupTest() {
+	s.app = tests.NewApp(at(time.March, 20, 12))
+	ctx := context.Background()
+
+	published := []struct {
+		topic string
+		event *events.Event
+	}{
+		{events.Topics.TransactionEvents, tests.TransactionCompleted("dep-1", "acc-1", "deposit", 1000, at(time.February, 20, 9))},
+		{events.Topics.PaymentEvents, tests.PaymentCompleted("pay-1", "acc-1", 200, at(time.March, 2, 10))},
+		{events.Topics.TransactionEvents, tests.TransactionCompleted("cred-1", "acc-1", "credit", 300, at(time.March, 5, 10))},
+		{events.Topics.TransactionEvents, tests.TransactionCompleted("wd-1", "acc-1", "withdrawal", 50, at(time.March, 10, 10))},
+		{events.Topics.TransactionEvents, events.NewTransactionEvent(events.EventTypes.TransactionReversed, events.TransactionReversedPayload{
+			ReversalID: "rev-1", OriginalTransactionID: "wd-1", AccountID: "acc-1", Amount: 50, Currency: "BRL",
+			ReasonCode: "DUPLICATE", ReversedAt: at(time.March, 11, 10),
+		})},
+		{events.Topics.PaymentEvents, events.NewPaymentEvent(events.EventTypes.PaymentRefunded, events.PaymentRefundedPayload{
+			RefundID: "ref-1", PaymentID: "pay-1", AccountID: "acc-1", Amount: 20, Currency: "BRL", RefundedAt: at(time.March, 15, 10),
+		})},
+		{events.Topics.TransactionEvents, tests.TransactionCompleted("dep-2", "acc-1", "deposit", 999, at(time.April, 2, 9))},
+		{events.Topics.TransactionEvents, tests.TransactionCompleted("other", "acc-2", "deposit", 5, at(time.March, 3, 9))},
+	}
+	for _, p := range published {
+		s.Require().NoError(s.app.Bus.Publish(ctx, p.topic, p.event))
+	}
+}
+
+func (s *StatementFlowTestSuite) get(path string) map[string]interface{} {
+	rec := s.app.Get(path)
+	s.Require().Equal(http.StatusOK, rec.Code, rec.Body.String())
+
+	var body map[string]interface{}
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &body))
+	return body
+}
+
+func (s *StatementFlowTestSuite) TestStatementBalancesForRange() {
+	body := s.get("/v1/accounts/acc-1/statement?from=2026-03-01&to=2026-03-31")
+	data := body["data"].(map[string]interface{})
+
+	s.Equal(1000.0, data["opening_balance"])
+	s.Equal(1120.0, data["closing_balance"])
+	s.Equal(370.0, data["total_credits"])
+	s.Equal(250.0, data["total_debits"])
+
+	entries := data["entries"].([]interface{})
+	s.Len(entries, 5)
+	var balances []float64
+	for _, entry := range entries {
+		balances = append(balances, entry.(map[string]interface{})["balance"].(float64))
+	}
+	s.Equal([]float64{800, 1100, 1050, 1100, 1120}, balances)
+	s.Equal("credit", entries[3].(map[string]interface{})["direction"], "reversal of a debit")
+	s.Equal(false, body["meta"].(map[string]interface{})["has_more"] != nil)
+}
+
+func (s *StatementFlowTestSuite) TestStatementDefaultsToCurrentMonth() {
+	data := s.get("/v1/accounts/acc-1/statement")["data"].(map[string]interface{})
+
+	s.Len(data["entries"], 5)
+	s.Equal(1120.0, data["closing_balance"])
+}
+
+// link returns the target of the rel link in the Link header
+func link(rec *httptest.ResponseRecorder, rel string) string {
+	for _, value := range strings.Split(rec.Header().Get("Link"), ", ") {
+		target, params, _ := strings.Cut(value, ">; ")
+		if params == `rel="`+rel+`"` {
+			return strings.TrimPrefix(target, "<")
+		}
+	}
+	return ""
+}
+
+func (s *StatementFlowTestSuite) page(path string) (*httptest.ResponseRecorder, []string, map[string]interface{}) {
+	rec := s.app.Get(path)
+	s.Require().Equal(http.StatusOK, rec.Code, rec.Body.String())
+
+	var body map[string]interface{}
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &body))
+	data := body["data"].(map[string]interface{})
+	s.Equal(1000.0, data["opening_balance"], "balances cover the whole range on every page")
+	s.Equal(1120.0, data["closing_balance"])
+
+	var ids []string
+	for _, entry := range data["entries"].([]interface{}) {
+		ids = append(ids, entry.(map[string]interface{})["id"].(string))
+	}
+	return rec, ids, body["meta"].(map[string]interface{})
+}
+
+func (s *StatementFlowTestSuite) TestStatementCursorPagination() {
+	rec, ids, meta := s.page("/v1/accounts/acc-1/statement?from=2026-03-01&to=2026-03-31&limit=2")
+	s.Equal([]string{"pay-1", "cred-1"}, ids)
+	s.Equal(2.0, meta["per_page"])
+	s.Equal(true, meta["has_more"])
+	s.Nil(meta["prev_cursor"])
+	s.Empty(link(rec, "prev"))
+	s.Contains(link(rec, "next"), "cursor="+meta["next_cursor"].(string))
+	s.Contains(link(rec, "next"), "from=2026-03-01")
+
+	rec, ids, meta = s.page(link(rec, "next"))
+	s.Equal([]string{"wd-1", "rev-1"}, ids)
+	s.NotNil(meta["prev_cursor"])
+	s.Equal("/v1/accounts/acc-1/statement?from=2026-03-01&limit=2&to=2026-03-31", link(rec, "first"))
+
+	last, ids, meta := s.page(link(rec, "next"))
+	s.Equal([]string{"ref-1"}, ids)
+	s.Nil(meta["has_more"])
+	s.Nil(meta["next_cursor"])
+	s.Empty(link(last, "next"))
+
+	_, ids, _ = s.page(link(last, "prev"))
+	s.Equal([]string{"wd-1", "rev-1"}, ids)
+	_, ids, meta = s.page(link(rec, "prev"))
+	s.Equal([]string{"pay-1", "cred-1"}, ids)
+	s.Nil(meta["prev_cursor"])
+}
+
+func (s *StatementFlowTestSuite) TestStatementRejectsTamperedCursor() {
+	rec, _, meta := s.page("/v1/accounts/acc-1/statement?from=2026-03-01&to=2026-03-31&limit=2")
+	s.Require().NotEmpty(link(rec, "next"))
+	cursor := meta["next_cursor"].(string)
+
+	forged := s.app.Get("/v1/accounts/acc-1/statement?limit=2&cursor=x" + cursor[1:])
+
+	s.Equal(http.StatusBadRequest, forged.Code)
+	s.Contains(forged.Body.String(), "INVALID_CURSOR")
+}
+
+func (s *StatementFlowTestSuite) TestStatementForEarlierRange() {
+	data := s.get("/v1/accounts/acc-1/statement?from=2026-03-03&to=2026-03-10")["data"].(map[string]interface{})
+
+	s.Equal(800.0, data["opening_balance"])
+	s.Equal(1050.0, data["closing_balance"])
+	s.Len(data["entries"], 2)
+}
+
+func (s *StatementFlowTestSuite) TestDuplicateEventsAreProjectedOnce() {
+	event := tests.TransactionCompleted("cred-1", "acc-1", "credit", 300, at(time.March, 5, 10))
+	s.Require().NoError(s.app.Bus.Publish(context.Background(), events.Topics.TransactionEvents, event))
+
+	data := s.get("/v1/accounts/acc-1/statement?from=2026-03-01&to=2026-03-31")["data"].(map[string]interface{})
+	s.Equal(1120.0, data["closing_balance"])
+}
+
+func (s *StatementFlowTestSuite) TestCSVExport() {
+	rec := s.app.Get("/v1/accounts/acc-1/statement?from=2026-03-01&to=2026-03-31&format=csv&limit=1")
+
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal("text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+	s.Equal(`attachment; filename="statement-acc-1-20260301-20260331.csv"`, rec.Header().Get("Content-Disposition"))
+
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	s.Len(lines, 6, "exports ignore pagination")
+	s.Equal("date,id,kind,description,reference,amount,currency,balance", lines[0])
+	s.Equal("2026-03-02 10:00:00,pay-1,payment,pix,,-200.00,BRL,800.00", lines[1])
+	s.Equal("2026-03-11 10:00:00,rev-1,reversal,DUPLICATE,wd-1,50.00,BRL,1100.00", lines[4])
+}
+
+func (s *StatementFlowTestSuite) TestOFXExport() {
+	rec := s.app.Get("/v1/accounts/acc-1/statement?from=2026-03-01&to=2026-03-31&format=ofx")
+	body := rec.Body.String()
+
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal("application/x-ofx", rec.Header().Get("Content-Type"))
+	s.True(strings.HasPrefix(body, `<?xml version="1.0" encoding="UTF-8" standalone="no"?>`))
+	s.Contains(body, `<?OFX OFXHEADER="200" VERSION="220"`)
+	s.Contains(body, "<DTSTART>20260301000000.000[-3:BRT]</DTSTART>")
+	s.Contains(body, "<DTEND>20260331235959.999[-3:BRT]</DTEND>")
+	s.Equal(5, strings.Count(body, "<STMTTRN>"))
+	s.Contains(body, "<TRNAMT>-200.00</TRNAMT>")
+	s.Contains(body, "<BALAMT>1120.00</BALAMT>")
+	s.Contains(body, "<DTSERVER>20260320120000.000[-3:BRT]</DTSERVER>")
+}
+
+func (s *StatementFlowTestSuite) TestPDFExport() {
+	rec := s.app.Get("/v1/accounts/acc-1/statement?from=2026-03-01&to=2026-03-31&format=pdf")
+	body := rec.Body.String()
+
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal("application/pdf", rec.Header().Get("Content-Type"))
+	s.True(strings.HasPrefix(body, "%PDF-1.4"))
+	s.True(strings.HasSuffix(body, "%%EOF\n"))
+	s.Contains(body, "Saldo final")
+	s.Contains(body, "1.120,00")
+}
+
+func (s *StatementFlowTestSuite) TestInvalidQueries() {
+	for _, query := range []string{
+		"from=03-2026", "to=yesterday", "from=2026-03-10&to=2026-03-01",
+		"limit=0", "limit=abc", "limit=101", "format=xlsx",
+	} {
+		rec := s.app.Get("/v1/accounts/acc-1/statement?" + query)
+		s.Equal(http.StatusBadRequest, rec.Code, query)
+		s.Contains(rec.Body.String(), "INVALID_FIELD", query)
+	}
+
+	rec := s.app.Get("/v1/accounts/acc-1/statement?cursor=not-a-cursor")
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Contains(rec.Body.String(), "INVALID_CURSOR")
+}
+
+func (s *StatementFlowTestSuite) TestInvalidQueryAsProblemDetails() {
+	req := tests.Authorized(httptest.NewRequest(http.MethodGet, "/v1/accounts/acc-1/statement?from=yesterday", nil), s.app.AdminToken)
+	req.Header.Set("Accept", "application/problem+json")
+	req.Header.Set("X-Request-ID", "req-42")
+	rec := httptest.NewRecorder()
+
+	s.app.Router.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Equal("application/problem+json", rec.Header().Get("Content-Type"))
+	var problem map[string]interface{}
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &problem))
+	s.Equal("INVALID_FIELD", problem["code"])
+	s.Equal("from", problem["field"])
+	s.Equal("req-42", problem["instance"])
+	s.Equal(400.0, problem["status"])
+}
+
+func (s *StatementFlowTestSuite) TestStatementRequiresAPIKey() {
+	for _, token := range []string{"", "fk_unknown_secret"} {
+		rec := s.app.GetAs(token, "/v1/accounts/acc-1/statement")
+
+		s.Equal(http.StatusUnauthorized, rec.Code, token)
+		s.Equal("ApiKey", rec.Header().Get("WWW-Authenticate"))
+	}
+}
+
+func (s *StatementFlowTestSuite) TestStatementOnlyForAccountOwner() {
+	ctx := context.Background()
+	s.Require().NoError(s.app.Bus.Publish(ctx, events.Topics.AccountEvents, tests.AccountCreated("acc-1", "user-1")))
+	owner := s.app.CreateKey("user-1")
+	other := s.app.CreateKey("user-2")
+
+	s.Equal(http.StatusOK, s.app.GetAs(owner, "/v1/accounts/acc-1/statement").Code)
+
+	for _, path := range []string{"/v1/accounts/acc-1/statement", "/v1/accounts/acc-2/statement"} {
+		rec := s.app.GetAs(other, path)
+		s.Equal(http.StatusForbidden, rec.Code, path)
+		s.Contains(rec.Body.String(), "FORBIDDEN")
+	}
+	s.Equal(http.StatusForbidden, s.app.GetAs(owner, "/v1/accounts/acc-2/statement").Code)
+}
+
+func (s *StatementFlowTestSuite) TestHealth() {
+	rec := s.app.Get("/health")
+
+	s.Equal(http.StatusOK, rec.Code)
+	s.Contains(rec.Body.String(), "healthy")
+}
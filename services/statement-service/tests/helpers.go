@@ -0,0 +1,232 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Test Helpers - Fixtures shared by unit and feature tests
+// ═══════════════════════════════════════════════════════════════════════════
+
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/fintech-bank-platform/pkg/apikey"
+	"github.com/fintech-bank-platform/pkg/events"
+	"github.com/fintech-bank-platform/statement-service/internal/contracts"
+	"github.com/fintech-bank-platform/statement-service/internal/export"
+	appHttp "github.com/fintech-bank-platform/statement-service/internal/infrastructure/http"
+	"github.com/fintech-bank-platform/statement-service/internal/projection"
+	"github.com/fintech-bank-platform/statement-service/internal/statement"
+	"github.com/fintech-bank-platform/statement-service/internal/storage"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+)
+
+var (
+	// BRT is a fixed UTC-3 zone so tests do not depend on the tz database
+	BRT = time.FixedZone("BRT", -3*60*60)
+
+	ErrWrite = errors.New("connection reset")
+)
+
+func Config() contracts.StatementConfig {
+	return contracts.StatementConfig{DefaultPageSize: 50, MaxPageSize: 100, Currency: "BRL", BankID: "0001"}
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// App - the service wired over an in-memory bus
+// ═══════════════════════════════════════════════════════════════════════════
+
+type App struct {
+	Bus        *events.MemoryBus
+	Repository *storage.MemoryRepository
+	Router     *chi.Mux
+	Keys       *apikey.Manager
+	// AdminToken has contracts.ScopeAccountsAdmin and is sent by Get
+	AdminToken string
+}
+
+func NewApp(now time.Time) *App {
+	keys, adminToken := AdminKeys()
+	app := &App{
+		Bus:        events.NewMemoryBus(),
+		Repository: storage.NewMemoryRepository(),
+		Router:     chi.NewRouter(),
+		Keys:       keys,
+		AdminToken: adminToken,
+	}
+	projection.NewProjector(app.Repository, zerolog.Nop()).Subscribe(app.Bus)
+	projection.NewOwnerProjector(app.Repository, zerolog.Nop()).Subscribe(app.Bus)
+
+	handler := appHttp.NewStatementHandler(
+		statement.NewService(app.Repository),
+		export.New("0001", BRT),
+		Config(),
+		zerolog.Nop(),
+		appHttp.WithClock(func() time.Time { return now }),
+	)
+	appHttp.SetupRouter(app.Router, handler, app.Keys, app.Repository)
+	return app
+}
+
+func (a *App) Get(path string) *httptest.ResponseRecorder {
+	return a.GetAs(a.AdminToken, path)
+}
+
+// GetAs sends the request with "Authorization: ApiKey <token>", or without
+// credentials when token is empty
+func (a *App) GetAs(token, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	a.Router.ServeHTTP(rec, Authorized(httptest.NewRequest(http.MethodGet, path, nil), token))
+	return rec
+}
+
+// CreateKey issues an accounts:read key owned by owner
+func (a *App) CreateKey(owner string) string {
+	issued, err := a.Keys.Create(context.Background(), apikey.CreateRequest{Name: owner, Owner: owner, Scopes: []string{"accounts:read"}})
+	if err != nil {
+		panic(err)
+	}
+	return issued.Token
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// API keys
+// ═══════════════════════════════════════════════════════════════════════════
+
+// AdminKeys returns a key manager holding one key with
+// contracts.ScopeAccountsAdmin, and its token
+func AdminKeys() (*apikey.Manager, string) {
+	keys := apikey.NewManager(apikey.NewMemoryStore())
+	issued, err := keys.Create(context.Background(), apikey.CreateRequest{
+		Name:   "admin",
+		Owner:  "platform",
+		Scopes: []string{contracts.ScopeAccountsAdmin},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return keys, issued.Token
+}
+
+func Authorized(req *http.Request, token string) *http.Request {
+	if token != "" {
+		req.Header.Set("Authorization", "ApiKey "+token)
+	}
+	return req
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// Events
+// ═══════════════════════════════════════════════════════════════════════════
+
+func TransactionCompleted(id, accountID, transactionType string, amount float64, at time.Time) *events.Event {
+	return events.NewTransactionEvent(events.EventTypes.TransactionCompleted, events.TransactionCompletedPayload{
+		TransactionID: id,
+		AccountID:     accountID,
+		Type:          transactionType,
+		Amount:        amount,
+		Currency:      "BRL",
+		Status:        "completed",
+		CompletedAt:   at,
+	})
+}
+
+func PaymentCompleted(id, accountID string, amount float64, at time.Time) *events.Event {
+	return events.NewPaymentEvent(events.EventTypes.PaymentCompleted, events.PaymentCompletedPayload{
+		PaymentID:     id,
+		AccountID:     accountID,
+		PaymentMethod: "pix",
+		Amount:        amount,
+		Currency:      "BRL",
+		Status:        "completed",
+		CompletedAt:   at,
+	})
+}
+
+func AccountCreated(accountID, userID string) *events.Event {
+	return events.NewAccountEvent(events.EventTypes.AccountCreated, events.AccountCreatedPayload{
+		AccountID:   accountID,
+		UserID:      userID,
+		AccountType: "checking",
+		Status:      "active",
+	})
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// Failing Writers
+// ═══════════════════════════════════════════════════════════════════════════
+
+// FailingWriter accepts limit bytes, then fails every write
+type FailingWriter struct {
+	Limit   int
+	written int
+}
+
+func (w *FailingWriter) Write(p []byte) (int, error) {
+	if w.written+len(p) > w.Limit {
+		return 0, ErrWrite
+	}
+	w.written += len(p)
+	return len(p), nil
+}
+
+// FailingResponseWriter is a ResponseWriter whose body writes fail
+type FailingResponseWriter struct {
+	*httptest.ResponseRecorder
+}
+
+func (w FailingResponseWriter) Write([]byte) (int, error) {
+	return 0, ErrWrite
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// Failing Repository
+// ═══════════════════════════════════════════════════════════════════════════
+
+var ErrStorage = errors.New("storage unavailable")
+
+// FailingRepository wraps a MemoryRepository and fails the flagged methods
+type FailingRepository struct {
+	*storage.MemoryRepository
+	FailSave    bool
+	FailFind    bool
+	FailEntries bool
+	FailOwners  bool
+}
+
+func (r *FailingRepository) Save(ctx context.Context, entry *contracts.Entry) (bool, error) {
+	if r.FailSave {
+		return false, ErrStorage
+	}
+	return r.MemoryRepository.Save(ctx, entry)
+}
+
+func (r *FailingRepository) Find(ctx context.Context, accountID, id string) (*contracts.Entry, error) {
+	if r.FailFind {
+		return nil, ErrStorage
+	}
+	return r.MemoryRepository.Find(ctx, accountID, id)
+}
+
+func (r *FailingRepository) Entries(ctx context.Context, accountID string, before time.Time) ([]*contracts.Entry, error) {
+	if r.FailEntries {
+		return nil, ErrStorage
+	}
+	return r.MemoryRepository.Entries(ctx, accountID, before)
+}
+
+func (r *FailingRepository) SaveOwner(ctx context.Context, accountID, owner string) error {
+	if r.FailOwners {
+		return ErrStorage
+	}
+	return r.MemoryRepository.SaveOwner(ctx, accountID, owner)
+}
+
+func (r *FailingRepository) Owner(ctx context.Context, accountID string) (string, error) {
+	if r.FailOwners {
+		return "", ErrStorage
+	}
+	return r.MemoryRepository.Owner(ctx, accountID)
+}
@@ -0,0 +1,79 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Unit Test: Config
+// ═══════════════════════════════════════════════════════════════════════════
+
+package unit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	pkgconfig "github.com/fintech-bank-platform/pkg/config"
+	"github.com/fintech-bank-platform/statement-service/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigDefaults(t *testing.T) {
+	cfg, err := config.New()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "0.0.0.0:8086", cfg.Server.Address())
+	assert.Equal(t, 30*time.Second, cfg.Server.ReadTimeout)
+	assert.Equal(t, 10*time.Second, cfg.Server.ShutdownTimeout)
+	assert.Equal(t, 50, cfg.Statement.DefaultPageSize)
+	assert.Equal(t, 200, cfg.Statement.MaxPageSize)
+	assert.Equal(t, "BRL", cfg.Statement.Currency)
+	assert.Equal(t, "0001", cfg.Statement.BankID)
+	assert.Empty(t, cfg.Statement.CursorSecret)
+	assert.Empty(t, cfg.APIKeys.AdminKey)
+}
+
+func TestConfigWithEnvVars(t *testing.T) {
+	t.Setenv("SERVER_PORT", "9000")
+	t.Setenv("SERVER_IDLE_TIMEOUT", "1m")
+	t.Setenv("STATEMENT_PAGE_SIZE", "20")
+	t.Setenv("STATEMENT_CURRENCY", "USD")
+	t.Setenv("STATEMENT_BANK_ID", "0260")
+	t.Setenv("STATEMENT_CURSOR_SECRET", "s3cr3t")
+
+	cfg, _ := config.New()
+
+	assert.Equal(t, "0.0.0.0:9000", cfg.Server.Address())
+	assert.Equal(t, time.Minute, cfg.Server.IdleTimeout)
+	assert.Equal(t, 20, cfg.Statement.DefaultPageSize)
+	assert.Equal(t, "USD", cfg.Statement.Currency)
+	assert.Equal(t, "0260", cfg.Statement.BankID)
+	assert.Equal(t, "s3cr3t", cfg.Statement.CursorSecret)
+}
+
+func TestConfigReportsInvalidValues(t *testing.T) {
+	t.Setenv("STATEMENT_MAX_PAGE_SIZE", "lots")
+	t.Setenv("SERVER_WRITE_TIMEOUT", "soon")
+	t.Setenv("STATEMENT_CURRENCY", "REAL")
+
+	cfg, err := config.New()
+
+	assert.Nil(t, cfg)
+	var configErr *pkgconfig.Error
+	require.ErrorAs(t, err, &configErr)
+	assert.Equal(t, []string{
+		`SERVER_WRITE_TIMEOUT: invalid duration "soon" (from environment)`,
+		`STATEMENT_MAX_PAGE_SIZE: invalid integer "lots" (from environment)`,
+		"STATEMENT_CURRENCY: must be exactly 3 characters long",
+	}, configErr.Problems)
+}
+
+func TestConfigCursorSecretFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cursor_secret")
+	require.NoError(t, os.WriteFile(path, []byte("from-docker-secret\n"), 0o600))
+	t.Setenv("STATEMENT_CURSOR_SECRET_FILE", path)
+
+	cfg, err := config.New()
+
+	require.NoError(t, err)
+	assert.Equal(t, "from-docker-secret", cfg.Statement.CursorSecret)
+	assert.Equal(t, pkgconfig.Redacted, pkgconfig.Dump(cfg)["STATEMENT_CURSOR_SECRET"])
+}
@@ -0,0 +1,175 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Unit Test: Exports
+// ═══════════════════════════════════════════════════════════════════════════
+
+package unit
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fintech-bank-platform/statement-service/internal/contracts"
+	"github.com/fintech-bank-platform/statement-service/internal/export"
+	"github.com/fintech-bank-platform/statement-service/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleStatement(lines int) *contracts.Statement {
+	from := time.Date(2026, time.March, 1, 0, 0, 0, 0, tests.BRT)
+	st := &contracts.Statement{
+		AccountID:      "acc-1",
+		Currency:       "BRL",
+		From:           from,
+		To:             from.AddDate(0, 1, 0),
+		OpeningBalance: 1234567.5,
+		ClosingBalance: 1234567.5,
+	}
+	for i := 0; i < lines; i++ {
+		st.Lines = append(st.Lines, contracts.Line{
+			Entry: contracts.Entry{
+				ID: "t-1", Kind: "deposit", Direction: contracts.Credit, Amount: 10, Currency: "BRL",
+				PostedAt: from.Add(time.Duration(i) * time.Minute),
+			},
+			Balance: 1234567.5,
+		})
+	}
+	return st
+}
+
+func TestFormatContentType(t *testing.T) {
+	assert.Equal(t, "text/csv; charset=utf-8", export.FormatCSV.ContentType())
+	assert.Equal(t, "application/x-ofx", export.FormatOFX.ContentType())
+	assert.Equal(t, "application/pdf", export.FormatPDF.ContentType())
+}
+
+func TestFilenameUsesInclusiveRange(t *testing.T) {
+	exporter := export.New("0001", tests.BRT)
+
+	assert.Equal(t, "statement-acc-1-20260301-20260331.pdf", exporter.Filename(sampleStatement(0), export.FormatPDF))
+}
+
+func TestCSVQuotesFields(t *testing.T) {
+	st := sampleStatement(1)
+	st.Lines[0].Description = `Pagamento, "loja"`
+	var buf bytes.Buffer
+
+	require.NoError(t, export.New("0001", tests.BRT).CSV(&buf, st))
+
+	assert.Contains(t, buf.String(), `"Pagamento, ""loja"""`)
+}
+
+func TestCSVWriteError(t *testing.T) {
+	err := export.New("0001", tests.BRT).CSV(&tests.FailingWriter{}, sampleStatement(1))
+
+	assert.ErrorIs(t, err, tests.ErrWrite)
+}
+
+func TestExportsUseStatementCurrency(t *testing.T) {
+	exporter := export.New("0001", tests.BRT)
+
+	for code, want := range map[string][3]string{
+		"KWD": {"10.000", "1234567.500", "1.234.567,500"},
+		"JPY": {"10", "1234568", "1.234.568"},
+		"XYZ": {"10.00", "1234567.50", "1.234.567,50"},
+	} {
+		st := sampleStatement(1)
+		st.Currency, st.Lines[0].Currency = code, code
+		var csvBuf, ofxBuf, pdfBuf bytes.Buffer
+
+		require.NoError(t, exporter.CSV(&csvBuf, st))
+		require.NoError(t, exporter.OFX(&ofxBuf, st, st.From))
+		require.NoError(t, exporter.PDF(&pdfBuf, st))
+
+		assert.Contains(t, csvBuf.String(), ","+want[0]+","+code+","+want[1]+"\n", code)
+		assert.Contains(t, ofxBuf.String(), "<TRNAMT>"+want[0]+"</TRNAMT>", code)
+		assert.Contains(t, ofxBuf.String(), "<BALAMT>"+want[1]+"</BALAMT>", code)
+		assert.Contains(t, pdfBuf.String(), want[2], code)
+	}
+}
+
+func TestOFXDebitsAndMemo(t *testing.T) {
+	st := sampleStatement(1)
+	st.Lines[0].Direction = contracts.Debit
+	st.Lines[0].Description = "pix"
+	var buf bytes.Buffer
+
+	require.NoError(t, export.New("0260", tests.BRT).OFX(&buf, st, st.From))
+
+	assert.Contains(t, buf.String(), "<TRNTYPE>DEBIT</TRNTYPE>")
+	assert.Contains(t, buf.String(), "<TRNAMT>-10.00</TRNAMT>")
+	assert.Contains(t, buf.String(), "<MEMO>deposit pix</MEMO>")
+	assert.Contains(t, buf.String(), "<BANKID>0260</BANKID>")
+	assert.Contains(t, buf.String(), "<BALAMT>1234567.50</BALAMT>")
+}
+
+func TestOFXDates(t *testing.T) {
+	st := sampleStatement(1)
+	generatedAt := time.Date(2026, time.March, 20, 15, 0, 0, 0, time.UTC)
+
+	for zone, want := range map[*time.Location]string{
+		tests.BRT:                       "<DTSERVER>20260320120000.000[-3:BRT]</DTSERVER>",
+		time.FixedZone("-03", -3*60*60): "<DTSERVER>20260320120000.000[-3]</DTSERVER>",
+		time.FixedZone("+0530", 330*60): "<DTSERVER>20260320203000.000[5.5]</DTSERVER>",
+		time.FixedZone("UTC", 0):        "<DTSERVER>20260320150000.000[0:UTC]</DTSERVER>",
+	} {
+		var buf bytes.Buffer
+		require.NoError(t, export.New("0001", zone).OFX(&buf, st, generatedAt))
+
+		assert.Contains(t, buf.String(), want, zone.String())
+	}
+}
+
+func TestOFXWriteErrors(t *testing.T) {
+	exporter := export.New("0001", tests.BRT)
+	st := sampleStatement(1)
+
+	assert.ErrorIs(t, exporter.OFX(&tests.FailingWriter{}, st, st.From), tests.ErrWrite)
+	assert.ErrorIs(t, exporter.OFX(&tests.FailingWriter{Limit: 200}, st, st.From), tests.ErrWrite)
+}
+
+func TestPDFPaginatesAndFormatsAmounts(t *testing.T) {
+	var buf bytes.Buffer
+
+	require.NoError(t, export.New("0001", tests.BRT).PDF(&buf, sampleStatement(100)))
+
+	pdf := buf.String()
+	assert.Contains(t, pdf, "/Count 2")
+	assert.Contains(t, pdf, "P\xe1gina 2/2", "footer is WinAnsi encoded")
+	assert.Contains(t, pdf, "1.234.567,50")
+	assert.NotContains(t, pdf, "-0,00", "no debits is not negative zero")
+}
+
+func TestPDFKeepsEveryLineAcrossPages(t *testing.T) {
+	st := sampleStatement(150)
+	var buf bytes.Buffer
+
+	require.NoError(t, export.New("0001", tests.BRT).PDF(&buf, st))
+
+	pdf := buf.String()
+	for _, line := range st.Lines {
+		assert.Contains(t, pdf, line.PostedAt.Format("02/01/2006 15:04"), "footers do not overwrite the next page")
+	}
+	assert.Contains(t, pdf, "Saldo final")
+	// 9 header, 150 lines and 4 totals, plus a blank line and a footer per page
+	assert.Equal(t, 9+150+4+2*3, strings.Count(pdf, ") Tj T*"))
+}
+
+func TestPDFEscapesAndTruncates(t *testing.T) {
+	st := sampleStatement(1)
+	st.Lines[0].Description = "(estorno) \\ ✓ " + strings.Repeat("x", 50)
+	var buf bytes.Buffer
+
+	require.NoError(t, export.New("0001", tests.BRT).PDF(&buf, st))
+
+	assert.Contains(t, buf.String(), `deposit \(estorno\) \\ ? xxx`)
+	assert.Contains(t, buf.String(), "xxx...")
+}
+
+func TestPDFWriteError(t *testing.T) {
+	err := export.New("0001", tests.BRT).PDF(&tests.FailingWriter{}, sampleStatement(1))
+
+	assert.ErrorIs(t, err, tests.ErrWrite)
+}
@@ -0,0 +1,105 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Unit Test: Statement Handler
+// ═══════════════════════════════════════════════════════════════════════════
+
+package unit
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fintech-bank-platform/pkg/apikey"
+	"github.com/fintech-bank-platform/pkg/events"
+	"github.com/fintech-bank-platform/statement-service/internal/export"
+	appHttp "github.com/fintech-bank-platform/statement-service/internal/infrastructure/http"
+	"github.com/fintech-bank-platform/statement-service/internal/statement"
+	"github.com/fintech-bank-platform/statement-service/internal/storage"
+	"github.com/fintech-bank-platform/statement-service/tests"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerStorageError(t *testing.T) {
+	repo := &tests.FailingRepository{MemoryRepository: storage.NewMemoryRepository(), FailEntries: true}
+	router := chi.NewRouter()
+	keys, token := tests.AdminKeys()
+	appHttp.SetupRouter(router, appHttp.NewStatementHandler(statement.NewService(repo), export.New("0001", tests.BRT), tests.Config(), zerolog.Nop()), keys, repo)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, tests.Authorized(httptest.NewRequest(http.MethodGet, "/v1/accounts/acc-1/statement", nil), token))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestHandlerLogsInterruptedExport(t *testing.T) {
+	var logs bytes.Buffer
+	router := chi.NewRouter()
+	handler := appHttp.NewStatementHandler(
+		statement.NewService(storage.NewMemoryRepository()),
+		export.New("0001", tests.BRT),
+		tests.Config(),
+		zerolog.New(&logs),
+	)
+	keys, token := tests.AdminKeys()
+	appHttp.SetupRouter(router, handler, keys, storage.NewMemoryRepository())
+
+	for _, format := range []string{"csv", "ofx", "pdf"} {
+		logs.Reset()
+		rec := tests.FailingResponseWriter{ResponseRecorder: httptest.NewRecorder()}
+		router.ServeHTTP(rec, tests.Authorized(httptest.NewRequest(http.MethodGet, "/v1/accounts/acc-1/statement?format="+format, nil), token))
+
+		assert.Contains(t, logs.String(), "Statement export interrupted", format)
+		assert.Contains(t, logs.String(), `"format":"`+format+`"`)
+	}
+}
+
+func TestHandlerCurrencyAndLimit(t *testing.T) {
+	now := time.Date(2026, time.March, 20, 12, 0, 0, 0, tests.BRT)
+	app := tests.NewApp(now)
+	usd := tests.TransactionCompleted("t-1", "acc-1", "deposit", 10, now)
+	payload := map[string]interface{}{}
+	require.NoError(t, usd.DecodePayload(&payload))
+	payload["currency"] = "USD"
+	require.NoError(t, app.Bus.Publish(context.Background(), events.Topics.TransactionEvents, events.NewTransactionEvent(usd.Type, payload)))
+
+	brl := app.Get("/v1/accounts/acc-1/statement?format=json")
+	dollars := app.Get("/v1/accounts/acc-1/statement?currency=USD&limit=100")
+	tooMany := app.Get("/v1/accounts/acc-1/statement?limit=101")
+
+	assert.Contains(t, brl.Body.String(), `"entries":[]`)
+	assert.Contains(t, dollars.Body.String(), `"closing_balance":10`)
+	assert.Contains(t, dollars.Body.String(), `"per_page":100`)
+	assert.Equal(t, http.StatusBadRequest, tooMany.Code)
+	assert.Contains(t, tooMany.Body.String(), `"max":"100"`)
+}
+
+func TestRequireAccountOwnerStorageError(t *testing.T) {
+	repo := &tests.FailingRepository{MemoryRepository: storage.NewMemoryRepository(), FailOwners: true}
+	keys, _ := tests.AdminKeys()
+	issued, err := keys.Create(context.Background(), apikey.CreateRequest{Name: "user-1", Owner: "user-1", Scopes: []string{"accounts:read"}})
+	require.NoError(t, err)
+	router := chi.NewRouter()
+	appHttp.SetupRouter(router, appHttp.NewStatementHandler(statement.NewService(repo), export.New("0001", tests.BRT), tests.Config(), zerolog.Nop()), keys, repo)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, tests.Authorized(httptest.NewRequest(http.MethodGet, "/v1/accounts/acc-1/statement", nil), issued.Token))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestRequireAccountOwnerWithoutAPIKey(t *testing.T) {
+	router := chi.NewRouter()
+	router.With(appHttp.RequireAccountOwner(storage.NewMemoryRepository())).
+		Get("/v1/accounts/{accountID}/statement", func(w http.ResponseWriter, r *http.Request) {})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/accounts/acc-1/statement", nil))
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
@@ -0,0 +1,161 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Unit Test: Projector
+// ═══════════════════════════════════════════════════════════════════════════
+
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pkgerrors "github.com/fintech-bank-platform/pkg/errors"
+	"github.com/fintech-bank-platform/pkg/events"
+	"github.com/fintech-bank-platform/statement-service/internal/contracts"
+	"github.com/fintech-bank-platform/statement-service/internal/projection"
+	"github.com/fintech-bank-platform/statement-service/internal/storage"
+	"github.com/fintech-bank-platform/statement-service/tests"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var postedAt = time.Date(2026, time.March, 5, 10, 0, 0, 0, tests.BRT)
+
+func newProjector() (*projection.Projector, *tests.FailingRepository) {
+	repo := &tests.FailingRepository{MemoryRepository: storage.NewMemoryRepository()}
+	return projection.NewProjector(repo, zerolog.Nop()), repo
+}
+
+func project(t *testing.T, p *projection.Projector, event *events.Event) {
+	t.Helper()
+	require.NoError(t, p.Handle(context.Background(), event))
+}
+
+func TestProjectorTransactionDirection(t *testing.T) {
+	p, repo := newProjector()
+
+	project(t, p, tests.TransactionCompleted("t-1", "acc-1", "deposit", 100, postedAt))
+	project(t, p, tests.TransactionCompleted("t-2", "acc-1", "fee", 5, postedAt))
+
+	credit, _ := repo.Find(context.Background(), "acc-1", "t-1")
+	debit, _ := repo.Find(context.Background(), "acc-1", "t-2")
+	assert.Equal(t, contracts.Credit, credit.Direction)
+	assert.Equal(t, "deposit", credit.Kind)
+	assert.Equal(t, contracts.Debit, debit.Direction)
+	assert.NotEmpty(t, debit.EventID)
+}
+
+func TestProjectorFallsBackToEventTime(t *testing.T) {
+	p, repo := newProjector()
+	event := tests.TransactionCompleted("t-1", "acc-1", "deposit", 100, time.Time{})
+
+	project(t, p, event)
+
+	entry, _ := repo.Find(context.Background(), "acc-1", "t-1")
+	assert.True(t, entry.PostedAt.Equal(event.Timestamp))
+}
+
+func TestProjectorReversalInvertsOriginal(t *testing.T) {
+	p, repo := newProjector()
+	project(t, p, tests.TransactionCompleted("t-1", "acc-1", "deposit", 100, postedAt))
+	project(t, p, tests.TransactionCompleted("t-2", "acc-1", "withdrawal", 30, postedAt))
+
+	for _, original := range []string{"t-1", "t-2"} {
+		project(t, p, events.NewTransactionEvent(events.EventTypes.TransactionReversed, events.TransactionReversedPayload{
+			ReversalID: "rev-" + original, OriginalTransactionID: original, AccountID: "acc-1", Amount: 10,
+			Currency: "BRL", ReasonCode: "FRAUD", ReversedAt: postedAt.Add(time.Hour),
+		}))
+	}
+
+	reversedCredit, _ := repo.Find(context.Background(), "acc-1", "rev-t-1")
+	reversedDebit, _ := repo.Find(context.Background(), "acc-1", "rev-t-2")
+	assert.Equal(t, contracts.Debit, reversedCredit.Direction)
+	assert.Equal(t, contracts.Credit, reversedDebit.Direction)
+	assert.Equal(t, "reversal", reversedDebit.Kind)
+	assert.Equal(t, "FRAUD", reversedDebit.Description)
+	assert.Equal(t, "t-2", reversedDebit.Reference)
+}
+
+func TestProjectorReversalOfUnknownTransaction(t *testing.T) {
+	p, _ := newProjector()
+
+	err := p.Handle(context.Background(), events.NewTransactionEvent(events.EventTypes.TransactionReversed, events.TransactionReversedPayload{
+		ReversalID: "rev-1", OriginalTransactionID: "missing", AccountID: "acc-1",
+	}))
+
+	assert.ErrorIs(t, err, projection.ErrOriginalNotFound)
+	assert.Contains(t, err.Error(), "missing")
+}
+
+func TestProjectorPayments(t *testing.T) {
+	p, repo := newProjector()
+
+	project(t, p, tests.PaymentCompleted("pay-1", "acc-1", 80, postedAt))
+	project(t, p, events.NewPaymentEvent(events.EventTypes.PaymentRefunded, events.PaymentRefundedPayload{
+		RefundID: "ref-1", PaymentID: "pay-1", AccountID: "acc-1", Amount: 80, Currency: "BRL",
+		Reason: "chargeback", RefundedAt: postedAt.Add(time.Hour),
+	}))
+
+	payment, _ := repo.Find(context.Background(), "acc-1", "pay-1")
+	refund, _ := repo.Find(context.Background(), "acc-1", "ref-1")
+	assert.Equal(t, contracts.Debit, payment.Direction)
+	assert.Equal(t, "pix", payment.Description)
+	assert.Equal(t, contracts.Credit, refund.Direction)
+	assert.Equal(t, "pay-1", refund.Reference)
+	assert.Equal(t, "chargeback", refund.Description)
+}
+
+func TestProjectorIgnoresOtherEvents(t *testing.T) {
+	p, repo := newProjector()
+
+	project(t, p, events.NewPaymentEvent(events.EventTypes.PaymentProcessed, map[string]string{"payment_id": "pay-1"}))
+
+	entries, _ := repo.Entries(context.Background(), "acc-1", postedAt.AddDate(1, 0, 0))
+	assert.Empty(t, entries)
+}
+
+func TestProjectorInvalidPayloads(t *testing.T) {
+	p, _ := newProjector()
+
+	for _, eventType := range []string{
+		events.EventTypes.TransactionCompleted, events.EventTypes.TransactionReversed,
+		events.EventTypes.PaymentCompleted, events.EventTypes.PaymentRefunded,
+	} {
+		event := events.NewTransactionEvent(eventType, "not an object")
+		assert.Error(t, p.Handle(context.Background(), event), eventType)
+	}
+}
+
+func TestProjectorStorageErrors(t *testing.T) {
+	p, repo := newProjector()
+
+	repo.FailSave = true
+	assert.ErrorIs(t, p.Handle(context.Background(), tests.TransactionCompleted("t-1", "acc-1", "deposit", 1, postedAt)), tests.ErrStorage)
+
+	repo.FailFind = true
+	err := p.Handle(context.Background(), events.NewTransactionEvent(events.EventTypes.TransactionReversed, events.TransactionReversedPayload{
+		ReversalID: "rev-1", OriginalTransactionID: "t-1", AccountID: "acc-1",
+	}))
+	assert.ErrorIs(t, err, tests.ErrStorage)
+}
+
+func TestOwnerProjector(t *testing.T) {
+	repo := &tests.FailingRepository{MemoryRepository: storage.NewMemoryRepository()}
+	p := projection.NewOwnerProjector(repo, zerolog.Nop())
+	ctx := context.Background()
+
+	require.NoError(t, p.Handle(ctx, tests.AccountCreated("acc-1", "user-1")))
+	require.NoError(t, p.Handle(ctx, events.NewAccountEvent(events.EventTypes.AccountUpdated, map[string]string{"account_id": "acc-2"})))
+
+	owner, err := repo.Owner(ctx, "acc-1")
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", owner)
+	_, err = repo.Owner(ctx, "acc-2")
+	assert.ErrorIs(t, err, pkgerrors.ErrAccountNotFound)
+
+	assert.Error(t, p.Handle(ctx, events.NewAccountEvent(events.EventTypes.AccountCreated, "not an object")))
+
+	repo.FailOwners = true
+	assert.ErrorIs(t, p.Handle(ctx, tests.AccountCreated("acc-3", "user-3")), tests.ErrStorage)
+}
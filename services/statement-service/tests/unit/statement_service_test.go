@@ -0,0 +1,145 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Unit Test: Statement Service
+// ═══════════════════════════════════════════════════════════════════════════
+
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fintech-bank-platform/statement-service/internal/contracts"
+	"github.com/fintech-bank-platform/statement-service/internal/statement"
+	"github.com/fintech-bank-platform/statement-service/internal/storage"
+	"github.com/fintech-bank-platform/statement-service/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func seededService(t *testing.T, entries ...contracts.Entry) *statement.Service {
+	t.Helper()
+	repo := storage.NewMemoryRepository()
+	for _, entry := range entries {
+		entry.AccountID = "acc-1"
+		if entry.Currency == "" {
+			entry.Currency = "BRL"
+		}
+		_, err := repo.Save(context.Background(), &entry)
+		require.NoError(t, err)
+	}
+	return statement.NewService(repo)
+}
+
+func rangeQuery() statement.Query {
+	return statement.Query{AccountID: "acc-1", Currency: "BRL", From: postedAt, To: postedAt.AddDate(0, 0, 1)}
+}
+
+func TestStatementBalancesUseMinorUnits(t *testing.T) {
+	service := seededService(t,
+		contracts.Entry{ID: "a", Direction: contracts.Credit, Amount: 0.1, PostedAt: postedAt.Add(-time.Hour)},
+		contracts.Entry{ID: "b", Direction: contracts.Credit, Amount: 0.2, PostedAt: postedAt.Add(-time.Hour)},
+		contracts.Entry{ID: "c", Direction: contracts.Debit, Amount: 0.3, PostedAt: postedAt},
+	)
+
+	page, err := service.Statement(context.Background(), rangeQuery())
+
+	require.NoError(t, err)
+	assert.Equal(t, 0.3, page.Statement.OpeningBalance)
+	assert.Equal(t, 0.0, page.Statement.ClosingBalance)
+	assert.Equal(t, 0.3, page.Statement.TotalDebits)
+	assert.Equal(t, 0.0, page.Statement.Lines[0].Balance)
+}
+
+func TestStatementFiltersCurrency(t *testing.T) {
+	service := seededService(t,
+		contracts.Entry{ID: "a", Direction: contracts.Credit, Amount: 10, PostedAt: postedAt},
+		contracts.Entry{ID: "b", Direction: contracts.Credit, Amount: 5, Currency: "USD", PostedAt: postedAt},
+	)
+
+	page, err := service.Statement(context.Background(), rangeQuery())
+
+	require.NoError(t, err)
+	assert.Len(t, page.Statement.Lines, 1)
+	assert.Equal(t, 10.0, page.Statement.ClosingBalance)
+}
+
+func TestStatementEmptyRange(t *testing.T) {
+	page, err := seededService(t).Statement(context.Background(), rangeQuery())
+
+	require.NoError(t, err)
+	assert.NotNil(t, page.Statement.Lines)
+	assert.Empty(t, page.Statement.Lines)
+	assert.False(t, page.HasMore)
+	assert.Nil(t, page.Next)
+}
+
+func TestStatementCursorBreaksTiesByID(t *testing.T) {
+	service := seededService(t,
+		contracts.Entry{ID: "a", Direction: contracts.Credit, Amount: 1, PostedAt: postedAt},
+		contracts.Entry{ID: "b", Direction: contracts.Credit, Amount: 2, PostedAt: postedAt},
+		contracts.Entry{ID: "c", Direction: contracts.Credit, Amount: 3, PostedAt: postedAt},
+	)
+	q := rangeQuery()
+	q.Limit = 1
+
+	first, err := service.Statement(context.Background(), q)
+	require.NoError(t, err)
+	q.Cursor = first.Next
+	second, err := service.Statement(context.Background(), q)
+	require.NoError(t, err)
+
+	assert.True(t, first.HasMore)
+	assert.Nil(t, first.Prev)
+	assert.Equal(t, "b", second.Statement.Lines[0].ID)
+	assert.Equal(t, 3.0, second.Statement.Lines[0].Balance)
+	assert.Equal(t, &statement.Cursor{At: postedAt, ID: "b", Backward: true}, second.Prev)
+}
+
+func TestStatementBackwardCursor(t *testing.T) {
+	service := seededService(t,
+		contracts.Entry{ID: "a", Direction: contracts.Credit, Amount: 1, PostedAt: postedAt},
+		contracts.Entry{ID: "b", Direction: contracts.Credit, Amount: 2, PostedAt: postedAt.Add(time.Minute)},
+		contracts.Entry{ID: "c", Direction: contracts.Credit, Amount: 3, PostedAt: postedAt.Add(2 * time.Minute)},
+		contracts.Entry{ID: "d", Direction: contracts.Credit, Amount: 4, PostedAt: postedAt.Add(3 * time.Minute)},
+	)
+	q := rangeQuery()
+	q.Limit = 2
+	q.Cursor = &statement.Cursor{At: postedAt.Add(3 * time.Minute), ID: "d", Backward: true}
+
+	page, err := service.Statement(context.Background(), q)
+	require.NoError(t, err)
+
+	require.Len(t, page.Statement.Lines, 2)
+	assert.Equal(t, "b", page.Statement.Lines[0].ID)
+	assert.Equal(t, 3.0, page.Statement.Lines[0].Balance)
+	assert.Equal(t, "c", page.Statement.Lines[1].ID)
+	assert.Equal(t, &statement.Cursor{At: postedAt.Add(2 * time.Minute), ID: "c"}, page.Next)
+	assert.Equal(t, &statement.Cursor{At: postedAt.Add(time.Minute), ID: "b", Backward: true}, page.Prev)
+	assert.True(t, page.HasMore)
+}
+
+func TestStatementBackwardCursorAtStart(t *testing.T) {
+	service := seededService(t,
+		contracts.Entry{ID: "a", Direction: contracts.Credit, Amount: 1, PostedAt: postedAt},
+	)
+	q := rangeQuery()
+	q.Limit = 2
+	q.Cursor = &statement.Cursor{At: postedAt, ID: "a", Backward: true}
+
+	page, err := service.Statement(context.Background(), q)
+	require.NoError(t, err)
+
+	assert.Empty(t, page.Statement.Lines)
+	assert.Nil(t, page.Next)
+	assert.Nil(t, page.Prev)
+	assert.False(t, page.HasMore)
+}
+
+func TestStatementStorageError(t *testing.T) {
+	repo := &tests.FailingRepository{MemoryRepository: storage.NewMemoryRepository(), FailEntries: true}
+
+	_, err := statement.NewService(repo).Statement(context.Background(), rangeQuery())
+
+	assert.ErrorIs(t, err, tests.ErrStorage)
+}
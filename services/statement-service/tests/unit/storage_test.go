@@ -0,0 +1,81 @@
+// ═══════════════════════════════════════════════════════════════════════════
+// Unit Test: Memory Repository
+// ═══════════════════════════════════════════════════════════════════════════
+
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fintech-bank-platform/statement-service/internal/contracts"
+	"github.com/fintech-bank-platform/statement-service/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryRepositoryOrdersEntries(t *testing.T) {
+	repo := storage.NewMemoryRepository()
+	ctx := context.Background()
+
+	for _, entry := range []contracts.Entry{
+		{ID: "c", AccountID: "acc-1", PostedAt: postedAt.Add(time.Hour)},
+		{ID: "b", AccountID: "acc-1", PostedAt: postedAt},
+		{ID: "a", AccountID: "acc-1", PostedAt: postedAt},
+		{ID: "d", AccountID: "acc-1", PostedAt: postedAt.Add(2 * time.Hour)},
+	} {
+		saved, err := repo.Save(ctx, &entry)
+		require.NoError(t, err)
+		assert.True(t, saved)
+	}
+
+	entries, err := repo.Entries(ctx, "acc-1", postedAt.Add(2*time.Hour))
+	require.NoError(t, err)
+
+	var ids []string
+	for _, entry := range entries {
+		ids = append(ids, entry.ID)
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, ids)
+}
+
+func TestMemoryRepositoryDeduplicatesPerAccount(t *testing.T) {
+	repo := storage.NewMemoryRepository()
+	ctx := context.Background()
+
+	first, _ := repo.Save(ctx, &contracts.Entry{ID: "t-1", AccountID: "acc-1", PostedAt: postedAt})
+	duplicate, _ := repo.Save(ctx, &contracts.Entry{ID: "t-1", AccountID: "acc-1", PostedAt: postedAt.Add(time.Hour)})
+	otherAccount, _ := repo.Save(ctx, &contracts.Entry{ID: "t-1", AccountID: "acc-2", PostedAt: postedAt})
+
+	assert.True(t, first)
+	assert.False(t, duplicate)
+	assert.True(t, otherAccount)
+}
+
+func TestMemoryRepositoryReturnsCopies(t *testing.T) {
+	repo := storage.NewMemoryRepository()
+	ctx := context.Background()
+	entry := &contracts.Entry{ID: "t-1", AccountID: "acc-1", Amount: 10, PostedAt: postedAt}
+	_, _ = repo.Save(ctx, entry)
+	entry.Amount = 99
+
+	found, err := repo.Find(ctx, "acc-1", "t-1")
+	require.NoError(t, err)
+	found.Amount = 50
+	entries, _ := repo.Entries(ctx, "acc-1", postedAt.Add(time.Hour))
+	entries[0].Amount = 70
+
+	again, _ := repo.Find(ctx, "acc-1", "t-1")
+	assert.Equal(t, 10.0, again.Amount)
+}
+
+func TestMemoryRepositoryFindMissing(t *testing.T) {
+	repo := storage.NewMemoryRepository()
+	_, _ = repo.Save(context.Background(), &contracts.Entry{ID: "t-1", AccountID: "acc-1", PostedAt: postedAt})
+
+	found, err := repo.Find(context.Background(), "acc-1", "t-2")
+
+	assert.NoError(t, err)
+	assert.Nil(t, found)
+}